@@ -30,6 +30,7 @@ var (
 		"DATABASE_BACKUP_MISSING":          api.AnomalyDatabaseBackupMissing,
 		"DATABASE_CONNECTION":              api.AnomalyDatabaseConnection,
 		"DATABASE_SCHEMA_DRIFT":            api.AnomalyDatabaseSchemaDrift,
+		"DATABASE_BACKUP_DRILL_FAILED":     api.AnomalyDatabaseBackupDrillFailed,
 	}
 )
 
@@ -197,6 +198,18 @@ func convertToAnomaly(anomaly *store.AnomalyMessage, environmentID, instanceID,
 				ActualSchema:   detail.Actual,
 			},
 		}
+	case api.AnomalyDatabaseBackupDrillFailed:
+		var detail api.AnomalyDatabaseBackupDrillFailedPayload
+		if err := json.Unmarshal([]byte(anomaly.Payload), &detail); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal database backup drill failed anomaly payload")
+		}
+		pbAnomaly.Type = v1pb.Anomaly_DATABASE_BACKUP_DRILL_FAILED
+		pbAnomaly.Detail = &v1pb.Anomaly_DatabaseBackupDrillFailedDetail_{
+			DatabaseBackupDrillFailedDetail: &v1pb.Anomaly_DatabaseBackupDrillFailedDetail{
+				Backup: detail.BackupName,
+				Detail: detail.Detail,
+			},
+		}
 	}
 	pbAnomaly.Severity = getSeverityFromAnomalyType(pbAnomaly.Type)
 	return &pbAnomaly, nil
@@ -206,7 +219,7 @@ func getSeverityFromAnomalyType(tp v1pb.Anomaly_AnomalyType) v1pb.Anomaly_Anomal
 	switch tp {
 	case v1pb.Anomaly_DATABASE_BACKUP_POLICY_VIOLATION:
 		return v1pb.Anomaly_MEDIUM
-	case v1pb.Anomaly_DATABASE_BACKUP_MISSING:
+	case v1pb.Anomaly_DATABASE_BACKUP_MISSING, v1pb.Anomaly_DATABASE_BACKUP_DRILL_FAILED:
 		return v1pb.Anomaly_HIGH
 	case v1pb.Anomaly_INSTANCE_CONNECTION, v1pb.Anomaly_MIGRATION_SCHEMA, v1pb.Anomaly_DATABASE_CONNECTION, v1pb.Anomaly_DATABASE_SCHEMA_DRIFT:
 		return v1pb.Anomaly_CRITICAL
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestConvertToAnomalyBackupDrillFailed(t *testing.T) {
+	a := require.New(t)
+	anomaly, err := convertToAnomaly(&store.AnomalyMessage{
+		Type:    api.AnomalyDatabaseBackupDrillFailed,
+		Payload: `{"backupId":101,"backupName":"db-backup-1","detail":"failed to restore"}`,
+	}, "test", "instance", "db")
+	a.NoError(err)
+	a.Equal(v1pb.Anomaly_DATABASE_BACKUP_DRILL_FAILED, anomaly.Type)
+	a.Equal(v1pb.Anomaly_HIGH, anomaly.Severity)
+	a.Equal("instances/instance/databases/db", anomaly.Resource)
+	a.Equal(&v1pb.Anomaly_DatabaseBackupDrillFailedDetail{
+		Backup: "db-backup-1",
+		Detail: "failed to restore",
+	}, anomaly.GetDatabaseBackupDrillFailedDetail())
+
+	a.Equal(api.AnomalyDatabaseBackupDrillFailed, typesMap[v1pb.Anomaly_DATABASE_BACKUP_DRILL_FAILED.String()])
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -33,12 +35,24 @@ const (
 	rolePrefix                   = "roles/"
 	secretNamePrefix             = "secrets/"
 	webhookIDPrefix              = "webhooks/"
+	sheetIDPrefix                = "sheets/"
+	planPrefix                   = "plans/"
+	rolloutPrefix                = "rollouts/"
+	stagePrefix                  = "stages/"
+	taskPrefix                   = "tasks/"
+	taskRunPrefix                = "taskRuns/"
+	workspacePrefix              = "workspaces/"
+	issuePrefix                  = "issues/"
+	pipelinePrefix               = "pipelines/"
 
 	deploymentConfigSuffix = "/deploymentConfig"
 	backupSettingSuffix    = "/backupSetting"
 	schemaSuffix           = "/schema"
 	metadataSuffix         = "/metadata"
 
+	defaultPageSize = 50
+	maxPageSize     = 1000
+
 	setupExternalURLError = "external URL isn't setup yet, see https://www.bytebase.com/docs/get-started/install/external-url"
 
 	comparatorTypeEqual        comparatorType = "="
@@ -227,6 +241,110 @@ func getReviewID(name string) (int, error) {
 	return reviewID, nil
 }
 
+func getProjectIDSheetID(name string) (string, int, error) {
+	tokens, err := getNameParentTokens(name, projectNamePrefix, sheetIDPrefix)
+	if err != nil {
+		return "", 0, err
+	}
+	sheetID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, errors.Errorf("invalid sheet ID %q", tokens[1])
+	}
+	return tokens[0], sheetID, nil
+}
+
+func getProjectIDPlanID(name string) (string, int64, error) {
+	tokens, err := getNameParentTokens(name, projectNamePrefix, planPrefix)
+	if err != nil {
+		return "", 0, err
+	}
+	planID, err := strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return "", 0, errors.Errorf("invalid plan ID %q", tokens[1])
+	}
+	return tokens[0], planID, nil
+}
+
+func getProjectIDRolloutID(name string) (string, int, error) {
+	tokens, err := getNameParentTokens(name, projectNamePrefix, rolloutPrefix)
+	if err != nil {
+		return "", 0, err
+	}
+	rolloutID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, errors.Errorf("invalid rollout ID %q", tokens[1])
+	}
+	return tokens[0], rolloutID, nil
+}
+
+// getProjectIDRolloutIDMaybeStageIDMaybeTaskID parses the task name.
+// The stage ID and task ID are nil if they are "-".
+func getProjectIDRolloutIDMaybeStageIDMaybeTaskID(name string) (string, int, *int, *int, error) {
+	tokens, err := getNameParentTokens(name, projectNamePrefix, rolloutPrefix, stagePrefix, taskPrefix)
+	if err != nil {
+		return "", 0, nil, nil, err
+	}
+	rolloutID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, nil, nil, errors.Errorf("invalid rollout ID %q", tokens[1])
+	}
+	var maybeStageID, maybeTaskID *int
+	if tokens[2] != "-" {
+		stageID, err := strconv.Atoi(tokens[2])
+		if err != nil {
+			return "", 0, nil, nil, errors.Errorf("invalid stage ID %q", tokens[2])
+		}
+		maybeStageID = &stageID
+	}
+	if tokens[3] != "-" {
+		taskID, err := strconv.Atoi(tokens[3])
+		if err != nil {
+			return "", 0, nil, nil, errors.Errorf("invalid task ID %q", tokens[3])
+		}
+		maybeTaskID = &taskID
+	}
+	return tokens[0], rolloutID, maybeStageID, maybeTaskID, nil
+}
+
+// getPageSize returns the page size, coerced into (0, maxPageSize].
+func getPageSize(pageSize int32, defaultSize int) int {
+	if pageSize <= 0 {
+		return defaultSize
+	}
+	if pageSize > maxPageSize {
+		return maxPageSize
+	}
+	return int(pageSize)
+}
+
+// marshalPageToken marshals the offset of the next page into the page token.
+func marshalPageToken(offset int) (string, error) {
+	b, err := json.Marshal(offset)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal page token")
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// unmarshalPageToken unmarshals the page token and returns the offset. An empty page token means the first page.
+func unmarshalPageToken(pageToken string) (int, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(pageToken)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to decode page token")
+	}
+	var offset int
+	if err := json.Unmarshal(b, &offset); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal page token")
+	}
+	if offset < 0 {
+		return 0, errors.Errorf("invalid page token offset %d", offset)
+	}
+	return offset, nil
+}
+
 func getRoleID(name string) (string, error) {
 	tokens, err := getNameParentTokens(name, rolePrefix)
 	if err != nil {
@@ -127,6 +127,44 @@ func TestGetRiskID(t *testing.T) {
 	}
 }
 
+func TestGetProjectIDRolloutIDMaybeStageIDMaybeTaskID(t *testing.T) {
+	a := require.New(t)
+
+	projectID, rolloutID, stageID, taskID, err := getProjectIDRolloutIDMaybeStageIDMaybeTaskID("projects/p1/rollouts/101/stages/102/tasks/103")
+	a.NoError(err)
+	a.Equal("p1", projectID)
+	a.Equal(101, rolloutID)
+	a.Equal(102, *stageID)
+	a.Equal(103, *taskID)
+
+	projectID, rolloutID, stageID, taskID, err = getProjectIDRolloutIDMaybeStageIDMaybeTaskID("projects/p1/rollouts/101/stages/-/tasks/-")
+	a.NoError(err)
+	a.Equal("p1", projectID)
+	a.Equal(101, rolloutID)
+	a.Nil(stageID)
+	a.Nil(taskID)
+
+	_, _, _, _, err = getProjectIDRolloutIDMaybeStageIDMaybeTaskID("projects/p1/rollouts/-/stages/-/tasks/-")
+	a.Error(err)
+}
+
+func TestPageToken(t *testing.T) {
+	a := require.New(t)
+
+	offset, err := unmarshalPageToken("")
+	a.NoError(err)
+	a.Equal(0, offset)
+
+	token, err := marshalPageToken(150)
+	a.NoError(err)
+	offset, err = unmarshalPageToken(token)
+	a.NoError(err)
+	a.Equal(150, offset)
+
+	_, err = unmarshalPageToken("invalid")
+	a.Error(err)
+}
+
 func TestGetEBNFTokens(t *testing.T) {
 	testCases := []struct {
 		input   string
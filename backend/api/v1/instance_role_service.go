@@ -297,6 +297,20 @@ func validateRole(dbType db.Type, upsert *db.DatabaseRoleUpsertMessage) error {
 				return status.Error(codes.InvalidArgument, "Invalid number for valid_until, mysql valid_until should be an integer.")
 			}
 		}
+	case db.MSSQL:
+		if upsert.ConnectionLimit != nil {
+			return status.Errorf(codes.InvalidArgument, "Connection limit is not supported for SQL Server")
+		}
+		if upsert.ValidUntil != nil {
+			return status.Errorf(codes.InvalidArgument, "Valid until is not supported for SQL Server")
+		}
+	case db.Oracle:
+		if upsert.ConnectionLimit != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid connection limit, Oracle connection limit should be set by the PROFILE in attribute")
+		}
+		if upsert.ValidUntil != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid valid_until, Oracle password expiry should be set by the PROFILE in attribute")
+		}
 	}
 
 	return nil
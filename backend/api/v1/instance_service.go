@@ -186,7 +186,7 @@ func (s *InstanceService) SyncSlowQueries(ctx context.Context, request *v1pb.Syn
 	}
 
 	switch instance.Engine {
-	case db.MySQL:
+	case db.MySQL, db.Oracle, db.MSSQL, db.MongoDB:
 		driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, "" /* database name */)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,519 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// LoggingService implements the logging service.
+type LoggingService struct {
+	v1pb.UnimplementedLoggingServiceServer
+	store *store.Store
+}
+
+// NewLoggingService creates a new LoggingService.
+func NewLoggingService(store *store.Store) *LoggingService {
+	return &LoggingService{
+		store: store,
+	}
+}
+
+// ListLogs lists the logs.
+func (s *LoggingService) ListLogs(ctx context.Context, request *v1pb.ListLogsRequest) (*v1pb.ListLogsResponse, error) {
+	workspaceID, err := s.store.GetWorkspaceID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace id, error: %v", err)
+	}
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+
+	activityFind := &api.ActivityFind{}
+	switch {
+	case strings.HasPrefix(request.Parent, workspacePrefix):
+		id := strings.TrimPrefix(request.Parent, workspacePrefix)
+		if id != "-" && id != workspaceID {
+			return nil, status.Errorf(codes.NotFound, "workspace %q not found", request.Parent)
+		}
+		// Only the workspace Owner and DBA can list the logs of the whole workspace.
+		if !isOwnerOrDBA(role) {
+			return nil, status.Errorf(codes.PermissionDenied, "only the workspace owner and DBA can list the logs in %q", request.Parent)
+		}
+	case strings.HasPrefix(request.Parent, projectNamePrefix):
+		project, err := s.getProjectMessage(ctx, request.Parent)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwnerOrDBA(role) {
+			isMember, err := s.isProjectMember(ctx, project.ResourceID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to check project membership, error: %v", err)
+			}
+			if !isMember {
+				return nil, status.Errorf(codes.PermissionDenied, "not a member of project %q", request.Parent)
+			}
+		}
+		activityFind.ProjectID = &project.UID
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent %q", request.Parent)
+	}
+
+	isEmpty, err := s.applyFilter(ctx, activityFind, request.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return &v1pb.ListLogsResponse{}, nil
+	}
+
+	// The page token carries the ID of the first activity in the next page.
+	// We list the activities in descending order of ID so that the newly created activities won't shift the pages.
+	sinceID, err := unmarshalPageToken(request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token, error: %v", err)
+	}
+	if sinceID > 0 {
+		activityFind.SinceID = &sinceID
+	}
+	limit := getPageSize(request.PageSize, defaultPageSize)
+	limitPlusOne := limit + 1
+	activityFind.Limit = &limitPlusOne
+	order := api.DESC
+	activityFind.Order = &order
+
+	activityList, err := s.store.FindActivity(ctx, activityFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list activities, error: %v", err)
+	}
+
+	response := &v1pb.ListLogsResponse{}
+	if len(activityList) > limit {
+		nextPageToken, err := marshalPageToken(activityList[limit].ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+		activityList = activityList[:limit]
+	}
+
+	converter := &logEntryConverter{
+		store:       s.store,
+		workspaceID: workspaceID,
+		users:       make(map[int]*store.UserMessage),
+		projects:    make(map[int]*store.ProjectMessage),
+		databases:   make(map[int]*store.DatabaseMessage),
+	}
+	for _, activity := range activityList {
+		logEntry, err := converter.convert(ctx, activity)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert activity %d, error: %v", activity.ID, err)
+		}
+		response.LogEntries = append(response.LogEntries, logEntry)
+	}
+	return response, nil
+}
+
+// applyFilter applies the filter to the activity find.
+// It returns true if the filter can never be satisfied, for example, `action = "ACTION_ISSUE_CREATE" && resource = "pipelines/101"`.
+func (s *LoggingService) applyFilter(ctx context.Context, activityFind *api.ActivityFind, filter string) (bool, error) {
+	expressions, err := parseFilter(filter)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	var actionType, resourceTypePrefix string
+	for _, expression := range expressions {
+		switch expression.key {
+		case "creator":
+			if expression.comparator != comparatorTypeEqual {
+				return false, status.Errorf(codes.InvalidArgument, "invalid comparator %q for creator", expression.comparator)
+			}
+			user, err := s.getUserByIdentifier(ctx, expression.value)
+			if err != nil {
+				return false, err
+			}
+			activityFind.CreatorID = &user.ID
+		case "resource":
+			if expression.comparator != comparatorTypeEqual {
+				return false, status.Errorf(codes.InvalidArgument, "invalid comparator %q for resource", expression.comparator)
+			}
+			switch {
+			case strings.HasPrefix(expression.value, issuePrefix):
+				issueID, err := strconv.Atoi(strings.TrimPrefix(expression.value, issuePrefix))
+				if err != nil {
+					return false, status.Errorf(codes.InvalidArgument, "invalid issue resource %q", expression.value)
+				}
+				activityFind.ContainerID = &issueID
+				resourceTypePrefix = "bb.issue."
+			case strings.HasPrefix(expression.value, pipelinePrefix):
+				pipelineID, err := strconv.Atoi(strings.TrimPrefix(expression.value, pipelinePrefix))
+				if err != nil {
+					return false, status.Errorf(codes.InvalidArgument, "invalid pipeline resource %q", expression.value)
+				}
+				activityFind.ContainerID = &pipelineID
+				resourceTypePrefix = "bb.pipeline."
+			case strings.HasPrefix(expression.value, projectNamePrefix):
+				project, err := s.getProjectMessage(ctx, expression.value)
+				if err != nil {
+					return false, err
+				}
+				if activityFind.ProjectID != nil && *activityFind.ProjectID != project.UID {
+					return true, nil
+				}
+				activityFind.ProjectID = &project.UID
+			case strings.HasPrefix(expression.value, "user:"):
+				user, err := s.getUserByIdentifier(ctx, expression.value)
+				if err != nil {
+					return false, err
+				}
+				activityFind.ContainerID = &user.ID
+				resourceTypePrefix = "bb.member."
+			default:
+				return false, status.Errorf(codes.InvalidArgument, "invalid resource %q", expression.value)
+			}
+		case "action":
+			if expression.comparator != comparatorTypeEqual {
+				return false, status.Errorf(codes.InvalidArgument, "invalid comparator %q for action", expression.comparator)
+			}
+			action, ok := v1pb.LogEntry_Action_value[expression.value]
+			if !ok {
+				return false, status.Errorf(codes.InvalidArgument, "invalid action %q", expression.value)
+			}
+			activityType, err := convertToActivityType(v1pb.LogEntry_Action(action))
+			if err != nil {
+				return false, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			actionType = string(activityType)
+		case "level":
+			if expression.comparator != comparatorTypeEqual {
+				return false, status.Errorf(codes.InvalidArgument, "invalid comparator %q for level", expression.comparator)
+			}
+			level, ok := v1pb.LogEntry_Level_value[expression.value]
+			if !ok {
+				return false, status.Errorf(codes.InvalidArgument, "invalid level %q", expression.value)
+			}
+			activityLevel, err := convertToActivityLevel(v1pb.LogEntry_Level(level))
+			if err != nil {
+				return false, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			activityFind.LevelList = []api.ActivityLevel{activityLevel}
+		case "create_time":
+			t, err := time.Parse(time.RFC3339, expression.value)
+			if err != nil {
+				return false, status.Errorf(codes.InvalidArgument, "invalid create_time %q, should be in RFC-3339 format", expression.value)
+			}
+			// The created_ts is stored in seconds.
+			ts := t.Unix()
+			switch expression.comparator {
+			case comparatorTypeGreater:
+				ts++
+				activityFind.CreatedTsAfter = &ts
+			case comparatorTypeGreaterEqual:
+				activityFind.CreatedTsAfter = &ts
+			case comparatorTypeLess:
+				ts--
+				activityFind.CreatedTsBefore = &ts
+			case comparatorTypeLessEqual:
+				activityFind.CreatedTsBefore = &ts
+			default:
+				return false, status.Errorf(codes.InvalidArgument, "invalid comparator %q for create_time", expression.comparator)
+			}
+		default:
+			return false, status.Errorf(codes.InvalidArgument, "invalid filter key %q", expression.key)
+		}
+	}
+
+	switch {
+	case actionType != "":
+		if resourceTypePrefix != "" && !strings.HasPrefix(actionType, resourceTypePrefix) {
+			return true, nil
+		}
+		activityFind.TypePrefixList = []string{actionType}
+	case resourceTypePrefix != "":
+		activityFind.TypePrefixList = []string{resourceTypePrefix}
+	}
+	return false, nil
+}
+
+func (s *LoggingService) getProjectMessage(ctx context.Context, name string) (*store.ProjectMessage, error) {
+	projectID, err := getProjectID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID:  &projectID,
+		ShowDeleted: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", name)
+	}
+	return project, nil
+}
+
+func (s *LoggingService) getUserByIdentifier(ctx context.Context, identifier string) (*store.UserMessage, error) {
+	email := getUserEmailFromIdentifier(identifier)
+	user, err := s.store.GetUser(ctx, &store.FindUserMessage{
+		Email:       &email,
+		ShowDeleted: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %q not found", identifier)
+	}
+	return user, nil
+}
+
+func (s *LoggingService) isProjectMember(ctx context.Context, projectID string) (bool, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &projectID})
+	if err != nil {
+		return false, err
+	}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if member.ID == currentPrincipalID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// logEntryConverter converts the activities to log entries, caching the resources looked up along the way.
+type logEntryConverter struct {
+	store       *store.Store
+	workspaceID string
+	users       map[int]*store.UserMessage
+	projects    map[int]*store.ProjectMessage
+	databases   map[int]*store.DatabaseMessage
+}
+
+func (c *logEntryConverter) convert(ctx context.Context, activity *api.Activity) (*v1pb.LogEntry, error) {
+	resourceName, err := c.getResourceName(ctx, activity)
+	if err != nil {
+		return nil, err
+	}
+	logEntry := &v1pb.LogEntry{
+		CreateTime:   timestamppb.New(time.Unix(activity.CreatedTs, 0)),
+		UpdateTime:   timestamppb.New(time.Unix(activity.UpdatedTs, 0)),
+		Action:       convertToLogEntryAction(activity.Type),
+		Level:        convertToLogEntryLevel(activity.Level),
+		ResourceName: resourceName,
+		Comment:      activity.Comment,
+	}
+	if activity.Creator != nil {
+		logEntry.Creator = getUserIdentifier(activity.Creator.Email)
+	}
+	if activity.Payload != "" {
+		payload := &structpb.Struct{}
+		if err := protojson.Unmarshal([]byte(activity.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal payload")
+		}
+		logEntry.JsonPayload = payload
+	}
+	return logEntry, nil
+}
+
+func (c *logEntryConverter) getResourceName(ctx context.Context, activity *api.Activity) (string, error) {
+	workspaceName := fmt.Sprintf("%s%s", workspacePrefix, c.workspaceID)
+	switch {
+	case strings.HasPrefix(string(activity.Type), "bb.issue."):
+		return fmt.Sprintf("%s%d", issuePrefix, activity.ContainerID), nil
+	case strings.HasPrefix(string(activity.Type), "bb.pipeline."):
+		return fmt.Sprintf("%s%d", pipelinePrefix, activity.ContainerID), nil
+	case strings.HasPrefix(string(activity.Type), "bb.member."):
+		user, ok := c.users[activity.ContainerID]
+		if !ok {
+			var err error
+			if user, err = c.store.GetUserByID(ctx, activity.ContainerID); err != nil {
+				return "", errors.Wrapf(err, "failed to get user %d", activity.ContainerID)
+			}
+			c.users[activity.ContainerID] = user
+		}
+		if user == nil {
+			return workspaceName, nil
+		}
+		return getUserIdentifier(user.Email), nil
+	case strings.HasPrefix(string(activity.Type), "bb.project."), activity.Type == api.ActivityDatabaseRecoveryPITRDone:
+		return c.getProjectName(ctx, activity.ContainerID)
+	case activity.Type == api.ActivitySQLEditorQuery:
+		// The query in the database that belongs to a project is in the project resource,
+		// otherwise the query is in the workspace resource.
+		var payload api.ActivitySQLEditorQueryPayload
+		if err := json.Unmarshal([]byte(activity.Payload), &payload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal SQL editor query payload")
+		}
+		if payload.DatabaseID == 0 {
+			return workspaceName, nil
+		}
+		database, ok := c.databases[payload.DatabaseID]
+		if !ok {
+			var err error
+			if database, err = c.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+				UID:         &payload.DatabaseID,
+				ShowDeleted: true,
+			}); err != nil {
+				return "", errors.Wrapf(err, "failed to get database %d", payload.DatabaseID)
+			}
+			c.databases[payload.DatabaseID] = database
+		}
+		if database == nil {
+			return workspaceName, nil
+		}
+		return fmt.Sprintf("%s%s", projectNamePrefix, database.ProjectID), nil
+	}
+	return workspaceName, nil
+}
+
+func (c *logEntryConverter) getProjectName(ctx context.Context, projectUID int) (string, error) {
+	project, ok := c.projects[projectUID]
+	if !ok {
+		var err error
+		if project, err = c.store.GetProjectV2(ctx, &store.FindProjectMessage{
+			UID:         &projectUID,
+			ShowDeleted: true,
+		}); err != nil {
+			return "", errors.Wrapf(err, "failed to get project %d", projectUID)
+		}
+		c.projects[projectUID] = project
+	}
+	if project == nil {
+		return "", errors.Errorf("project %d not found", projectUID)
+	}
+	return fmt.Sprintf("%s%s", projectNamePrefix, project.ResourceID), nil
+}
+
+func convertToLogEntryAction(activityType api.ActivityType) v1pb.LogEntry_Action {
+	switch activityType {
+	case api.ActivityMemberCreate:
+		return v1pb.LogEntry_ACTION_MEMBER_CREATE
+	case api.ActivityMemberRoleUpdate:
+		return v1pb.LogEntry_ACTION_MEMBER_ROLE_UPDATE
+	case api.ActivityMemberActivate:
+		return v1pb.LogEntry_ACTION_MEMBER_ACTIVATE
+	case api.ActivityMemberDeactivate:
+		return v1pb.LogEntry_ACTION_MEMBER_DEACTIVE
+	case api.ActivityIssueCreate:
+		return v1pb.LogEntry_ACTION_ISSUE_CREATE
+	case api.ActivityIssueCommentCreate:
+		return v1pb.LogEntry_ACTION_ISSUE_COMMENT_CREATE
+	case api.ActivityIssueFieldUpdate:
+		return v1pb.LogEntry_ACTION_ISSUE_FIELD_UPDATE
+	case api.ActivityIssueStatusUpdate:
+		return v1pb.LogEntry_ACTION_ISSUE_STATUS_UPDATE
+	case api.ActivityPipelineStageStatusUpdate:
+		return v1pb.LogEntry_ACTION_PIPELINE_STAGE_STATUS_UPDATE
+	case api.ActivityPipelineTaskStatusUpdate:
+		return v1pb.LogEntry_ACTION_PIPELINE_TASK_STATUS_UPDATE
+	case api.ActivityPipelineTaskFileCommit:
+		return v1pb.LogEntry_ACTION_PIPELINE_TASK_FILE_COMMIT
+	case api.ActivityPipelineTaskStatementUpdate:
+		return v1pb.LogEntry_ACTION_PIPELINE_TASK_STATEMENT_UPDATE
+	case api.ActivityPipelineTaskEarliestAllowedTimeUpdate:
+		return v1pb.LogEntry_ACITON_PIPELINE_TASK_EARLIEST_ALLOWED_DATE_UPDATE
+	case api.ActivityProjectMemberCreate:
+		return v1pb.LogEntry_ACTION_PROJECT_MEMBER_CREATE
+	case api.ActivityProjectMemberRoleUpdate:
+		return v1pb.LogEntry_ACTION_PROJECT_MEMBER_ROLE_UPDATE
+	case api.ActivityProjectMemberDelete:
+		return v1pb.LogEntry_ACTION_PROJECT_MEMBER_DELETE
+	case api.ActivityProjectRepositoryPush:
+		return v1pb.LogEntry_ACTION_PROJECT_REPOSITORY_PUSH
+	case api.ActivityProjectDatabaseTransfer:
+		return v1pb.LogEntry_ACTION_PROJECT_DTABASE_TRANSFER
+	case api.ActivityDatabaseRecoveryPITRDone:
+		return v1pb.LogEntry_ACTION_PROJECT_DATABASE_RECOVERY_PITR_DONE
+	case api.ActivitySQLEditorQuery:
+		return v1pb.LogEntry_ACTION_SQL_EDITOR_QUERY
+	}
+	return v1pb.LogEntry_ACTION_UNSPECIFIED
+}
+
+func convertToActivityType(action v1pb.LogEntry_Action) (api.ActivityType, error) {
+	switch action {
+	case v1pb.LogEntry_ACTION_MEMBER_CREATE:
+		return api.ActivityMemberCreate, nil
+	case v1pb.LogEntry_ACTION_MEMBER_ROLE_UPDATE:
+		return api.ActivityMemberRoleUpdate, nil
+	case v1pb.LogEntry_ACTION_MEMBER_ACTIVATE:
+		return api.ActivityMemberActivate, nil
+	case v1pb.LogEntry_ACTION_MEMBER_DEACTIVE:
+		return api.ActivityMemberDeactivate, nil
+	case v1pb.LogEntry_ACTION_ISSUE_CREATE:
+		return api.ActivityIssueCreate, nil
+	case v1pb.LogEntry_ACTION_ISSUE_COMMENT_CREATE:
+		return api.ActivityIssueCommentCreate, nil
+	case v1pb.LogEntry_ACTION_ISSUE_FIELD_UPDATE:
+		return api.ActivityIssueFieldUpdate, nil
+	case v1pb.LogEntry_ACTION_ISSUE_STATUS_UPDATE:
+		return api.ActivityIssueStatusUpdate, nil
+	case v1pb.LogEntry_ACTION_PIPELINE_STAGE_STATUS_UPDATE:
+		return api.ActivityPipelineStageStatusUpdate, nil
+	case v1pb.LogEntry_ACTION_PIPELINE_TASK_STATUS_UPDATE:
+		return api.ActivityPipelineTaskStatusUpdate, nil
+	case v1pb.LogEntry_ACTION_PIPELINE_TASK_FILE_COMMIT:
+		return api.ActivityPipelineTaskFileCommit, nil
+	case v1pb.LogEntry_ACTION_PIPELINE_TASK_STATEMENT_UPDATE:
+		return api.ActivityPipelineTaskStatementUpdate, nil
+	case v1pb.LogEntry_ACITON_PIPELINE_TASK_EARLIEST_ALLOWED_DATE_UPDATE:
+		return api.ActivityPipelineTaskEarliestAllowedTimeUpdate, nil
+	case v1pb.LogEntry_ACTION_PROJECT_MEMBER_CREATE:
+		return api.ActivityProjectMemberCreate, nil
+	case v1pb.LogEntry_ACTION_PROJECT_MEMBER_ROLE_UPDATE:
+		return api.ActivityProjectMemberRoleUpdate, nil
+	case v1pb.LogEntry_ACTION_PROJECT_MEMBER_DELETE:
+		return api.ActivityProjectMemberDelete, nil
+	case v1pb.LogEntry_ACTION_PROJECT_REPOSITORY_PUSH:
+		return api.ActivityProjectRepositoryPush, nil
+	case v1pb.LogEntry_ACTION_PROJECT_DTABASE_TRANSFER:
+		return api.ActivityProjectDatabaseTransfer, nil
+	case v1pb.LogEntry_ACTION_PROJECT_DATABASE_RECOVERY_PITR_DONE:
+		return api.ActivityDatabaseRecoveryPITRDone, nil
+	case v1pb.LogEntry_ACTION_SQL_EDITOR_QUERY:
+		return api.ActivitySQLEditorQuery, nil
+	}
+	return "", errors.Errorf("unsupported action %q", action)
+}
+
+func convertToLogEntryLevel(level api.ActivityLevel) v1pb.LogEntry_Level {
+	switch level {
+	case api.ActivityInfo:
+		return v1pb.LogEntry_LEVEL_INFO
+	case api.ActivityWarn:
+		return v1pb.LogEntry_LEVEL_WARNING
+	case api.ActivityError:
+		return v1pb.LogEntry_LEVEL_ERROR
+	}
+	return v1pb.LogEntry_LEVEL_UNSPECIFIED
+}
+
+func convertToActivityLevel(level v1pb.LogEntry_Level) (api.ActivityLevel, error) {
+	switch level {
+	case v1pb.LogEntry_LEVEL_INFO:
+		return api.ActivityInfo, nil
+	case v1pb.LogEntry_LEVEL_WARNING:
+		return api.ActivityWarn, nil
+	case v1pb.LogEntry_LEVEL_ERROR:
+		return api.ActivityError, nil
+	}
+	return "", errors.Errorf("unsupported level %q", level)
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestConvertLogEntryAction(t *testing.T) {
+	a := require.New(t)
+
+	for value := range v1pb.LogEntry_Action_name {
+		action := v1pb.LogEntry_Action(value)
+		if action == v1pb.LogEntry_ACTION_UNSPECIFIED {
+			_, err := convertToActivityType(action)
+			a.Error(err)
+			continue
+		}
+		activityType, err := convertToActivityType(action)
+		a.NoError(err, action.String())
+		a.Equal(action, convertToLogEntryAction(activityType))
+	}
+}
+
+func TestConvertLogEntryLevel(t *testing.T) {
+	a := require.New(t)
+
+	for value := range v1pb.LogEntry_Level_name {
+		level := v1pb.LogEntry_Level(value)
+		if level == v1pb.LogEntry_LEVEL_UNSPECIFIED {
+			_, err := convertToActivityLevel(level)
+			a.Error(err)
+			continue
+		}
+		activityLevel, err := convertToActivityLevel(level)
+		a.NoError(err, level.String())
+		a.Equal(level, convertToLogEntryLevel(activityLevel))
+	}
+}
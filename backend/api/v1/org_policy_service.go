@@ -497,8 +497,8 @@ func convertPolicyPayloadToString(policy *v1pb.Policy) (string, error) {
 			if v.Table == "" || v.Column == "" {
 				return "", errors.Errorf("sensitive data policy rule cannot have empty table or column name")
 			}
-			if v.Type != api.SensitiveDataMaskTypeDefault {
-				return "", errors.Errorf("sensitive data policy rule must have mask type %q", api.SensitiveDataMaskTypeDefault)
+			if err := v.Validate(); err != nil {
+				return "", err
 			}
 		}
 		return payload.String()
@@ -703,14 +703,38 @@ func convertToV1PBSensitiveDataPolicy(payloadStr string) (*v1pb.Policy_Sensitive
 	var sensitiveDataList []*v1pb.SensitiveData
 	for _, data := range payload.SensitiveDataList {
 		maskType := v1pb.SensitiveDataMaskType_MASK_TYPE_UNSPECIFIED
-		if data.Type == api.SensitiveDataMaskTypeDefault {
+		switch data.Type {
+		case api.SensitiveDataMaskTypeDefault:
 			maskType = v1pb.SensitiveDataMaskType_DEFAULT
+		case api.SensitiveDataMaskTypeFull:
+			maskType = v1pb.SensitiveDataMaskType_FULL
+		case api.SensitiveDataMaskTypePartial:
+			maskType = v1pb.SensitiveDataMaskType_PARTIAL
+		case api.SensitiveDataMaskTypeHash:
+			maskType = v1pb.SensitiveDataMaskType_HASH
+		case api.SensitiveDataMaskTypeRange:
+			maskType = v1pb.SensitiveDataMaskType_RANGE
+		case api.SensitiveDataMaskTypeEmail:
+			maskType = v1pb.SensitiveDataMaskType_EMAIL
+		case api.SensitiveDataMaskTypePhone:
+			maskType = v1pb.SensitiveDataMaskType_PHONE
+		}
+		var maskOption *v1pb.SensitiveDataMaskOption
+		if option := data.Option; option != nil {
+			maskOption = &v1pb.SensitiveDataMaskOption{
+				PrefixLength: int32(option.PrefixLength),
+				SuffixLength: int32(option.SuffixLength),
+				Salt:         option.Salt,
+				BucketSize:   option.BucketSize,
+				DateUnit:     option.DateUnit,
+			}
 		}
 		sensitiveDataList = append(sensitiveDataList, &v1pb.SensitiveData{
-			Schema:   data.Schema,
-			Table:    data.Table,
-			Column:   data.Column,
-			MaskType: maskType,
+			Schema:     data.Schema,
+			Table:      data.Table,
+			Column:     data.Column,
+			MaskType:   maskType,
+			MaskOption: maskOption,
 		})
 	}
 
@@ -724,14 +748,41 @@ func convertToV1PBSensitiveDataPolicy(payloadStr string) (*v1pb.Policy_Sensitive
 func convertToSensitiveDataPolicyPayload(policy *v1pb.SensitiveDataPolicy) (*api.SensitiveDataPolicy, error) {
 	var sensitiveDataList []api.SensitiveData
 	for _, data := range policy.SensitiveData {
-		if data.MaskType != v1pb.SensitiveDataMaskType_DEFAULT {
+		var maskType api.SensitiveDataMaskType
+		switch data.MaskType {
+		case v1pb.SensitiveDataMaskType_DEFAULT:
+			maskType = api.SensitiveDataMaskTypeDefault
+		case v1pb.SensitiveDataMaskType_FULL:
+			maskType = api.SensitiveDataMaskTypeFull
+		case v1pb.SensitiveDataMaskType_PARTIAL:
+			maskType = api.SensitiveDataMaskTypePartial
+		case v1pb.SensitiveDataMaskType_HASH:
+			maskType = api.SensitiveDataMaskTypeHash
+		case v1pb.SensitiveDataMaskType_RANGE:
+			maskType = api.SensitiveDataMaskTypeRange
+		case v1pb.SensitiveDataMaskType_EMAIL:
+			maskType = api.SensitiveDataMaskTypeEmail
+		case v1pb.SensitiveDataMaskType_PHONE:
+			maskType = api.SensitiveDataMaskTypePhone
+		default:
 			return nil, errors.Errorf("invalid sensitive data mask type %v", data.MaskType)
 		}
+		var maskOption *api.SensitiveDataMaskOption
+		if option := data.MaskOption; option != nil {
+			maskOption = &api.SensitiveDataMaskOption{
+				PrefixLength: int(option.PrefixLength),
+				SuffixLength: int(option.SuffixLength),
+				Salt:         option.Salt,
+				BucketSize:   option.BucketSize,
+				DateUnit:     option.DateUnit,
+			}
+		}
 		sensitiveDataList = append(sensitiveDataList, api.SensitiveData{
 			Schema: data.Schema,
 			Table:  data.Table,
 			Column: data.Column,
-			Type:   api.SensitiveDataMaskTypeDefault,
+			Type:   maskType,
+			Option: maskOption,
 		})
 	}
 	return &api.SensitiveDataPolicy{
@@ -755,10 +806,17 @@ func convertToV1PBBackupPlanPolicy(payloadStr string) (*v1pb.Policy_BackupPlanPo
 		schedule = v1pb.BackupPlanSchedule_WEEKLY
 	}
 
+	storageBackend := v1pb.BackupStorageBackend_BACKUP_STORAGE_BACKEND_UNSPECIFIED
+	if payload.StorageBackend == api.BackupStorageBackendLocal {
+		storageBackend = v1pb.BackupStorageBackend_BACKUP_STORAGE_BACKEND_LOCAL
+	}
+
 	return &v1pb.Policy_BackupPlanPolicy{
 		BackupPlanPolicy: &v1pb.BackupPlanPolicy{
 			Schedule:          schedule,
 			RetentionDuration: &durationpb.Duration{Seconds: int64(payload.RetentionPeriodTs)},
+			StorageBackend:    storageBackend,
+			Storage:           payload.Storage,
 		},
 	}, nil
 }
@@ -781,9 +839,23 @@ func convertToBackupPlanPolicyPayload(policy *v1pb.BackupPlanPolicy) (*api.Backu
 		retentionPeriodTs = int(policy.RetentionDuration.Seconds)
 	}
 
+	var storageBackend api.BackupStorageBackend
+	switch policy.StorageBackend {
+	case v1pb.BackupStorageBackend_BACKUP_STORAGE_BACKEND_UNSPECIFIED:
+	case v1pb.BackupStorageBackend_BACKUP_STORAGE_BACKEND_LOCAL:
+		storageBackend = api.BackupStorageBackendLocal
+	default:
+		return nil, errors.Errorf("invalid backup storage backend %v", policy.StorageBackend)
+	}
+	if policy.Storage != "" && storageBackend != "" {
+		return nil, errors.Errorf("backup storage %q cannot be set with backup storage backend %v", policy.Storage, policy.StorageBackend)
+	}
+
 	return &api.BackupPlanPolicy{
 		Schedule:          schedule,
 		RetentionPeriodTs: retentionPeriodTs,
+		StorageBackend:    storageBackend,
+		Storage:           policy.Storage,
 	}, nil
 }
 
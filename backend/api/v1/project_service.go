@@ -452,6 +452,8 @@ func (s *ProjectService) UpdateWebhook(ctx context.Context, request *v1pb.Update
 				return nil, status.Errorf(codes.InvalidArgument, err.Error())
 			}
 			update.ActivityList = types
+		case "secret":
+			update.Secret = &request.Webhook.Secret
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", path)
 		}
@@ -570,6 +572,7 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 			CreatorEmail: "support@bytebase.com",
 			CreatedTs:    time.Now().Unix(),
 			Project:      &webhookPlugin.Project{Name: project.Title},
+			Secret:       webhook.Secret,
 		},
 	)
 
@@ -590,6 +593,7 @@ func convertToStoreProjectWebhookMessage(webhook *v1pb.Webhook) (*store.ProjectW
 		URL:          webhook.Url,
 		Title:        webhook.Title,
 		ActivityList: activityTypes,
+		Secret:       webhook.Secret,
 	}, nil
 }
 
@@ -716,6 +720,14 @@ func convertToAPIWebhookTypeString(tp v1pb.Webhook_Type) (string, error) {
 		return "bb.plugin.webhook.wecom", nil
 	case v1pb.Webhook_TYPE_CUSTOM:
 		return "bb.plugin.webhook.custom", nil
+	case v1pb.Webhook_TYPE_MATTERMOST:
+		return "bb.plugin.webhook.mattermost", nil
+	case v1pb.Webhook_TYPE_GOOGLE_CHAT:
+		return "bb.plugin.webhook.googlechat", nil
+	case v1pb.Webhook_TYPE_TELEGRAM:
+		return "bb.plugin.webhook.telegram", nil
+	case v1pb.Webhook_TYPE_PAGERDUTY:
+		return "bb.plugin.webhook.pagerduty", nil
 	default:
 		return "", common.Errorf(common.Invalid, "webhook type %q is not supported", tp)
 	}
@@ -737,6 +749,14 @@ func convertWebhookTypeString(tp string) v1pb.Webhook_Type {
 		return v1pb.Webhook_TYPE_WECOM
 	case "bb.plugin.webhook.custom":
 		return v1pb.Webhook_TYPE_CUSTOM
+	case "bb.plugin.webhook.mattermost":
+		return v1pb.Webhook_TYPE_MATTERMOST
+	case "bb.plugin.webhook.googlechat":
+		return v1pb.Webhook_TYPE_GOOGLE_CHAT
+	case "bb.plugin.webhook.telegram":
+		return v1pb.Webhook_TYPE_TELEGRAM
+	case "bb.plugin.webhook.pagerduty":
+		return v1pb.Webhook_TYPE_PAGERDUTY
 	default:
 		return v1pb.Webhook_TYPE_UNSPECIFIED
 	}
@@ -759,11 +779,11 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 				return nil, common.Errorf(common.Invalid, "label selector expression must not be empty")
 			}
 			switch e.Operator {
-			case v1pb.OperatorType_OPERATOR_TYPE_IN:
+			case v1pb.OperatorType_OPERATOR_TYPE_IN, v1pb.OperatorType_OPERATOR_TYPE_NOT_IN:
 				if len(e.Values) == 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator should have at least one value", e.Key, e.Operator)
 				}
-			case v1pb.OperatorType_OPERATOR_TYPE_EXISTS:
+			case v1pb.OperatorType_OPERATOR_TYPE_EXISTS, v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST:
 				if len(e.Values) > 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator shouldn't have values", e.Key, e.Operator)
 				}
@@ -780,10 +800,35 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", api.EnvironmentLabelKey)
 		}
+		if err := validateRolloutStrategy(d.Spec.Strategy); err != nil {
+			return nil, err
+		}
 	}
 	return convertToStoreDeploymentConfig(deployment)
 }
 
+func validateRolloutStrategy(strategy *v1pb.RolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+	if strategy.CanaryPercentage < 0 || strategy.CanaryPercentage > 100 {
+		return common.Errorf(common.Invalid, "canary percentage should be between 0 and 100, got %d", strategy.CanaryPercentage)
+	}
+	if strategy.CanaryCount < 0 {
+		return common.Errorf(common.Invalid, "canary count should not be negative, got %d", strategy.CanaryCount)
+	}
+	if strategy.CanaryPercentage > 0 && strategy.CanaryCount > 0 {
+		return common.Errorf(common.Invalid, "only one of canary percentage and canary count can be set")
+	}
+	if strategy.MaxParallelism < 0 {
+		return common.Errorf(common.Invalid, "max parallelism should not be negative, got %d", strategy.MaxParallelism)
+	}
+	if strategy.FailureThreshold < 0 {
+		return common.Errorf(common.Invalid, "failure threshold should not be negative, got %d", strategy.FailureThreshold)
+	}
+	return nil
+}
+
 func (s *ProjectService) getProjectMessage(ctx context.Context, name string) (*store.ProjectMessage, error) {
 	projectID, err := getProjectID(name)
 	if err != nil {
@@ -1097,6 +1142,7 @@ func convertToStoreDeployment(deployment *v1pb.ScheduleDeployment) (*store.Deplo
 func convertToSpec(spec *store.DeploymentSpec) *v1pb.DeploymentSpec {
 	return &v1pb.DeploymentSpec{
 		LabelSelector: convertToLabelSelector(spec.Selector),
+		Strategy:      convertToRolloutStrategy(spec.Strategy),
 	}
 }
 
@@ -1107,9 +1153,34 @@ func convertToStoreSpec(spec *v1pb.DeploymentSpec) (*store.DeploymentSpec, error
 	}
 	return &store.DeploymentSpec{
 		Selector: selector,
+		Strategy: convertToStoreRolloutStrategy(spec.Strategy),
 	}, nil
 }
 
+func convertToRolloutStrategy(strategy *store.RolloutStrategy) *v1pb.RolloutStrategy {
+	if strategy == nil {
+		return nil
+	}
+	return &v1pb.RolloutStrategy{
+		CanaryPercentage: int32(strategy.CanaryPercentage),
+		CanaryCount:      int32(strategy.CanaryCount),
+		MaxParallelism:   int32(strategy.MaxParallelism),
+		FailureThreshold: int32(strategy.FailureThreshold),
+	}
+}
+
+func convertToStoreRolloutStrategy(strategy *v1pb.RolloutStrategy) *store.RolloutStrategy {
+	if strategy == nil {
+		return nil
+	}
+	return &store.RolloutStrategy{
+		CanaryPercentage: int(strategy.CanaryPercentage),
+		CanaryCount:      int(strategy.CanaryCount),
+		MaxParallelism:   int(strategy.MaxParallelism),
+		FailureThreshold: int(strategy.FailureThreshold),
+	}
+}
+
 func convertToLabelSelector(selector *store.LabelSelector) *v1pb.LabelSelector {
 	var exprs []*v1pb.LabelSelectorRequirement
 	for _, expr := range selector.MatchExpressions {
@@ -1159,8 +1230,12 @@ func convertToLabelSelectorOperator(operator store.OperatorType) v1pb.OperatorTy
 	switch operator {
 	case store.InOperatorType:
 		return v1pb.OperatorType_OPERATOR_TYPE_IN
+	case store.NotInOperatorType:
+		return v1pb.OperatorType_OPERATOR_TYPE_NOT_IN
 	case store.ExistsOperatorType:
 		return v1pb.OperatorType_OPERATOR_TYPE_EXISTS
+	case store.DoesNotExistOperatorType:
+		return v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST
 	}
 	return v1pb.OperatorType_OPERATOR_TYPE_UNSPECIFIED
 }
@@ -1169,8 +1244,12 @@ func convertToStoreLabelSelectorOperator(operator v1pb.OperatorType) (store.Oper
 	switch operator {
 	case v1pb.OperatorType_OPERATOR_TYPE_IN:
 		return store.InOperatorType, nil
+	case v1pb.OperatorType_OPERATOR_TYPE_NOT_IN:
+		return store.NotInOperatorType, nil
 	case v1pb.OperatorType_OPERATOR_TYPE_EXISTS:
 		return store.ExistsOperatorType, nil
+	case v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST:
+		return store.DoesNotExistOperatorType, nil
 	}
 	return store.OperatorType(""), errors.Errorf("invalid operator type: %v", operator)
 }
@@ -0,0 +1,1066 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/runner/taskcheck"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// RolloutService represents a service for managing rollout.
+type RolloutService struct {
+	v1pb.UnimplementedRolloutServiceServer
+	store              *store.Store
+	licenseService     enterpriseAPI.LicenseService
+	taskCheckScheduler *taskcheck.Scheduler
+}
+
+// NewRolloutService returns a rollout service instance.
+func NewRolloutService(store *store.Store, licenseService enterpriseAPI.LicenseService, taskCheckScheduler *taskcheck.Scheduler) *RolloutService {
+	return &RolloutService{
+		store:              store,
+		licenseService:     licenseService,
+		taskCheckScheduler: taskCheckScheduler,
+	}
+}
+
+// GetPlan gets a plan.
+func (s *RolloutService) GetPlan(ctx context.Context, request *v1pb.GetPlanRequest) (*v1pb.Plan, error) {
+	projectID, planID, err := getProjectIDPlanID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkProjectMember(ctx, projectID); err != nil {
+		return nil, err
+	}
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID, ProjectID: &projectID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
+	}
+	if plan == nil {
+		return nil, status.Errorf(codes.NotFound, "plan %q not found", request.Name)
+	}
+	return convertToPlan(plan), nil
+}
+
+// ListPlans lists plans.
+func (s *RolloutService) ListPlans(ctx context.Context, request *v1pb.ListPlansRequest) (*v1pb.ListPlansResponse, error) {
+	projectID, err := getProjectID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	offset, err := unmarshalPageToken(request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token, error: %v", err)
+	}
+	limit := getPageSize(request.PageSize, defaultPageSize)
+	// Fetch one more plan to know whether there is a next page.
+	limitPlusOne := limit + 1
+
+	find := &store.FindPlanMessage{
+		Limit:  &limitPlusOne,
+		Offset: &offset,
+	}
+	if projectID != "-" {
+		if err := s.checkProjectMember(ctx, projectID); err != nil {
+			return nil, err
+		}
+		find.ProjectID = &projectID
+	} else if role := ctx.Value(common.RoleContextKey).(api.Role); !isOwnerOrDBA(role) {
+		// Users other than the workspace Owner and DBA can only list the plans of the projects they are members of.
+		projectIDs, err := s.getMemberProjectIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		find.ProjectIDs = &projectIDs
+	}
+	plans, err := s.store.ListPlans(ctx, find)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list plans, error: %v", err)
+	}
+
+	response := &v1pb.ListPlansResponse{}
+	if len(plans) == limitPlusOne {
+		plans = plans[:limit]
+		nextPageToken, err := marshalPageToken(offset + limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+	}
+	for _, plan := range plans {
+		response.Plans = append(response.Plans, convertToPlan(plan))
+	}
+	return response, nil
+}
+
+// CreatePlan creates a new plan.
+func (s *RolloutService) CreatePlan(ctx context.Context, request *v1pb.CreatePlanRequest) (*v1pb.Plan, error) {
+	if request.Plan == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "plan must be set")
+	}
+	project, err := s.getProjectMessage(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectPermission(ctx, project.ResourceID, api.ProjectPermissionChangeDatabase); err != nil {
+		return nil, err
+	}
+	if err := validatePlanSteps(request.Plan.Steps); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	config := convertPlanSteps(request.Plan.Steps)
+	// Validate the plan by building the pipeline.
+	if _, err := s.getPipelineCreate(ctx, project, request.Plan.Title, config.Steps); err != nil {
+		return nil, err
+	}
+
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	plan, err := s.store.CreatePlan(ctx, &store.PlanMessage{
+		ProjectID:   project.ResourceID,
+		Name:        request.Plan.Title,
+		Description: request.Plan.Description,
+		Config:      config,
+	}, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create plan, error: %v", err)
+	}
+	return convertToPlan(plan), nil
+}
+
+// UpdatePlan updates a plan.
+func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePlanRequest) (*v1pb.Plan, error) {
+	if request.Plan == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "plan must be set")
+	}
+	if request.UpdateMask == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must be set")
+	}
+	projectID, planID, err := getProjectIDPlanID(request.Plan.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkProjectPermission(ctx, projectID, api.ProjectPermissionChangeDatabase); err != nil {
+		return nil, err
+	}
+	oldPlan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID, ProjectID: &projectID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
+	}
+	if oldPlan == nil {
+		return nil, status.Errorf(codes.NotFound, "plan %q not found", request.Plan.Name)
+	}
+
+	patch := &store.UpdatePlanMessage{
+		UID:       oldPlan.UID,
+		UpdaterID: ctx.Value(common.PrincipalIDContextKey).(int),
+	}
+	for _, path := range request.UpdateMask.Paths {
+		switch path {
+		case "title":
+			patch.Name = &request.Plan.Title
+		case "description":
+			patch.Description = &request.Plan.Description
+		case "steps":
+			if oldPlan.PipelineUID != nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "cannot update the steps of plan %q because its rollout has been created", request.Plan.Name)
+			}
+			project, err := s.getProjectMessage(ctx, fmt.Sprintf("%s%s", projectNamePrefix, projectID))
+			if err != nil {
+				return nil, err
+			}
+			if err := validatePlanSteps(request.Plan.Steps); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			config := convertPlanSteps(request.Plan.Steps)
+			if _, err := s.getPipelineCreate(ctx, project, oldPlan.Name, config.Steps); err != nil {
+				return nil, err
+			}
+			patch.Config = config
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update mask path: %s", path)
+		}
+	}
+
+	plan, err := s.store.UpdatePlan(ctx, patch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update plan, error: %v", err)
+	}
+	return convertToPlan(plan), nil
+}
+
+// GetRollout gets a rollout.
+func (s *RolloutService) GetRollout(ctx context.Context, request *v1pb.GetRolloutRequest) (*v1pb.Rollout, error) {
+	projectID, rolloutID, err := getProjectIDRolloutID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkProjectMember(ctx, projectID); err != nil {
+		return nil, err
+	}
+	project, pipeline, err := s.getProjectAndPipeline(ctx, projectID, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+	rollout, err := convertToRollout(ctx, s.store, project, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert to rollout, error: %v", err)
+	}
+	return rollout, nil
+}
+
+// CreateRollout creates a rollout from the plan.
+func (s *RolloutService) CreateRollout(ctx context.Context, request *v1pb.CreateRolloutRequest) (*v1pb.Rollout, error) {
+	project, err := s.getProjectMessage(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %q has been deleted", request.Parent)
+	}
+	if err := s.checkProjectPermission(ctx, project.ResourceID, api.ProjectPermissionChangeDatabase); err != nil {
+		return nil, err
+	}
+	planProjectID, planID, err := getProjectIDPlanID(request.Plan)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if planProjectID != project.ResourceID {
+		return nil, status.Errorf(codes.InvalidArgument, "plan %q does not belong to project %q", request.Plan, request.Parent)
+	}
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID, ProjectID: &planProjectID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
+	}
+	if plan == nil {
+		return nil, status.Errorf(codes.NotFound, "plan %q not found", request.Plan)
+	}
+	if plan.PipelineUID != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "the rollout of plan %q has been created", request.Plan)
+	}
+
+	pipelineCreate, err := s.getPipelineCreate(ctx, project, plan.Name, plan.Config.Steps)
+	if err != nil {
+		return nil, err
+	}
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	pipeline, err := s.store.CreatePipelineAIO(ctx, plan.UID, pipelineCreate, principalID)
+	if err != nil {
+		if common.ErrorCode(err) == common.Conflict {
+			return nil, status.Errorf(codes.AlreadyExists, "the rollout of plan %q has been created", request.Plan)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create pipeline, error: %v", err)
+	}
+	if err := s.taskCheckScheduler.SchedulePipelineTaskCheck(ctx, pipeline.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to schedule task checks for the rollout, error: %v", err)
+	}
+
+	rollout, err := convertToRollout(ctx, s.store, project, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert to rollout, error: %v", err)
+	}
+	return rollout, nil
+}
+
+// PreviewRollout previews the rollout for a plan without creating it.
+func (s *RolloutService) PreviewRollout(ctx context.Context, request *v1pb.PreviewRolloutRequest) (*v1pb.Rollout, error) {
+	if request.Plan == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "plan must be set")
+	}
+	project, err := s.getProjectMessage(ctx, request.Project)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectPermission(ctx, project.ResourceID, api.ProjectPermissionChangeDatabase); err != nil {
+		return nil, err
+	}
+	if err := validatePlanSteps(request.Plan.Steps); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	config := convertPlanSteps(request.Plan.Steps)
+	pipelineCreate, err := s.getPipelineCreate(ctx, project, request.Plan.Title, config.Steps)
+	if err != nil {
+		return nil, err
+	}
+	rollout, err := convertToRolloutPreview(ctx, s.store, project, pipelineCreate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert to rollout, error: %v", err)
+	}
+	return rollout, nil
+}
+
+// ListTaskRuns lists the task runs of a rollout.
+func (s *RolloutService) ListTaskRuns(ctx context.Context, request *v1pb.ListTaskRunsRequest) (*v1pb.ListTaskRunsResponse, error) {
+	projectID, rolloutID, maybeStageID, maybeTaskID, err := getProjectIDRolloutIDMaybeStageIDMaybeTaskID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkProjectMember(ctx, projectID); err != nil {
+		return nil, err
+	}
+	project, pipeline, err := s.getProjectAndPipeline(ctx, projectID, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.store.ListTasks(ctx, &api.TaskFind{PipelineID: &pipeline.ID, StripPayload: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tasks, error: %v", err)
+	}
+	taskStageIDs := make(map[int]int)
+	for _, task := range tasks {
+		taskStageIDs[task.ID] = task.StageID
+	}
+
+	taskRuns, err := s.store.ListTaskRun(ctx, &store.TaskRunFind{
+		PipelineID: &pipeline.ID,
+		StageID:    maybeStageID,
+		TaskID:     maybeTaskID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list task runs, error: %v", err)
+	}
+
+	response := &v1pb.ListTaskRunsResponse{}
+	for _, taskRun := range taskRuns {
+		v1TaskRun, err := convertToTaskRun(ctx, s.store, project, pipeline.ID, taskStageIDs[taskRun.TaskID], taskRun)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert to task run, error: %v", err)
+		}
+		response.TaskRuns = append(response.TaskRuns, v1TaskRun)
+	}
+	return response, nil
+}
+
+func (s *RolloutService) getProjectMessage(ctx context.Context, name string) (*store.ProjectMessage, error) {
+	projectID, err := getProjectID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID:  &projectID,
+		ShowDeleted: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", name)
+	}
+	return project, nil
+}
+
+func (s *RolloutService) getProjectRoles(ctx context.Context, projectID string) (map[common.ProjectRole]bool, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &projectID})
+	if err != nil {
+		return nil, err
+	}
+	projectRoles := make(map[common.ProjectRole]bool)
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if member.ID == currentPrincipalID {
+				projectRoles[common.ProjectRole(binding.Role)] = true
+				break
+			}
+		}
+	}
+	return projectRoles, nil
+}
+
+// checkProjectMember checks whether the current user is a member of the project.
+// Workspace Owner or DBA assumes project Owner role for all projects.
+func (s *RolloutService) checkProjectMember(ctx context.Context, projectID string) error {
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+	if isOwnerOrDBA(role) {
+		return nil
+	}
+	projectRoles, err := s.getProjectRoles(ctx, projectID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get project roles, error: %v", err)
+	}
+	if len(projectRoles) == 0 {
+		return status.Errorf(codes.PermissionDenied, "not a member of project %q", projectID)
+	}
+	return nil
+}
+
+// checkProjectPermission checks whether the current user has the permission in the project.
+// Workspace Owner or DBA assumes project Owner role for all projects.
+func (s *RolloutService) checkProjectPermission(ctx context.Context, projectID string, permission api.ProjectPermissionType) error {
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+	if isOwnerOrDBA(role) {
+		return nil
+	}
+	projectRoles, err := s.getProjectRoles(ctx, projectID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get project roles, error: %v", err)
+	}
+	if len(projectRoles) == 0 {
+		return status.Errorf(codes.PermissionDenied, "not a member of project %q", projectID)
+	}
+	if !api.ProjectPermission(permission, s.licenseService.GetEffectivePlan(), projectRoles) {
+		return status.Errorf(codes.PermissionDenied, "permission %q denied in project %q", permission, projectID)
+	}
+	return nil
+}
+
+// getMemberProjectIDs returns the IDs of the projects that the current user is a member of.
+func (s *RolloutService) getMemberProjectIDs(ctx context.Context) ([]string, error) {
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	projects, err := s.store.ListProjectV2(ctx, &store.FindProjectMessage{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list projects, error: %v", err)
+	}
+	projectIDs := []string{}
+	for _, project := range projects {
+		policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &project.ResourceID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project policy, error: %v", err)
+		}
+		if isProjectMember(policy, principalID) {
+			projectIDs = append(projectIDs, project.ResourceID)
+		}
+	}
+	return projectIDs, nil
+}
+
+// getProjectAndPipeline gets the project and the pipeline, and checks that the pipeline belongs to the project
+// either by the plan or by the issue.
+func (s *RolloutService) getProjectAndPipeline(ctx context.Context, projectID string, pipelineUID int) (*store.ProjectMessage, *store.PipelineMessage, error) {
+	project, err := s.getProjectMessage(ctx, fmt.Sprintf("%s%s", projectNamePrefix, projectID))
+	if err != nil {
+		return nil, nil, err
+	}
+	pipeline, err := s.store.GetPipelineV2ByID(ctx, pipelineUID)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get pipeline, error: %v", err)
+	}
+	if pipeline == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "rollout %d not found in project %q", pipelineUID, projectID)
+	}
+
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{PipelineUID: &pipeline.ID, ProjectID: &project.ResourceID})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
+	}
+	if plan != nil {
+		return project, pipeline, nil
+	}
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &pipeline.ID, ProjectUID: &project.UID})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get issue, error: %v", err)
+	}
+	if issue != nil {
+		return project, pipeline, nil
+	}
+	return nil, nil, status.Errorf(codes.NotFound, "rollout %d not found in project %q", pipelineUID, projectID)
+}
+
+// getPipelineCreate builds the pipeline from the plan steps.
+// Tasks are grouped into stages by the environment of the target databases, and the stages are ordered by the environment order.
+// Tasks generated from a step are blocked by the tasks generated from the previous step in the same stage.
+func (s *RolloutService) getPipelineCreate(ctx context.Context, project *store.ProjectMessage, title string, steps []*storepb.PlanConfig_Step) (*api.PipelineCreate, error) {
+	type stageTasks struct {
+		environment *store.EnvironmentMessage
+		taskCreates []api.TaskCreate
+		// stepTaskIndexes is the indexes of the tasks of each step that has tasks in the stage.
+		stepTaskIndexes [][]int
+	}
+	stageMap := make(map[string]*stageTasks)
+	specIDs := make(map[string]bool)
+
+	for _, step := range steps {
+		stepStageTaskIndexes := make(map[string][]int)
+		for _, spec := range step.Specs {
+			if spec.Id == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "spec id must be set")
+			}
+			if specIDs[spec.Id] {
+				return nil, status.Errorf(codes.InvalidArgument, "duplicate spec id %q", spec.Id)
+			}
+			specIDs[spec.Id] = true
+
+			var taskCreate *api.TaskCreate
+			var environment *store.EnvironmentMessage
+			switch config := spec.Config.(type) {
+			case *storepb.PlanConfig_Spec_ChangeDatabaseConfig:
+				c, e, err := s.getTaskCreateFromChangeDatabaseConfig(ctx, project, spec.Id, config.ChangeDatabaseConfig)
+				if err != nil {
+					return nil, err
+				}
+				taskCreate, environment = c, e
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "unsupported config of spec %q", spec.Id)
+			}
+
+			stage, ok := stageMap[environment.ResourceID]
+			if !ok {
+				stage = &stageTasks{environment: environment}
+				stageMap[environment.ResourceID] = stage
+			}
+			stage.taskCreates = append(stage.taskCreates, *taskCreate)
+			stepStageTaskIndexes[environment.ResourceID] = append(stepStageTaskIndexes[environment.ResourceID], len(stage.taskCreates)-1)
+		}
+		for environmentID, indexes := range stepStageTaskIndexes {
+			stageMap[environmentID].stepTaskIndexes = append(stageMap[environmentID].stepTaskIndexes, indexes)
+		}
+	}
+
+	var stages []*stageTasks
+	for _, stage := range stageMap {
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		return stages[i].environment.Order < stages[j].environment.Order
+	})
+
+	create := &api.PipelineCreate{
+		Name: title,
+	}
+	for _, stage := range stages {
+		stageCreate := api.StageCreate{
+			Name:          fmt.Sprintf("%s Stage", stage.environment.Title),
+			EnvironmentID: stage.environment.UID,
+			TaskList:      stage.taskCreates,
+		}
+		for i := 1; i < len(stage.stepTaskIndexes); i++ {
+			for _, from := range stage.stepTaskIndexes[i-1] {
+				for _, to := range stage.stepTaskIndexes[i] {
+					stageCreate.TaskIndexDAGList = append(stageCreate.TaskIndexDAGList, api.TaskIndexDAG{FromIndex: from, ToIndex: to})
+				}
+			}
+		}
+		create.StageList = append(create.StageList, stageCreate)
+	}
+	return create, nil
+}
+
+// validatePlanSteps validates that the plan has steps and every step has specs,
+// otherwise the rollout of the plan would have no tasks.
+func validatePlanSteps(steps []*v1pb.Plan_Step) error {
+	if len(steps) == 0 {
+		return errors.Errorf("plan must have at least one step")
+	}
+	for i, step := range steps {
+		if len(step.GetSpecs()) == 0 {
+			return errors.Errorf("step %d of the plan must have at least one spec", i+1)
+		}
+	}
+	return nil
+}
+
+func (s *RolloutService) getTaskCreateFromChangeDatabaseConfig(ctx context.Context, project *store.ProjectMessage, specID string, c *storepb.PlanConfig_ChangeDatabaseConfig) (*api.TaskCreate, *store.EnvironmentMessage, error) {
+	instanceID, databaseName, err := getInstanceDatabaseID(c.Target)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid target of spec %q, error: %v", specID, err)
+	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &instanceID})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get instance %q, error: %v", instanceID, err)
+	}
+	if instance == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "instance %q not found", instanceID)
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instanceID, DatabaseName: &databaseName})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get database %q, error: %v", c.Target, err)
+	}
+	if database == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "database %q not found", c.Target)
+	}
+	if database.ProjectID != project.ResourceID {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "database %q does not belong to project %q", c.Target, project.ResourceID)
+	}
+	environment, err := s.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EnvironmentID})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get environment %q, error: %v", database.EnvironmentID, err)
+	}
+	if environment == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "environment %q not found", database.EnvironmentID)
+	}
+
+	schemaVersion := c.SchemaVersion
+	if schemaVersion == "" {
+		schemaVersion = common.DefaultMigrationVersion()
+	}
+
+	var sheetUID int
+	if c.Type != storepb.PlanConfig_ChangeDatabaseConfig_BASELINE {
+		sheetProjectID, uid, err := getProjectIDSheetID(c.Sheet)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid sheet of spec %q, error: %v", specID, err)
+		}
+		if sheetProjectID != project.ResourceID {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "sheet %q does not belong to project %q", c.Sheet, project.ResourceID)
+		}
+		sheet, err := s.store.GetSheetV2(ctx, &api.SheetFind{ID: &uid}, api.SystemBotID)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to get sheet %q, error: %v", c.Sheet, err)
+		}
+		if sheet == nil || sheet.Project.ResourceID != project.ResourceID {
+			return nil, nil, status.Errorf(codes.NotFound, "sheet %q not found", c.Sheet)
+		}
+		sheetUID = uid
+	}
+
+	var taskName string
+	var taskType api.TaskType
+	var payload any
+	switch c.Type {
+	case storepb.PlanConfig_ChangeDatabaseConfig_BASELINE:
+		taskName = fmt.Sprintf("Establish baseline for database %q", database.DatabaseName)
+		taskType = api.TaskDatabaseSchemaBaseline
+		payload = api.TaskDatabaseSchemaBaselinePayload{
+			SpecID:        specID,
+			SchemaVersion: schemaVersion,
+		}
+	case storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE:
+		taskName = fmt.Sprintf("DDL(schema) for database %q", database.DatabaseName)
+		taskType = api.TaskDatabaseSchemaUpdate
+		payload = api.TaskDatabaseSchemaUpdatePayload{
+			SpecID:        specID,
+			SheetID:       sheetUID,
+			SchemaVersion: schemaVersion,
+		}
+	case storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE_SDL:
+		taskName = fmt.Sprintf("SDL for database %q", database.DatabaseName)
+		taskType = api.TaskDatabaseSchemaUpdateSDL
+		payload = api.TaskDatabaseSchemaUpdateSDLPayload{
+			SpecID:        specID,
+			SheetID:       sheetUID,
+			SchemaVersion: schemaVersion,
+		}
+	case storepb.PlanConfig_ChangeDatabaseConfig_DATA:
+		taskName = fmt.Sprintf("DML(data) for database %q", database.DatabaseName)
+		taskType = api.TaskDatabaseDataUpdate
+		payload = api.TaskDatabaseDataUpdatePayload{
+			SpecID:            specID,
+			SheetID:           sheetUID,
+			SchemaVersion:     schemaVersion,
+			RollbackEnabled:   c.RollbackEnabled,
+			RollbackSQLStatus: api.RollbackSQLStatusPending,
+		}
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported change database config type %q of spec %q", c.Type, specID)
+	}
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to marshal task payload, error: %v", err)
+	}
+
+	return &api.TaskCreate{
+		Name:       taskName,
+		InstanceID: instance.UID,
+		DatabaseID: &database.UID,
+		Status:     api.TaskPendingApproval,
+		Type:       taskType,
+		Payload:    string(bytes),
+	}, environment, nil
+}
+
+func convertToPlan(plan *store.PlanMessage) *v1pb.Plan {
+	v1Plan := &v1pb.Plan{
+		Name:        fmt.Sprintf("%s%s/%s%d", projectNamePrefix, plan.ProjectID, planPrefix, plan.UID),
+		Uid:         fmt.Sprintf("%d", plan.UID),
+		Title:       plan.Name,
+		Description: plan.Description,
+		Steps:       convertToPlanSteps(plan.Config.Steps),
+	}
+	if plan.PipelineUID != nil {
+		v1Plan.Rollout = fmt.Sprintf("%s%s/%s%d", projectNamePrefix, plan.ProjectID, rolloutPrefix, *plan.PipelineUID)
+	}
+	return v1Plan
+}
+
+func convertToPlanSteps(steps []*storepb.PlanConfig_Step) []*v1pb.Plan_Step {
+	var v1Steps []*v1pb.Plan_Step
+	for _, step := range steps {
+		v1Step := &v1pb.Plan_Step{}
+		for _, spec := range step.Specs {
+			v1Spec := &v1pb.Plan_Spec{Id: spec.Id}
+			if config, ok := spec.Config.(*storepb.PlanConfig_Spec_ChangeDatabaseConfig); ok {
+				c := config.ChangeDatabaseConfig
+				v1Spec.Config = &v1pb.Plan_Spec_ChangeDatabaseConfig{
+					ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
+						Target:          c.Target,
+						Sheet:           c.Sheet,
+						Type:            v1pb.Plan_ChangeDatabaseConfig_Type(c.Type),
+						SchemaVersion:   c.SchemaVersion,
+						RollbackEnabled: c.RollbackEnabled,
+					},
+				}
+			}
+			v1Step.Specs = append(v1Step.Specs, v1Spec)
+		}
+		v1Steps = append(v1Steps, v1Step)
+	}
+	return v1Steps
+}
+
+func convertPlanSteps(steps []*v1pb.Plan_Step) *storepb.PlanConfig {
+	config := &storepb.PlanConfig{}
+	for _, step := range steps {
+		storeStep := &storepb.PlanConfig_Step{}
+		for _, spec := range step.Specs {
+			storeSpec := &storepb.PlanConfig_Spec{Id: spec.Id}
+			if c, ok := spec.Config.(*v1pb.Plan_Spec_ChangeDatabaseConfig); ok && c.ChangeDatabaseConfig != nil {
+				storeSpec.Config = &storepb.PlanConfig_Spec_ChangeDatabaseConfig{
+					ChangeDatabaseConfig: &storepb.PlanConfig_ChangeDatabaseConfig{
+						Target:          c.ChangeDatabaseConfig.Target,
+						Sheet:           c.ChangeDatabaseConfig.Sheet,
+						Type:            storepb.PlanConfig_ChangeDatabaseConfig_Type(c.ChangeDatabaseConfig.Type),
+						SchemaVersion:   c.ChangeDatabaseConfig.SchemaVersion,
+						RollbackEnabled: c.ChangeDatabaseConfig.RollbackEnabled,
+					},
+				}
+			}
+			storeStep.Specs = append(storeStep.Specs, storeSpec)
+		}
+		config.Steps = append(config.Steps, storeStep)
+	}
+	return config
+}
+
+func convertToRollout(ctx context.Context, s *store.Store, project *store.ProjectMessage, pipeline *store.PipelineMessage) (*v1pb.Rollout, error) {
+	rolloutName := fmt.Sprintf("%s%s/%s%d", projectNamePrefix, project.ResourceID, rolloutPrefix, pipeline.ID)
+	rollout := &v1pb.Rollout{
+		Name:  rolloutName,
+		Uid:   fmt.Sprintf("%d", pipeline.ID),
+		Title: pipeline.Name,
+	}
+	plan, err := s.GetPlan(ctx, &store.FindPlanMessage{PipelineUID: &pipeline.ID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get plan of pipeline %d", pipeline.ID)
+	}
+	if plan != nil {
+		rollout.Plan = fmt.Sprintf("%s%s/%s%d", projectNamePrefix, plan.ProjectID, planPrefix, plan.UID)
+	}
+
+	stages, err := s.ListStageV2(ctx, pipeline.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list stages of pipeline %d", pipeline.ID)
+	}
+	tasks, err := s.ListTasks(ctx, &api.TaskFind{PipelineID: &pipeline.ID, StripPayload: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list tasks of pipeline %d", pipeline.ID)
+	}
+	taskStageIDs := make(map[int]int)
+	for _, task := range tasks {
+		taskStageIDs[task.ID] = task.StageID
+	}
+
+	for _, stage := range stages {
+		environment, err := s.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{UID: &stage.EnvironmentID})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get environment %d", stage.EnvironmentID)
+		}
+		if environment == nil {
+			return nil, errors.Errorf("environment %d not found", stage.EnvironmentID)
+		}
+		stageName := fmt.Sprintf("%s/%s%d", rolloutName, stagePrefix, stage.ID)
+		v1Stage := &v1pb.Stage{
+			Name:        stageName,
+			Uid:         fmt.Sprintf("%d", stage.ID),
+			Environment: fmt.Sprintf("%s%s", environmentNamePrefix, environment.ResourceID),
+			Title:       stage.Name,
+		}
+		for _, task := range tasks {
+			if task.StageID != stage.ID {
+				continue
+			}
+			v1Task, err := convertToTask(ctx, s, project, task)
+			if err != nil {
+				return nil, err
+			}
+			v1Task.Name = fmt.Sprintf("%s/%s%d", stageName, taskPrefix, task.ID)
+			v1Task.Uid = fmt.Sprintf("%d", task.ID)
+			for _, blockedBy := range task.BlockedBy {
+				v1Task.BlockedByTasks = append(v1Task.BlockedByTasks, fmt.Sprintf("%s/%s%d/%s%d", rolloutName, stagePrefix, taskStageIDs[blockedBy], taskPrefix, blockedBy))
+			}
+			v1Stage.Tasks = append(v1Stage.Tasks, v1Task)
+		}
+		rollout.Stages = append(rollout.Stages, v1Stage)
+	}
+	return rollout, nil
+}
+
+// convertToRolloutPreview converts the pipeline to be created to the rollout.
+// The names and uids are empty because the resources are not created yet.
+func convertToRolloutPreview(ctx context.Context, s *store.Store, project *store.ProjectMessage, pipelineCreate *api.PipelineCreate) (*v1pb.Rollout, error) {
+	rollout := &v1pb.Rollout{
+		Title: pipelineCreate.Name,
+	}
+	for _, stageCreate := range pipelineCreate.StageList {
+		environmentUID := stageCreate.EnvironmentID
+		environment, err := s.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{UID: &environmentUID})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get environment %d", environmentUID)
+		}
+		if environment == nil {
+			return nil, errors.Errorf("environment %d not found", environmentUID)
+		}
+		v1Stage := &v1pb.Stage{
+			Environment: fmt.Sprintf("%s%s", environmentNamePrefix, environment.ResourceID),
+			Title:       stageCreate.Name,
+		}
+		for _, taskCreate := range stageCreate.TaskList {
+			v1Task, err := convertToTask(ctx, s, project, &store.TaskMessage{
+				InstanceID: taskCreate.InstanceID,
+				DatabaseID: taskCreate.DatabaseID,
+				Name:       taskCreate.Name,
+				Status:     taskCreate.Status,
+				Type:       taskCreate.Type,
+				Payload:    taskCreate.Payload,
+			})
+			if err != nil {
+				return nil, err
+			}
+			v1Stage.Tasks = append(v1Stage.Tasks, v1Task)
+		}
+		rollout.Stages = append(rollout.Stages, v1Stage)
+	}
+	return rollout, nil
+}
+
+func convertToTask(ctx context.Context, s *store.Store, project *store.ProjectMessage, task *store.TaskMessage) (*v1pb.Task, error) {
+	instance, err := s.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance %d", task.InstanceID)
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	v1Task := &v1pb.Task{
+		Title:  task.Name,
+		Status: convertToTaskStatus(task.Status),
+		Type:   convertToTaskType(task.Type),
+		Target: fmt.Sprintf("%s%s", instanceNamePrefix, instance.ResourceID),
+	}
+	if task.DatabaseID != nil {
+		database, err := s.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID, ShowDeleted: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get database %d", *task.DatabaseID)
+		}
+		if database == nil {
+			return nil, errors.Errorf("database %d not found", *task.DatabaseID)
+		}
+		v1Task.Target = fmt.Sprintf("%s%s/%s%s", instanceNamePrefix, database.InstanceID, databaseIDPrefix, database.DatabaseName)
+	}
+
+	sheetName := func(sheetUID int) string {
+		if sheetUID == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%s%s/%s%d", projectNamePrefix, project.ResourceID, sheetIDPrefix, sheetUID)
+	}
+	switch task.Type {
+	case api.TaskDatabaseCreate:
+		payload := &api.TaskDatabaseCreatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.Payload = &v1pb.Task_DatabaseCreate_{
+			DatabaseCreate: &v1pb.Task_DatabaseCreate{
+				Project:      fmt.Sprintf("%s%s", projectNamePrefix, project.ResourceID),
+				Database:     payload.DatabaseName,
+				Table:        payload.TableName,
+				Sheet:        sheetName(payload.SheetID),
+				CharacterSet: payload.CharacterSet,
+				Collation:    payload.Collation,
+			},
+		}
+	case api.TaskDatabaseSchemaBaseline:
+		payload := &api.TaskDatabaseSchemaBaselinePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.SpecId = payload.SpecID
+		v1Task.Payload = &v1pb.Task_DatabaseSchemaBaseline_{
+			DatabaseSchemaBaseline: &v1pb.Task_DatabaseSchemaBaseline{
+				SchemaVersion: payload.SchemaVersion,
+			},
+		}
+	case api.TaskDatabaseSchemaUpdate:
+		payload := &api.TaskDatabaseSchemaUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.SpecId = payload.SpecID
+		v1Task.Payload = &v1pb.Task_DatabaseSchemaUpdate_{
+			DatabaseSchemaUpdate: &v1pb.Task_DatabaseSchemaUpdate{
+				Sheet:         sheetName(payload.SheetID),
+				SchemaVersion: payload.SchemaVersion,
+			},
+		}
+	case api.TaskDatabaseSchemaUpdateSDL:
+		payload := &api.TaskDatabaseSchemaUpdateSDLPayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.SpecId = payload.SpecID
+		v1Task.Payload = &v1pb.Task_DatabaseSchemaUpdate_{
+			DatabaseSchemaUpdate: &v1pb.Task_DatabaseSchemaUpdate{
+				Sheet:         sheetName(payload.SheetID),
+				SchemaVersion: payload.SchemaVersion,
+			},
+		}
+	case api.TaskDatabaseSchemaUpdateGhostSync:
+		payload := &api.TaskDatabaseSchemaUpdateGhostSyncPayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.Payload = &v1pb.Task_DatabaseSchemaUpdate_{
+			DatabaseSchemaUpdate: &v1pb.Task_DatabaseSchemaUpdate{
+				Sheet:         sheetName(payload.SheetID),
+				SchemaVersion: payload.SchemaVersion,
+			},
+		}
+	case api.TaskDatabaseSchemaUpdatePGOnlineSync:
+		payload := &api.TaskDatabaseSchemaUpdatePGOnlineSyncPayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.Payload = &v1pb.Task_DatabaseSchemaUpdate_{
+			DatabaseSchemaUpdate: &v1pb.Task_DatabaseSchemaUpdate{
+				Sheet:         sheetName(payload.SheetID),
+				SchemaVersion: payload.SchemaVersion,
+			},
+		}
+	case api.TaskDatabaseDataUpdate:
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task payload")
+		}
+		v1Task.SpecId = payload.SpecID
+		v1Task.Payload = &v1pb.Task_DatabaseDataUpdate_{
+			DatabaseDataUpdate: &v1pb.Task_DatabaseDataUpdate{
+				Sheet:           sheetName(payload.SheetID),
+				SchemaVersion:   payload.SchemaVersion,
+				RollbackEnabled: payload.RollbackEnabled,
+			},
+		}
+	}
+	return v1Task, nil
+}
+
+func convertToTaskRun(ctx context.Context, s *store.Store, project *store.ProjectMessage, pipelineUID, stageUID int, taskRun *store.TaskRunMessage) (*v1pb.TaskRun, error) {
+	creator, err := s.GetUserByID(ctx, taskRun.CreatorID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get creator %d", taskRun.CreatorID)
+	}
+	if creator == nil {
+		return nil, errors.Errorf("creator %d not found", taskRun.CreatorID)
+	}
+	updater, err := s.GetUserByID(ctx, taskRun.UpdaterID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get updater %d", taskRun.UpdaterID)
+	}
+	if updater == nil {
+		return nil, errors.Errorf("updater %d not found", taskRun.UpdaterID)
+	}
+
+	v1TaskRun := &v1pb.TaskRun{
+		Name:       fmt.Sprintf("%s%s/%s%d/%s%d/%s%d/%s%d", projectNamePrefix, project.ResourceID, rolloutPrefix, pipelineUID, stagePrefix, stageUID, taskPrefix, taskRun.TaskID, taskRunPrefix, taskRun.ID),
+		Uid:        fmt.Sprintf("%d", taskRun.ID),
+		Creator:    getUserIdentifier(creator.Email),
+		Updater:    getUserIdentifier(updater.Email),
+		CreateTime: timestamppb.New(time.Unix(taskRun.CreatedTs, 0)),
+		UpdateTime: timestamppb.New(time.Unix(taskRun.UpdatedTs, 0)),
+		Title:      taskRun.Name,
+		Status:     convertToTaskRunStatus(taskRun.Status),
+		Detail:     taskRun.Comment,
+	}
+	if taskRun.Result != "" {
+		result := &api.TaskRunResultPayload{}
+		if err := json.Unmarshal([]byte(taskRun.Result), result); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task run result")
+		}
+		v1TaskRun.SchemaVersion = result.Version
+		if v1TaskRun.Detail == "" {
+			v1TaskRun.Detail = result.Detail
+		}
+	}
+	return v1TaskRun, nil
+}
+
+func convertToTaskStatus(taskStatus api.TaskStatus) v1pb.Task_Status {
+	switch taskStatus {
+	case api.TaskPendingApproval:
+		return v1pb.Task_PENDING_APPROVAL
+	case api.TaskPending:
+		return v1pb.Task_PENDING
+	case api.TaskRunning:
+		return v1pb.Task_RUNNING
+	case api.TaskDone:
+		return v1pb.Task_DONE
+	case api.TaskFailed:
+		return v1pb.Task_FAILED
+	case api.TaskCanceled:
+		return v1pb.Task_CANCELED
+	default:
+		return v1pb.Task_STATUS_UNSPECIFIED
+	}
+}
+
+func convertToTaskType(taskType api.TaskType) v1pb.Task_Type {
+	switch taskType {
+	case api.TaskGeneral:
+		return v1pb.Task_GENERAL
+	case api.TaskDatabaseCreate:
+		return v1pb.Task_DATABASE_CREATE
+	case api.TaskDatabaseSchemaBaseline:
+		return v1pb.Task_DATABASE_SCHEMA_BASELINE
+	case api.TaskDatabaseSchemaUpdate:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE
+	case api.TaskDatabaseSchemaUpdateSDL:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_SDL
+	case api.TaskDatabaseSchemaUpdateGhostSync:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_GHOST_SYNC
+	case api.TaskDatabaseSchemaUpdateGhostCutover:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER
+	case api.TaskDatabaseSchemaUpdatePGOnlineSync:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC
+	case api.TaskDatabaseSchemaUpdatePGOnlineCutover:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER
+	case api.TaskDatabaseDataUpdate:
+		return v1pb.Task_DATABASE_DATA_UPDATE
+	case api.TaskDatabaseBackup:
+		return v1pb.Task_DATABASE_BACKUP
+	case api.TaskDatabaseRestorePITRRestore:
+		return v1pb.Task_DATABASE_RESTORE_RESTORE
+	case api.TaskDatabaseRestorePITRCutover:
+		return v1pb.Task_DATABASE_RESTORE_CUTOVER
+	default:
+		return v1pb.Task_TYPE_UNSPECIFIED
+	}
+}
+
+func convertToTaskRunStatus(taskRunStatus api.TaskRunStatus) v1pb.TaskRun_Status {
+	switch taskRunStatus {
+	case api.TaskRunRunning:
+		return v1pb.TaskRun_RUNNING
+	case api.TaskRunDone:
+		return v1pb.TaskRun_DONE
+	case api.TaskRunFailed:
+		return v1pb.TaskRun_FAILED
+	case api.TaskRunCanceled:
+		return v1pb.TaskRun_CANCELED
+	default:
+		return v1pb.TaskRun_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestValidatePlanSteps(t *testing.T) {
+	spec := &v1pb.Plan_Spec{
+		Id: "spec",
+		Config: &v1pb.Plan_Spec_ChangeDatabaseConfig{
+			ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
+				Target: "instances/instance/databases/db",
+				Sheet:  "projects/project/sheets/1",
+				Type:   v1pb.Plan_ChangeDatabaseConfig_MIGRATE,
+			},
+		},
+	}
+	tests := []struct {
+		steps   []*v1pb.Plan_Step
+		wantErr bool
+	}{
+		// No steps.
+		{
+			steps:   nil,
+			wantErr: true,
+		},
+		{
+			steps:   []*v1pb.Plan_Step{},
+			wantErr: true,
+		},
+		// Step without specs.
+		{
+			steps: []*v1pb.Plan_Step{
+				{},
+			},
+			wantErr: true,
+		},
+		{
+			steps: []*v1pb.Plan_Step{
+				{Specs: []*v1pb.Plan_Spec{spec}},
+				{Specs: []*v1pb.Plan_Spec{}},
+			},
+			wantErr: true,
+		},
+		{
+			steps: []*v1pb.Plan_Step{
+				{Specs: []*v1pb.Plan_Spec{spec}},
+			},
+			wantErr: false,
+		},
+	}
+
+	a := require.New(t)
+	for _, tt := range tests {
+		err := validatePlanSteps(tt.steps)
+		if tt.wantErr {
+			a.Error(err)
+		} else {
+			a.NoError(err)
+		}
+	}
+}
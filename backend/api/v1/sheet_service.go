@@ -0,0 +1,762 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	vcsPlugin "github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// SheetService implements the sheet service.
+type SheetService struct {
+	v1pb.UnimplementedSheetServiceServer
+	store          *store.Store
+	licenseService enterpriseAPI.LicenseService
+}
+
+// NewSheetService creates a new SheetService.
+func NewSheetService(store *store.Store, licenseService enterpriseAPI.LicenseService) *SheetService {
+	return &SheetService{
+		store:          store,
+		licenseService: licenseService,
+	}
+}
+
+// CreateSheet creates a new sheet.
+func (s *SheetService) CreateSheet(ctx context.Context, request *v1pb.CreateSheetRequest) (*v1pb.Sheet, error) {
+	if request.Sheet == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "sheet must be set")
+	}
+	if request.Sheet.Title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "sheet title must be set")
+	}
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+
+	project, err := s.getProjectMessage(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %q has been deleted", request.Parent)
+	}
+	// Non-workspace Owner or DBA can only create sheet into the project where she has the membership.
+	if err := s.checkProjectPermission(ctx, project, api.ProjectPermissionCreateSheet); err != nil {
+		return nil, err
+	}
+
+	visibility, err := convertToStoreSheetVisibility(request.Sheet.Visibility)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	sheetCreate := &api.SheetCreate{
+		CreatorID:  currentPrincipalID,
+		ProjectID:  project.UID,
+		Name:       request.Sheet.Title,
+		Statement:  string(request.Sheet.Content),
+		Visibility: visibility,
+		Source:     api.SheetFromBytebase,
+		Type:       api.SheetForSQL,
+	}
+	if request.Sheet.Database != "" {
+		database, err := s.getDatabaseMessage(ctx, request.Sheet.Database)
+		if err != nil {
+			return nil, err
+		}
+		if database.ProjectID != project.ResourceID {
+			return nil, status.Errorf(codes.InvalidArgument, "database %q does not belong to project %q", request.Sheet.Database, request.Parent)
+		}
+		sheetCreate.DatabaseID = &database.UID
+	}
+
+	created, err := s.store.CreateSheet(ctx, sheetCreate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create sheet, error: %v", err)
+	}
+	sheet, err := s.store.GetSheetV2(ctx, &api.SheetFind{ID: &created.ID}, currentPrincipalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sheet, error: %v", err)
+	}
+	if sheet == nil {
+		return nil, status.Errorf(codes.NotFound, "sheet %d not found", created.ID)
+	}
+	v1pbSheet, err := s.convertToAPISheetMessage(ctx, sheet)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert sheet, error: %v", err)
+	}
+	return v1pbSheet, nil
+}
+
+// GetSheet returns the requested sheet, cutoff the content if the content is too long and the `raw` flag in request is false.
+func (s *SheetService) GetSheet(ctx context.Context, request *v1pb.GetSheetRequest) (*v1pb.Sheet, error) {
+	sheet, err := s.getSheetMessage(ctx, request.Name, request.Raw)
+	if err != nil {
+		return nil, err
+	}
+	canAccess, err := s.canReadSheet(ctx, sheet)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check access with error: %v", err)
+	}
+	if !canAccess {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot access sheet %q", request.Name)
+	}
+
+	v1pbSheet, err := s.convertToAPISheetMessage(ctx, sheet)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert sheet, error: %v", err)
+	}
+	return v1pbSheet, nil
+}
+
+// SearchSheets returns a list of sheets based on the search filters.
+func (s *SheetService) SearchSheets(ctx context.Context, request *v1pb.SearchSheetsRequest) (*v1pb.SearchSheetsResponse, error) {
+	projectID, err := getProjectID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+
+	normalRowStatus := api.Normal
+	sheetFind := &api.SheetFind{
+		RowStatus: &normalRowStatus,
+	}
+	if projectID != "-" {
+		project, err := s.getProjectMessage(ctx, request.Parent)
+		if err != nil {
+			return nil, err
+		}
+		sheetFind.ProjectID = &project.UID
+	}
+
+	// excludedCreatorID and excludeStarred are filtered in memory because the store doesn't support the negative conditions.
+	var excludedCreatorID *int
+	excludeStarred := false
+	expressions, err := parseFilter(request.Filter)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	for _, expression := range expressions {
+		switch expression.key {
+		case "creator":
+			user, err := s.store.GetUser(ctx, &store.FindUserMessage{
+				Email: func() *string {
+					email := getUserEmailFromIdentifier(expression.value)
+					return &email
+				}(),
+				ShowDeleted: true,
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+			}
+			if user == nil {
+				return nil, status.Errorf(codes.NotFound, "user %q not found", expression.value)
+			}
+			switch expression.comparator {
+			case comparatorTypeEqual:
+				sheetFind.CreatorID = &user.ID
+			case comparatorTypeNotEqual:
+				excludedCreatorID = &user.ID
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "invalid comparator %q for creator", expression.comparator)
+			}
+		case "starred":
+			starred, err := strconv.ParseBool(expression.value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid starred value %q", expression.value)
+			}
+			switch expression.comparator {
+			case comparatorTypeEqual:
+			case comparatorTypeNotEqual:
+				starred = !starred
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "invalid comparator %q for starred", expression.comparator)
+			}
+			if starred {
+				sheetFind.OrganizerPrincipalID = &currentPrincipalID
+			} else {
+				excludeStarred = true
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter key %q", expression.key)
+		}
+	}
+
+	sheetList, err := s.store.ListSheetsV2(ctx, sheetFind, currentPrincipalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sheets, error: %v", err)
+	}
+	// Sort the sheets by the id in descending order so that the newest sheet comes first and the pagination is stable.
+	sort.Slice(sheetList, func(i, j int) bool {
+		return sheetList[i].UID > sheetList[j].UID
+	})
+
+	var sheets []*store.SheetMessage
+	for _, sheet := range sheetList {
+		if excludedCreatorID != nil && sheet.Creator.ID == *excludedCreatorID {
+			continue
+		}
+		if excludeStarred && sheet.Starred {
+			continue
+		}
+		canAccess, err := s.canReadSheet(ctx, sheet)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check access with error: %v", err)
+		}
+		if !canAccess {
+			continue
+		}
+		sheets = append(sheets, sheet)
+	}
+
+	offset, err := unmarshalPageToken(request.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token, error: %v", err)
+	}
+	limit := getPageSize(request.PageSize, defaultPageSize)
+	response := &v1pb.SearchSheetsResponse{}
+	if offset > len(sheets) {
+		offset = len(sheets)
+	}
+	sheets = sheets[offset:]
+	if len(sheets) > limit {
+		sheets = sheets[:limit]
+		nextPageToken, err := marshalPageToken(offset + limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+	}
+	for _, sheet := range sheets {
+		v1pbSheet, err := s.convertToAPISheetMessage(ctx, sheet)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert sheet, error: %v", err)
+		}
+		response.Sheets = append(response.Sheets, v1pbSheet)
+	}
+	return response, nil
+}
+
+// UpdateSheet updates a sheet.
+func (s *SheetService) UpdateSheet(ctx context.Context, request *v1pb.UpdateSheetRequest) (*v1pb.Sheet, error) {
+	if request.Sheet == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "sheet cannot be empty")
+	}
+	if request.UpdateMask == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "update mask cannot be empty")
+	}
+	if request.Sheet.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "sheet name cannot be empty")
+	}
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+
+	sheet, err := s.getSheetMessage(ctx, request.Sheet.Name, false /* raw */)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPatch := &api.SheetPatch{
+		ID:        sheet.UID,
+		UpdaterID: currentPrincipalID,
+	}
+	patchSheet := false
+	for _, path := range request.UpdateMask.Paths {
+		switch path {
+		case "title":
+			sheetPatch.Name = &request.Sheet.Title
+			patchSheet = true
+		case "content":
+			statement := string(request.Sheet.Content)
+			sheetPatch.Statement = &statement
+			patchSheet = true
+		case "visibility":
+			visibility, err := convertToStoreSheetVisibility(request.Sheet.Visibility)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			stringVisibility := string(visibility)
+			sheetPatch.Visibility = &stringVisibility
+			patchSheet = true
+		case "database":
+			if request.Sheet.Database == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot unset the database of sheet %q", request.Sheet.Name)
+			}
+			database, err := s.getDatabaseMessage(ctx, request.Sheet.Database)
+			if err != nil {
+				return nil, err
+			}
+			if database.ProjectID != sheet.Project.ResourceID {
+				return nil, status.Errorf(codes.InvalidArgument, "database %q does not belong to the project of sheet %q", request.Sheet.Database, request.Sheet.Name)
+			}
+			sheetPatch.DatabaseID = &database.UID
+			patchSheet = true
+		case "starred":
+			// Starring a sheet only needs the read permission since it only affects the current user.
+			canAccess, err := s.canReadSheet(ctx, sheet)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to check access with error: %v", err)
+			}
+			if !canAccess {
+				return nil, status.Errorf(codes.PermissionDenied, "cannot access sheet %q", request.Sheet.Name)
+			}
+			if _, err := s.store.UpsertSheetOrganizer(ctx, &api.SheetOrganizerUpsert{
+				SheetID:     sheet.UID,
+				PrincipalID: currentPrincipalID,
+				Starred:     request.Sheet.Starred,
+				Pinned:      sheet.Pinned,
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert organizer for sheet %q, error: %v", request.Sheet.Name, err)
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update mask path %q", path)
+		}
+	}
+
+	if patchSheet {
+		canAccess, err := s.canWriteSheet(ctx, sheet)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check access with error: %v", err)
+		}
+		if !canAccess {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot write sheet %q", request.Sheet.Name)
+		}
+		if _, err := s.store.PatchSheet(ctx, sheetPatch); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update sheet, error: %v", err)
+		}
+	}
+
+	updated, err := s.store.GetSheetV2(ctx, &api.SheetFind{ID: &sheet.UID}, currentPrincipalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sheet, error: %v", err)
+	}
+	if updated == nil {
+		return nil, status.Errorf(codes.NotFound, "sheet %q not found", request.Sheet.Name)
+	}
+	v1pbSheet, err := s.convertToAPISheetMessage(ctx, updated)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert sheet, error: %v", err)
+	}
+	return v1pbSheet, nil
+}
+
+// DeleteSheet deletes a sheet.
+func (s *SheetService) DeleteSheet(ctx context.Context, request *v1pb.DeleteSheetRequest) (*emptypb.Empty, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	sheet, err := s.getSheetMessage(ctx, request.Name, false /* raw */)
+	if err != nil {
+		return nil, err
+	}
+	canAccess, err := s.canWriteSheet(ctx, sheet)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check access with error: %v", err)
+	}
+	if !canAccess {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot write sheet %q", request.Name)
+	}
+
+	usedByIssues, err := s.store.GetSheetUsedByIssues(ctx, sheet.UID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find the issues that are using sheet %q, error: %v", request.Name, err)
+	}
+	if len(usedByIssues) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot delete sheet %q because it is used by issues %v", request.Name, usedByIssues)
+	}
+
+	if err := s.store.DeleteSheet(ctx, &api.SheetDelete{
+		ID:        sheet.UID,
+		DeleterID: currentPrincipalID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete sheet, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SyncSheets syncs sheets from the VCS repository of the project.
+func (s *SheetService) SyncSheets(ctx context.Context, request *v1pb.SyncSheetsRequest) (*emptypb.Empty, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	project, err := s.getProjectMessage(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %q has been deleted", request.Parent)
+	}
+	if project.Workflow != api.VCSWorkflow {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %q uses workflow %s, need %s to sync sheets", request.Parent, project.Workflow, api.VCSWorkflow)
+	}
+	if err := s.checkProjectPermission(ctx, project, api.ProjectPermissionSyncSheet); err != nil {
+		return nil, err
+	}
+
+	repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{ProjectID: &project.UID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find the repository of project %q, error: %v", request.Parent, err)
+	}
+	if repo == nil {
+		return nil, status.Errorf(codes.NotFound, "repository not found for project %q", request.Parent)
+	}
+	vcs, err := s.store.GetVCSByID(ctx, repo.VCSID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find VCS %d, error: %v", repo.VCSID, err)
+	}
+	if vcs == nil {
+		return nil, status.Errorf(codes.NotFound, "VCS %d not found", repo.VCSID)
+	}
+
+	var sheetSource api.SheetSource
+	switch vcs.Type {
+	case vcsPlugin.GitLab:
+		sheetSource = api.SheetFromGitLab
+	case vcsPlugin.GitHub:
+		sheetSource = api.SheetFromGitHub
+	case vcsPlugin.Bitbucket:
+		sheetSource = api.SheetFromBitbucket
+	}
+	oauthContext := common.OauthContext{
+		ClientID:     vcs.ApplicationID,
+		ClientSecret: vcs.Secret,
+		AccessToken:  repo.AccessToken,
+		RefreshToken: repo.RefreshToken,
+		Refresher:    utils.RefreshToken(ctx, s.store, repo.WebURL),
+	}
+	provider := vcsPlugin.Get(vcs.Type, vcsPlugin.ProviderConfig{})
+
+	basePath := filepath.Dir(repo.SheetPathTemplate)
+	fileList, err := provider.FetchRepositoryFileList(ctx, oauthContext, vcs.InstanceURL, repo.ExternalID, repo.BranchFilter, basePath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch repository file list from VCS %q, error: %v", vcs.InstanceURL, err)
+	}
+
+	for _, file := range fileList {
+		sheetInfo, err := utils.ParseSheetInfo(file.Path, repo.SheetPathTemplate)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse sheet info from template, error: %v", err)
+		}
+		if sheetInfo.SheetName == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "sheet name cannot be empty from sheet path %s with template %s", file.Path, repo.SheetPathTemplate)
+		}
+
+		fileContent, err := provider.ReadFileContent(ctx, oauthContext, vcs.InstanceURL, repo.ExternalID, file.Path, repo.BranchFilter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch file content from VCS, instance URL: %s, repo ID: %s, file path: %s, branch: %s, error: %v", vcs.InstanceURL, repo.ExternalID, file.Path, repo.BranchFilter, err)
+		}
+		fileMeta, err := provider.ReadFileMeta(ctx, oauthContext, vcs.InstanceURL, repo.ExternalID, file.Path, repo.BranchFilter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch file meta from VCS, instance URL: %s, repo ID: %s, file path: %s, branch: %s, error: %v", vcs.InstanceURL, repo.ExternalID, file.Path, repo.BranchFilter, err)
+		}
+		lastCommit, err := provider.FetchCommitByID(ctx, oauthContext, vcs.InstanceURL, repo.ExternalID, fileMeta.LastCommitID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch commit data from VCS, instance URL: %s, repo ID: %s, commit ID: %s, error: %v", vcs.InstanceURL, repo.ExternalID, fileMeta.LastCommitID, err)
+		}
+
+		sheetVCSPayload := &api.SheetVCSPayload{
+			FileName:     fileMeta.Name,
+			FilePath:     fileMeta.Path,
+			Size:         fileMeta.Size,
+			Author:       lastCommit.AuthorName,
+			LastCommitID: lastCommit.ID,
+			LastSyncTs:   time.Now().Unix(),
+		}
+		payload, err := json.Marshal(sheetVCSPayload)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal sheet VCS payload, error: %v", err)
+		}
+
+		var databaseID *int
+		// In non-tenant mode, we can set a databaseId for sheet with ENV_ID and DB_NAME,
+		// and ENV_ID and DB_NAME is either both present or neither present.
+		if project.TenantMode != api.TenantModeDisabled {
+			if sheetInfo.EnvironmentID != "" && sheetInfo.DatabaseName != "" {
+				databases, err := s.store.ListDatabases(ctx, &store.FindDatabaseMessage{ProjectID: &project.ResourceID, DatabaseName: &sheetInfo.DatabaseName})
+				if err != nil {
+					return nil, status.Errorf(codes.Internal, "failed to find database %q in project %q, error: %v", sheetInfo.DatabaseName, request.Parent, err)
+				}
+				for _, database := range databases {
+					database := database // create a new var "database".
+					if database.EnvironmentID == sheetInfo.EnvironmentID {
+						databaseID = &database.UID
+						break
+					}
+				}
+			}
+		}
+
+		sheetType := api.SheetForSQL
+		sheet, err := s.store.GetSheetV2(ctx, &api.SheetFind{
+			Name:      &sheetInfo.SheetName,
+			ProjectID: &project.UID,
+			Source:    &sheetSource,
+			Type:      &sheetType,
+		}, currentPrincipalID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find sheet %q in project %q, error: %v", sheetInfo.SheetName, request.Parent, err)
+		}
+		if sheet == nil {
+			if _, err := s.store.CreateSheet(ctx, &api.SheetCreate{
+				ProjectID:  project.UID,
+				CreatorID:  currentPrincipalID,
+				DatabaseID: databaseID,
+				Name:       sheetInfo.SheetName,
+				Statement:  fileContent,
+				Visibility: api.ProjectSheet,
+				Source:     sheetSource,
+				Type:       sheetType,
+				Payload:    string(payload),
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to create sheet from VCS, error: %v", err)
+			}
+		} else {
+			payloadString := string(payload)
+			if _, err := s.store.PatchSheet(ctx, &api.SheetPatch{
+				ID:         sheet.UID,
+				UpdaterID:  currentPrincipalID,
+				DatabaseID: databaseID,
+				Statement:  &fileContent,
+				Payload:    &payloadString,
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to patch sheet from VCS, error: %v", err)
+			}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *SheetService) getProjectMessage(ctx context.Context, name string) (*store.ProjectMessage, error) {
+	projectID, err := getProjectID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID:  &projectID,
+		ShowDeleted: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", name)
+	}
+	return project, nil
+}
+
+func (s *SheetService) getDatabaseMessage(ctx context.Context, name string) (*store.DatabaseMessage, error) {
+	instanceID, databaseName, err := getInstanceDatabaseID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+		InstanceID:   &instanceID,
+		DatabaseName: &databaseName,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get database %q, error: %v", name, err)
+	}
+	if database == nil {
+		return nil, status.Errorf(codes.NotFound, "database %q not found", name)
+	}
+	return database, nil
+}
+
+func (s *SheetService) getSheetMessage(ctx context.Context, name string, raw bool) (*store.SheetMessage, error) {
+	projectID, sheetID, err := getProjectIDSheetID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	sheet, err := s.store.GetSheetV2(ctx, &api.SheetFind{
+		ID:       &sheetID,
+		LoadFull: raw,
+	}, currentPrincipalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sheet, error: %v", err)
+	}
+	if sheet == nil || sheet.Project.ResourceID != projectID {
+		return nil, status.Errorf(codes.NotFound, "sheet %q not found", name)
+	}
+	return sheet, nil
+}
+
+// getProjectRoles returns the project roles of the current user.
+func (s *SheetService) getProjectRoles(ctx context.Context, projectID string) (map[common.ProjectRole]bool, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &projectID})
+	if err != nil {
+		return nil, err
+	}
+	projectRoles := make(map[common.ProjectRole]bool)
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if member.ID == currentPrincipalID {
+				projectRoles[common.ProjectRole(binding.Role)] = true
+				break
+			}
+		}
+	}
+	return projectRoles, nil
+}
+
+// checkProjectPermission checks whether the current user has the permission in the project.
+// Workspace Owner or DBA assumes project Owner role for all projects.
+func (s *SheetService) checkProjectPermission(ctx context.Context, project *store.ProjectMessage, permission api.ProjectPermissionType) error {
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+	if isOwnerOrDBA(role) {
+		return nil
+	}
+	projectRoles, err := s.getProjectRoles(ctx, project.ResourceID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get project roles, error: %v", err)
+	}
+	if len(projectRoles) == 0 {
+		return status.Errorf(codes.PermissionDenied, "not a member of project %q", project.ResourceID)
+	}
+	if !api.ProjectPermission(permission, s.licenseService.GetEffectivePlan(), projectRoles) {
+		return status.Errorf(codes.PermissionDenied, "permission %q denied in project %q", permission, project.ResourceID)
+	}
+	return nil
+}
+
+// canReadSheet checks if the current user can read the sheet.
+// 1. Creator can always read her own sheet.
+// 2. PUBLIC sheets can be read by everyone.
+// 3. PROJECT sheets can be read by the project members.
+// 4. PRIVATE sheets can only be read by the creator.
+func (s *SheetService) canReadSheet(ctx context.Context, sheet *store.SheetMessage) (bool, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	if sheet.Creator.ID == currentPrincipalID {
+		return true, nil
+	}
+
+	switch sheet.Visibility {
+	case api.PrivateSheet:
+		return false, nil
+	case api.PublicSheet:
+		return true, nil
+	case api.ProjectSheet:
+		role := ctx.Value(common.RoleContextKey).(api.Role)
+		if isOwnerOrDBA(role) {
+			return true, nil
+		}
+		projectRoles, err := s.getProjectRoles(ctx, sheet.Project.ResourceID)
+		if err != nil {
+			return false, err
+		}
+		return len(projectRoles) > 0, nil
+	}
+	return false, nil
+}
+
+// canWriteSheet checks if the current user can write the sheet.
+// 1. Creator can always write her own sheet.
+// 2. PUBLIC and PRIVATE sheets can only be written by the creator.
+// 3. PROJECT sheets can be written by the project owners.
+func (s *SheetService) canWriteSheet(ctx context.Context, sheet *store.SheetMessage) (bool, error) {
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	if sheet.Creator.ID == currentPrincipalID {
+		return true, nil
+	}
+
+	switch sheet.Visibility {
+	case api.PrivateSheet, api.PublicSheet:
+		return false, nil
+	case api.ProjectSheet:
+		role := ctx.Value(common.RoleContextKey).(api.Role)
+		if isOwnerOrDBA(role) {
+			return true, nil
+		}
+		projectRoles, err := s.getProjectRoles(ctx, sheet.Project.ResourceID)
+		if err != nil {
+			return false, err
+		}
+		return api.ProjectPermission(api.ProjectPermissionAdminSheet, s.licenseService.GetEffectivePlan(), projectRoles), nil
+	}
+	return false, nil
+}
+
+func (s *SheetService) convertToAPISheetMessage(ctx context.Context, sheet *store.SheetMessage) (*v1pb.Sheet, error) {
+	databaseParent := ""
+	if sheet.DatabaseID != nil {
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+			UID:         sheet.DatabaseID,
+			ShowDeleted: true,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get database %d", *sheet.DatabaseID)
+		}
+		if database != nil {
+			databaseParent = fmt.Sprintf("%s%s/%s%s", instanceNamePrefix, database.InstanceID, databaseIDPrefix, database.DatabaseName)
+		}
+	}
+
+	visibility := v1pb.Sheet_VISIBILITY_UNSPECIFIED
+	switch sheet.Visibility {
+	case api.PublicSheet:
+		visibility = v1pb.Sheet_VISIBILITY_PUBLIC
+	case api.ProjectSheet:
+		visibility = v1pb.Sheet_VISIBILITY_PROJECT
+	case api.PrivateSheet:
+		visibility = v1pb.Sheet_VISIBILITY_PRIVATE
+	}
+
+	source := v1pb.Sheet_SOURCE_UNSPECIFIED
+	switch sheet.Source {
+	case api.SheetFromBytebase:
+		source = v1pb.Sheet_SOURCE_BYTEBASE
+	case api.SheetFromBytebaseArtifact:
+		source = v1pb.Sheet_SOURCE_BYTEBASE_ARTIFACT
+	case api.SheetFromGitLab:
+		source = v1pb.Sheet_SOURCE_GITLAB
+	case api.SheetFromGitHub:
+		source = v1pb.Sheet_SOURCE_GITHUB
+	case api.SheetFromBitbucket:
+		source = v1pb.Sheet_SOURCE_BITBUCKET
+	}
+
+	tp := v1pb.Sheet_TYPE_UNSPECIFIED
+	if sheet.Type == api.SheetForSQL {
+		tp = v1pb.Sheet_TYPE_SQL
+	}
+
+	return &v1pb.Sheet{
+		Name:        fmt.Sprintf("%s%s/%s%d", projectNamePrefix, sheet.Project.ResourceID, sheetIDPrefix, sheet.UID),
+		Database:    databaseParent,
+		Title:       sheet.Name,
+		Creator:     getUserIdentifier(sheet.Creator.Email),
+		CreateTime:  timestamppb.New(sheet.CreatedTime),
+		UpdateTime:  timestamppb.New(sheet.UpdatedTime),
+		Content:     []byte(sheet.Statement),
+		ContentSize: []byte(strconv.FormatInt(sheet.Size, 10)),
+		Visibility:  visibility,
+		Source:      source,
+		Type:        tp,
+		Starred:     sheet.Starred,
+	}, nil
+}
+
+func convertToStoreSheetVisibility(visibility v1pb.Sheet_Visibility) (api.SheetVisibility, error) {
+	switch visibility {
+	case v1pb.Sheet_VISIBILITY_PUBLIC:
+		return api.PublicSheet, nil
+	case v1pb.Sheet_VISIBILITY_PROJECT:
+		return api.ProjectSheet, nil
+	case v1pb.Sheet_VISIBILITY_PRIVATE:
+		return api.PrivateSheet, nil
+	default:
+		return "", errors.Errorf("invalid sheet visibility %q", visibility)
+	}
+}
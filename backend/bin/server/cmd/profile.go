@@ -7,12 +7,21 @@ import (
 	"github.com/bytebase/bytebase/backend/component/config"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/app/feishu"
+	"github.com/bytebase/bytebase/backend/plugin/storage/codec"
 )
 
 func getBaseProfile(dataDir string) config.Profile {
-	backupStorageBackend := api.BackupStorageBackendLocal
-	if flags.backupBucket != "" {
-		backupStorageBackend = api.BackupStorageBackendS3
+	backupStorageBackend := flags.backupStorageBackend
+	if backupStorageBackend == "" {
+		backupStorageBackend = api.BackupStorageBackendLocal
+	}
+
+	var backupCompression codec.Compression
+	switch flags.backupCompression {
+	case "gzip":
+		backupCompression = codec.CompressionGzip
+	case "zstd":
+		backupCompression = codec.CompressionZstd
 	}
 
 	return config.Profile{
@@ -33,6 +42,10 @@ func getBaseProfile(dataDir string) config.Profile {
 		BackupRegion:         flags.backupRegion,
 		BackupBucket:         flags.backupBucket,
 		BackupCredentialFile: flags.backupCredential,
+		BackupEndpoint:       flags.backupEndpoint,
+		BackupUsePathStyle:   flags.backupPathStyle,
+		BackupCompression:    backupCompression,
+		BackupStorageList:    flags.backupStorageList,
 		FeishuAPIURL:         feishu.APIPath,
 		LastActiveTs:         time.Now().Unix(),
 	}
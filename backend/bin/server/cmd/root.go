@@ -9,15 +9,19 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"syscall"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/config"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/server"
 )
 
@@ -83,6 +87,16 @@ var (
 		backupRegion     string
 		backupBucket     string
 		backupCredential string
+		backupEndpoint   string
+		backupPathStyle  bool
+		// backupCompression is the compression algorithm of the backup files.
+		backupCompression string
+		// backupStorageBackend is derived from the scheme of --backup-bucket.
+		backupStorageBackend api.BackupStorageBackend
+		// backupStorageConfig is the file of the named backup storages that the environments can choose.
+		backupStorageConfig string
+		// backupStorageList is loaded from --backup-storage-config.
+		backupStorageList []*config.BackupStorage
 	}
 
 	rootCmd = &cobra.Command{
@@ -127,14 +141,20 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&flags.disableMetric, "disable-metric", false, "disable the metric collector")
 
 	// Cloud backup related flags.
-	// TODO(dragonly): Add GCS usages when it's supported.
-	rootCmd.PersistentFlags().StringVar(&flags.backupBucket, "backup-bucket", "", "bucket where Bytebase stores backup data, e.g., s3://example-bucket. When provided, Bytebase will store data to the S3 bucket.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupBucket, "backup-bucket", "", "bucket where Bytebase stores backup data, e.g., s3://example-bucket, gs://example-bucket, azblob://example-container or file:///mnt/backup. When provided, Bytebase will store data to the bucket.")
 	rootCmd.PersistentFlags().StringVar(&flags.backupRegion, "backup-region", "", "region of the backup bucket, e.g., us-west-2 for AWS S3.")
-	rootCmd.PersistentFlags().StringVar(&flags.backupCredential, "backup-credential", "", "credentials file to use for the backup bucket. It should be the same format as the AWS/GCP credential files.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupCredential, "backup-credential", "", "credentials file to use for the backup bucket. It should be the AWS credential file for S3, the service account key file for GCS, or a JSON file with accountName and accountKey for Azure Blob Storage.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupEndpoint, "backup-endpoint", "", "endpoint of the S3-compatible storage or Azure Blob Storage, e.g., http://minio:9000. Empty means the default endpoint of the cloud provider.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupCompression, "backup-compression", "zstd", "compression algorithm of the backup files, one of none, gzip and zstd.")
+	rootCmd.PersistentFlags().BoolVar(&flags.backupPathStyle, "backup-path-style", false, "whether to use the path-style addressing for the S3-compatible storage, which is required by most self-hosted storages such as MinIO.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupStorageConfig, "backup-storage-config", "", "YAML file of the named backup storages, each with its own bucket URI, region, endpoint, path-style addressing and credential file. The backup plan policy of an environment can choose one of them by name instead of the --backup-bucket storage.")
 }
 
 // -----------------------------------Command Line Config END--------------------------------------
 
+// backupStorageNameMatcher matches a valid backup storage name.
+var backupStorageNameMatcher = regexp.MustCompile("^[a-z]([a-z0-9-]{0,61}[a-z0-9])?$")
+
 func checkDataDir() error {
 	// Clean data directory path.
 	flags.dataDir = filepath.Clean(flags.dataDir)
@@ -156,18 +176,135 @@ func checkDataDir() error {
 }
 
 func checkCloudBackupFlags() error {
+	switch flags.backupCompression {
+	case "none", "gzip", "zstd":
+	default:
+		return errors.Errorf("invalid --backup-compression %q, it should be one of none, gzip and zstd", flags.backupCompression)
+	}
+	backupStorageList, err := loadBackupStorageConfig(flags.backupStorageConfig)
+	if err != nil {
+		return errors.Wrapf(err, "invalid --backup-storage-config %q", flags.backupStorageConfig)
+	}
+	flags.backupStorageList = backupStorageList
+
+	flags.backupStorageBackend = api.BackupStorageBackendLocal
 	if flags.backupBucket == "" {
 		return nil
 	}
-	if !strings.HasPrefix(flags.backupBucket, "s3://") {
-		return errors.Errorf("only support bucket URI starting with s3://")
+	backupStorage := &config.BackupStorage{
+		Region:         flags.backupRegion,
+		CredentialFile: flags.backupCredential,
+		Endpoint:       flags.backupEndpoint,
+		UsePathStyle:   flags.backupPathStyle,
+	}
+	if err := parseBackupBucket(backupStorage, flags.backupBucket); err != nil {
+		return errors.Wrapf(err, "invalid --backup-bucket %q", flags.backupBucket)
+	}
+	flags.backupStorageBackend = backupStorage.Backend
+	flags.backupBucket = backupStorage.Bucket
+	flags.backupRegion = backupStorage.Region
+	return nil
+}
+
+// backupStorageConfig is the content of the --backup-storage-config file.
+type backupStorageConfig struct {
+	Storages []struct {
+		Name string `yaml:"name"`
+		// Bucket is the bucket URI in the same format as --backup-bucket.
+		Bucket     string `yaml:"bucket"`
+		Region     string `yaml:"region"`
+		Credential string `yaml:"credential"`
+		Endpoint   string `yaml:"endpoint"`
+		PathStyle  bool   `yaml:"pathStyle"`
+	} `yaml:"storages"`
+}
+
+// loadBackupStorageConfig loads the named backup storages from the config file, e.g.
+//
+//	storages:
+//	  - name: eu
+//	    bucket: s3://eu-backup
+//	    region: eu-central-1
+//	    credential: /etc/bytebase/eu-backup-credential
+//	  - name: nfs
+//	    bucket: file:///mnt/backup
+func loadBackupStorageConfig(path string) ([]*config.BackupStorage, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file")
+	}
+	var storageConfig backupStorageConfig
+	if err := yaml.Unmarshal(content, &storageConfig); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal file")
+	}
+
+	var result []*config.BackupStorage
+	names := make(map[string]bool)
+	for _, s := range storageConfig.Storages {
+		if !backupStorageNameMatcher.MatchString(s.Name) {
+			return nil, errors.Errorf("invalid backup storage name %q, it should match %s", s.Name, backupStorageNameMatcher.String())
+		}
+		if names[s.Name] {
+			return nil, errors.Errorf("duplicate backup storage name %q", s.Name)
+		}
+		names[s.Name] = true
+		backupStorage := &config.BackupStorage{
+			Name:           s.Name,
+			Region:         s.Region,
+			CredentialFile: s.Credential,
+			Endpoint:       s.Endpoint,
+			UsePathStyle:   s.PathStyle,
+		}
+		if err := parseBackupBucket(backupStorage, s.Bucket); err != nil {
+			return nil, errors.Wrapf(err, "invalid bucket %q of backup storage %q", s.Bucket, s.Name)
+		}
+		result = append(result, backupStorage)
 	}
-	flags.backupBucket = strings.TrimPrefix(flags.backupBucket, "s3://")
-	if flags.backupCredential == "" {
-		return errors.Errorf("must specify --backup-credential when --backup-bucket is present")
+	return result, nil
+}
+
+// parseBackupBucket parses the bucket URI into the backend and the bucket of the backup storage, and validates the storage options.
+func parseBackupBucket(backupStorage *config.BackupStorage, bucketURI string) error {
+	switch {
+	case strings.HasPrefix(bucketURI, "s3://"):
+		backupStorage.Backend = api.BackupStorageBackendS3
+		backupStorage.Bucket = strings.TrimPrefix(bucketURI, "s3://")
+		if backupStorage.CredentialFile == "" {
+			return errors.Errorf("must specify the credential file for the S3 bucket")
+		}
+		if backupStorage.Region == "" {
+			if backupStorage.Endpoint == "" {
+				return errors.Errorf("must specify the region for AWS S3 backup")
+			}
+			// The S3-compatible storages usually ignore the region, but the AWS SDK requires one.
+			backupStorage.Region = "us-east-1"
+		}
+	case strings.HasPrefix(bucketURI, "gs://"):
+		backupStorage.Backend = api.BackupStorageBackendGCS
+		backupStorage.Bucket = strings.TrimPrefix(bucketURI, "gs://")
+		if backupStorage.CredentialFile == "" {
+			return errors.Errorf("must specify the credential file for the GCS bucket")
+		}
+	case strings.HasPrefix(bucketURI, "azblob://"):
+		backupStorage.Backend = api.BackupStorageBackendAzure
+		backupStorage.Bucket = strings.TrimPrefix(bucketURI, "azblob://")
+		if backupStorage.CredentialFile == "" {
+			return errors.Errorf("must specify the credential file for the Azure Blob Storage container")
+		}
+	case strings.HasPrefix(bucketURI, "file://"):
+		backupStorage.Backend = api.BackupStorageBackendFilesystem
+		backupStorage.Bucket = strings.TrimPrefix(bucketURI, "file://")
+		if !filepath.IsAbs(backupStorage.Bucket) {
+			return errors.Errorf("backup directory %q must be an absolute path, e.g., file:///mnt/backup", backupStorage.Bucket)
+		}
+	default:
+		return errors.Errorf("only support bucket URI starting with s3://, gs://, azblob:// or file://")
 	}
-	if flags.backupRegion == "" {
-		return errors.Errorf("must specify --backup-region for AWS S3 backup")
+	if backupStorage.Bucket == "" {
+		return errors.Errorf("bucket name must not be empty")
 	}
 	return nil
 }
@@ -11,7 +11,6 @@ import (
 
 	"github.com/gosimple/slug"
 
-	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/webhook"
@@ -112,10 +111,7 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 		CreatorName:  anyActivity.Creator.Name,
 		CreatorEmail: anyActivity.Creator.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(webhookCtx, webhookList)
-
-	return nil
+	return m.enqueueWebhookDeliveries(ctx, webhookCtx, webhookList)
 }
 
 // CreateActivity creates an activity.
@@ -164,29 +160,36 @@ func (m *Manager) CreateActivity(ctx context.Context, create *api.ActivityCreate
 			zap.Error(err))
 		return activity, nil
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(webhookCtx, webhookList)
+	// The activity has been created, so failing to enqueue the webhook deliveries shouldn't fail the caller.
+	if err := m.enqueueWebhookDeliveries(ctx, webhookCtx, webhookList); err != nil {
+		log.Error("Failed to enqueue webhook event",
+			zap.String("issue_name", meta.Issue.Title),
+			zap.Int("activity_id", activity.ID),
+			zap.Error(err))
+	}
 
 	return activity, nil
 }
 
-func postWebhookList(webhookCtx webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+// enqueueWebhookDeliveries adds the webhook event to the delivery outbox of each webhook,
+// the webhook runner calls the external webhook endpoints to avoid blocking web serving thread.
+func (m *Manager) enqueueWebhookDeliveries(ctx context.Context, webhookCtx webhook.Context, webhookList []*store.ProjectWebhookMessage) error {
+	webhookCtx.CreatedTs = time.Now().Unix()
+	payload, err := json.Marshal(webhookCtx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook context")
+	}
+	var creates []*store.ProjectWebhookDeliveryMessage
 	for _, hook := range webhookList {
-		webhookCtx.URL = hook.URL
-		webhookCtx.CreatedTs = time.Now().Unix()
-		if err := common.Retry(func() error {
-			return webhook.Post(hook.Type, webhookCtx)
-		}); err != nil {
-			// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
-			log.Warn("Failed to post webhook event on activity",
-				zap.String("webhook type", hook.Type),
-				zap.String("webhook name", hook.Title),
-				zap.String("activity type", webhookCtx.ActivityType),
-				zap.String("title", webhookCtx.Title),
-				zap.Error(err))
-			return
-		}
+		creates = append(creates, &store.ProjectWebhookDeliveryMessage{
+			ProjectWebhookID: hook.ID,
+			Payload:          string(payload),
+		})
+	}
+	if _, err := m.store.CreateProjectWebhookDeliveries(ctx, creates); err != nil {
+		return errors.Wrapf(err, "failed to create webhook deliveries")
 	}
+	return nil
 }
 
 func (m *Manager) getWebhookContext(ctx context.Context, activity *api.Activity, meta *Metadata, updater *store.UserMessage) (webhook.Context, error) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/storage/codec"
 )
 
 // Profile is the configuration to start main server.
@@ -47,9 +48,17 @@ type Profile struct {
 	BackupStorageBackend api.BackupStorageBackend
 
 	// Cloud backup related fields
-	BackupRegion         string
+	BackupRegion string
+	// BackupBucket is the bucket or the container of the cloud storage, or the directory of the filesystem storage.
 	BackupBucket         string
 	BackupCredentialFile string
+	// BackupEndpoint is the endpoint of the S3-compatible storage or Azure Blob Storage.
+	BackupEndpoint     string
+	BackupUsePathStyle bool
+	// BackupCompression is the compression algorithm of the backup files.
+	BackupCompression codec.Compression
+	// BackupStorageList is the named backup storages that the environments can choose in the backup plan policies.
+	BackupStorageList []*BackupStorage
 
 	// IM integration related fields
 	// FeishuAPIURL is the URL of Feishu API server.
@@ -73,6 +82,31 @@ type Profile struct {
 	LastActiveTs int64
 }
 
+// BackupStorage is the configuration of a named backup storage.
+type BackupStorage struct {
+	// Name is the name of the backup storage referred by the backup plan policies.
+	Name string
+	// Backend is the storage backend, which is derived from the scheme of the bucket URI.
+	Backend api.BackupStorageBackend
+	Region  string
+	// Bucket is the bucket or the container of the cloud storage, or the directory of the filesystem storage.
+	Bucket         string
+	CredentialFile string
+	// Endpoint is the endpoint of the S3-compatible storage or Azure Blob Storage.
+	Endpoint     string
+	UsePathStyle bool
+}
+
+// GetBackupStorage returns the named backup storage, or nil if not found.
+func (prof *Profile) GetBackupStorage(name string) *BackupStorage {
+	for _, backupStorage := range prof.BackupStorageList {
+		if backupStorage.Name == name {
+			return backupStorage
+		}
+	}
+	return nil
+}
+
 // UseEmbedDB returns whether to use embedDB.
 func (prof *Profile) UseEmbedDB() bool {
 	return len(prof.PgURL) == 0
@@ -0,0 +1,164 @@
+// Package prom contains the Prometheus metrics of the runners, tasks and drivers.
+// The metrics are registered to the default registry, which is exposed on /metrics along with the API metrics.
+package prom
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/bytebase/bytebase/backend/component/state"
+)
+
+const namespace = "bytebase"
+
+var (
+	// TaskRunsTotal counts the finished task runs by the task type and the status.
+	TaskRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "runs_total",
+		Help:      "The number of finished task runs.",
+	}, []string{"type", "status"})
+	// TaskRunDuration observes the duration of the finished task runs by the task type and the status.
+	TaskRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "run_duration_seconds",
+		Help:      "The duration of finished task runs.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 10),
+	}, []string{"type", "status"})
+	// TasksRunning is the number of the running tasks by the task type.
+	TasksRunning = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "task",
+		Name:      "running",
+		Help:      "The number of running tasks.",
+	}, []string{"type"})
+	// TaskCheckRunDuration observes the duration of the task check runs by the check type and the status.
+	TaskCheckRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "task_check",
+		Name:      "run_duration_seconds",
+		Help:      "The duration of task check runs.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"type", "status"})
+	// BackupDuration observes the duration of the backups by the storage backend and the status.
+	BackupDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "duration_seconds",
+		Help:      "The duration of backups.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"storage_backend", "status"})
+	// BackupDumpSize observes the size of the successful backups before compression and encryption by the storage backend.
+	BackupDumpSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "dump_size_bytes",
+		Help:      "The size of backup dumps before compression and encryption.",
+		Buckets:   prometheus.ExponentialBuckets(1024*1024, 4, 11),
+	}, []string{"storage_backend"})
+	// SchemaSyncErrorsTotal counts the failed instance schema syncs by the instance.
+	SchemaSyncErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "schema_sync",
+		Name:      "errors_total",
+		Help:      "The number of failed instance schema syncs.",
+	}, []string{"instance"})
+	// SlowQuerySyncErrorsTotal counts the failed slow query syncs by the instance.
+	SlowQuerySyncErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "slow_query_sync",
+		Name:      "errors_total",
+		Help:      "The number of failed slow query syncs.",
+	}, []string{"instance"})
+	// WebhookDeliveryFailuresTotal counts the failed webhook deliveries by the webhook type.
+	WebhookDeliveryFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "delivery_failures_total",
+		Help:      "The number of failed webhook deliveries.",
+	}, []string{"webhook_type"})
+
+	schemaSyncLag = &schemaSyncLagCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "schema_sync", "lag_seconds"),
+			"The seconds since the last successful instance schema sync.",
+			[]string{"instance"}, nil,
+		),
+		lastSyncTime: make(map[string]time.Time),
+	}
+	instanceConnections = &instanceConnectionCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "instance", "outstanding_connections"),
+			"The number of outstanding connections of tasks and task checks per instance.",
+			[]string{"instance_id"}, nil,
+		),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(schemaSyncLag, instanceConnections)
+}
+
+// ObserveSchemaSync records a successful schema sync of the instance.
+func ObserveSchemaSync(instance string) {
+	schemaSyncLag.Lock()
+	defer schemaSyncLag.Unlock()
+	schemaSyncLag.lastSyncTime[instance] = time.Now()
+}
+
+// SetState sets the server state to report the outstanding connections per instance.
+func SetState(stateCfg *state.State) {
+	instanceConnections.Lock()
+	defer instanceConnections.Unlock()
+	instanceConnections.stateCfg = stateCfg
+}
+
+// schemaSyncLagCollector reports the lag of the instance schema syncs at the scrape time.
+type schemaSyncLagCollector struct {
+	desc         *prometheus.Desc
+	lastSyncTime map[string]time.Time
+	sync.Mutex
+}
+
+func (c *schemaSyncLagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *schemaSyncLagCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	defer c.Unlock()
+	for instance, lastSyncTime := range c.lastSyncTime {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(lastSyncTime).Seconds(), instance)
+	}
+}
+
+// instanceConnectionCollector reports the InstanceOutstandingConnections of the server state.
+type instanceConnectionCollector struct {
+	desc     *prometheus.Desc
+	stateCfg *state.State
+	sync.Mutex
+}
+
+func (c *instanceConnectionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *instanceConnectionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	stateCfg := c.stateCfg
+	c.Unlock()
+	if stateCfg == nil {
+		return
+	}
+	stateCfg.Lock()
+	defer stateCfg.Unlock()
+	for instanceID, connections := range stateCfg.InstanceOutstandingConnections {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(connections), strconv.Itoa(instanceID))
+	}
+}
@@ -296,6 +296,9 @@ type ActivityFind struct {
 	Limit           *int
 	CreatedTsAfter  *int64
 	CreatedTsBefore *int64
+	// If specified, only find activities belonging to the project with this ID,
+	// including the activities of its issues, pipelines, databases and members.
+	ProjectID *int
 	// If specified, only find activities whose ID is smaller than SinceID.
 	SinceID *int
 	// If specified, sorts the returned list by created_ts in <<ORDER>>
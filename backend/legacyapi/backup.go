@@ -35,10 +35,14 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 or S3-compatible storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
-	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
+	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
+	// BackupStorageBackendAzure is the Azure Blob Storage backend for a backup.
+	BackupStorageBackendAzure BackupStorageBackend = "AZURE"
+	// BackupStorageBackendFilesystem is the mounted directory storage backend for a backup, e.g. an NFS share.
+	BackupStorageBackendFilesystem BackupStorageBackend = "FILESYSTEM"
 	// BackupStorageBackendOSS is the AliCloud Object Storage Service (OSS) storage backend for a backup. Not used yet.
 	BackupStorageBackendOSS BackupStorageBackend = "OSS"
 )
@@ -62,6 +66,38 @@ type BackupPayload struct {
 	// It is recorded within the same transaction as the dump so that the binlog position is consistent with the dump.
 	// Please refer to https://github.com/bytebase/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	BinlogInfo BinlogInfo `json:"binlogInfo"`
+
+	// Compression is the compression algorithm of the backup file. Empty means not compressed.
+	Compression string `json:"compression,omitempty"`
+	// Encryption is the envelope encryption of the backup file. Nil means not encrypted.
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+	// Checksum is the SHA-256 checksum in hex of the backup file as stored.
+	Checksum string `json:"checksum,omitempty"`
+	// DumpSize is the size of the dump before compression and encryption.
+	DumpSize int64 `json:"dumpSize,omitempty"`
+	// SchemaOnly is true if the backup contains the schema only, which is the case for the engines not supporting data dumps such as Redshift.
+	SchemaOnly bool `json:"schemaOnly,omitempty"`
+
+	// Drill is the result of the latest restore drill of the backup. Nil means the backup has never been drilled.
+	Drill *BackupDrillResult `json:"drill,omitempty"`
+}
+
+// BackupDrillResult is the result of a restore drill of a backup.
+type BackupDrillResult struct {
+	// Ts is the timestamp when the drill finished.
+	Ts int64 `json:"ts"`
+	// Success is true if the backup is restored and the restored database matches the source database.
+	Success bool `json:"success"`
+	// Detail is the failure detail.
+	Detail string `json:"detail,omitempty"`
+}
+
+// BackupEncryption is the envelope encryption of a backup file.
+type BackupEncryption struct {
+	// KeyID identifies the workspace backup encryption key which encrypts the data key.
+	KeyID string `json:"keyId"`
+	// EncryptedDataKey is the data key encrypting the backup file, encrypted by the workspace backup encryption key.
+	EncryptedDataKey []byte `json:"encryptedDataKey"`
 }
 
 // Backup is the API message for a backup.
@@ -30,6 +30,25 @@ type Deployment struct {
 // DeploymentSpec is the API message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Strategy is the rollout strategy for the databases in the deployment.
+	// All databases are rolled out at the same time if the strategy is not set.
+	Strategy *RolloutStrategy `json:"strategy,omitempty"`
+}
+
+// RolloutStrategy is the API message for the rollout strategy of a deployment.
+type RolloutStrategy struct {
+	// CanaryPercentage is the percentage of databases in the deployment to roll out first.
+	// The rest databases will not start until all the canary databases are done.
+	CanaryPercentage int `json:"canaryPercentage,omitempty"`
+	// CanaryCount is the number of databases in the deployment to roll out first.
+	// Only one of CanaryPercentage and CanaryCount can be set.
+	CanaryCount int `json:"canaryCount,omitempty"`
+	// MaxParallelism is the maximum number of tasks running at the same time in the deployment.
+	// Zero means no limit.
+	MaxParallelism int `json:"maxParallelism,omitempty"`
+	// FailureThreshold is the number of failed tasks that halts the remaining tasks in the deployment.
+	// The remaining tasks will continue after the failed tasks are retried or skipped. Zero means never halt.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
 }
 
 // LabelSelector is the API message for label selector.
@@ -39,15 +58,18 @@ type LabelSelector struct {
 }
 
 // OperatorType is the type of label selector requirement operator.
-// Valid operators are In, Exists.
-// Note: NotIn and DoesNotExist are not supported initially.
+// Valid operators are In, NotIn, Exists and DoesNotExist.
 type OperatorType string
 
 const (
 	// InOperatorType is the operator type for In.
 	InOperatorType OperatorType = "In"
+	// NotInOperatorType is the operator type for NotIn.
+	NotInOperatorType OperatorType = "NotIn"
 	// ExistsOperatorType is the operator type for Exists.
 	ExistsOperatorType OperatorType = "Exists"
+	// DoesNotExistOperatorType is the operator type for DoesNotExist.
+	DoesNotExistOperatorType OperatorType = "DoesNotExist"
 )
 
 // LabelSelectorRequirement is the API message for label selector.
@@ -102,11 +124,11 @@ func ValidateAndGetDeploymentSchedule(payload string) (*DeploymentSchedule, erro
 		hasEnv := false
 		for _, e := range d.Spec.Selector.MatchExpressions {
 			switch e.Operator {
-			case InOperatorType:
+			case InOperatorType, NotInOperatorType:
 				if len(e.Values) == 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator should have at least one value", e.Key, e.Operator)
 				}
-			case ExistsOperatorType:
+			case ExistsOperatorType, DoesNotExistOperatorType:
 				if len(e.Values) > 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator shouldn't have values", e.Key, e.Operator)
 				}
@@ -123,6 +145,31 @@ func ValidateAndGetDeploymentSchedule(payload string) (*DeploymentSchedule, erro
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", EnvironmentLabelKey)
 		}
+		if err := validateRolloutStrategy(d.Spec.Strategy); err != nil {
+			return nil, err
+		}
 	}
 	return schedule, nil
 }
+
+func validateRolloutStrategy(strategy *RolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+	if strategy.CanaryPercentage < 0 || strategy.CanaryPercentage > 100 {
+		return common.Errorf(common.Invalid, "canary percentage should be between 0 and 100, got %d", strategy.CanaryPercentage)
+	}
+	if strategy.CanaryCount < 0 {
+		return common.Errorf(common.Invalid, "canary count should not be negative, got %d", strategy.CanaryCount)
+	}
+	if strategy.CanaryPercentage > 0 && strategy.CanaryCount > 0 {
+		return common.Errorf(common.Invalid, "only one of canary percentage and canary count can be set")
+	}
+	if strategy.MaxParallelism < 0 {
+		return common.Errorf(common.Invalid, "max parallelism should not be negative, got %d", strategy.MaxParallelism)
+	}
+	if strategy.FailureThreshold < 0 {
+		return common.Errorf(common.Invalid, "failure threshold should not be negative, got %d", strategy.FailureThreshold)
+	}
+	return nil
+}
@@ -92,6 +92,66 @@ func TestGetDeploymentSchedule(t *testing.T) {
 			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"Exists"},{"key":"location","operator":"In","values":["us-central1","europe-west1"]}]}}}]}`,
 			nil,
 			"should must use operator",
+		}, {
+			"notInAndDoesNotExistOperators",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]},{"key":"location","operator":"NotIn","values":["us-central1"]},{"key":"bb.tenant","operator":"DoesNotExist"}]},"strategy":{"canaryPercentage":10,"maxParallelism":20,"failureThreshold":3}}}]}`,
+			&DeploymentSchedule{
+				Deployments: []*Deployment{
+					{
+						Name: "deployment1",
+						Spec: &DeploymentSpec{
+							Selector: &LabelSelector{
+								MatchExpressions: []*LabelSelectorRequirement{
+									{
+										Key:      "bb.environment",
+										Operator: "In",
+										Values:   []string{"prod"},
+									}, {
+										Key:      "location",
+										Operator: "NotIn",
+										Values:   []string{"us-central1"},
+									}, {
+										Key:      "bb.tenant",
+										Operator: "DoesNotExist",
+										Values:   nil,
+									},
+								},
+							},
+							Strategy: &RolloutStrategy{
+								CanaryPercentage: 10,
+								MaxParallelism:   20,
+								FailureThreshold: 3,
+							},
+						},
+					},
+				},
+			},
+			"",
+		}, {
+			"notInOperatorWithNoValue",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]},{"key":"location","operator":"NotIn"}]}}}]}`,
+			nil,
+			"operator should have at least one value",
+		}, {
+			"doesNotExistOperatorWithValues",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]},{"key":"location","operator":"DoesNotExist","values":["us-central1"]}]}}}]}`,
+			nil,
+			"operator shouldn't have values",
+		}, {
+			"canaryPercentageAndCount",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"strategy":{"canaryPercentage":10,"canaryCount":2}}}]}`,
+			nil,
+			"only one of canary percentage and canary count",
+		}, {
+			"invalidCanaryPercentage",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"strategy":{"canaryPercentage":120}}}]}`,
+			nil,
+			"canary percentage should be between 0 and 100",
+		}, {
+			"negativeMaxParallelism",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"strategy":{"maxParallelism":-1}}}]}`,
+			nil,
+			"max parallelism should not be negative",
 		}, {
 			"environmentMultiValues",
 			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod", "dev"]},{"key":"location","operator":"In","values":["us-central1","europe-west1"]}]}}}]}`,
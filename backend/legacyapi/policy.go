@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -45,6 +46,8 @@ const (
 	PolicyTypeAccessControl PolicyType = "bb.policy.access-control"
 	// PolicyTypeSlowQuery is the slow query policy type.
 	PolicyTypeSlowQuery PolicyType = "bb.policy.slow-query"
+	// PolicyTypeBackupRestoreDrill is the backup restore drill policy type.
+	PolicyTypeBackupRestoreDrill PolicyType = "bb.policy.backup-restore-drill"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -85,13 +88,14 @@ const (
 var (
 	// AllowedResourceTypes includes allowed resource types for each policy type.
 	AllowedResourceTypes = map[PolicyType][]PolicyResourceType{
-		PolicyTypePipelineApproval: {PolicyResourceTypeEnvironment},
-		PolicyTypeBackupPlan:       {PolicyResourceTypeEnvironment},
-		PolicyTypeSQLReview:        {PolicyResourceTypeEnvironment},
-		PolicyTypeEnvironmentTier:  {PolicyResourceTypeEnvironment},
-		PolicyTypeSensitiveData:    {PolicyResourceTypeDatabase},
-		PolicyTypeAccessControl:    {PolicyResourceTypeEnvironment, PolicyResourceTypeDatabase},
-		PolicyTypeSlowQuery:        {PolicyResourceTypeInstance},
+		PolicyTypePipelineApproval:   {PolicyResourceTypeEnvironment},
+		PolicyTypeBackupPlan:         {PolicyResourceTypeEnvironment},
+		PolicyTypeSQLReview:          {PolicyResourceTypeEnvironment},
+		PolicyTypeEnvironmentTier:    {PolicyResourceTypeEnvironment},
+		PolicyTypeSensitiveData:      {PolicyResourceTypeDatabase},
+		PolicyTypeAccessControl:      {PolicyResourceTypeEnvironment, PolicyResourceTypeDatabase},
+		PolicyTypeSlowQuery:          {PolicyResourceTypeInstance},
+		PolicyTypeBackupRestoreDrill: {PolicyResourceTypeEnvironment},
 	}
 )
 
@@ -178,6 +182,13 @@ type BackupPlanPolicy struct {
 	Schedule BackupPlanPolicySchedule `json:"schedule"`
 	// RetentionPeriodTs is the minimum allowed period that backup data is kept for databases in an environment.
 	RetentionPeriodTs int `json:"retentionPeriodTs"`
+	// StorageBackend is the storage backend for the backups of databases in an environment.
+	// Empty means the workspace storage backend configured by the server flags, and LOCAL means the local disk.
+	StorageBackend BackupStorageBackend `json:"storageBackend,omitempty"`
+	// Storage is the name of the backup storage configured by the --backup-storage-config server flag,
+	// so that the environment keeps the backups in its own bucket with its own credentials.
+	// It can only be set if StorageBackend is empty.
+	Storage string `json:"storage,omitempty"`
 }
 
 func (bp *BackupPlanPolicy) String() (string, error) {
@@ -231,6 +242,21 @@ type SensitiveData struct {
 	Table  string                `json:"table"`
 	Column string                `json:"column"`
 	Type   SensitiveDataMaskType `json:"maskType"`
+	// Option is the option of the mask algorithm, it's only applicable to the PARTIAL, HASH and RANGE types.
+	Option *SensitiveDataMaskOption `json:"maskOption,omitempty"`
+}
+
+// SensitiveDataMaskOption is the option of the mask algorithm.
+type SensitiveDataMaskOption struct {
+	// PrefixLength and SuffixLength are the number of characters kept by the PARTIAL type.
+	PrefixLength int `json:"prefixLength,omitempty"`
+	SuffixLength int `json:"suffixLength,omitempty"`
+	// Salt is prepended to the value before hashing by the HASH type, which requires a non-empty salt.
+	Salt string `json:"salt,omitempty"`
+	// BucketSize is the width of the numeric bucket used by the RANGE type.
+	BucketSize float64 `json:"bucketSize,omitempty"`
+	// DateUnit is the unit that the dates are truncated to by the RANGE type, one of YEAR, MONTH and DAY.
+	DateUnit string `json:"dateUnit,omitempty"`
 }
 
 // SensitiveDataMaskType is the mask type for sensitive data.
@@ -240,8 +266,47 @@ const (
 	// SensitiveDataMaskTypeDefault is the sensitive data type to hide data with a default method.
 	// The default method is subject to change.
 	SensitiveDataMaskTypeDefault SensitiveDataMaskType = "DEFAULT"
+	// SensitiveDataMaskTypeFull is the sensitive data type to replace the whole value with asterisks.
+	SensitiveDataMaskTypeFull SensitiveDataMaskType = "FULL"
+	// SensitiveDataMaskTypePartial is the sensitive data type to keep the first and last N characters.
+	SensitiveDataMaskTypePartial SensitiveDataMaskType = "PARTIAL"
+	// SensitiveDataMaskTypeHash is the sensitive data type to replace the value with its salted hash.
+	SensitiveDataMaskTypeHash SensitiveDataMaskType = "HASH"
+	// SensitiveDataMaskTypeRange is the sensitive data type to replace numbers and dates with the bucket they fall into.
+	SensitiveDataMaskTypeRange SensitiveDataMaskType = "RANGE"
+	// SensitiveDataMaskTypeEmail is the sensitive data type to mask the email but the first character and the domain.
+	SensitiveDataMaskTypeEmail SensitiveDataMaskType = "EMAIL"
+	// SensitiveDataMaskTypePhone is the sensitive data type to mask the phone number but the last four digits.
+	SensitiveDataMaskTypePhone SensitiveDataMaskType = "PHONE"
 )
 
+// Validate validates the sensitive data mask type and option.
+func (data SensitiveData) Validate() error {
+	switch data.Type {
+	case SensitiveDataMaskTypeDefault, SensitiveDataMaskTypeFull, SensitiveDataMaskTypeEmail, SensitiveDataMaskTypePhone:
+	case SensitiveDataMaskTypeHash:
+		if data.Option == nil || data.Option.Salt == "" {
+			return errors.Errorf("mask type %q requires a non-empty salt", data.Type)
+		}
+	case SensitiveDataMaskTypePartial:
+		if data.Option == nil || data.Option.PrefixLength < 0 || data.Option.SuffixLength < 0 || data.Option.PrefixLength+data.Option.SuffixLength == 0 {
+			return errors.Errorf("mask type %q requires non-negative prefixLength and suffixLength, and at least one of them is positive", data.Type)
+		}
+	case SensitiveDataMaskTypeRange:
+		if data.Option == nil || (data.Option.BucketSize <= 0 && data.Option.DateUnit == "") {
+			return errors.Errorf("mask type %q requires a positive bucketSize or a dateUnit", data.Type)
+		}
+		switch data.Option.DateUnit {
+		case "", "YEAR", "MONTH", "DAY":
+		default:
+			return errors.Errorf("invalid dateUnit %q, it should be one of YEAR, MONTH and DAY", data.Option.DateUnit)
+		}
+	default:
+		return errors.Errorf("invalid sensitive data mask type %q", data.Type)
+	}
+	return nil
+}
+
 // UnmarshalSensitiveDataPolicy will unmarshal payload to sensitive data policy.
 func UnmarshalSensitiveDataPolicy(payload string) (*SensitiveDataPolicy, error) {
 	var p SensitiveDataPolicy
@@ -314,6 +379,43 @@ func (p *SlowQueryPolicy) String() (string, error) {
 	return string(s), nil
 }
 
+// BackupRestoreDrillPolicy is the policy configuration for backup restore drills.
+// The drills periodically restore the latest backups of the databases in an environment into scratch databases
+// and check the restored schema and data against the source databases.
+type BackupRestoreDrillPolicy struct {
+	// Schedule is how often the latest backup of each database is drilled. UNSET disables the drills.
+	Schedule BackupPlanPolicySchedule `json:"schedule"`
+	// InstanceID is the resource ID of the instance where the scratch databases are created.
+	// The instance must have the same engine as the drilled databases.
+	InstanceID string `json:"instanceId"`
+	// DatabaseList is the databases to drill in the format of instances/{instance}/databases/{database}.
+	// Empty means all databases in the environment.
+	DatabaseList []string `json:"databaseList"`
+	// RowCountCheck enables comparing the row count of each restored table with the source table.
+	RowCountCheck bool `json:"rowCountCheck"`
+	// RowCountTolerance is the allowed difference in percent between the restored and the source row counts,
+	// which accounts for the changes made to the source database after the backup.
+	RowCountTolerance int `json:"rowCountTolerance"`
+}
+
+// UnmarshalBackupRestoreDrillPolicy will unmarshal payload to backup restore drill policy.
+func UnmarshalBackupRestoreDrillPolicy(payload string) (*BackupRestoreDrillPolicy, error) {
+	var p BackupRestoreDrillPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal backup restore drill policy %q", payload)
+	}
+	return &p, nil
+}
+
+// String will return the string representation of the policy.
+func (p *BackupRestoreDrillPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
 // UnmarshalEnvironmentTierPolicy will unmarshal payload to environment tier policy.
 func UnmarshalEnvironmentTierPolicy(payload string) (*EnvironmentTierPolicy, error) {
 	var p EnvironmentTierPolicy
@@ -377,6 +479,14 @@ func ValidatePolicy(resourceType PolicyResourceType, pType PolicyType, payload *
 		if bp.Schedule != BackupPlanPolicyScheduleUnset && bp.Schedule != BackupPlanPolicyScheduleDaily && bp.Schedule != BackupPlanPolicyScheduleWeekly {
 			return errors.Errorf("invalid backup plan policy schedule: %q", bp.Schedule)
 		}
+		switch bp.StorageBackend {
+		case "", BackupStorageBackendLocal:
+		default:
+			return errors.Errorf("invalid backup plan policy storage backend: %q, should be %q or empty for the workspace backup storage", bp.StorageBackend, BackupStorageBackendLocal)
+		}
+		if bp.Storage != "" && bp.StorageBackend != "" {
+			return errors.Errorf("backup plan policy storage %q cannot be set with storage backend %q", bp.Storage, bp.StorageBackend)
+		}
 		return nil
 	case PolicyTypeSQLReview:
 		sr, err := UnmarshalSQLReviewPolicy(*payload)
@@ -405,8 +515,8 @@ func ValidatePolicy(resourceType PolicyResourceType, pType PolicyType, payload *
 			if v.Table == "" || v.Column == "" {
 				return errors.Errorf("sensitive data policy rule cannot have empty table or column name")
 			}
-			if v.Type != SensitiveDataMaskTypeDefault {
-				return errors.Errorf("sensitive data policy rule must have mask type %q", SensitiveDataMaskTypeDefault)
+			if err := v.Validate(); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -415,10 +525,40 @@ func ValidatePolicy(resourceType PolicyResourceType, pType PolicyType, payload *
 			return err
 		}
 		return nil
+	case PolicyTypeBackupRestoreDrill:
+		p, err := UnmarshalBackupRestoreDrillPolicy(*payload)
+		if err != nil {
+			return err
+		}
+		if p.Schedule != BackupPlanPolicyScheduleUnset && p.Schedule != BackupPlanPolicyScheduleDaily && p.Schedule != BackupPlanPolicyScheduleWeekly {
+			return errors.Errorf("invalid backup restore drill policy schedule: %q", p.Schedule)
+		}
+		if p.Schedule != BackupPlanPolicyScheduleUnset && p.InstanceID == "" {
+			return errors.Errorf("backup restore drill policy must specify the instance for the scratch databases")
+		}
+		for _, database := range p.DatabaseList {
+			if _, _, err := ParseDrillDatabaseName(database); err != nil {
+				return err
+			}
+		}
+		if p.RowCountTolerance < 0 || p.RowCountTolerance > 100 {
+			return errors.Errorf("invalid backup restore drill policy row count tolerance %d, must be between 0 and 100", p.RowCountTolerance)
+		}
+		return nil
 	}
 	return nil
 }
 
+// ParseDrillDatabaseName parses the database name in the backup restore drill policy
+// in the format of instances/{instance}/databases/{database}, and returns the instance ID and the database name.
+func ParseDrillDatabaseName(name string) (string, string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 || parts[0] != "instances" || parts[1] == "" || parts[2] != "databases" || parts[3] == "" {
+		return "", "", errors.Errorf("invalid database name %q in backup restore drill policy, expecting instances/{instance}/databases/{database}", name)
+	}
+	return parts[1], parts[3], nil
+}
+
 // GetDefaultPolicy will return the default value for the given policy type.
 // The default policy can be empty when we don't have anything to enforce at runtime.
 func GetDefaultPolicy(pType PolicyType) (string, error) {
@@ -444,6 +584,11 @@ func GetDefaultPolicy(pType PolicyType) (string, error) {
 	case PolicyTypeSensitiveData:
 		policy := SensitiveDataPolicy{}
 		return policy.String()
+	case PolicyTypeBackupRestoreDrill:
+		policy := BackupRestoreDrillPolicy{
+			Schedule: BackupPlanPolicyScheduleUnset,
+		}
+		return policy.String()
 	}
 	return "", nil
 }
@@ -29,6 +29,8 @@ type ProjectWebhookCreate struct {
 	Name         string   `jsonapi:"attr,name"`
 	URL          string   `jsonapi:"attr,url"`
 	ActivityList []string `jsonapi:"attr,activityList"`
+	// Secret is the secret to sign the request body of the custom webhook, which is write-only.
+	Secret string `jsonapi:"attr,secret"`
 }
 
 // ProjectWebhookPatch is the API message for patching a project webhook.
@@ -43,9 +45,45 @@ type ProjectWebhookPatch struct {
 	Name         *string `jsonapi:"attr,name"`
 	URL          *string `jsonapi:"attr,url"`
 	ActivityList *string `jsonapi:"attr,activityList"`
+	Secret       *string `jsonapi:"attr,secret"`
 }
 
 // ProjectWebhookTestResult is the test result of a project webhook.
 type ProjectWebhookTestResult struct {
 	Error string `jsonapi:"attr,error"`
 }
+
+// ProjectWebhookDeliveryStatus is the status of a project webhook delivery.
+type ProjectWebhookDeliveryStatus string
+
+const (
+	// ProjectWebhookDeliveryPending is the status of a delivery waiting for the first or the next attempt.
+	ProjectWebhookDeliveryPending ProjectWebhookDeliveryStatus = "PENDING"
+	// ProjectWebhookDeliveryDone is the status of a delivered delivery.
+	ProjectWebhookDeliveryDone ProjectWebhookDeliveryStatus = "DONE"
+	// ProjectWebhookDeliveryFailed is the status of a delivery given up after the maximum attempts.
+	ProjectWebhookDeliveryFailed ProjectWebhookDeliveryStatus = "FAILED"
+)
+
+// ProjectWebhookDelivery is the API message for a project webhook delivery.
+type ProjectWebhookDelivery struct {
+	ID int `jsonapi:"primary,projectWebhookDelivery"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+	UpdatedTs int64 `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	ProjectWebhookID int `jsonapi:"attr,projectWebhookId"`
+
+	// Domain specific fields
+	ActivityType  string                       `jsonapi:"attr,activityType"`
+	Title         string                       `jsonapi:"attr,title"`
+	Status        ProjectWebhookDeliveryStatus `jsonapi:"attr,status"`
+	Attempts      int                          `jsonapi:"attr,attempts"`
+	NextAttemptTs int64                        `jsonapi:"attr,nextAttemptTs"`
+	// ResponseCode is the HTTP status code of the last attempt, 0 if no response is received.
+	ResponseCode int    `jsonapi:"attr,responseCode"`
+	LatencyMs    int    `jsonapi:"attr,latencyMs"`
+	Error        string `jsonapi:"attr,error"`
+}
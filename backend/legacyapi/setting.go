@@ -38,6 +38,8 @@ const (
 	SettingPluginAgent SettingName = "bb.plugin.agent"
 	// SettingWorkspaceMailDelivery is the setting name for workspace mail delivery.
 	SettingWorkspaceMailDelivery SettingName = "bb.workspace.mail-delivery"
+	// SettingBackupEncryptionKey is the setting name for the workspace key encrypting the backup data keys.
+	SettingBackupEncryptionKey SettingName = "bb.workspace.backup-encryption-key"
 )
 
 // IMType is the type of IM.
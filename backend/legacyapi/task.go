@@ -43,6 +43,10 @@ const (
 	TaskDatabaseSchemaUpdateGhostSync TaskType = "bb.task.database.schema.update.ghost.sync"
 	// TaskDatabaseSchemaUpdateGhostCutover is the task type for gh-ost switching the original table and the ghost table.
 	TaskDatabaseSchemaUpdateGhostCutover TaskType = "bb.task.database.schema.update.ghost.cutover"
+	// TaskDatabaseSchemaUpdatePGOnlineSync is the task type for PostgreSQL online migration syncing shadow table.
+	TaskDatabaseSchemaUpdatePGOnlineSync TaskType = "bb.task.database.schema.update.pg-online.sync"
+	// TaskDatabaseSchemaUpdatePGOnlineCutover is the task type for PostgreSQL online migration switching the original table and the shadow table.
+	TaskDatabaseSchemaUpdatePGOnlineCutover TaskType = "bb.task.database.schema.update.pg-online.cutover"
 	// TaskDatabaseDataUpdate is the task type for updating database data.
 	TaskDatabaseDataUpdate TaskType = "bb.task.database.data.update"
 	// TaskDatabaseBackup is the task type for creating database backups.
@@ -113,6 +117,8 @@ type TaskDatabaseSchemaBaselinePayload struct {
 	// Common fields
 	Skipped       bool   `json:"skipped,omitempty"`
 	SkippedReason string `json:"skippedReason,omitempty"`
+	// SpecID is the ID of the plan spec that the task is created from.
+	SpecID string `json:"specId,omitempty"`
 
 	SchemaVersion string `json:"schemaVersion,omitempty"`
 }
@@ -122,6 +128,8 @@ type TaskDatabaseSchemaUpdatePayload struct {
 	// Common fields
 	Skipped       bool   `json:"skipped,omitempty"`
 	SkippedReason string `json:"skippedReason,omitempty"`
+	// SpecID is the ID of the plan spec that the task is created from.
+	SpecID string `json:"specId,omitempty"`
 
 	SheetID       int            `json:"sheetId,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
@@ -133,6 +141,8 @@ type TaskDatabaseSchemaUpdateSDLPayload struct {
 	// Common fields
 	Skipped       bool   `json:"skipped,omitempty"`
 	SkippedReason string `json:"skippedReason,omitempty"`
+	// SpecID is the ID of the plan spec that the task is created from.
+	SpecID string `json:"specId,omitempty"`
 
 	SheetID       int            `json:"sheetId,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
@@ -161,6 +171,26 @@ type TaskDatabaseSchemaUpdateGhostCutoverPayload struct {
 	SkippedReason string `json:"skippedReason,omitempty"`
 }
 
+// TaskDatabaseSchemaUpdatePGOnlineSyncPayload is the task payload for PostgreSQL online migration syncing shadow table.
+type TaskDatabaseSchemaUpdatePGOnlineSyncPayload struct {
+	// Common fields
+	Skipped       bool   `json:"skipped,omitempty"`
+	SkippedReason string `json:"skippedReason,omitempty"`
+
+	SheetID       int            `json:"sheetId,omitempty"`
+	SchemaVersion string         `json:"schemaVersion,omitempty"`
+	VCSPushEvent  *vcs.PushEvent `json:"pushEvent,omitempty"`
+	// The shadow table, trigger and trigger function names are derived from the sync task ID.
+	// See taskrun.pgOnlineMigration for the naming template.
+}
+
+// TaskDatabaseSchemaUpdatePGOnlineCutoverPayload is the task payload for PostgreSQL online migration switching the original table and the shadow table.
+type TaskDatabaseSchemaUpdatePGOnlineCutoverPayload struct {
+	// Common fields
+	Skipped       bool   `json:"skipped,omitempty"`
+	SkippedReason string `json:"skippedReason,omitempty"`
+}
+
 // RollbackSQLStatus is the status of a rollback SQL generation task.
 type RollbackSQLStatus string
 
@@ -178,6 +208,8 @@ type TaskDatabaseDataUpdatePayload struct {
 	// Common fields
 	Skipped       bool   `json:"skipped,omitempty"`
 	SkippedReason string `json:"skippedReason,omitempty"`
+	// SpecID is the ID of the plan spec that the task is created from.
+	SpecID string `json:"specId,omitempty"`
 
 	SheetID       int            `json:"sheetId,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
@@ -190,8 +222,11 @@ type TaskDatabaseDataUpdatePayload struct {
 	// RollbackSQLStatus is the status of the rollback generation.
 	RollbackSQLStatus RollbackSQLStatus `json:"rollbackSqlStatus,omitempty"`
 	// TransactionID is the ID of the transaction executing the migration.
-	// It is only use for Oracle to find Rollback SQL statement now.
+	// It is used for Oracle to find Rollback SQL statement, and for Postgres to mark the migration has backed up the changed rows.
 	TransactionID string `json:"transactionId,omitempty"`
+	// DataBackupList is the backup tables of the rows changed by the migration in the execution order.
+	// It is only used for Postgres to generate Rollback SQL statement now.
+	DataBackupList []*DataBackup `json:"dataBackupList,omitempty"`
 	// ThreadID is the ID of the connection executing the migration.
 	// We use it to filter the binlog events of the migration transaction.
 	ThreadID string `json:"threadId,omitempty"`
@@ -213,6 +248,20 @@ type TaskDatabaseDataUpdatePayload struct {
 	RollbackFromTaskID int `json:"rollbackFromTaskId,omitempty"`
 }
 
+// DataBackup is the backup table of the rows changed by one DML statement.
+type DataBackup struct {
+	// Operation is the type of the DML statement, which is one of INSERT, UPDATE and DELETE.
+	Operation string `json:"operation,omitempty"`
+	// Schema and Table are the table changed by the DML statement.
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table,omitempty"`
+	// BackupTable is the table in the backup schema.
+	// It stores the inserted rows for INSERT, and the rows before the change for UPDATE and DELETE.
+	BackupTable string `json:"backupTable,omitempty"`
+	// UpdatedColumnList is the columns assigned by the UPDATE statement.
+	UpdatedColumnList []string `json:"updatedColumnList,omitempty"`
+}
+
 // TaskDatabaseBackupPayload is the task payload for database backup.
 type TaskDatabaseBackupPayload struct {
 	// Common fields
@@ -103,6 +103,9 @@ type TaskCheckResult struct {
 	Content   string          `json:"content,omitempty"`
 	Line      int             `json:"line,omitempty"`
 	Details   string          `json:"details,omitempty"`
+	// Suppressed is true if the advice is suppressed by the SQL review suppression comment.
+	Suppressed     bool   `json:"suppressed,omitempty"`
+	SuppressReason string `json:"suppressReason,omitempty"`
 }
 
 // TaskCheckRunResultPayload is the result payload of a task check run.
@@ -186,7 +189,7 @@ func IsTaskCheckReportSupported(dbType db.Type) bool {
 // IsTaskCheckReportNeededForTaskType checks if the task report is needed for the task type.
 func IsTaskCheckReportNeededForTaskType(taskType TaskType) bool {
 	switch taskType {
-	case TaskDatabaseSchemaUpdate, TaskDatabaseSchemaUpdateSDL, TaskDatabaseSchemaUpdateGhostSync, TaskDatabaseDataUpdate:
+	case TaskDatabaseSchemaUpdate, TaskDatabaseSchemaUpdateSDL, TaskDatabaseSchemaUpdateGhostSync, TaskDatabaseSchemaUpdatePGOnlineSync, TaskDatabaseDataUpdate:
 		return true
 	default:
 		return false
@@ -106,4 +106,7 @@ type VCSSQLReviewRequest struct {
 	// In GitHub, the URL should be "https://github.com". Docs: https://docs.github.com/en/actions/learn-github-actions/environment-variables
 	// In GitLab, the URL should be the base URL of the GitLab instance like "https://gitlab.bytebase.com". Docs: https://docs.gitlab.com/ee/ci/variables/predefined_variables.html
 	WebURL string `json:"webURL"`
+	// Format is the optional output format, such as SARIF or JUNIT.
+	// If empty, the output is the native format of the VCS, i.e. GitHub action messages or GitLab JUnit report.
+	Format advisor.ExportFormat `json:"format"`
 }
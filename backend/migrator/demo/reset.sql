@@ -13,6 +13,9 @@ UPDATE setting SET creator_id = 1, updater_id = 1 WHERE name = 'bb.enterprise.li
 DELETE FROM
     risk;
 
+DELETE FROM
+    slow_query_snapshot;
+
 DELETE FROM
     slow_query;
 
@@ -46,6 +49,9 @@ DELETE FROM
 DELETE FROM
     issue;
 
+DELETE FROM
+    plan;
+
 DELETE FROM
     task_check_run;
 
@@ -98,6 +104,9 @@ DELETE FROM
 DELETE FROM
     environment;
 
+DELETE FROM
+    project_webhook_delivery;
+
 DELETE FROM
     project_webhook;
 
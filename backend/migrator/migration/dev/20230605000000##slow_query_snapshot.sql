@@ -0,0 +1,5 @@
+CREATE TABLE slow_query_snapshot (
+    instance_id INTEGER PRIMARY KEY REFERENCES instance (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    snapshot JSONB NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,5 @@
+ALTER TABLE backup DROP CONSTRAINT backup_storage_backend_check;
+
+ALTER TABLE backup ADD CONSTRAINT backup_storage_backend_check CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS', 'AZURE', 'FILESYSTEM'));
+
+ALTER TABLE backup ADD COLUMN storage TEXT NOT NULL DEFAULT '';
@@ -301,7 +301,8 @@ CREATE TABLE project_webhook (
     type TEXT NOT NULL CHECK (type LIKE 'bb.plugin.webhook.%'),
     name TEXT NOT NULL,
     url TEXT NOT NULL,
-    activity_list TEXT ARRAY NOT NULL
+    activity_list TEXT ARRAY NOT NULL,
+    secret TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_project_webhook_project_id ON project_webhook(project_id);
@@ -316,6 +317,33 @@ UPDATE
     ON project_webhook FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- Project Hook Delivery is the outbox of the project webhook messages.
+CREATE TABLE project_webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'DONE', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    response_code INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_project_webhook_delivery_project_webhook_id ON project_webhook_delivery(project_webhook_id);
+
+CREATE INDEX idx_project_webhook_delivery_status_next_attempt_ts ON project_webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE project_webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_project_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON project_webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- Instance
 CREATE TABLE instance (
     id SERIAL PRIMARY KEY,
@@ -469,7 +497,8 @@ CREATE TABLE backup (
     name TEXT NOT NULL,
     status TEXT NOT NULL CHECK (status IN ('PENDING_CREATE', 'DONE', 'FAILED')),
     type TEXT NOT NULL CHECK (type IN ('MANUAL', 'AUTOMATIC', 'PITR')),
-    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS')),
+    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS', 'AZURE', 'FILESYSTEM')),
+    storage TEXT NOT NULL DEFAULT '',
     migration_history_version TEXT NOT NULL,
     path TEXT NOT NULL,
     comment TEXT NOT NULL DEFAULT '',
@@ -715,6 +744,33 @@ UPDATE
     ON issue FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- plan is the spec of changes that can be rolled out as a pipeline.
+CREATE TABLE plan (
+    id BIGSERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_id INTEGER NOT NULL REFERENCES project (id),
+    pipeline_id INTEGER REFERENCES pipeline (id),
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    config JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_plan_project_id ON plan(project_id);
+
+CREATE INDEX idx_plan_pipeline_id ON plan(pipeline_id);
+
+ALTER SEQUENCE plan_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_plan_updated_ts
+BEFORE
+UPDATE
+    ON plan FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- stores the issue subscribers. Unlike other tables, it doesn't have row_status/creator_id/created_ts/updater_id/updated_ts.
 -- We use a separate table mainly because we can't leverage indexed query if the subscriber id is stored
 -- as a comma separated id list in the issue table.
@@ -1184,3 +1240,12 @@ BEFORE
 UPDATE
     ON slow_query FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- slow_query_snapshot stores the latest snapshot of the slow query statistics accumulated by the engines, such as Oracle and SQL Server.
+-- The statistics accumulated since the snapshot are added to the slow_query on the next sync.
+CREATE TABLE slow_query_snapshot (
+    instance_id INTEGER PRIMARY KEY REFERENCES instance (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    -- snapshot is the map from the database name to the accumulated slow query statistics.
+    snapshot JSONB NOT NULL DEFAULT '{}'
+);
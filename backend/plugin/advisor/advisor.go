@@ -214,6 +214,9 @@ const (
 	// MySQLStatementDMLDryRun is an advisor type for MySQL DML dry run.
 	MySQLStatementDMLDryRun Type = "bb.plugin.advisor.mysql.statement.dml-dry-run"
 
+	// MySQLCustomCEL is an advisor type for MySQL user-defined rule written in CEL.
+	MySQLCustomCEL Type = "bb.plugin.advisor.mysql.custom.cel"
+
 	// PostgreSQL Advisor.
 
 	// PostgreSQLSyntax is an advisor type for PostgreSQL syntax.
@@ -333,6 +336,9 @@ const (
 	// PostgreSQLCollationAllowlist is an advisor type for PostgreSQL collation allowlist.
 	PostgreSQLCollationAllowlist Type = "bb.plugin.advisor.postgresql.collation.allowlist"
 
+	// PostgreSQLCustomCEL is an advisor type for PostgreSQL user-defined rule written in CEL.
+	PostgreSQLCustomCEL Type = "bb.plugin.advisor.postgresql.custom.cel"
+
 	// Oracle Advisor.
 
 	// OracleTableRequirePK is an advisor type for Oracle table require primary key.
@@ -394,6 +400,10 @@ type Advice struct {
 	Content string `json:"content"`
 	Line    int    `json:"line"`
 	Details string `json:"details,omitempty"`
+	// Suppressed is true if the advice is suppressed by the suppression comment, e.g. -- bytebase:disable statement.where.require.
+	Suppressed bool `json:"suppressed,omitempty"`
+	// SuppressReason is the reason given in the suppression comment.
+	SuppressReason string `json:"suppressReason,omitempty"`
 }
 
 // MarshalLogObject constructs a field that carries Advice.
@@ -404,6 +414,8 @@ func (a Advice) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("content", a.Content)
 	enc.AddInt("line", a.Line)
 	enc.AddString("details", a.Details)
+	enc.AddBool("suppressed", a.Suppressed)
+	enc.AddString("suppressReason", a.SuppressReason)
 	return nil
 }
 
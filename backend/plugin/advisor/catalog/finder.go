@@ -24,12 +24,17 @@ type FinderContext struct {
 
 	// EngineType is the engine type for database engine.
 	EngineType db.Type
+
+	// CurrentSchema is the schema for the unqualified object names.
+	// It's only used for Oracle, where the schema is the current user.
+	CurrentSchema string
 }
 
 // Copy returns the deep copy.
 func (ctx *FinderContext) Copy() *FinderContext {
 	return &FinderContext{
 		CheckIntegrity: ctx.CheckIntegrity,
+		CurrentSchema:  ctx.CurrentSchema,
 	}
 }
 
@@ -49,6 +54,12 @@ func NewEmptyFinder(ctx *FinderContext) *Finder {
 	return &Finder{Origin: newDatabaseState(&storepb.DatabaseMetadata{}, ctx), Final: newDatabaseState(&storepb.DatabaseMetadata{}, ctx)}
 }
 
+// SetCurrentSchema sets the schema for the unqualified object names.
+func (f *Finder) SetCurrentSchema(schema string) {
+	f.Origin.ctx.CurrentSchema = schema
+	f.Final.ctx.CurrentSchema = schema
+}
+
 // WalkThrough does the walk through.
 func (f *Finder) WalkThrough(statements string) error {
 	return f.Final.WalkThrough(statements)
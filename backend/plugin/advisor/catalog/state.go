@@ -6,6 +6,7 @@ package catalog
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
@@ -52,6 +53,7 @@ func newSchemaState(s *storepb.SchemaMetadata, context *FinderContext) *SchemaSt
 		tableSet:      make(tableStateMap),
 		viewSet:       make(viewStateMap),
 		identifierMap: make(identifierMap),
+		sequenceSet:   make(map[string]bool),
 	}
 
 	for _, table := range s.Tables {
@@ -324,6 +326,10 @@ type SchemaState struct {
 	// PostgreSQL specific fields
 	// All relation names in PostgreSQL must be distinct in schema level.
 	identifierMap identifierMap
+
+	// Oracle specific fields
+	// The sequences are not synced, so it only contains the sequences created in the walk-through.
+	sequenceSet map[string]bool
 }
 type schemaStateMap map[string]*SchemaState
 
@@ -350,6 +356,21 @@ func (table *TableState) CountIndex() int {
 	return len(table.indexSet)
 }
 
+// ListColumns returns the columns ordered by the position.
+func (table *TableState) ListColumns() []*ColumnState {
+	var columnList []*ColumnState
+	for _, column := range table.columnSet {
+		columnList = append(columnList, column)
+	}
+	sort.Slice(columnList, func(i, j int) bool {
+		if columnList[i].position != nil && columnList[j].position != nil && *columnList[i].position != *columnList[j].position {
+			return *columnList[i].position < *columnList[j].position
+		}
+		return columnList[i].name < columnList[j].name
+	})
+	return columnList
+}
+
 func (table *TableState) copy() *TableState {
 	return &TableState{
 		name:      table.name,
@@ -460,6 +481,19 @@ func (col *ColumnState) copy() *ColumnState {
 	}
 }
 
+// Name returns the name for the column.
+func (col *ColumnState) Name() string {
+	return col.name
+}
+
+// Default returns the default value for the column, it's empty if the column has no default value.
+func (col *ColumnState) Default() string {
+	if col.defaultValue != nil {
+		return *col.defaultValue
+	}
+	return ""
+}
+
 // Nullable returns nullable for the column.
 func (col *ColumnState) Nullable() bool {
 	return col.nullable != nil && *col.nullable
@@ -218,6 +218,8 @@ func (d *DatabaseState) WalkThrough(stmt string) error {
 			d.usable = false
 		}
 		return nil
+	case db.Oracle:
+		return d.oracleWalkThrough(stmt)
 	default:
 		return &WalkThroughError{
 			Type:    ErrorTypeUnsupported,
@@ -1305,10 +1307,11 @@ func (t *TableState) createPrimaryKey(keys []string, tp string) *WalkThroughErro
 
 func (d *DatabaseState) createSchema(name string) *SchemaState {
 	schema := &SchemaState{
-		ctx:      d.ctx.Copy(),
-		name:     name,
-		tableSet: make(tableStateMap),
-		viewSet:  make(viewStateMap),
+		ctx:         d.ctx.Copy(),
+		name:        name,
+		tableSet:    make(tableStateMap),
+		viewSet:     make(viewStateMap),
+		sequenceSet: make(map[string]bool),
 	}
 
 	d.schemaSet[name] = schema
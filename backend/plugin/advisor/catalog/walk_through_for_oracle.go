@@ -0,0 +1,834 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
+	plsql "github.com/bytebase/plsql-parser"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+const (
+	oracleIndexTypeNormal         = "NORMAL"
+	oracleIndexTypeBitmap         = "BITMAP"
+	oracleIndexTypeFunctionNormal = "FUNCTION-BASED NORMAL"
+)
+
+func (d *DatabaseState) oracleWalkThrough(stmt string) error {
+	tree, err := parser.ParsePLSQL(stmt + ";")
+	if err != nil {
+		parseErr := NewParseError(err.Error())
+		if syntaxErr, ok := err.(*parser.SyntaxError); ok {
+			parseErr.Line = syntaxErr.Line
+		}
+		return parseErr
+	}
+
+	script, ok := tree.(*plsql.Sql_scriptContext)
+	if !ok {
+		return nil
+	}
+	for _, unit := range script.AllUnit_statement() {
+		if err := d.oracleChangeState(unit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DatabaseState) oracleChangeState(in plsql.IUnit_statementContext) (err *WalkThroughError) {
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err.Line == 0 {
+			err.Line = in.GetStart().GetLine()
+		}
+	}()
+
+	if d.deleted {
+		return &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf(`Database %q is deleted`, d.name),
+		}
+	}
+	switch {
+	case in.Create_table() != nil:
+		return d.oracleCreateTable(in.Create_table())
+	case in.Create_index() != nil:
+		return d.oracleCreateIndex(in.Create_index())
+	case in.Create_sequence() != nil:
+		return d.oracleCreateSequence(in.Create_sequence())
+	case in.Alter_table() != nil:
+		return d.oracleAlterTable(in.Alter_table())
+	case in.Alter_index() != nil:
+		return d.oracleAlterIndex(in.Alter_index())
+	case in.Drop_table() != nil:
+		return d.oracleDropTable(in.Drop_table())
+	case in.Drop_index() != nil:
+		return d.oracleDropIndex(in.Drop_index())
+	case in.Drop_sequence() != nil:
+		return d.oracleDropSequence(in.Drop_sequence())
+	case in.Rename_object() != nil:
+		return d.oracleRenameObject(in.Rename_object())
+	default:
+		return nil
+	}
+}
+
+func (d *DatabaseState) oracleCreateTable(ctx plsql.ICreate_tableContext) *WalkThroughError {
+	schema, err := d.oracleGetSchema(oracleNormalizeSchemaName(ctx.Schema_name()))
+	if err != nil {
+		return err
+	}
+	tableName := oracleNormalizeIdentifier(ctx.Table_name().Identifier())
+	if _, exists := schema.tableSet[tableName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeTableExists,
+			Content: fmt.Sprintf(`The table %q already exists in the schema %q`, tableName, schema.name),
+		}
+	}
+	if schema.oracleObjectExists(tableName) {
+		return NewRelationExistsError(tableName, schema.name)
+	}
+
+	relationalTable := ctx.Relational_table()
+	if relationalTable == nil {
+		// We do not deal with the object table and XMLType table.
+		return nil
+	}
+	if properties := relationalTable.Table_properties(); properties != nil && properties.AS() != nil {
+		return &WalkThroughError{
+			Type:    ErrorTypeUseCreateTableAs,
+			Content: fmt.Sprintf("Disallow the CREATE TABLE AS statement but \"%s\" uses", oracleGetText(ctx)),
+		}
+	}
+
+	table := &TableState{
+		name:          tableName,
+		columnSet:     make(columnStateMap),
+		indexSet:      make(indexStateMap),
+		dependentView: make(map[string]bool),
+	}
+	schema.tableSet[table.name] = table
+
+	var constraintList []plsql.IOut_of_line_constraintContext
+	for _, property := range relationalTable.AllRelational_property() {
+		switch {
+		case property.Column_definition() != nil:
+			column := property.Column_definition()
+			if oracleIsCheckConstraint(column) {
+				// We do not deal with CHECK constraint.
+				continue
+			}
+			if err := schema.oracleCreateColumn(schema.ctx, table, column); err != nil {
+				err.Line = column.GetStart().GetLine()
+				return err
+			}
+		case property.Virtual_column_definition() != nil:
+			column := property.Virtual_column_definition()
+			if err := table.oracleCreateVirtualColumn(column); err != nil {
+				err.Line = column.GetStart().GetLine()
+				return err
+			}
+		case property.Out_of_line_constraint() != nil:
+			// The out-of-line constraints may reference the columns defined after them.
+			constraintList = append(constraintList, property.Out_of_line_constraint())
+		}
+	}
+
+	for _, constraint := range constraintList {
+		if err := schema.oracleCreateConstraint(schema.ctx, table, constraint); err != nil {
+			err.Line = constraint.GetStart().GetLine()
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SchemaState) oracleCreateColumn(ctx *FinderContext, t *TableState, column plsql.IColumn_definitionContext) *WalkThroughError {
+	columnName := oracleNormalizeColumnName(column.Column_name())
+	if _, exists := t.columnSet[columnName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("The column %q already exists in table %q", columnName, t.name),
+		}
+	}
+
+	columnState := &ColumnState{
+		name:          columnName,
+		position:      newIntPointer(len(t.columnSet) + 1),
+		nullable:      newTruePointer(),
+		characterSet:  newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		dependentView: make(map[string]bool),
+	}
+	switch {
+	case column.Datatype() != nil:
+		columnState.columnType = newStringPointer(oracleNormalizeDataType(column.Datatype()))
+	case column.Regular_id() != nil:
+		columnState.columnType = newStringPointer(oracleNormalizeDataType(column.Regular_id()))
+	default:
+		columnState.columnType = newEmptyStringPointer()
+	}
+	if column.DEFAULT() != nil && column.Expression() != nil {
+		columnState.defaultValue = newStringPointer(oracleGetText(column.Expression()))
+	}
+	t.columnSet[columnName] = columnState
+
+	for _, constraint := range column.AllInline_constraint() {
+		if err := s.oracleCreateInlineConstraint(ctx, t, columnState, constraint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleCreateVirtualColumn(column plsql.IVirtual_column_definitionContext) *WalkThroughError {
+	columnName := oracleNormalizeColumnName(column.Column_name())
+	if _, exists := t.columnSet[columnName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("The column %q already exists in table %q", columnName, t.name),
+		}
+	}
+
+	columnType := ""
+	if column.Datatype() != nil {
+		columnType = oracleNormalizeDataType(column.Datatype())
+	}
+	t.columnSet[columnName] = &ColumnState{
+		name:          columnName,
+		position:      newIntPointer(len(t.columnSet) + 1),
+		nullable:      newTruePointer(),
+		columnType:    newStringPointer(columnType),
+		characterSet:  newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		dependentView: make(map[string]bool),
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleCreateInlineConstraint(ctx *FinderContext, t *TableState, column *ColumnState, constraint plsql.IInline_constraintContext) *WalkThroughError {
+	constraintName := ""
+	if constraint.Constraint_name() != nil {
+		constraintName = oracleNormalizeConstraintName(constraint.Constraint_name())
+	}
+	switch {
+	case constraint.NULL_() != nil:
+		column.nullable = newBoolPointer(constraint.NOT() == nil)
+	case constraint.PRIMARY() != nil:
+		column.nullable = newFalsePointer()
+		return s.oracleCreateIndex(ctx, t, constraintName, []string{column.name}, true /* unique */, true /* primary */, oracleIndexTypeNormal)
+	case constraint.UNIQUE() != nil:
+		return s.oracleCreateIndex(ctx, t, constraintName, []string{column.name}, true /* unique */, false /* primary */, oracleIndexTypeNormal)
+	default:
+		// We do not deal with FOREIGN KEY and CHECK constraint.
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleCreateConstraint(ctx *FinderContext, t *TableState, constraint plsql.IOut_of_line_constraintContext) *WalkThroughError {
+	constraintName := ""
+	if constraint.Constraint_name() != nil {
+		constraintName = oracleNormalizeConstraintName(constraint.Constraint_name())
+	}
+	if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+		// We do not deal with FOREIGN KEY and CHECK constraint.
+		return nil
+	}
+
+	var keyList []string
+	for _, column := range constraint.AllColumn_name() {
+		columnName := oracleNormalizeColumnName(column)
+		columnState, err := t.oracleGetColumn(ctx, columnName)
+		if err != nil {
+			return err
+		}
+		if constraint.PRIMARY() != nil {
+			columnState.nullable = newFalsePointer()
+		}
+		keyList = append(keyList, columnName)
+	}
+	return s.oracleCreateIndex(ctx, t, constraintName, keyList, true /* unique */, constraint.PRIMARY() != nil, oracleIndexTypeNormal)
+}
+
+func (d *DatabaseState) oracleCreateIndex(ctx plsql.ICreate_indexContext) *WalkThroughError {
+	tableIndex := ctx.Table_index_clause()
+	if tableIndex == nil {
+		// We do not deal with the cluster index and bitmap join index.
+		return nil
+	}
+
+	schemaName, indexName := oracleNormalizeIndexName(ctx.Index_name())
+	indexSchema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	schema, table, err := d.oracleGetTable(tableIndex.Tableview_name())
+	if err != nil {
+		return err
+	}
+	if indexSchema != schema {
+		return &WalkThroughError{
+			Type:    ErrorTypeUnsupported,
+			Content: fmt.Sprintf("Creating index %q in schema %q on table %q in schema %q is not supported", indexName, indexSchema.name, table.name, schema.name),
+		}
+	}
+
+	indexType := oracleIndexTypeNormal
+	if ctx.BITMAP() != nil {
+		indexType = oracleIndexTypeBitmap
+	}
+	var keyList []string
+	for _, expr := range tableIndex.AllIndex_expr() {
+		if expr.Column_name() != nil {
+			columnName := oracleNormalizeColumnName(expr.Column_name())
+			if _, err := table.oracleGetColumn(schema.ctx, columnName); err != nil {
+				return err
+			}
+			keyList = append(keyList, columnName)
+			continue
+		}
+		if indexType == oracleIndexTypeNormal {
+			indexType = oracleIndexTypeFunctionNormal
+		}
+		keyList = append(keyList, oracleGetText(expr))
+	}
+	return schema.oracleCreateIndex(schema.ctx, table, indexName, keyList, ctx.UNIQUE() != nil, false /* primary */, indexType)
+}
+
+func (s *SchemaState) oracleCreateIndex(ctx *FinderContext, t *TableState, name string, keyList []string, unique bool, primary bool, indexType string) *WalkThroughError {
+	if len(keyList) == 0 {
+		return &WalkThroughError{
+			Type:    ErrorTypeIndexEmptyKeys,
+			Content: fmt.Sprintf("Index %q in table %q has empty key", name, t.name),
+		}
+	}
+	if primary {
+		if pk := t.oraclePrimaryKey(); pk != nil {
+			return &WalkThroughError{
+				Type:    ErrorTypePrimaryKeyExists,
+				Content: fmt.Sprintf("Primary key exists in table %q", t.name),
+			}
+		}
+	}
+
+	if name == "" {
+		// Oracle generates the name such as SYS_C0012345 for the unnamed constraint, we use a readable name instead.
+		name = s.oracleGenerateIndexName(t, keyList, primary)
+	} else if table, _ := s.oracleFindIndex(name); table != nil {
+		return NewIndexExistsError(table.name, name)
+	}
+
+	if ctx.CheckIntegrity && !primary {
+		// Oracle doesn't allow to create an index on the column list which is already indexed.
+		for _, index := range t.indexSet {
+			if strings.Join(index.expressionList, ",") == strings.Join(keyList, ",") {
+				return &WalkThroughError{
+					Type:    ErrorTypeIndexExists,
+					Content: fmt.Sprintf("The column list %q of index %q is already indexed by %q in table %q", strings.Join(keyList, ", "), name, index.name, t.name),
+				}
+			}
+		}
+	}
+
+	t.indexSet[name] = &IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer(indexType),
+		unique:         newBoolPointer(unique),
+		primary:        newBoolPointer(primary),
+		visible:        newTruePointer(),
+		comment:        newEmptyStringPointer(),
+		isConstraint:   primary || unique,
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleGenerateIndexName(t *TableState, keyList []string, primary bool) string {
+	suffix := "UK"
+	if primary {
+		suffix = "PK"
+	}
+	base := fmt.Sprintf("%s_%s", t.name, suffix)
+	name := base
+	for i := 2; ; i++ {
+		if table, _ := s.oracleFindIndex(name); table == nil {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+func (d *DatabaseState) oracleCreateSequence(ctx plsql.ICreate_sequenceContext) *WalkThroughError {
+	schemaName, sequenceName := oracleNormalizeSequenceName(ctx.Sequence_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	if schema.oracleObjectExists(sequenceName) {
+		return NewRelationExistsError(sequenceName, schema.name)
+	}
+	schema.sequenceSet[sequenceName] = true
+	return nil
+}
+
+func (d *DatabaseState) oracleAlterTable(ctx plsql.IAlter_tableContext) *WalkThroughError {
+	schema, table, err := d.oracleGetTable(ctx.Tableview_name())
+	if err != nil {
+		return err
+	}
+	for _, child := range ctx.GetChildren() {
+		if err := d.oracleAlterTableClause(schema, table, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DatabaseState) oracleAlterTableClause(schema *SchemaState, table *TableState, tree antlr.Tree) *WalkThroughError {
+	switch ctx := tree.(type) {
+	case *plsql.Column_clausesContext, *plsql.Add_modify_drop_column_clausesContext:
+		for _, child := range tree.GetChildren() {
+			if err := d.oracleAlterTableClause(schema, table, child); err != nil {
+				return err
+			}
+		}
+	case *plsql.Add_column_clauseContext:
+		for _, column := range ctx.AllColumn_definition() {
+			if err := schema.oracleCreateColumn(schema.ctx, table, column); err != nil {
+				return err
+			}
+		}
+		for _, column := range ctx.AllVirtual_column_definition() {
+			if err := table.oracleCreateVirtualColumn(column); err != nil {
+				return err
+			}
+		}
+	case *plsql.Modify_column_clausesContext:
+		for _, column := range ctx.AllModify_col_properties() {
+			if err := schema.oracleModifyColumn(schema.ctx, table, column); err != nil {
+				return err
+			}
+		}
+	case *plsql.Drop_column_clauseContext:
+		for _, column := range ctx.AllColumn_name() {
+			if err := table.oracleDropColumn(schema.ctx, oracleNormalizeColumnName(column)); err != nil {
+				return err
+			}
+		}
+	case *plsql.Rename_column_clauseContext:
+		oldName := oracleNormalizeColumnName(ctx.Old_column_name().Column_name())
+		newName := oracleNormalizeColumnName(ctx.New_column_name().Column_name())
+		return table.renameColumn(schema.ctx, oldName, newName)
+	case *plsql.Constraint_clausesContext:
+		return d.oracleConstraintClauses(schema, table, ctx)
+	case *plsql.Alter_table_propertiesContext:
+		if ctx.RENAME() == nil {
+			return nil
+		}
+		// The table cannot be moved to another schema by ALTER TABLE RENAME TO, so we only take the table name.
+		_, newName := oracleNormalizeTableviewName(ctx.Tableview_name())
+		return schema.oracleRenameTable(schema.ctx, table.name, newName)
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleModifyColumn(ctx *FinderContext, t *TableState, column plsql.IModify_col_propertiesContext) *WalkThroughError {
+	columnState, err := t.oracleGetColumn(ctx, oracleNormalizeColumnName(column.Column_name()))
+	if err != nil {
+		return err
+	}
+	if column.Datatype() != nil {
+		columnState.columnType = newStringPointer(oracleNormalizeDataType(column.Datatype()))
+	}
+	if column.DEFAULT() != nil && column.Expression() != nil {
+		defaultValue := oracleGetText(column.Expression())
+		if strings.EqualFold(defaultValue, "NULL") {
+			columnState.defaultValue = nil
+		} else {
+			columnState.defaultValue = newStringPointer(defaultValue)
+		}
+	}
+	for _, constraint := range column.AllInline_constraint() {
+		if err := s.oracleCreateInlineConstraint(ctx, t, columnState, constraint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleDropColumn(ctx *FinderContext, columnName string) *WalkThroughError {
+	if ctx.CheckIntegrity {
+		if _, exists := t.columnSet[columnName]; !exists {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+	}
+	return t.dropColumn(ctx, columnName)
+}
+
+func (d *DatabaseState) oracleConstraintClauses(schema *SchemaState, table *TableState, ctx *plsql.Constraint_clausesContext) *WalkThroughError {
+	switch {
+	case ctx.ADD() != nil:
+		for _, constraint := range ctx.AllOut_of_line_constraint() {
+			if err := schema.oracleCreateConstraint(schema.ctx, table, constraint); err != nil {
+				return err
+			}
+		}
+	case ctx.RENAME() != nil:
+		oldName := oracleNormalizeConstraintName(ctx.Old_constraint_name().Constraint_name())
+		newName := oracleNormalizeConstraintName(ctx.New_constraint_name().Constraint_name())
+		if _, exists := table.indexSet[oldName]; !exists {
+			// The constraint may be a FOREIGN KEY or CHECK constraint, which we do not deal with.
+			return nil
+		}
+		if t, _ := schema.oracleFindIndex(newName); t != nil {
+			return NewIndexExistsError(t.name, newName)
+		}
+		return table.renameIndex(schema.ctx, oldName, newName)
+	default:
+		for _, drop := range ctx.AllDrop_constraint_clause() {
+			if err := table.oracleDropConstraint(schema.ctx, drop.Drop_primary_key_or_unique_or_generic_clause()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleDropConstraint(ctx *FinderContext, clause plsql.IDrop_primary_key_or_unique_or_generic_clauseContext) *WalkThroughError {
+	switch {
+	case clause.PRIMARY() != nil:
+		pk := t.oraclePrimaryKey()
+		if pk == nil {
+			if !ctx.CheckIntegrity {
+				return nil
+			}
+			return &WalkThroughError{
+				Type:    ErrorTypePrimaryKeyNotExists,
+				Content: fmt.Sprintf("Primary key does not exist in table %q", t.name),
+			}
+		}
+		delete(t.indexSet, pk.name)
+	case clause.UNIQUE() != nil:
+		var keyList []string
+		for _, column := range clause.AllColumn_name() {
+			keyList = append(keyList, oracleNormalizeColumnName(column))
+		}
+		for _, index := range t.indexSet {
+			if index.Unique() && !index.Primary() && strings.Join(index.expressionList, ",") == strings.Join(keyList, ",") {
+				delete(t.indexSet, index.name)
+				return nil
+			}
+		}
+		if ctx.CheckIntegrity {
+			return &WalkThroughError{
+				Type:    ErrorTypeIndexNotExists,
+				Content: fmt.Sprintf("Unique constraint on %q does not exist in table %q", strings.Join(keyList, ", "), t.name),
+			}
+		}
+	default:
+		// The constraint may be a FOREIGN KEY or CHECK constraint, so we don't return error if the index doesn't exist.
+		delete(t.indexSet, oracleNormalizeConstraintName(clause.Constraint_name()))
+	}
+	return nil
+}
+
+func (d *DatabaseState) oracleAlterIndex(ctx plsql.IAlter_indexContext) *WalkThroughError {
+	ops := ctx.Alter_index_ops_set2()
+	if ops == nil || ops.RENAME() == nil {
+		return nil
+	}
+
+	schemaName, indexName := oracleNormalizeIndexName(ctx.Index_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	table, _ := schema.oracleFindIndex(indexName)
+	if table == nil {
+		if !schema.ctx.CheckIntegrity {
+			return nil
+		}
+		return &WalkThroughError{
+			Type:    ErrorTypeIndexNotExists,
+			Content: fmt.Sprintf("Index %q does not exist in schema %q", indexName, schema.name),
+		}
+	}
+	_, newName := oracleNormalizeIndexName(ops.New_index_name().Index_name())
+	if t, _ := schema.oracleFindIndex(newName); t != nil {
+		return NewIndexExistsError(t.name, newName)
+	}
+	return table.renameIndex(schema.ctx, indexName, newName)
+}
+
+func (d *DatabaseState) oracleDropTable(ctx plsql.IDrop_tableContext) *WalkThroughError {
+	schemaName, tableName := oracleNormalizeTableviewName(ctx.Tableview_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	if _, exists := schema.tableSet[tableName]; !exists {
+		if !schema.ctx.CheckIntegrity {
+			return nil
+		}
+		return &WalkThroughError{
+			Type:    ErrorTypeTableNotExists,
+			Content: fmt.Sprintf("The table %q doesn't exist in schema %q", tableName, schema.name),
+		}
+	}
+
+	delete(schema.tableSet, tableName)
+	return nil
+}
+
+func (d *DatabaseState) oracleDropIndex(ctx plsql.IDrop_indexContext) *WalkThroughError {
+	schemaName, indexName := oracleNormalizeIndexName(ctx.Index_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	table, _ := schema.oracleFindIndex(indexName)
+	if table == nil {
+		if !schema.ctx.CheckIntegrity {
+			return nil
+		}
+		return &WalkThroughError{
+			Type:    ErrorTypeIndexNotExists,
+			Content: fmt.Sprintf("Index %q does not exist in schema %q", indexName, schema.name),
+		}
+	}
+
+	delete(table.indexSet, indexName)
+	return nil
+}
+
+func (d *DatabaseState) oracleDropSequence(ctx plsql.IDrop_sequenceContext) *WalkThroughError {
+	schemaName, sequenceName := oracleNormalizeSequenceName(ctx.Sequence_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	// The sequences are not synced into the catalog, so we don't return error if the sequence doesn't exist.
+	delete(schema.sequenceSet, sequenceName)
+	return nil
+}
+
+func (d *DatabaseState) oracleRenameObject(ctx plsql.IRename_objectContext) *WalkThroughError {
+	nameList := ctx.AllObject_name()
+	if len(nameList) != 2 {
+		return nil
+	}
+	oldSchemaName, oldName := oracleNormalizeObjectName(nameList[0])
+	newSchemaName, newName := oracleNormalizeObjectName(nameList[1])
+	schema, err := d.oracleGetSchema(oldSchemaName)
+	if err != nil {
+		return err
+	}
+	if newSchemaName != "" && newSchemaName != schema.name {
+		return &WalkThroughError{
+			Type:    ErrorTypeInvalidStatement,
+			Content: fmt.Sprintf("Cannot rename %q in schema %q to another schema %q", oldName, schema.name, newSchemaName),
+		}
+	}
+
+	switch {
+	case schema.tableSet[oldName] != nil:
+		return schema.oracleRenameTable(schema.ctx, oldName, newName)
+	case schema.sequenceSet[oldName]:
+		if schema.oracleObjectExists(newName) {
+			return NewRelationExistsError(newName, schema.name)
+		}
+		delete(schema.sequenceSet, oldName)
+		schema.sequenceSet[newName] = true
+	}
+	// The object may be a sequence, view or synonym which is not in the catalog.
+	return nil
+}
+
+func (s *SchemaState) oracleRenameTable(ctx *FinderContext, oldName string, newName string) *WalkThroughError {
+	if oldName != newName && s.tableSet[newName] == nil && s.oracleObjectExists(newName) {
+		return NewRelationExistsError(newName, s.name)
+	}
+	return s.renameTable(ctx, oldName, newName)
+}
+
+// oracleGetSchema returns the schema, the empty schema name means the current schema.
+func (d *DatabaseState) oracleGetSchema(schemaName string) (*SchemaState, *WalkThroughError) {
+	if schemaName == "" {
+		schemaName = d.ctx.CurrentSchema
+	}
+	schema, exists := d.schemaSet[schemaName]
+	if !exists {
+		// The schema in Oracle is the user, and the catalog only contains the schemas synced for the database.
+		// So we cannot know the objects in other schemas, and skip the integrity checking for them.
+		schema = d.createSchema(schemaName)
+		schema.ctx.CheckIntegrity = false
+	}
+	return schema, nil
+}
+
+func (d *DatabaseState) oracleGetTable(tableview plsql.ITableview_nameContext) (*SchemaState, *TableState, *WalkThroughError) {
+	schemaName, tableName := oracleNormalizeTableviewName(tableview)
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return nil, nil, err
+	}
+	table, exists := schema.tableSet[tableName]
+	if !exists {
+		if schema.ctx.CheckIntegrity {
+			return nil, nil, &WalkThroughError{
+				Type:    ErrorTypeTableNotExists,
+				Content: fmt.Sprintf("The table %q doesn't exist in schema %q", tableName, schema.name),
+			}
+		}
+		table = schema.createIncompleteTable(tableName)
+	}
+	return schema, table, nil
+}
+
+func (t *TableState) oracleGetColumn(ctx *FinderContext, columnName string) (*ColumnState, *WalkThroughError) {
+	column, exists := t.columnSet[columnName]
+	if !exists {
+		if ctx.CheckIntegrity {
+			return nil, NewColumnNotExistsError(t.name, columnName)
+		}
+		column = t.createIncompleteColumn(columnName)
+	}
+	return column, nil
+}
+
+func (t *TableState) oraclePrimaryKey() *IndexState {
+	for _, index := range t.indexSet {
+		if index.primary != nil && *index.primary {
+			return index
+		}
+	}
+	return nil
+}
+
+// oracleFindIndex finds the index in the schema, the index name is unique in the schema for Oracle.
+func (s *SchemaState) oracleFindIndex(indexName string) (*TableState, *IndexState) {
+	for _, table := range s.tableSet {
+		if index, exists := table.indexSet[indexName]; exists {
+			return table, index
+		}
+	}
+	return nil, nil
+}
+
+// oracleObjectExists returns true if the name is used by a table, view or sequence, which share the same namespace in Oracle.
+func (s *SchemaState) oracleObjectExists(name string) bool {
+	if _, exists := s.tableSet[name]; exists {
+		return true
+	}
+	if _, exists := s.viewSet[name]; exists {
+		return true
+	}
+	return s.sequenceSet[name]
+}
+
+// oracleIsCheckConstraint returns true if the named CHECK constraint is parsed as a column definition,
+// such as CONSTRAINT chk CHECK (a > 0), because the CONSTRAINT keyword can be a column name in the grammar.
+func oracleIsCheckConstraint(column plsql.IColumn_definitionContext) bool {
+	if !strings.EqualFold(column.Column_name().GetText(), "CONSTRAINT") {
+		return false
+	}
+	constraintList := column.AllInline_constraint()
+	return len(constraintList) == 1 && constraintList[0].Check_constraint() != nil
+}
+
+// oracleGetText returns the original text of the context.
+func oracleGetText(ctx antlr.ParserRuleContext) string {
+	return ctx.GetStart().GetInputStream().GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetStart(), ctx.GetStop().GetStop()))
+}
+
+// oracleNormalizeDataType returns the data type in upper case with the whitespaces collapsed.
+func oracleNormalizeDataType(ctx antlr.ParserRuleContext) string {
+	return strings.ToUpper(strings.Join(strings.Fields(oracleGetText(ctx)), " "))
+}
+
+func oracleNormalizeIDExpression(ctx plsql.IId_expressionContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if ctx.Regular_id() != nil {
+		return strings.ToUpper(ctx.Regular_id().GetText())
+	}
+	if ctx.DELIMITED_ID() != nil {
+		return strings.Trim(ctx.DELIMITED_ID().GetText(), `"`)
+	}
+	return ""
+}
+
+func oracleNormalizeIdentifier(ctx plsql.IIdentifierContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return oracleNormalizeIDExpression(ctx.Id_expression())
+}
+
+func oracleNormalizeSchemaName(ctx plsql.ISchema_nameContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return oracleNormalizeIdentifier(ctx.Identifier())
+}
+
+func oracleNormalizeColumnName(ctx plsql.IColumn_nameContext) string {
+	name := oracleNormalizeIdentifier(ctx.Identifier())
+	for _, id := range ctx.AllId_expression() {
+		name = oracleNormalizeIDExpression(id)
+	}
+	return name
+}
+
+func oracleNormalizeConstraintName(ctx plsql.IConstraint_nameContext) string {
+	name := oracleNormalizeIdentifier(ctx.Identifier())
+	for _, id := range ctx.AllId_expression() {
+		name = oracleNormalizeIDExpression(id)
+	}
+	return name
+}
+
+// oracleNormalizeTableviewName returns the schema name and table name.
+func oracleNormalizeTableviewName(ctx plsql.ITableview_nameContext) (string, string) {
+	if ctx.Id_expression() != nil {
+		return oracleNormalizeIdentifier(ctx.Identifier()), oracleNormalizeIDExpression(ctx.Id_expression())
+	}
+	return "", oracleNormalizeIdentifier(ctx.Identifier())
+}
+
+// oracleNormalizeIndexName returns the schema name and index name.
+func oracleNormalizeIndexName(ctx plsql.IIndex_nameContext) (string, string) {
+	if ctx.Id_expression() != nil {
+		return oracleNormalizeIdentifier(ctx.Identifier()), oracleNormalizeIDExpression(ctx.Id_expression())
+	}
+	return "", oracleNormalizeIdentifier(ctx.Identifier())
+}
+
+// oracleNormalizeSequenceName returns the schema name and sequence name.
+func oracleNormalizeSequenceName(ctx plsql.ISequence_nameContext) (string, string) {
+	idList := ctx.AllId_expression()
+	if len(idList) > 1 {
+		return oracleNormalizeIDExpression(idList[len(idList)-2]), oracleNormalizeIDExpression(idList[len(idList)-1])
+	}
+	return "", oracleNormalizeIDExpression(idList[0])
+}
+
+// oracleNormalizeObjectName returns the schema name and object name.
+func oracleNormalizeObjectName(ctx plsql.IObject_nameContext) (string, string) {
+	idList := ctx.AllId_expression()
+	if len(idList) > 1 {
+		return oracleNormalizeIDExpression(idList[0]), oracleNormalizeIDExpression(idList[1])
+	}
+	return "", oracleNormalizeIDExpression(idList[0])
+}
@@ -105,6 +105,53 @@ func TestPostgreSQLWalkThrough(t *testing.T) {
 	}
 }
 
+func TestOracleWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseMetadata{
+		Name: "ORCL",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "HR",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "EMPLOYEES",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "ID",
+								Position: 1,
+								Type:     "NUMBER",
+								Nullable: false,
+							},
+							{
+								Name:     "NAME",
+								Position: 2,
+								Type:     "VARCHAR2",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "EMPLOYEES_PK",
+								Expressions: []string{"ID"},
+								Type:        "NORMAL",
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"oracle_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, db.Oracle, originDatabase, false /* record */)
+	}
+}
+
 func convertInterfaceSliceToStringSlice(slice []any) []string {
 	var res []string
 	for _, item := range slice {
@@ -133,6 +180,9 @@ func runWalkThroughTest(t *testing.T, file string, engineType db.Type, originDat
 			finder := NewEmptyFinder(&FinderContext{CheckIntegrity: false, EngineType: engineType})
 			state = finder.Origin
 		}
+		if engineType == db.Oracle {
+			state.ctx.CurrentSchema = "HR"
+		}
 
 		err := state.WalkThrough(test.Statement)
 		if err != nil {
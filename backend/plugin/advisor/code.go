@@ -132,6 +132,9 @@ const (
 
 	// 1301 ~ 1399 comment error code.
 	CommentTooLong Code = 1301
+
+	// 1401 ~ 1499 custom rule error code.
+	CustomRuleViolation Code = 1401
 )
 
 // Int returns the int type of code.
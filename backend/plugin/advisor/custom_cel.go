@@ -0,0 +1,185 @@
+package advisor
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+)
+
+// The statement types of the normalized statement view for the custom CEL rule.
+const (
+	CELStatementTypeCreateTable = "CREATE_TABLE"
+	CELStatementTypeAlterTable  = "ALTER_TABLE"
+	CELStatementTypeDropTable   = "DROP_TABLE"
+	CELStatementTypeCreateIndex = "CREATE_INDEX"
+	CELStatementTypeInsert      = "INSERT"
+	CELStatementTypeUpdate      = "UPDATE"
+	CELStatementTypeDelete      = "DELETE"
+	CELStatementTypeSelect      = "SELECT"
+	CELStatementTypeOther       = "OTHER"
+)
+
+// CustomCELRuleFactors are the variables of the normalized statement view for the custom CEL rule.
+// The table in table_list is a map with keys schema, name, exists and column_list, the exists and column_list come from the catalog before the statements.
+// The column in column_list is a map with keys table, name, type, nullable, has_default and default.
+var CustomCELRuleFactors = []cel.EnvOption{
+	// The statement type, such as CREATE_TABLE and UPDATE.
+	cel.Variable("statement_type", cel.StringType),
+	cel.Variable("statement_text", cel.StringType),
+	// has_where is true if the UPDATE, DELETE or SELECT statement has the WHERE clause.
+	cel.Variable("has_where", cel.BoolType),
+	// table_list is the tables changed or queried by the statement.
+	cel.Variable("table_list", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+	// column_list is the columns defined or changed by the statement.
+	cel.Variable("column_list", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+}
+
+// CELStatement is the normalized view of a statement evaluated by the custom CEL rule.
+type CELStatement struct {
+	Type       string
+	Text       string
+	Line       int
+	HasWhere   bool
+	TableList  []*CELTable
+	ColumnList []*CELColumn
+}
+
+// CELTable is the table referenced by the statement.
+type CELTable struct {
+	Schema string
+	Name   string
+}
+
+// CELColumn is the column defined or changed by the statement.
+type CELColumn struct {
+	Table      string
+	Name       string
+	Type       string
+	Nullable   bool
+	HasDefault bool
+	Default    string
+}
+
+// CompileCustomCELRule compiles the CEL expression of the custom rule, the expression must be a boolean expression.
+func CompileCustomCELRule(expression string) (cel.Program, error) {
+	e, err := cel.NewEnv(CustomCELRuleFactors...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), "failed to compile custom CEL rule expression %q", expression)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, errors.Errorf("custom CEL rule expression %q must return bool, but found %v", expression, ast.OutputType())
+	}
+	return e.Program(ast)
+}
+
+// CheckCustomCELRule evaluates the custom CEL rule against the normalized statements.
+func CheckCustomCELRule(ctx Context, stmtList []*CELStatement) ([]Advice, error) {
+	level, err := NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := UnmarshalCustomCELRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := CompileCustomCELRule(payload.Expression)
+	if err != nil {
+		return nil, err
+	}
+	title := payload.Title
+	if title == "" {
+		title = string(ctx.Rule.Type)
+	}
+
+	var adviceList []Advice
+	for _, stmt := range stmtList {
+		out, _, err := prg.Eval(map[string]any{
+			"statement_type": stmt.Type,
+			"statement_text": stmt.Text,
+			"has_where":      stmt.HasWhere,
+			"table_list":     convertCELTableList(ctx.Catalog, stmt.TableList),
+			"column_list":    convertCELColumnList(stmt.ColumnList),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate custom CEL rule expression %q", payload.Expression)
+		}
+		violated, ok := out.Value().(bool)
+		if !ok {
+			return nil, errors.Errorf("custom CEL rule expression %q must return bool, but found %v", payload.Expression, out.Type())
+		}
+		if !violated {
+			continue
+		}
+		content := payload.Message
+		if content == "" {
+			content = "The statement violates the custom rule"
+		}
+		adviceList = append(adviceList, Advice{
+			Status:  level,
+			Code:    CustomRuleViolation,
+			Title:   title,
+			Content: content,
+			Line:    stmt.Line,
+		})
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, Advice{
+			Status:  Success,
+			Code:    Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+func convertCELTableList(finder *catalog.Finder, tableList []*CELTable) []any {
+	result := []any{}
+	for _, table := range tableList {
+		exists := false
+		columnList := []any{}
+		if finder != nil && finder.Origin != nil {
+			if tableState := finder.Origin.FindTable(&catalog.TableFind{SchemaName: table.Schema, TableName: table.Name}); tableState != nil {
+				exists = true
+				for _, column := range tableState.ListColumns() {
+					columnList = append(columnList, map[string]any{
+						"table":       table.Name,
+						"name":        column.Name(),
+						"type":        column.Type(),
+						"nullable":    column.Nullable(),
+						"has_default": column.HasDefault(),
+						"default":     column.Default(),
+					})
+				}
+			}
+		}
+		result = append(result, map[string]any{
+			"schema":      table.Schema,
+			"name":        table.Name,
+			"exists":      exists,
+			"column_list": columnList,
+		})
+	}
+	return result
+}
+
+func convertCELColumnList(columnList []*CELColumn) []any {
+	result := []any{}
+	for _, column := range columnList {
+		result = append(result, map[string]any{
+			"table":       column.Table,
+			"name":        column.Name,
+			"type":        column.Type,
+			"nullable":    column.Nullable,
+			"has_default": column.HasDefault,
+			"default":     column.Default,
+		})
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCustomCELRule(t *testing.T) {
+	tests := []struct {
+		payload string
+		wantErr bool
+	}{
+		{
+			payload: `{"expression": "statement_type == \"DELETE\" && !has_where", "title": "no-delete-all", "message": "DELETE requires WHERE"}`,
+		},
+		{
+			payload: `{"expression": "table_list.exists(t, t.name.startsWith(\"hot_\") && !t.exists)"}`,
+		},
+		{
+			// Missing expression.
+			payload: `{"title": "empty"}`,
+			wantErr: true,
+		},
+		{
+			// The expression must return bool.
+			payload: `{"expression": "statement_text"}`,
+			wantErr: true,
+		},
+		{
+			// Unknown variable.
+			payload: `{"expression": "unknown_variable == 1"}`,
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		rule := &SQLReviewRule{Type: SchemaRuleCustomCEL, Level: SchemaRuleLevelWarning, Payload: test.payload}
+		err := rule.Validate()
+		if test.wantErr {
+			a.Error(err, test.payload)
+		} else {
+			a.NoError(err, test.payload)
+		}
+	}
+}
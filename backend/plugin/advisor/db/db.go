@@ -12,6 +12,7 @@ import (
 type Type string
 
 // TODO(d): use a centric database type.
+// TODO: support SQL Server and Snowflake after the T-SQL and Snowflake parsers are available, the advisors are parser-backed.
 const (
 	// MySQL is the database type for MYSQL.
 	MySQL Type = "MYSQL"
@@ -0,0 +1,310 @@
+package advisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ExportFormat is the format for exporting the SQL review results.
+type ExportFormat string
+
+const (
+	// ExportFormatJUnit is the JUnit XML format, used by GitLab test reports and Jenkins.
+	// https://llg.cubic.org/docs/junit/
+	ExportFormatJUnit ExportFormat = "JUNIT"
+	// ExportFormatSARIF is the SARIF 2.1.0 format, used by GitHub code scanning.
+	// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+	ExportFormatSARIF ExportFormat = "SARIF"
+
+	// ErrorCodeDocsURL is the document URL for the advisor error code.
+	ErrorCodeDocsURL = "https://www.bytebase.com/docs/reference/error-code/advisor"
+
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// ExportAdviceMap renders the advices of each file in the format.
+func ExportAdviceMap(format ExportFormat, adviceMap map[string][]Advice) (string, error) {
+	switch format {
+	case ExportFormatJUnit:
+		return exportJUnit(adviceMap), nil
+	case ExportFormatSARIF:
+		return exportSARIF(adviceMap)
+	default:
+		return "", errors.Errorf("unsupported SQL review export format %q", format)
+	}
+}
+
+// GetAdviceMapStatus returns the most severe status of the advices, the suppressed advice is ignored.
+func GetAdviceMapStatus(adviceMap map[string][]Advice) Status {
+	status := Success
+	for _, adviceList := range adviceMap {
+		for _, advice := range adviceList {
+			if advice.Code == Ok {
+				continue
+			}
+			if advice.Status == Error {
+				status = advice.Status
+			} else if advice.Status == Warn && status != Error {
+				status = advice.Status
+			}
+		}
+	}
+	return status
+}
+
+// GetSuppressedAdviceMessage returns the message of the advice suppressed by the SQL review suppression comment.
+func GetSuppressedAdviceMessage(advice Advice) string {
+	if advice.SuppressReason == "" {
+		return fmt.Sprintf("Suppressed: %s", advice.Content)
+	}
+	return fmt.Sprintf("Suppressed with reason %q: %s", advice.SuppressReason, advice.Content)
+}
+
+// sortedFileList returns the file paths in the advice map in order.
+func sortedFileList(adviceMap map[string][]Advice) []string {
+	var fileList []string
+	for filePath := range adviceMap {
+		fileList = append(fileList, filePath)
+	}
+	sort.Strings(fileList)
+	return fileList
+}
+
+func exportJUnit(adviceMap map[string][]Advice) string {
+	testsuiteList := []string{}
+	for _, filePath := range sortedFileList(adviceMap) {
+		testcaseList := []string{}
+		pathes := strings.Split(filePath, "/")
+		filename := pathes[len(pathes)-1]
+
+		for _, advice := range adviceMap[filePath] {
+			if advice.Code == Ok {
+				continue
+			}
+
+			line := advice.Line
+			if line <= 0 {
+				line = 1
+			}
+
+			if advice.Suppressed {
+				testcaseList = append(testcaseList, fmt.Sprintf(
+					"<testcase name=\"%s\" classname=\"%s\" file=\"%s#L%d\">\n<skipped message=\"%s\"/>\n</testcase>",
+					escapeXMLAttr(fmt.Sprintf("[SUPPRESSED] %s#L%d: %s", filename, line, advice.Title)),
+					escapeXMLAttr(filePath),
+					escapeXMLAttr(filePath),
+					line,
+					escapeXMLAttr(GetSuppressedAdviceMessage(advice)),
+				))
+				continue
+			}
+
+			content := fmt.Sprintf("Error: %s.\nYou can check the docs at %s#%d",
+				advice.Content,
+				ErrorCodeDocsURL,
+				advice.Code,
+			)
+			testcaseList = append(testcaseList, fmt.Sprintf(
+				"<testcase name=\"%s\" classname=\"%s\" file=\"%s#L%d\">\n<failure>\n%s\n</failure>\n</testcase>",
+				escapeXMLAttr(fmt.Sprintf("[%s] %s#L%d: %s", advice.Status, filename, line, advice.Title)),
+				escapeXMLAttr(filePath),
+				escapeXMLAttr(filePath),
+				line,
+				escapeXMLText(content),
+			))
+		}
+
+		if len(testcaseList) > 0 {
+			testsuiteList = append(
+				testsuiteList,
+				fmt.Sprintf("<testsuite name=\"%s\">\n%s\n</testsuite>", escapeXMLAttr(filePath), strings.Join(testcaseList, "\n")),
+			)
+		}
+	}
+
+	return fmt.Sprintf(
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<testsuites name=\"SQL Review\">\n%s\n</testsuites>",
+		strings.Join(testsuiteList, "\n"),
+	)
+}
+
+// xmlTextReplacer escapes the characters not allowed in the XML character data.
+var xmlTextReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeXMLAttr escapes the text for the XML attribute value.
+func escapeXMLAttr(s string) string {
+	return html.EscapeString(replaceInvalidXMLChar(s))
+}
+
+// escapeXMLText escapes the text for the XML character data.
+func escapeXMLText(s string) string {
+	return xmlTextReplacer.Replace(replaceInvalidXMLChar(s))
+}
+
+// replaceInvalidXMLChar replaces the characters not allowed in XML 1.0, such as the NUL character, with the replacement character.
+func replaceInvalidXMLChar(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r <= 0xD7FF) || (r >= 0xE000 && r <= 0xFFFD) || (r >= 0x10000 && r <= 0x10FFFF) {
+			return r
+		}
+		return utf8.RuneError
+	}, s)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	HelpURI          string       `json:"helpUri"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func exportSARIF(adviceMap map[string][]Advice) (string, error) {
+	ruleMap := make(map[Code]sarifRule)
+	results := []sarifResult{}
+	for _, filePath := range sortedFileList(adviceMap) {
+		for _, advice := range adviceMap[filePath] {
+			if advice.Code == Ok {
+				continue
+			}
+
+			line := advice.Line
+			if line <= 0 {
+				line = 1
+			}
+
+			ruleID := strconv.Itoa(advice.Code.Int())
+			if _, ok := ruleMap[advice.Code]; !ok {
+				ruleMap[advice.Code] = sarifRule{
+					ID:               ruleID,
+					Name:             advice.Title,
+					HelpURI:          fmt.Sprintf("%s#%d", ErrorCodeDocsURL, advice.Code),
+					ShortDescription: sarifMessage{Text: advice.Title},
+				}
+			}
+
+			result := sarifResult{
+				RuleID:  ruleID,
+				Level:   convertToSARIFLevel(advice.Status),
+				Message: sarifMessage{Text: advice.Content},
+				Locations: []sarifLocation{
+					{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: filePath},
+							Region:           sarifRegion{StartLine: line},
+						},
+					},
+				},
+			}
+			if advice.Suppressed {
+				result.Suppressions = []sarifSuppression{{Kind: "inSource", Justification: advice.SuppressReason}}
+			}
+			results = append(results, result)
+		}
+	}
+
+	rules := []sarifRule{}
+	for _, rule := range ruleMap {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		a, _ := strconv.Atoi(rules[i].ID)
+		b, _ := strconv.Atoi(rules[j].ID)
+		return a < b
+	})
+
+	s, err := json.MarshalIndent(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "Bytebase SQL Review",
+						InformationURI: "https://www.bytebase.com/docs/sql-review/overview",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal SARIF log")
+	}
+	return string(s), nil
+}
+
+func convertToSARIFLevel(status Status) string {
+	switch status {
+	case Error:
+		return "error"
+	case Warn:
+		return "warning"
+	default:
+		// The suppressed advice has the SUCCESS status.
+		return "note"
+	}
+}
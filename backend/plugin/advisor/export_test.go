@@ -0,0 +1,168 @@
+package advisor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAdviceMap(t *testing.T) {
+	adviceMap := map[string][]Advice{
+		"migration/prod/1.0__create_table.sql": {
+			{
+				Status:  Warn,
+				Code:    TableNoPK,
+				Title:   "table.require-pk",
+				Content: "Table \"book\" requires PRIMARY KEY",
+				Line:    1,
+			},
+			{
+				Status:  Error,
+				Code:    StatementNoWhere,
+				Title:   "statement.where.require",
+				Content: "\"DELETE FROM t\" requires WHERE clause",
+				Line:    3,
+			},
+			{
+				Status:         Success,
+				Code:           StatementNoWhere,
+				Title:          "statement.where.require",
+				Content:        "\"DELETE FROM t2\" requires WHERE clause",
+				Line:           5,
+				Suppressed:     true,
+				SuppressReason: "backfill",
+			},
+		},
+		"migration/prod/2.0__insert.sql": {
+			{
+				Status:  Success,
+				Code:    Ok,
+				Title:   "OK",
+				Content: "",
+			},
+		},
+	}
+
+	t.Run("JUnit", func(t *testing.T) {
+		content, err := ExportAdviceMap(ExportFormatJUnit, adviceMap)
+		require.NoError(t, err)
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="SQL Review">
+<testsuite name="migration/prod/1.0__create_table.sql">
+<testcase name="[WARN] 1.0__create_table.sql#L1: table.require-pk" classname="migration/prod/1.0__create_table.sql" file="migration/prod/1.0__create_table.sql#L1">
+<failure>
+Error: Table "book" requires PRIMARY KEY.
+You can check the docs at https://www.bytebase.com/docs/reference/error-code/advisor#601
+</failure>
+</testcase>
+<testcase name="[ERROR] 1.0__create_table.sql#L3: statement.where.require" classname="migration/prod/1.0__create_table.sql" file="migration/prod/1.0__create_table.sql#L3">
+<failure>
+Error: "DELETE FROM t" requires WHERE clause.
+You can check the docs at https://www.bytebase.com/docs/reference/error-code/advisor#202
+</failure>
+</testcase>
+<testcase name="[SUPPRESSED] 1.0__create_table.sql#L5: statement.where.require" classname="migration/prod/1.0__create_table.sql" file="migration/prod/1.0__create_table.sql#L5">
+<skipped message="Suppressed with reason &#34;backfill&#34;: &#34;DELETE FROM t2&#34; requires WHERE clause"/>
+</testcase>
+</testsuite>
+</testsuites>`, content)
+	})
+
+	t.Run("SARIF", func(t *testing.T) {
+		content, err := ExportAdviceMap(ExportFormatSARIF, adviceMap)
+		require.NoError(t, err)
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal([]byte(content), &log))
+		require.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		run := log.Runs[0]
+		require.Equal(t, []sarifRule{
+			{
+				ID:               "202",
+				Name:             "statement.where.require",
+				HelpURI:          "https://www.bytebase.com/docs/reference/error-code/advisor#202",
+				ShortDescription: sarifMessage{Text: "statement.where.require"},
+			},
+			{
+				ID:               "601",
+				Name:             "table.require-pk",
+				HelpURI:          "https://www.bytebase.com/docs/reference/error-code/advisor#601",
+				ShortDescription: sarifMessage{Text: "table.require-pk"},
+			},
+		}, run.Tool.Driver.Rules)
+
+		require.Len(t, run.Results, 3)
+		location := func(line int) []sarifLocation {
+			return []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: "migration/prod/1.0__create_table.sql"},
+						Region:           sarifRegion{StartLine: line},
+					},
+				},
+			}
+		}
+		require.Equal(t, sarifResult{
+			RuleID:    "601",
+			Level:     "warning",
+			Message:   sarifMessage{Text: "Table \"book\" requires PRIMARY KEY"},
+			Locations: location(1),
+		}, run.Results[0])
+		require.Equal(t, sarifResult{
+			RuleID:    "202",
+			Level:     "error",
+			Message:   sarifMessage{Text: "\"DELETE FROM t\" requires WHERE clause"},
+			Locations: location(3),
+		}, run.Results[1])
+		require.Equal(t, sarifResult{
+			RuleID:       "202",
+			Level:        "note",
+			Message:      sarifMessage{Text: "\"DELETE FROM t2\" requires WHERE clause"},
+			Locations:    location(5),
+			Suppressions: []sarifSuppression{{Kind: "inSource", Justification: "backfill"}},
+		}, run.Results[2])
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := ExportAdviceMap("CSV", adviceMap)
+		require.Error(t, err)
+	})
+
+	require.Equal(t, Error, GetAdviceMapStatus(adviceMap))
+}
+
+func TestExportJUnitEscape(t *testing.T) {
+	a := require.New(t)
+	adviceMap := map[string][]Advice{
+		"migration/<prod>&\"dev\".sql": {
+			{
+				Status:  Error,
+				Code:    StatementNoWhere,
+				Title:   "statement.where.require",
+				Content: "\"DELETE FROM t WHERE a < 1 AND b > 2 -- x\x00y\" requires WHERE clause",
+				Line:    1,
+			},
+		},
+	}
+	content, err := ExportAdviceMap(ExportFormatJUnit, adviceMap)
+	a.NoError(err)
+
+	var report struct {
+		Testsuites []struct {
+			Name      string `xml:"name,attr"`
+			Testcases []struct {
+				Classname string `xml:"classname,attr"`
+				Failure   string `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	a.NoError(xml.Unmarshal([]byte(content), &report))
+	a.Len(report.Testsuites, 1)
+	a.Equal("migration/<prod>&\"dev\".sql", report.Testsuites[0].Name)
+	a.Len(report.Testsuites[0].Testcases, 1)
+	a.Equal("migration/<prod>&\"dev\".sql", report.Testsuites[0].Testcases[0].Classname)
+	a.Contains(report.Testsuites[0].Testcases[0].Failure, "\"DELETE FROM t WHERE a < 1 AND b > 2 -- x�y\" requires WHERE clause")
+}
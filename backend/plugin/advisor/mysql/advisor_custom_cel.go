@@ -0,0 +1,133 @@
+package mysql
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*CustomCELAdvisor)(nil)
+	_ ast.Visitor     = (*tableNameCollector)(nil)
+)
+
+func init() {
+	advisor.Register(db.MySQL, advisor.MySQLCustomCEL, &CustomCELAdvisor{})
+	advisor.Register(db.TiDB, advisor.MySQLCustomCEL, &CustomCELAdvisor{})
+	advisor.Register(db.MariaDB, advisor.MySQLCustomCEL, &CustomCELAdvisor{})
+}
+
+// CustomCELAdvisor is the advisor checking for the user-defined rule written in CEL.
+type CustomCELAdvisor struct {
+}
+
+// Check checks for the user-defined rule written in CEL.
+func (*CustomCELAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	var celStmtList []*advisor.CELStatement
+	for _, stmt := range stmtList {
+		celStmtList = append(celStmtList, convertToCELStatement(stmt))
+	}
+	return advisor.CheckCustomCELRule(ctx, celStmtList)
+}
+
+// convertToCELStatement converts the statement to the normalized statement view for the custom CEL rule.
+func convertToCELStatement(stmt ast.StmtNode) *advisor.CELStatement {
+	result := &advisor.CELStatement{
+		Type: advisor.CELStatementTypeOther,
+		Text: stmt.Text(),
+		Line: stmt.OriginTextPosition(),
+	}
+	switch node := stmt.(type) {
+	case *ast.CreateTableStmt:
+		result.Type = advisor.CELStatementTypeCreateTable
+		result.TableList = []*advisor.CELTable{{Name: node.Table.Name.O}}
+		for _, column := range node.Cols {
+			result.ColumnList = append(result.ColumnList, convertToCELColumn(node.Table.Name.O, column))
+		}
+		return result
+	case *ast.AlterTableStmt:
+		result.Type = advisor.CELStatementTypeAlterTable
+		result.TableList = []*advisor.CELTable{{Name: node.Table.Name.O}}
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns, ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				for _, column := range spec.NewColumns {
+					result.ColumnList = append(result.ColumnList, convertToCELColumn(node.Table.Name.O, column))
+				}
+			}
+		}
+		return result
+	case *ast.DropTableStmt:
+		result.Type = advisor.CELStatementTypeDropTable
+	case *ast.CreateIndexStmt:
+		result.Type = advisor.CELStatementTypeCreateIndex
+	case *ast.InsertStmt:
+		result.Type = advisor.CELStatementTypeInsert
+	case *ast.UpdateStmt:
+		result.Type = advisor.CELStatementTypeUpdate
+		result.HasWhere = node.Where != nil
+	case *ast.DeleteStmt:
+		result.Type = advisor.CELStatementTypeDelete
+		result.HasWhere = node.Where != nil
+	case *ast.SelectStmt:
+		result.Type = advisor.CELStatementTypeSelect
+		result.HasWhere = node.Where != nil
+	}
+
+	collector := &tableNameCollector{tableSet: make(map[string]bool)}
+	stmt.Accept(collector)
+	result.TableList = collector.tableList
+	return result
+}
+
+func convertToCELColumn(table string, column *ast.ColumnDef) *advisor.CELColumn {
+	result := &advisor.CELColumn{
+		Table:    table,
+		Name:     column.Name.Name.O,
+		Type:     strings.ToUpper(column.Tp.CompactStr()),
+		Nullable: true,
+	}
+	for _, option := range column.Options {
+		switch option.Tp {
+		case ast.ColumnOptionNotNull, ast.ColumnOptionPrimaryKey:
+			result.Nullable = false
+		case ast.ColumnOptionDefaultValue:
+			result.HasDefault = true
+			if defaultValue, err := restoreNode(option.Expr, format.RestoreStringWithoutCharset); err == nil {
+				result.Default = defaultValue
+			}
+		}
+	}
+	return result
+}
+
+// tableNameCollector collects the tables referenced by the statement.
+type tableNameCollector struct {
+	tableSet  map[string]bool
+	tableList []*advisor.CELTable
+}
+
+// Enter implements the ast.Visitor interface.
+func (collector *tableNameCollector) Enter(in ast.Node) (ast.Node, bool) {
+	if node, ok := in.(*ast.TableName); ok {
+		if !collector.tableSet[node.Name.O] {
+			collector.tableSet[node.Name.O] = true
+			collector.tableList = append(collector.tableList, &advisor.CELTable{Name: node.Name.O})
+		}
+	}
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (*tableNameCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
@@ -116,6 +116,9 @@ func TestMySQLRules(t *testing.T) {
 
 		// advisor.SchemaRuleCollationAllowlist enforce the collation allowlist.
 		advisor.SchemaRuleCollationAllowlist,
+
+		// advisor.SchemaRuleCustomCEL enforce the user-defined rule written in CEL.
+		advisor.SchemaRuleCustomCEL,
 	}
 
 	for _, rule := range mysqlRules {
@@ -0,0 +1,122 @@
+package pg
+
+import (
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CustomCELAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLCustomCEL, &CustomCELAdvisor{})
+}
+
+// CustomCELAdvisor is the advisor checking for the user-defined rule written in CEL.
+type CustomCELAdvisor struct {
+}
+
+// Check checks for the user-defined rule written in CEL.
+func (*CustomCELAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	var celStmtList []*advisor.CELStatement
+	for _, stmt := range stmts {
+		celStmtList = append(celStmtList, convertToCELStatement(stmt))
+	}
+	return advisor.CheckCustomCELRule(ctx, celStmtList)
+}
+
+// convertToCELStatement converts the statement to the normalized statement view for the custom CEL rule.
+func convertToCELStatement(stmt ast.Node) *advisor.CELStatement {
+	result := &advisor.CELStatement{
+		Type: advisor.CELStatementTypeOther,
+		Text: stmt.Text(),
+		Line: stmt.LastLine(),
+	}
+	switch node := stmt.(type) {
+	case *ast.CreateTableStmt:
+		result.Type = advisor.CELStatementTypeCreateTable
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Name)}
+		for _, column := range node.ColumnList {
+			result.ColumnList = append(result.ColumnList, convertToCELColumn(node.Name, column, node.ConstraintList))
+		}
+	case *ast.AlterTableStmt:
+		result.Type = advisor.CELStatementTypeAlterTable
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Table)}
+		for _, item := range node.AlterItemList {
+			if cmd, ok := item.(*ast.AddColumnListStmt); ok {
+				for _, column := range cmd.ColumnList {
+					result.ColumnList = append(result.ColumnList, convertToCELColumn(node.Table, column, nil))
+				}
+			}
+		}
+	case *ast.DropTableStmt:
+		result.Type = advisor.CELStatementTypeDropTable
+		for _, table := range node.TableList {
+			result.TableList = append(result.TableList, convertToCELTable(table))
+		}
+	case *ast.CreateIndexStmt:
+		result.Type = advisor.CELStatementTypeCreateIndex
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Index.Table)}
+	case *ast.InsertStmt:
+		result.Type = advisor.CELStatementTypeInsert
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Table)}
+	case *ast.UpdateStmt:
+		result.Type = advisor.CELStatementTypeUpdate
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Table)}
+		result.HasWhere = node.WhereClause != nil
+	case *ast.DeleteStmt:
+		result.Type = advisor.CELStatementTypeDelete
+		result.TableList = []*advisor.CELTable{convertToCELTable(node.Table)}
+		result.HasWhere = node.WhereClause != nil
+	case *ast.SelectStmt:
+		result.Type = advisor.CELStatementTypeSelect
+		result.HasWhere = node.WhereClause != nil
+	}
+	return result
+}
+
+func convertToCELTable(table *ast.TableDef) *advisor.CELTable {
+	return &advisor.CELTable{
+		Schema: normalizeSchemaName(table.Schema),
+		Name:   table.Name,
+	}
+}
+
+func convertToCELColumn(table *ast.TableDef, column *ast.ColumnDef, tableConstraintList []*ast.ConstraintDef) *advisor.CELColumn {
+	result := &advisor.CELColumn{
+		Table:    table.Name,
+		Name:     column.ColumnName,
+		Nullable: true,
+	}
+	if tp, err := parser.Deparse(parser.Postgres, parser.DeparseContext{}, column.Type); err == nil {
+		result.Type = tp
+	}
+	for _, constraint := range column.ConstraintList {
+		switch constraint.Type {
+		case ast.ConstraintTypeNotNull, ast.ConstraintTypePrimary:
+			result.Nullable = false
+		case ast.ConstraintTypeDefault:
+			result.HasDefault = true
+			result.Default = constraint.Expression.Text()
+		}
+	}
+	for _, constraint := range tableConstraintList {
+		if constraint.Type != ast.ConstraintTypePrimary {
+			continue
+		}
+		for _, key := range constraint.KeyList {
+			if key == column.ColumnName {
+				result.Nullable = false
+			}
+		}
+	}
+	return result
+}
@@ -165,6 +165,9 @@ const (
 	// SchemaRuleCommentLength limit comment length.
 	SchemaRuleCommentLength SQLReviewRuleType = "system.comment.length"
 
+	// SchemaRuleCustomCEL is the user-defined rule written in CEL.
+	SchemaRuleCustomCEL SQLReviewRuleType = "custom.cel"
+
 	// TableNameTemplateToken is the token for table name.
 	TableNameTemplateToken = "{{table}}"
 	// ColumnListTemplateToken is the token for column name list.
@@ -277,10 +280,26 @@ func (rule *SQLReviewRule) Validate() error {
 		if _, err := UnmarshalStringArrayTypeRulePayload(rule.Payload); err != nil {
 			return err
 		}
+	case SchemaRuleCustomCEL:
+		payload, err := UnmarshalCustomCELRulePayload(rule.Payload)
+		if err != nil {
+			return err
+		}
+		if _, err := CompileCustomCELRule(payload.Expression); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// CustomCELRulePayload is the payload for the user-defined rule written in CEL.
+type CustomCELRulePayload struct {
+	// Expression is the CEL expression evaluated against each statement, the statement violates the rule if it's true.
+	Expression string `json:"expression"`
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+}
+
 // NamingRulePayload is the payload for naming rule.
 type NamingRulePayload struct {
 	MaxLength int    `json:"maxLength"`
@@ -436,6 +455,18 @@ func UnmarshalStringArrayTypeRulePayload(payload string) (*StringArrayTypeRulePa
 	return &trr, nil
 }
 
+// UnmarshalCustomCELRulePayload will unmarshal payload to CustomCELRulePayload.
+func UnmarshalCustomCELRulePayload(payload string) (*CustomCELRulePayload, error) {
+	var ccr CustomCELRulePayload
+	if err := json.Unmarshal([]byte(payload), &ccr); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal custom CEL rule payload %q", payload)
+	}
+	if ccr.Expression == "" {
+		return nil, errors.Errorf("invalid custom CEL rule payload %q, expression cannot be empty", payload)
+	}
+	return &ccr, nil
+}
+
 // SQLReviewCheckContext is the context for SQL review check.
 type SQLReviewCheckContext struct {
 	Charset   string
@@ -459,8 +490,17 @@ func SQLReviewCheck(statements string, ruleList []*SQLReviewRule, checkContext S
 		if err := finder.WalkThrough(statements); err != nil {
 			return convertWalkThroughErrorToAdvice(checkContext, err)
 		}
+	case db.Oracle:
+		finder.SetCurrentSchema(checkContext.CurrentSchema)
+		if err := finder.WalkThrough(statements); err != nil {
+			return convertWalkThroughErrorToAdvice(checkContext, err)
+		}
 	}
 
+	suppressionList, err := parseSuppressionList(statements, checkContext.DbType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the SQL review suppression comments")
+	}
 	for _, rule := range ruleList {
 		if rule.Engine != "" && rule.Engine != checkContext.DbType {
 			continue
@@ -495,7 +535,7 @@ func SQLReviewCheck(statements string, ruleList []*SQLReviewRule, checkContext S
 			return nil, errors.Wrap(err, "failed to check statement")
 		}
 
-		result = append(result, adviceList...)
+		result = append(result, applySuppressionList(suppressionList, rule.Type, adviceList)...)
 	}
 
 	// There may be multiple syntax errors, return one only.
@@ -1139,6 +1179,13 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 		if engine == db.Postgres {
 			return PostgreSQLCommentConvention, nil
 		}
+	case SchemaRuleCustomCEL:
+		switch engine {
+		case db.MySQL, db.TiDB, db.MariaDB:
+			return MySQLCustomCEL, nil
+		case db.Postgres:
+			return PostgreSQLCustomCEL, nil
+		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
 }
@@ -0,0 +1,207 @@
+package advisor
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+// SuppressionScope is the scope of the SQL review suppression comment.
+type SuppressionScope string
+
+const (
+	// SuppressionScopeStatement suppresses the rules for the next statement, e.g. -- bytebase:disable statement.where.require reason="backfill".
+	SuppressionScopeStatement SuppressionScope = "disable"
+	// SuppressionScopeBlock suppresses the rules until the enable-block comment or the end of the file,
+	// e.g. -- bytebase:disable-block statement.where.require reason="backfill".
+	SuppressionScopeBlock SuppressionScope = "disable-block"
+	// SuppressionScopeFile suppresses the rules for the whole file, e.g. -- bytebase:disable-file statement.where.require reason="backfill".
+	SuppressionScopeFile SuppressionScope = "disable-file"
+
+	// suppressionEnableBlock ends the block scope suppression, e.g. -- bytebase:enable-block statement.where.require.
+	suppressionEnableBlock = "enable-block"
+	// suppressionAllRules suppresses all rules.
+	suppressionAllRules = "all"
+)
+
+// suppressionRegexp matches the suppression comment, the rules and the reason follow the scope.
+var suppressionRegexp = regexp.MustCompile(`(?:--|#|/\*)\s*bytebase:(disable-block|enable-block|disable-file|disable)(?:\s+(.*?))?\s*(?:\*/.*)?$`)
+
+// suppressionReasonRegexp matches the reason of the suppression comment.
+var suppressionReasonRegexp = regexp.MustCompile(`reason="([^"]*)"`)
+
+// suppressionRuleRegexp matches a valid rule type in the rule list.
+var suppressionRuleRegexp = regexp.MustCompile(`^[\w.\-]+$`)
+
+// suppression is a parsed SQL review suppression comment.
+type suppression struct {
+	scope SuppressionScope
+	// ruleList is the suppressed rule types, nil means all rules.
+	ruleList []SQLReviewRuleType
+	reason   string
+	// startLine and endLine are the 1-based line range that the suppression applies to.
+	startLine int
+	endLine   int
+}
+
+// parseSuppressionList parses the suppression comments in the statements.
+// The suppression comment must be on its own line before the statements it applies to, e.g.
+//
+//	-- bytebase:disable statement.where.require, statement.dml-dry-run reason="backfill"
+//	DELETE FROM t;
+//
+// The rules are separated by commas or whitespaces, and no rule means all rules.
+// The statement scope suppression applies to the whole next statement, so it returns an error if the statements cannot be split.
+func parseSuppressionList(statements string, dbType db.Type) ([]*suppression, error) {
+	var result []*suppression
+	var openBlockList []*suppression
+	var statementSuppressionList []*suppression
+	lines := strings.Split(statements, "\n")
+	for i, line := range lines {
+		loc := suppressionRegexp.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		lineNumber := i + 1
+		if strings.TrimSpace(line[:loc[0]]) != "" {
+			return nil, errors.Errorf("the suppression comment at line %d must be on its own line before the statements it applies to", lineNumber)
+		}
+		scope := line[loc[2]:loc[3]]
+		var body string
+		if loc[4] >= 0 {
+			body = line[loc[4]:loc[5]]
+		}
+		ruleList, reason, err := parseSuppressionBody(body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid suppression comment at line %d", lineNumber)
+		}
+		if scope == suppressionEnableBlock {
+			var remain []*suppression
+			for _, block := range openBlockList {
+				if ruleList == nil || equalSuppressionRuleList(block.ruleList, ruleList) {
+					block.endLine = lineNumber
+					continue
+				}
+				remain = append(remain, block)
+			}
+			openBlockList = remain
+			continue
+		}
+
+		s := &suppression{
+			scope:     SuppressionScope(scope),
+			ruleList:  ruleList,
+			reason:    reason,
+			startLine: lineNumber,
+			endLine:   math.MaxInt,
+		}
+		switch s.scope {
+		case SuppressionScopeFile:
+			s.startLine = 0
+		case SuppressionScopeBlock:
+			openBlockList = append(openBlockList, s)
+		case SuppressionScopeStatement:
+			s.endLine = lineNumber + 1
+			statementSuppressionList = append(statementSuppressionList, s)
+		}
+		result = append(result, s)
+	}
+
+	// The statement scope ends at the last line of the next statement.
+	if len(statementSuppressionList) > 0 {
+		list, err := parser.SplitMultiSQL(parser.EngineType(dbType), statements)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to split the statements for the statement scope suppression")
+		}
+		for _, s := range statementSuppressionList {
+			for _, sql := range list {
+				if sql.LastLine > s.startLine {
+					s.endLine = sql.LastLine
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseSuppressionBody parses the rule list and the reason following the scope of the suppression comment.
+func parseSuppressionBody(body string) ([]SQLReviewRuleType, string, error) {
+	reason := ""
+	if loc := suppressionReasonRegexp.FindStringSubmatchIndex(body); loc != nil {
+		if strings.TrimSpace(body[loc[1]:]) != "" {
+			return nil, "", errors.Errorf("unexpected %q after the reason", strings.TrimSpace(body[loc[1]:]))
+		}
+		reason = body[loc[2]:loc[3]]
+		body = body[:loc[0]]
+	}
+
+	var ruleList []SQLReviewRuleType
+	for _, rule := range strings.FieldsFunc(body, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		if !suppressionRuleRegexp.MatchString(rule) {
+			return nil, "", errors.Errorf("invalid rule %q", rule)
+		}
+		if rule == suppressionAllRules {
+			return nil, reason, nil
+		}
+		ruleList = append(ruleList, SQLReviewRuleType(rule))
+	}
+	return ruleList, reason, nil
+}
+
+func equalSuppressionRuleList(a, b []SQLReviewRuleType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// suppress returns whether the suppression applies to the advice produced by the rule.
+// Except for the file scope, the suppression only applies to the advices after the line of the comment.
+func (s *suppression) suppress(ruleType SQLReviewRuleType, advice Advice) bool {
+	if s.scope != SuppressionScopeFile && (advice.Line <= s.startLine || advice.Line > s.endLine) {
+		return false
+	}
+	if s.ruleList == nil {
+		return true
+	}
+	for _, rule := range s.ruleList {
+		if rule == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+// applySuppressionList marks the advices suppressed by the suppression comments.
+// The suppressed advice is downgraded to SUCCESS, and keeps the finding with the reason so that reviewers can accept the exception.
+func applySuppressionList(suppressionList []*suppression, ruleType SQLReviewRuleType, adviceList []Advice) []Advice {
+	for i, advice := range adviceList {
+		// The syntax error cannot be suppressed.
+		if advice.Status == Success || advice.Title == SyntaxErrorTitle {
+			continue
+		}
+		for _, s := range suppressionList {
+			if !s.suppress(ruleType, advice) {
+				continue
+			}
+			adviceList[i].Status = Success
+			adviceList[i].Suppressed = true
+			adviceList[i].SuppressReason = s.reason
+			break
+		}
+	}
+	return adviceList
+}
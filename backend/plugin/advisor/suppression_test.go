@@ -0,0 +1,128 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+func TestApplySuppressionList(t *testing.T) {
+	statements := `-- bytebase:disable-file naming.table reason="legacy table"
+DELETE FROM t1;
+-- bytebase:disable statement.where.require reason="backfill"
+DELETE FROM t2
+  WHERE 1 = 1 OR
+  id > 0;
+DELETE FROM t3;
+/* bytebase:disable-block statement.where.require,statement.dml-dry-run */
+UPDATE t4 SET a = 1;
+-- bytebase:enable-block statement.where.require,statement.dml-dry-run
+UPDATE t5 SET a = 1;
+-- bytebase:disable all
+UPDATE t6 SET a = 1;`
+
+	tests := []struct {
+		ruleType   SQLReviewRuleType
+		line       int
+		suppressed bool
+		reason     string
+	}{
+		{ruleType: SchemaRuleTableNaming, line: 0, suppressed: true, reason: "legacy table"},
+		{ruleType: SchemaRuleTableNaming, line: 11, suppressed: true, reason: "legacy table"},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 2, suppressed: false},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 5, suppressed: true, reason: "backfill"},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 6, suppressed: true, reason: "backfill"},
+		{ruleType: SchemaRuleStatementNoSelectAll, line: 6, suppressed: false},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 7, suppressed: false},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 9, suppressed: true},
+		{ruleType: SchemaRuleStatementRequireWhere, line: 11, suppressed: false},
+		{ruleType: SchemaRuleStatementNoSelectAll, line: 13, suppressed: true},
+	}
+
+	a := require.New(t)
+	suppressionList, err := parseSuppressionList(statements, db.Postgres)
+	a.NoError(err)
+	for _, test := range tests {
+		adviceList := applySuppressionList(suppressionList, test.ruleType, []Advice{{Status: Warn, Code: StatementNoWhere, Line: test.line}})
+		a.Equal(test.suppressed, adviceList[0].Suppressed, "%s at line %d", test.ruleType, test.line)
+		a.Equal(test.reason, adviceList[0].SuppressReason, "%s at line %d", test.ruleType, test.line)
+		if test.suppressed {
+			a.Equal(Success, adviceList[0].Status)
+		} else {
+			a.Equal(Warn, adviceList[0].Status)
+		}
+	}
+
+	// The syntax error cannot be suppressed.
+	adviceList := applySuppressionList(suppressionList, SchemaRuleTableNaming, []Advice{{Status: Error, Code: StatementSyntaxError, Title: SyntaxErrorTitle, Line: 2}})
+	a.False(adviceList[0].Suppressed)
+}
+
+func TestParseSuppressionList(t *testing.T) {
+	tests := []struct {
+		statements string
+		ruleList   []SQLReviewRuleType
+		reason     string
+		wantErr    bool
+	}{
+		{
+			statements: `-- bytebase:disable reason="backfill"
+DELETE FROM t;`,
+			ruleList: nil,
+			reason:   "backfill",
+		},
+		{
+			statements: `-- bytebase:disable statement.where.require, statement.dml-dry-run reason="backfill"
+DELETE FROM t;`,
+			ruleList: []SQLReviewRuleType{SchemaRuleStatementRequireWhere, SchemaRuleStatementDMLDryRun},
+			reason:   "backfill",
+		},
+		{
+			statements: `/* bytebase:disable-block statement.where.require */
+DELETE FROM t;`,
+			ruleList: []SQLReviewRuleType{SchemaRuleStatementRequireWhere},
+		},
+		{
+			statements: `-- bytebase:disable all reason="legacy"
+DELETE FROM t;`,
+			ruleList: nil,
+			reason:   "legacy",
+		},
+		// The suppression comment must be on its own line.
+		{
+			statements: `DELETE FROM t; -- bytebase:disable statement.where.require`,
+			wantErr:    true,
+		},
+		// The statement scope cannot be determined if the statements cannot be split.
+		{
+			statements: `-- bytebase:disable statement.where.require
+DELETE FROM t WHERE a = 'x;`,
+			wantErr: true,
+		},
+		{
+			statements: `-- bytebase:disable statement.where.require reason="backfill" now
+DELETE FROM t;`,
+			wantErr: true,
+		},
+		{
+			statements: `-- bytebase:disable statement.where.require; reason="backfill"
+DELETE FROM t;`,
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		suppressionList, err := parseSuppressionList(test.statements, db.Postgres)
+		if test.wantErr {
+			a.Error(err, test.statements)
+			continue
+		}
+		a.NoError(err, test.statements)
+		a.Len(suppressionList, 1, test.statements)
+		a.Equal(test.ruleList, suppressionList[0].ruleList, test.statements)
+		a.Equal(test.reason, suppressionList[0].reason, test.statements)
+	}
+}
@@ -469,6 +469,13 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType) (string, error) {
 		payload, err = json.Marshal(StringArrayTypeRulePayload{
 			List: []string{"serial", "bigserial", "int", "bigint"},
 		})
+	case SchemaRuleCustomCEL:
+		payload, err = json.Marshal(CustomCELRulePayload{
+			Expression: `column_list.exists(c, c.table.startsWith("hot_") && c.type.matches("(?i)^text$")) || ` +
+				`(statement_type == "DROP_TABLE" && table_list.exists(t, t.exists && t.column_list.exists(c, c.name == "id")))`,
+			Title:   "custom.no-text-in-hot-table",
+			Message: "Disallow TEXT columns in the hot tables or dropping the tables with id column",
+		})
 	default:
 		return "", errors.Errorf("unknown SQL review type for default payload: %s", ruleTp)
 	}
@@ -594,8 +594,35 @@ const (
 	// SensitiveDataMaskTypeDefault is the sensitive data type to hide data with a default method.
 	// The default method is subject to change.
 	SensitiveDataMaskTypeDefault SensitiveDataMaskType = "DEFAULT"
+	// SensitiveDataMaskTypeFull is the sensitive data type to replace the whole value with asterisks.
+	SensitiveDataMaskTypeFull SensitiveDataMaskType = "FULL"
+	// SensitiveDataMaskTypePartial is the sensitive data type to keep the first and last N characters.
+	SensitiveDataMaskTypePartial SensitiveDataMaskType = "PARTIAL"
+	// SensitiveDataMaskTypeHash is the sensitive data type to replace the value with its salted SHA-256 hash.
+	// The same value is always hashed into the same result, so the masked column can still be joined or grouped.
+	SensitiveDataMaskTypeHash SensitiveDataMaskType = "HASH"
+	// SensitiveDataMaskTypeRange is the sensitive data type to replace numbers and dates with the bucket they fall into.
+	SensitiveDataMaskTypeRange SensitiveDataMaskType = "RANGE"
+	// SensitiveDataMaskTypeEmail is the sensitive data type to keep the first character of the local part and the domain of emails.
+	SensitiveDataMaskTypeEmail SensitiveDataMaskType = "EMAIL"
+	// SensitiveDataMaskTypePhone is the sensitive data type to keep the last four digits of phone numbers.
+	SensitiveDataMaskTypePhone SensitiveDataMaskType = "PHONE"
 )
 
+// SensitiveDataMask is the mask algorithm for a sensitive column.
+type SensitiveDataMask struct {
+	Type SensitiveDataMaskType
+	// PrefixLength and SuffixLength are the number of characters kept by the PARTIAL type.
+	PrefixLength int
+	SuffixLength int
+	// Salt is prepended to the value before hashing by the HASH type.
+	Salt string
+	// BucketSize is the width of the numeric bucket used by the RANGE type.
+	BucketSize float64
+	// DateUnit is the unit that the dates are truncated to by the RANGE type, one of YEAR, MONTH and DAY.
+	DateUnit string
+}
+
 // SensitiveSchemaInfo is the schema info using to extract sensitive fields.
 type SensitiveSchemaInfo struct {
 	DatabaseList []DatabaseSchema
@@ -617,10 +644,15 @@ type TableSchema struct {
 type ColumnInfo struct {
 	Name      string
 	Sensitive bool
+	// Mask is the mask algorithm for the sensitive column, nil means the default mask.
+	Mask *SensitiveDataMask
 }
 
 // SensitiveField is the struct about SELECT fields.
 type SensitiveField struct {
 	Name      string
 	Sensitive bool
+	// Mask is the mask algorithm for the sensitive field, nil means the default mask.
+	// The fields derived from expressions always use the default mask.
+	Mask *SensitiveDataMask
 }
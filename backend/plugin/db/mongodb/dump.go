@@ -0,0 +1,303 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/pkg/errors"
+)
+
+// The dump is a stream of the records in canonical extended JSON, one record per line.
+// The records are in the order of collections, indexes, documents and views,
+// so that the documents are inserted after the collections are created with the validators.
+const (
+	collectionRecordType = "collection"
+	indexRecordType      = "index"
+	documentRecordType   = "document"
+
+	// insertBatchSize is the number of documents in one insertMany command when restoring.
+	insertBatchSize = 1000
+	// namespaceExistsErrorCode is the error code returned by the create command if the collection already exists.
+	namespaceExistsErrorCode = 48
+	// duplicateKeyErrorCode is the error code returned by the insert command if the document violates a unique index.
+	duplicateKeyErrorCode = 11000
+)
+
+// dumpRecord is a line of the dump.
+type dumpRecord struct {
+	Type       string `bson:"type"`
+	Collection string `bson:"collection"`
+	// Options is the options of the collection or view, such as validator, capped, timeseries, viewOn and pipeline.
+	Options bson.Raw `bson:"options,omitempty"`
+	// Index is the index spec used by the createIndexes command.
+	Index bson.Raw `bson:"index,omitempty"`
+	// Document is the document of the collection.
+	Document bson.Raw `bson:"document,omitempty"`
+}
+
+// collectionSpec is the subset of the listCollections command result.
+// https://www.mongodb.com/docs/manual/reference/command/listCollections/#output
+type collectionSpec struct {
+	Name    string   `bson:"name"`
+	Type    string   `bson:"type"`
+	Options bson.Raw `bson:"options"`
+}
+
+// Dump dumps the collections with the indexes and validators, and the documents if schemaOnly is false.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	if driver.databaseName == "" {
+		return "", errors.Errorf("cannot dump the instance without database")
+	}
+	database := driver.client.Database(driver.databaseName)
+	collections, err := listCollectionSpecs(ctx, database)
+	if err != nil {
+		return "", err
+	}
+
+	// The views are created at last because they depend on the collections.
+	var views []*collectionSpec
+	for _, spec := range collections {
+		if spec.Type == "view" {
+			views = append(views, spec)
+			continue
+		}
+		if err := writeRecord(out, &dumpRecord{Type: collectionRecordType, Collection: spec.Name, Options: spec.Options}); err != nil {
+			return "", err
+		}
+		collection := database.Collection(spec.Name)
+		if err := dumpIndexes(ctx, collection, out); err != nil {
+			return "", errors.Wrapf(err, "failed to dump indexes of collection %s", spec.Name)
+		}
+		if schemaOnly {
+			continue
+		}
+		if err := dumpDocuments(ctx, collection, out); err != nil {
+			return "", errors.Wrapf(err, "failed to dump documents of collection %s", spec.Name)
+		}
+	}
+	for _, spec := range views {
+		if err := writeRecord(out, &dumpRecord{Type: collectionRecordType, Collection: spec.Name, Options: spec.Options}); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// listCollectionSpecs lists the non-system collections and views in the order of name.
+func listCollectionSpecs(ctx context.Context, database *mongo.Database) ([]*collectionSpec, error) {
+	cursor, err := database.ListCollections(ctx, bson.D{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list collections")
+	}
+	defer cursor.Close(ctx)
+
+	var specs []*collectionSpec
+	for cursor.Next(ctx) {
+		spec := &collectionSpec{}
+		if err := cursor.Decode(spec); err != nil {
+			return nil, errors.Wrap(err, "failed to decode collection spec")
+		}
+		if systemCollection[spec.Name] || strings.HasPrefix(spec.Name, "system.") {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs, nil
+}
+
+func dumpIndexes(ctx context.Context, collection *mongo.Collection, out io.Writer) error {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list indexes")
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		index, err := convertIndexSpec(cursor.Current)
+		if err != nil {
+			return err
+		}
+		// The _id index is created along with the collection.
+		if index == nil {
+			continue
+		}
+		if err := writeRecord(out, &dumpRecord{Type: indexRecordType, Collection: collection.Name(), Index: index}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// convertIndexSpec converts the listIndexes output into the index spec of createIndexes command.
+// It returns nil for the _id index.
+func convertIndexSpec(spec bson.Raw) (bson.Raw, error) {
+	elements, err := spec.Elements()
+	if err != nil {
+		return nil, err
+	}
+	var index bson.D
+	for _, element := range elements {
+		switch element.Key() {
+		case "name":
+			if name, ok := element.Value().StringValueOK(); ok && name == "_id_" {
+				return nil, nil
+			}
+		case "v", "ns":
+			// The index version and namespace are determined by the server.
+			continue
+		}
+		index = append(index, bson.E{Key: element.Key(), Value: element.Value()})
+	}
+	return bson.Marshal(index)
+}
+
+func dumpDocuments(ctx context.Context, collection *mongo.Collection, out io.Writer) error {
+	cursor, err := collection.Find(ctx, bson.D{})
+	if err != nil {
+		return errors.Wrap(err, "failed to find documents")
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := writeRecord(out, &dumpRecord{Type: documentRecordType, Collection: collection.Name(), Document: cursor.Current}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func writeRecord(out io.Writer, record *dumpRecord) error {
+	line, err := bson.MarshalExtJSON(record, true /* canonical */, false /* escapeHTML */)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s record of collection %s", record.Type, record.Collection)
+	}
+	line = append(line, '\n')
+	_, err = out.Write(line)
+	return err
+}
+
+// readRecords reads the records from the dump line by line.
+func readRecords(src io.Reader, f func(*dumpRecord) error) error {
+	reader := bufio.NewReader(src)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(strings.TrimSpace(string(line))) > 0 {
+			record := &dumpRecord{}
+			if err := bson.UnmarshalExtJSON(line, true /* canonical */, record); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal record %q", string(line))
+			}
+			if err := f(record); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// Restore restores the collections, indexes and documents from the dump read from src.
+// The existing collections are reused and the documents already restored are skipped,
+// so the restore can be re-run on a partially restored database.
+func (driver *Driver) Restore(ctx context.Context, src io.Reader) error {
+	if driver.databaseName == "" {
+		return errors.Errorf("cannot restore the instance without database")
+	}
+	database := driver.client.Database(driver.databaseName)
+
+	var batchCollection string
+	var batch []any
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// The unordered insert continues after the duplicate key errors of the documents already restored.
+		if _, err := database.Collection(batchCollection).InsertMany(ctx, batch, options.InsertMany().SetOrdered(false)); err != nil && !isDuplicateKeyOnly(err) {
+			return errors.Wrapf(err, "failed to insert documents into collection %s", batchCollection)
+		}
+		batch = nil
+		return nil
+	}
+
+	if err := readRecords(src, func(record *dumpRecord) error {
+		if record.Type != documentRecordType || record.Collection != batchCollection {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		switch record.Type {
+		case collectionRecordType:
+			return createCollection(ctx, database, record)
+		case indexRecordType:
+			command := bson.D{
+				{Key: "createIndexes", Value: record.Collection},
+				{Key: "indexes", Value: bson.A{record.Index}},
+			}
+			if err := database.RunCommand(ctx, command).Err(); err != nil {
+				return errors.Wrapf(err, "failed to create index on collection %s", record.Collection)
+			}
+		case documentRecordType:
+			batchCollection = record.Collection
+			batch = append(batch, record.Document)
+			if len(batch) >= insertBatchSize {
+				return flush()
+			}
+		default:
+			return errors.Errorf("unknown record type %q", record.Type)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func createCollection(ctx context.Context, database *mongo.Database, record *dumpRecord) error {
+	command := bson.D{{Key: "create", Value: record.Collection}}
+	if len(record.Options) > 0 {
+		elements, err := record.Options.Elements()
+		if err != nil {
+			return err
+		}
+		for _, element := range elements {
+			command = append(command, bson.E{Key: element.Key(), Value: element.Value()})
+		}
+	}
+	if err := database.RunCommand(ctx, command).Err(); err != nil {
+		var commandErr mongo.CommandError
+		if errors.As(err, &commandErr) && commandErr.Code == namespaceExistsErrorCode {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to create collection %s", record.Collection)
+	}
+	return nil
+}
+
+// isDuplicateKeyOnly returns true if the error of the bulk write consists of duplicate key errors only.
+func isDuplicateKeyOnly(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKeyErrorCode {
+			return false
+		}
+	}
+	return true
+}
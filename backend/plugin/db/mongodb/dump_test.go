@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWriteAndReadRecords(t *testing.T) {
+	a := require.New(t)
+
+	options, err := bson.Marshal(bson.D{{Key: "validator", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 0}}}}}})
+	a.NoError(err)
+	document, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: primitive.NewObjectID()},
+		{Key: "age", Value: int64(42)},
+		{Key: "born", Value: primitive.NewDateTimeFromTime(time.Date(1980, 1, 2, 3, 4, 5, 0, time.UTC))},
+		{Key: "note", Value: "line\nbreak"},
+	})
+	a.NoError(err)
+	records := []*dumpRecord{
+		{Type: collectionRecordType, Collection: "people", Options: options},
+		{Type: documentRecordType, Collection: "people", Document: document},
+	}
+
+	var buf bytes.Buffer
+	for _, record := range records {
+		a.NoError(writeRecord(&buf, record))
+	}
+	a.Equal(2, bytes.Count(buf.Bytes(), []byte("\n")))
+
+	var got []*dumpRecord
+	a.NoError(readRecords(&buf, func(record *dumpRecord) error {
+		got = append(got, record)
+		return nil
+	}))
+	a.Len(got, 2)
+	a.Equal(collectionRecordType, got[0].Type)
+	a.Equal("people", got[0].Collection)
+	a.Equal(bson.Raw(options), got[0].Options)
+	a.Empty(got[0].Document)
+	a.Equal(documentRecordType, got[1].Type)
+	// The canonical extended JSON keeps the BSON types.
+	a.Equal(bson.Raw(document), got[1].Document)
+}
+
+func TestConvertIndexSpec(t *testing.T) {
+	a := require.New(t)
+
+	idIndex, err := bson.Marshal(bson.D{{Key: "v", Value: 2}, {Key: "key", Value: bson.D{{Key: "_id", Value: 1}}}, {Key: "name", Value: "_id_"}})
+	a.NoError(err)
+	got, err := convertIndexSpec(idIndex)
+	a.NoError(err)
+	a.Nil(got)
+
+	index, err := bson.Marshal(bson.D{{Key: "v", Value: 2}, {Key: "key", Value: bson.D{{Key: "email", Value: 1}}}, {Key: "name", Value: "email_1"}, {Key: "unique", Value: true}})
+	a.NoError(err)
+	got, err = convertIndexSpec(index)
+	a.NoError(err)
+	want, err := bson.Marshal(bson.D{{Key: "key", Value: bson.D{{Key: "email", Value: 1}}}, {Key: "name", Value: "email_1"}, {Key: "unique", Value: true}})
+	a.NoError(err)
+	a.Equal(bson.Raw(want), got)
+}
+
+func TestIsDuplicateKeyOnly(t *testing.T) {
+	a := require.New(t)
+	duplicate := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: duplicateKeyErrorCode}}
+	other := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 121}}
+
+	a.True(isDuplicateKeyOnly(mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, duplicate}}))
+	a.False(isDuplicateKeyOnly(mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, other}}))
+	a.False(isDuplicateKeyOnly(mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate}, WriteConcernError: &mongo.WriteConcernError{}}))
+	a.False(isDuplicateKeyOnly(mongo.ErrClientDisconnected))
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 )
@@ -94,3 +95,29 @@ func TestReplaceCharactersWithPercentEncoding(t *testing.T) {
 		a.Equal(tt.want, got)
 	}
 }
+
+func TestGetFingerprint(t *testing.T) {
+	a := require.New(t)
+
+	command, err := bson.Marshal(bson.D{
+		{Key: "find", Value: "users"},
+		{Key: "filter", Value: bson.D{
+			{Key: "age", Value: bson.D{{Key: "$gt", Value: 30}}},
+			{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+		}},
+		{Key: "lsid", Value: bson.D{{Key: "id", Value: "session"}}},
+		{Key: "$db", Value: "test"},
+	})
+	a.NoError(err)
+	a.Equal(`query test.users {"find":?,"filter":{"age":{"$gt":?},"tags":{"$in":[?]}}}`, getFingerprint("query", "test.users", command))
+
+	pipeline, err := bson.Marshal(bson.D{
+		{Key: "aggregate", Value: "users"},
+		{Key: "pipeline", Value: bson.A{
+			bson.D{{Key: "$match", Value: bson.D{{Key: "age", Value: 1}}}},
+			bson.D{{Key: "$limit", Value: 10}},
+		}},
+	})
+	a.NoError(err)
+	a.Equal(`command test.users {"aggregate":?,"pipeline":[{"$match":{"age":?}},{"$limit":?}]}`, getFingerprint("command", "test.users", pipeline))
+}
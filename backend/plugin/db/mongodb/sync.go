@@ -3,18 +3,24 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math/rand"
 	"sort"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -305,12 +311,202 @@ func convertEmptyInterfaceToInt64(value any) (int64, error) {
 	}
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// profileEntry is the subset of the document in the system.profile collection.
+// https://www.mongodb.com/docs/manual/reference/database-profiler/
+type profileEntry struct {
+	Op           string    `bson:"op"`
+	NS           string    `bson:"ns"`
+	Command      bson.Raw  `bson:"command"`
+	Millis       int64     `bson:"millis"`
+	TS           time.Time `bson:"ts"`
+	NReturned    int64     `bson:"nreturned"`
+	DocsExamined int64     `bson:"docsExamined"`
 }
 
-// CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// ignoredCommandFields are the fields of the command which don't contribute to the fingerprint.
+var ignoredCommandFields = map[string]bool{
+	"lsid":            true,
+	"$db":             true,
+	"$clusterTime":    true,
+	"$readPreference": true,
+	"txnNumber":       true,
+	"comment":         true,
+	"cursor":          true,
+}
+
+// SyncSlowQuery syncs the slow operations recorded by the database profiler in system.profile on the day of logDateTs.
+// If the driver isn't connected to a specific database, all non-system databases are synced.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	databaseNames := []string{driver.databaseName}
+	if driver.databaseName == "" {
+		var err error
+		if databaseNames, err = driver.getNonSystemDatabaseList(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for _, databaseName := range databaseNames {
+		statistics, err := driver.syncDatabaseSlowQuery(ctx, databaseName, logDateTs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to sync slow query of database %s", databaseName)
+		}
+		if len(statistics.Items) > 0 {
+			result[databaseName] = statistics
+		}
+	}
+	return result, nil
+}
+
+func (driver *Driver) syncDatabaseSlowQuery(ctx context.Context, databaseName string, logDateTs time.Time) (*storepb.SlowQueryStatistics, error) {
+	collection := driver.client.Database(databaseName).Collection("system.profile")
+	filter := bson.D{{Key: "ts", Value: bson.D{
+		{Key: "$gte", Value: logDateTs},
+		{Key: "$lt", Value: logDateTs.AddDate(0, 0, 1)},
+	}}}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find system.profile")
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]*storepb.SlowQueryDetails, 0, db.SlowQueryMaxSamplePerDay)
+	var fingerprints []string
+	for cursor.Next(ctx) {
+		var entry profileEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, errors.Wrap(err, "failed to decode profile entry")
+		}
+		fingerprint := getFingerprint(entry.Op, entry.NS, entry.Command)
+		if len(fingerprint) > db.SlowQueryMaxLen {
+			fingerprint = fingerprint[:db.SlowQueryMaxLen]
+		}
+		sqlText := fingerprint
+		if command, err := bson.MarshalExtJSON(entry.Command, false /* canonical */, false /* escapeHTML */); err == nil {
+			sqlText = string(command)
+		}
+		if len(sqlText) > db.SlowQueryMaxLen {
+			sqlText = sqlText[:db.SlowQueryMaxLen]
+		}
+		details := &storepb.SlowQueryDetails{
+			StartTime:    timestamppb.New(entry.TS),
+			QueryTime:    durationpb.New(time.Duration(entry.Millis) * time.Millisecond),
+			RowsSent:     entry.NReturned,
+			RowsExamined: entry.DocsExamined,
+			SqlText:      sqlText,
+		}
+
+		// Use Reservoir Sampling to sample slow logs.
+		// See https://en.wikipedia.org/wiki/Reservoir_sampling
+		if len(logs) < db.SlowQueryMaxSamplePerDay {
+			logs = append(logs, details)
+			fingerprints = append(fingerprints, fingerprint)
+		} else {
+			pos := rand.Intn(len(logs))
+			logs[pos] = details
+			fingerprints[pos] = fingerprint
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	statisticsMap := make(map[string]*storepb.SlowQueryStatisticsItem)
+	for i, details := range logs {
+		statisticsMap[fingerprints[i]] = util.MergeSlowQueryDetails(fingerprints[i], statisticsMap[fingerprints[i]], details)
+	}
+	statistics := &storepb.SlowQueryStatistics{}
+	for _, item := range statisticsMap {
+		statistics.Items = append(statistics.Items, item)
+	}
+	return statistics, nil
+}
+
+// getFingerprint returns the fingerprint of the operation, which is the operation type, the namespace,
+// and the shape of the command with all the values replaced by question marks.
+func getFingerprint(op, ns string, command bson.Raw) string {
+	var buf strings.Builder
+	_, _ = buf.WriteString(op)
+	_, _ = buf.WriteString(" ")
+	_, _ = buf.WriteString(ns)
+	if len(command) > 0 {
+		_, _ = buf.WriteString(" ")
+		writeDocumentShape(&buf, command, true /* topLevel */)
+	}
+	return buf.String()
+}
+
+func writeDocumentShape(buf *strings.Builder, document bson.Raw, topLevel bool) {
+	elements, err := document.Elements()
+	if err != nil {
+		_, _ = buf.WriteString("?")
+		return
+	}
+	_, _ = buf.WriteString("{")
+	first := true
+	for _, element := range elements {
+		if topLevel && ignoredCommandFields[element.Key()] {
+			continue
+		}
+		if !first {
+			_, _ = buf.WriteString(",")
+		}
+		first = false
+		_, _ = fmt.Fprintf(buf, "%q:", element.Key())
+		writeValueShape(buf, element.Value())
+	}
+	_, _ = buf.WriteString("}")
+}
+
+func writeValueShape(buf *strings.Builder, value bson.RawValue) {
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		writeDocumentShape(buf, value.Document(), false /* topLevel */)
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			_, _ = buf.WriteString("?")
+			return
+		}
+		// The arrays of documents such as the aggregation pipeline are kept, while the arrays of values are collapsed.
+		_, _ = buf.WriteString("[")
+		for i, v := range values {
+			if v.Type != bsontype.EmbeddedDocument {
+				_, _ = buf.WriteString("?")
+				break
+			}
+			if i > 0 {
+				_, _ = buf.WriteString(",")
+			}
+			writeValueShape(buf, v)
+		}
+		_, _ = buf.WriteString("]")
+	default:
+		_, _ = buf.WriteString("?")
+	}
+}
+
+// CheckSlowQueryLogEnabled checks if the database profiler is enabled.
+// If the driver isn't connected to a specific database, at least one non-system database should enable the profiler.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	databaseNames := []string{driver.databaseName}
+	if driver.databaseName == "" {
+		var err error
+		if databaseNames, err = driver.getNonSystemDatabaseList(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, databaseName := range databaseNames {
+		var result struct {
+			Was int `bson:"was"`
+		}
+		if err := driver.client.Database(databaseName).RunCommand(ctx, bson.D{{Key: "profile", Value: -1}}).Decode(&result); err != nil {
+			return errors.Wrapf(err, "failed to get the profiling level of database %s", databaseName)
+		}
+		if result.Was > 0 {
+			return nil
+		}
+	}
+	return errors.New("database profiler is not enabled, please set the profiling level to 1 by db.setProfilingLevel(1)")
 }
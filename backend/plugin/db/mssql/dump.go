@@ -1,18 +1,1016 @@
 package mssql
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/pkg/errors"
+)
+
+// Dump and restore.
+const (
+	// batchSeparator is the batch separator of SQL Server tools such as sqlcmd and SSMS.
+	// Every statement in the dump is followed by a batch separator line so that
+	// the dump can be replayed batch by batch, including the procedures and triggers.
+	batchSeparator = "GO"
+	headerStmt     = "" +
+		"SET ANSI_NULLS ON\n" +
+		batchSeparator + "\n\n" +
+		"SET QUOTED_IDENTIFIER ON\n" +
+		batchSeparator + "\n\n"
+	schemaStmtFmt = "" +
+		"--\n" +
+		"-- Schema structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+	sequenceStmtFmt = "" +
+		"--\n" +
+		"-- Sequence structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+	tableStmtFmt = "" +
+		"--\n" +
+		"-- Table structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+	dataStmtFmt = "" +
+		"--\n" +
+		"-- Data for %s\n" +
+		"--\n"
+	indexStmtFmt = "" +
+		"--\n" +
+		"-- Index structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+	foreignKeyStmtFmt = "" +
+		"--\n" +
+		"-- Foreign key structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+	moduleStmtFmt = "" +
+		"--\n" +
+		"-- %s structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		batchSeparator + "\n\n"
+
+	// dataBatchSize is the number of rows in one INSERT statement.
+	// SQL Server allows at most 1000 rows in a table value constructor.
+	dataBatchSize = 100
 )
 
 // Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	if driver.databaseName == "" {
+		return "", errors.Errorf("cannot dump the instance without database")
+	}
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out, schemaOnly); err != nil {
+		return "", err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
-// Restore restores a database.
-func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
-	// TODO(d): implement it.
+// dumpTxn dumps the database in the order of
+// schemas, sequences, tables, data, indexes, foreign keys, and views, functions, procedures and triggers,
+// so that the data is loaded before the indexes are built and the triggers are created.
+// The schema objects are created only if they don't exist, so the dump can be restored to a database with the schema.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer, schemaOnly bool) error {
+	if _, err := io.WriteString(out, headerStmt); err != nil {
+		return err
+	}
+
+	schemaNames, err := getSchemas(txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get schemas")
+	}
+	for _, schemaName := range schemaNames {
+		if schemaName == "dbo" {
+			continue
+		}
+		// CREATE SCHEMA must be the only statement in the batch, so we use the dynamic SQL to make it re-runnable.
+		stmt := fmt.Sprintf("IF SCHEMA_ID(%s) IS NULL EXEC(%s)", quoteString(schemaName), quoteString(fmt.Sprintf("CREATE SCHEMA %s", quoteIdentifier(schemaName))))
+		if _, err := fmt.Fprintf(out, schemaStmtFmt, quoteIdentifier(schemaName), stmt); err != nil {
+			return err
+		}
+	}
+
+	sequences, err := getDumpSequences(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sequences")
+	}
+	for _, sequence := range sequences {
+		if _, err := fmt.Fprintf(out, sequenceStmtFmt, sequence.fullName(), sequence.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	tables, err := getDumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tables")
+	}
+	for _, table := range tables {
+		if _, err := fmt.Fprintf(out, tableStmtFmt, table.fullName(), table.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	if !schemaOnly {
+		for _, table := range tables {
+			if err := exportTableData(ctx, txn, table, out); err != nil {
+				return errors.Wrapf(err, "failed to export data of table %s", table.fullName())
+			}
+		}
+		// Restart the sequences after the current values so that the restored sequences won't generate duplicate values.
+		for _, sequence := range sequences {
+			if sequence.restartValue == "" {
+				continue
+			}
+			stmt := fmt.Sprintf("ALTER SEQUENCE %s RESTART WITH %s", sequence.fullName(), sequence.restartValue)
+			if _, err := fmt.Fprintf(out, sequenceStmtFmt, sequence.fullName(), stmt); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range tables {
+		for _, index := range table.indexes {
+			if _, err := fmt.Fprintf(out, indexStmtFmt, quoteIdentifier(index.name), index.createStatement(table)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, table := range tables {
+		for _, foreignKey := range table.foreignKeys {
+			if _, err := fmt.Fprintf(out, foreignKeyStmtFmt, quoteIdentifier(foreignKey.name), foreignKey.createStatement(table)); err != nil {
+				return err
+			}
+		}
+	}
+
+	modules, err := getDumpModules(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get views, functions, procedures and triggers")
+	}
+	for _, module := range modules {
+		if _, err := fmt.Fprintf(out, moduleStmtFmt, module.objectType, module.fullName(), module.createStatement()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// dumpSequence is the dump structure of a sequence.
+type dumpSequence struct {
+	schemaName   string
+	name         string
+	dataType     string
+	startValue   string
+	increment    string
+	minValue     string
+	maxValue     string
+	cycle        bool
+	cached       bool
+	cacheSize    sql.NullInt64
+	restartValue string
+}
+
+func (s *dumpSequence) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(s.schemaName), quoteIdentifier(s.name))
+}
+
+func (s *dumpSequence) createStatement() string {
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "IF OBJECT_ID(%s, N'SO') IS NULL\n", quoteString(s.fullName()))
+	_, _ = fmt.Fprintf(&buf, "CREATE SEQUENCE %s AS %s START WITH %s INCREMENT BY %s MINVALUE %s MAXVALUE %s", s.fullName(), s.dataType, s.startValue, s.increment, s.minValue, s.maxValue)
+	if s.cycle {
+		_, _ = buf.WriteString(" CYCLE")
+	} else {
+		_, _ = buf.WriteString(" NO CYCLE")
+	}
+	switch {
+	case !s.cached:
+		_, _ = buf.WriteString(" NO CACHE")
+	case s.cacheSize.Valid:
+		_, _ = fmt.Fprintf(&buf, " CACHE %d", s.cacheSize.Int64)
+	}
+	return buf.String()
+}
+
+func getDumpSequences(ctx context.Context, txn *sql.Tx) ([]*dumpSequence, error) {
+	query := `
+		SELECT
+			SCHEMA_NAME(s.schema_id),
+			s.name,
+			TYPE_NAME(s.user_type_id),
+			s.precision,
+			s.scale,
+			CAST(s.start_value AS NVARCHAR(64)),
+			CAST(s.increment AS NVARCHAR(64)),
+			CAST(s.minimum_value AS NVARCHAR(64)),
+			CAST(s.maximum_value AS NVARCHAR(64)),
+			s.is_cycling,
+			s.is_cached,
+			s.cache_size,
+			CASE WHEN s.is_exhausted = 0 THEN CAST(CAST(s.current_value AS DECIMAL(38, 0)) + CAST(s.increment AS DECIMAL(38, 0)) AS NVARCHAR(64)) ELSE '' END
+		FROM sys.sequences s
+		ORDER BY 1, 2;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []*dumpSequence
+	for rows.Next() {
+		sequence := &dumpSequence{}
+		var precision, scale int
+		if err := rows.Scan(&sequence.schemaName, &sequence.name, &sequence.dataType, &precision, &scale, &sequence.startValue, &sequence.increment, &sequence.minValue, &sequence.maxValue, &sequence.cycle, &sequence.cached, &sequence.cacheSize, &sequence.restartValue); err != nil {
+			return nil, err
+		}
+		if sequence.dataType == "decimal" || sequence.dataType == "numeric" {
+			sequence.dataType = fmt.Sprintf("%s(%d, %d)", sequence.dataType, precision, scale)
+		}
+		sequences = append(sequences, sequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
+
+// dumpTable is the dump structure of a table.
+type dumpTable struct {
+	schemaName  string
+	name        string
+	columns     []*dumpColumn
+	constraints []string
+	indexes     []*dumpIndex
+	foreignKeys []*dumpForeignKey
+}
+
+func (t *dumpTable) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schemaName), quoteIdentifier(t.name))
+}
+
+func (t *dumpTable) createStatement() string {
+	var lines []string
+	for _, column := range t.columns {
+		lines = append(lines, fmt.Sprintf("    %s", column.definition()))
+	}
+	for _, constraint := range t.constraints {
+		lines = append(lines, fmt.Sprintf("    %s", constraint))
+	}
+	return fmt.Sprintf("IF OBJECT_ID(%s, N'U') IS NULL\nCREATE TABLE %s (\n%s\n)", quoteString(t.fullName()), t.fullName(), strings.Join(lines, ",\n"))
+}
+
+// dumpColumn is the dump structure of a column.
+type dumpColumn struct {
+	name string
+	// dataType is the type used in the column definition, e.g. nvarchar(20).
+	dataType string
+	// systemType is the system type name of the column, used to format the data.
+	systemType     string
+	assemblyType   bool
+	nullable       bool
+	collation      sql.NullString
+	identity       bool
+	identitySeed   sql.NullString
+	identityStep   sql.NullString
+	computed       bool
+	computedDef    sql.NullString
+	persisted      sql.NullBool
+	defaultName    sql.NullString
+	defaultDef     sql.NullString
+	rowGUIDColumn  bool
+	userDefinedTyp bool
+}
+
+func (c *dumpColumn) definition() string {
+	if c.computed {
+		def := fmt.Sprintf("%s AS %s", quoteIdentifier(c.name), c.computedDef.String)
+		if c.persisted.Valid && c.persisted.Bool {
+			def += " PERSISTED"
+		}
+		return def
+	}
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "%s %s", quoteIdentifier(c.name), c.dataType)
+	if c.collation.Valid && !c.userDefinedTyp {
+		_, _ = fmt.Fprintf(&buf, " COLLATE %s", c.collation.String)
+	}
+	if c.identity {
+		_, _ = fmt.Fprintf(&buf, " IDENTITY(%s, %s)", c.identitySeed.String, c.identityStep.String)
+	}
+	if c.rowGUIDColumn {
+		_, _ = buf.WriteString(" ROWGUIDCOL")
+	}
+	if c.nullable {
+		_, _ = buf.WriteString(" NULL")
+	} else {
+		_, _ = buf.WriteString(" NOT NULL")
+	}
+	if c.defaultDef.Valid {
+		_, _ = fmt.Fprintf(&buf, " CONSTRAINT %s DEFAULT %s", quoteIdentifier(c.defaultName.String), c.defaultDef.String)
+	}
+	return buf.String()
+}
+
+// insertable returns true if the column value can be inserted explicitly.
+func (c *dumpColumn) insertable() bool {
+	return !c.computed && c.systemType != "timestamp"
+}
+
+// dumpIndex is the dump structure of an index which doesn't belong to a constraint.
+type dumpIndex struct {
+	name     string
+	typeDesc string
+	unique   bool
+	filter   sql.NullString
+	keys     []string
+	includes []string
+}
+
+func (i *dumpIndex) createStatement(table *dumpTable) string {
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(%s) AND name = %s)\n", quoteString(table.fullName()), quoteString(i.name))
+	_, _ = buf.WriteString("CREATE ")
+	if i.unique {
+		_, _ = buf.WriteString("UNIQUE ")
+	}
+	_, _ = fmt.Fprintf(&buf, "%s INDEX %s ON %s (%s)", i.typeDesc, quoteIdentifier(i.name), table.fullName(), strings.Join(i.keys, ", "))
+	if len(i.includes) > 0 {
+		_, _ = fmt.Fprintf(&buf, " INCLUDE (%s)", strings.Join(i.includes, ", "))
+	}
+	if i.filter.Valid {
+		_, _ = fmt.Fprintf(&buf, " WHERE %s", i.filter.String)
+	}
+	return buf.String()
+}
+
+// dumpForeignKey is the dump structure of a foreign key.
+type dumpForeignKey struct {
+	name              string
+	columns           []string
+	referencedSchema  string
+	referencedTable   string
+	referencedColumns []string
+	onDelete          string
+	onUpdate          string
+}
+
+func (f *dumpForeignKey) createStatement(table *dumpTable) string {
+	return fmt.Sprintf("IF OBJECT_ID(%s, N'F') IS NULL\nALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s.%s (%s) ON DELETE %s ON UPDATE %s",
+		quoteString(fmt.Sprintf("%s.%s", quoteIdentifier(table.schemaName), quoteIdentifier(f.name))),
+		table.fullName(),
+		quoteIdentifier(f.name),
+		strings.Join(f.columns, ", "),
+		quoteIdentifier(f.referencedSchema),
+		quoteIdentifier(f.referencedTable),
+		strings.Join(f.referencedColumns, ", "),
+		f.onDelete,
+		f.onUpdate,
+	)
+}
+
+// getDumpTables gets the tables with columns, constraints, indexes and foreign keys.
+func getDumpTables(ctx context.Context, txn *sql.Tx) ([]*dumpTable, error) {
+	var tables []*dumpTable
+	tableMap := make(map[string]*dumpTable)
+	getTable := func(schemaName, tableName string) *dumpTable {
+		return tableMap[fmt.Sprintf("%s.%s", schemaName, tableName)]
+	}
+
+	tableQuery := `
+		SELECT SCHEMA_NAME(t.schema_id), t.name
+		FROM sys.tables t
+		WHERE t.is_ms_shipped = 0
+		ORDER BY 1, 2;`
+	if err := queryRows(ctx, txn, tableQuery, func(rows *sql.Rows) error {
+		table := &dumpTable{}
+		if err := rows.Scan(&table.schemaName, &table.name); err != nil {
+			return err
+		}
+		tables = append(tables, table)
+		tableMap[fmt.Sprintf("%s.%s", table.schemaName, table.name)] = table
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	columnQuery := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			c.name,
+			TYPE_NAME(c.user_type_id),
+			COALESCE(TYPE_NAME(c.system_type_id), TYPE_NAME(c.user_type_id)),
+			ty.is_user_defined,
+			ty.is_assembly_type,
+			SCHEMA_NAME(ty.schema_id),
+			c.max_length,
+			c.precision,
+			c.scale,
+			c.is_nullable,
+			c.collation_name,
+			c.is_identity,
+			CAST(ic.seed_value AS NVARCHAR(64)),
+			CAST(ic.increment_value AS NVARCHAR(64)),
+			c.is_computed,
+			cc.definition,
+			cc.is_persisted,
+			dc.name,
+			dc.definition,
+			c.is_rowguidcol
+		FROM sys.tables t
+		INNER JOIN sys.columns c ON c.object_id = t.object_id
+		INNER JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+		LEFT JOIN sys.identity_columns ic ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+		LEFT JOIN sys.default_constraints dc ON dc.object_id = c.default_object_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY 1, 2, c.column_id;`
+	if err := queryRows(ctx, txn, columnQuery, func(rows *sql.Rows) error {
+		column := &dumpColumn{}
+		var schemaName, tableName, typeName, typeSchema string
+		var maxLength, precision, scale int
+		if err := rows.Scan(
+			&schemaName,
+			&tableName,
+			&column.name,
+			&typeName,
+			&column.systemType,
+			&column.userDefinedTyp,
+			&column.assemblyType,
+			&typeSchema,
+			&maxLength,
+			&precision,
+			&scale,
+			&column.nullable,
+			&column.collation,
+			&column.identity,
+			&column.identitySeed,
+			&column.identityStep,
+			&column.computed,
+			&column.computedDef,
+			&column.persisted,
+			&column.defaultName,
+			&column.defaultDef,
+			&column.rowGUIDColumn,
+		); err != nil {
+			return err
+		}
+		if column.assemblyType {
+			column.systemType = typeName
+		}
+		column.dataType = formatColumnType(typeName, typeSchema, column.userDefinedTyp && !column.assemblyType, maxLength, precision, scale)
+		if table := getTable(schemaName, tableName); table != nil {
+			table.columns = append(table.columns, column)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	keyQuery := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			kc.name,
+			kc.type,
+			i.type_desc,
+			c.name,
+			ic.is_descending_key
+		FROM sys.key_constraints kc
+		INNER JOIN sys.tables t ON t.object_id = kc.parent_object_id
+		INNER JOIN sys.indexes i ON i.object_id = kc.parent_object_id AND i.index_id = kc.unique_index_id
+		INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE t.is_ms_shipped = 0 AND ic.is_included_column = 0
+		ORDER BY 1, 2, 3, ic.key_ordinal;`
+	type keyConstraint struct {
+		table      *dumpTable
+		name       string
+		constraint string
+		typeDesc   string
+		columns    []string
+	}
+	var keyConstraints []*keyConstraint
+	if err := queryRows(ctx, txn, keyQuery, func(rows *sql.Rows) error {
+		var schemaName, tableName, name, constraintType, typeDesc, columnName string
+		var descending bool
+		if err := rows.Scan(&schemaName, &tableName, &name, &constraintType, &typeDesc, &columnName, &descending); err != nil {
+			return err
+		}
+		table := getTable(schemaName, tableName)
+		if table == nil {
+			return nil
+		}
+		if len(keyConstraints) == 0 || keyConstraints[len(keyConstraints)-1].table != table || keyConstraints[len(keyConstraints)-1].name != name {
+			constraint := "UNIQUE"
+			if strings.TrimSpace(constraintType) == "PK" {
+				constraint = "PRIMARY KEY"
+			}
+			keyConstraints = append(keyConstraints, &keyConstraint{table: table, name: name, constraint: constraint, typeDesc: typeDesc})
+		}
+		last := keyConstraints[len(keyConstraints)-1]
+		last.columns = append(last.columns, formatIndexKey(columnName, descending))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, kc := range keyConstraints {
+		kc.table.constraints = append(kc.table.constraints, fmt.Sprintf("CONSTRAINT %s %s %s (%s)", quoteIdentifier(kc.name), kc.constraint, kc.typeDesc, strings.Join(kc.columns, ", ")))
+	}
+
+	checkQuery := `
+		SELECT SCHEMA_NAME(t.schema_id), t.name, cc.name, cc.definition
+		FROM sys.check_constraints cc
+		INNER JOIN sys.tables t ON t.object_id = cc.parent_object_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY 1, 2, 3;`
+	if err := queryRows(ctx, txn, checkQuery, func(rows *sql.Rows) error {
+		var schemaName, tableName, name, definition string
+		if err := rows.Scan(&schemaName, &tableName, &name, &definition); err != nil {
+			return err
+		}
+		if table := getTable(schemaName, tableName); table != nil {
+			table.constraints = append(table.constraints, fmt.Sprintf("CONSTRAINT %s CHECK %s", quoteIdentifier(name), definition))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Only the rowstore clustered and nonclustered indexes are dumped.
+	indexQuery := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			i.name,
+			i.type_desc,
+			i.is_unique,
+			i.filter_definition,
+			c.name,
+			ic.is_descending_key,
+			ic.is_included_column
+		FROM sys.indexes i
+		INNER JOIN sys.tables t ON t.object_id = i.object_id
+		INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE t.is_ms_shipped = 0 AND i.is_primary_key = 0 AND i.is_unique_constraint = 0 AND i.is_hypothetical = 0 AND i.type IN (1, 2)
+		ORDER BY 1, 2, 3, ic.is_included_column, ic.key_ordinal, ic.index_column_id;`
+	if err := queryRows(ctx, txn, indexQuery, func(rows *sql.Rows) error {
+		var schemaName, tableName, name, typeDesc, columnName string
+		var unique, descending, included bool
+		var filter sql.NullString
+		if err := rows.Scan(&schemaName, &tableName, &name, &typeDesc, &unique, &filter, &columnName, &descending, &included); err != nil {
+			return err
+		}
+		table := getTable(schemaName, tableName)
+		if table == nil {
+			return nil
+		}
+		if len(table.indexes) == 0 || table.indexes[len(table.indexes)-1].name != name {
+			table.indexes = append(table.indexes, &dumpIndex{name: name, typeDesc: typeDesc, unique: unique, filter: filter})
+		}
+		index := table.indexes[len(table.indexes)-1]
+		if included {
+			index.includes = append(index.includes, quoteIdentifier(columnName))
+		} else {
+			index.keys = append(index.keys, formatIndexKey(columnName, descending))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	foreignKeyQuery := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			fk.name,
+			c.name,
+			SCHEMA_NAME(rt.schema_id),
+			rt.name,
+			rc.name,
+			fk.delete_referential_action_desc,
+			fk.update_referential_action_desc
+		FROM sys.foreign_keys fk
+		INNER JOIN sys.tables t ON t.object_id = fk.parent_object_id
+		INNER JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+		INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		INNER JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+		INNER JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY 1, 2, 3, fkc.constraint_column_id;`
+	if err := queryRows(ctx, txn, foreignKeyQuery, func(rows *sql.Rows) error {
+		var schemaName, tableName, name, columnName, referencedColumnName, onDelete, onUpdate string
+		foreignKey := &dumpForeignKey{}
+		if err := rows.Scan(&schemaName, &tableName, &name, &columnName, &foreignKey.referencedSchema, &foreignKey.referencedTable, &referencedColumnName, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		table := getTable(schemaName, tableName)
+		if table == nil {
+			return nil
+		}
+		if len(table.foreignKeys) == 0 || table.foreignKeys[len(table.foreignKeys)-1].name != name {
+			foreignKey.name = name
+			// The action desc is like NO_ACTION, CASCADE, SET_NULL and SET_DEFAULT.
+			foreignKey.onDelete = strings.ReplaceAll(onDelete, "_", " ")
+			foreignKey.onUpdate = strings.ReplaceAll(onUpdate, "_", " ")
+			table.foreignKeys = append(table.foreignKeys, foreignKey)
+		}
+		last := table.foreignKeys[len(table.foreignKeys)-1]
+		last.columns = append(last.columns, quoteIdentifier(columnName))
+		last.referencedColumns = append(last.referencedColumns, quoteIdentifier(referencedColumnName))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// dumpModule is the dump structure of a view, function, procedure or trigger.
+type dumpModule struct {
+	schemaName string
+	name       string
+	objectType string
+	definition string
+}
+
+func (m *dumpModule) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(m.schemaName), quoteIdentifier(m.name))
+}
+
+// createStatement returns the statement creating the module if it doesn't exist.
+// CREATE VIEW, FUNCTION, PROCEDURE and TRIGGER must be the first statement in the batch,
+// so the definition is executed as the dynamic SQL.
+func (m *dumpModule) createStatement() string {
+	return fmt.Sprintf("DECLARE @definition NVARCHAR(MAX) = %s;\nIF OBJECT_ID(%s) IS NULL EXEC sp_executesql @definition;", quoteString(m.definition), quoteString(m.fullName()))
+}
+
+// getDumpModules gets the views, functions, procedures and triggers in the order of creation
+// so that the dependencies are created first, and the triggers are created at last.
+func getDumpModules(ctx context.Context, txn *sql.Tx) ([]*dumpModule, error) {
+	query := `
+		SELECT
+			SCHEMA_NAME(o.schema_id),
+			o.name,
+			o.type,
+			m.definition
+		FROM sys.sql_modules m
+		INNER JOIN sys.objects o ON o.object_id = m.object_id
+		WHERE o.is_ms_shipped = 0 AND o.type IN ('V', 'FN', 'IF', 'TF', 'P', 'TR')
+		ORDER BY CASE WHEN o.type = 'TR' THEN 1 ELSE 0 END, o.create_date, o.object_id;`
+	var modules []*dumpModule
+	if err := queryRows(ctx, txn, query, func(rows *sql.Rows) error {
+		module := &dumpModule{}
+		var objectType string
+		var definition sql.NullString
+		if err := rows.Scan(&module.schemaName, &module.name, &objectType, &definition); err != nil {
+			return err
+		}
+		// The definition is NULL if the module is encrypted.
+		if !definition.Valid {
+			return nil
+		}
+		switch strings.TrimSpace(objectType) {
+		case "V":
+			module.objectType = "View"
+		case "FN", "IF", "TF":
+			module.objectType = "Function"
+		case "P":
+			module.objectType = "Procedure"
+		case "TR":
+			module.objectType = "Trigger"
+		}
+		module.definition = strings.TrimSpace(definition.String)
+		modules = append(modules, module)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// exportTableData exports the data of the table in INSERT batches.
+func exportTableData(ctx context.Context, txn *sql.Tx, table *dumpTable, out io.Writer) error {
+	var columns []*dumpColumn
+	var columnNames, selectItems []string
+	hasIdentity := false
+	for _, column := range table.columns {
+		if !column.insertable() {
+			continue
+		}
+		columns = append(columns, column)
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+		if column.assemblyType {
+			// The CLR types such as geometry and hierarchyid are exported in the binary format.
+			selectItems = append(selectItems, fmt.Sprintf("CAST(%s AS VARBINARY(MAX))", quoteIdentifier(column.name)))
+		} else {
+			selectItems = append(selectItems, quoteIdentifier(column.name))
+		}
+		if column.identity {
+			hasIdentity = true
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s;", strings.Join(selectItems, ", "), table.fullName())
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if _, err := fmt.Fprintf(out, dataStmtFmt, table.fullName()); err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	refs := make([]any, len(columns))
+	for i := range values {
+		refs[i] = &values[i]
+	}
+	var batch []string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		var buf strings.Builder
+		if hasIdentity {
+			_, _ = fmt.Fprintf(&buf, "SET IDENTITY_INSERT %s ON;\n", table.fullName())
+		}
+		_, _ = fmt.Fprintf(&buf, "INSERT INTO %s (%s) VALUES\n%s;\n", table.fullName(), strings.Join(columnNames, ", "), strings.Join(batch, ",\n"))
+		if hasIdentity {
+			_, _ = fmt.Fprintf(&buf, "SET IDENTITY_INSERT %s OFF;\n", table.fullName())
+		}
+		_, _ = fmt.Fprintf(&buf, "%s\n\n", batchSeparator)
+		batch = nil
+		_, err := io.WriteString(out, buf.String())
+		return err
+	}
+	for rows.Next() {
+		if err := rows.Scan(refs...); err != nil {
+			return err
+		}
+		tokens := make([]string, len(columns))
+		for i, column := range columns {
+			token, err := formatValue(column, values[i])
+			if err != nil {
+				return errors.Wrapf(err, "failed to format value of column %s", quoteIdentifier(column.name))
+			}
+			tokens[i] = token
+		}
+		batch = append(batch, fmt.Sprintf("(%s)", strings.Join(tokens, ", ")))
+		if len(batch) >= dataBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// formatValue formats the value scanned from the column into a T-SQL literal.
+func formatValue(column *dumpColumn, value any) (string, error) {
+	if value == nil {
+		return "NULL", nil
+	}
+	if column.assemblyType {
+		b, ok := value.([]byte)
+		if !ok {
+			return "", errors.Errorf("unexpected value type %T for %s", value, column.systemType)
+		}
+		return fmt.Sprintf("CAST(0x%s AS %s)", hex.EncodeToString(b), column.dataType), nil
+	}
+
+	switch column.systemType {
+	case "uniqueidentifier":
+		var uuid mssql.UniqueIdentifier
+		if err := uuid.Scan(value); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("'%s'", uuid.String()), nil
+	case "decimal", "numeric", "money", "smallmoney":
+		switch v := value.(type) {
+		case []byte:
+			return string(v), nil
+		case string:
+			return v, nil
+		}
+	case "date", "time", "datetime", "smalldatetime", "datetime2", "datetimeoffset":
+		if t, ok := value.(time.Time); ok {
+			return fmt.Sprintf("'%s'", formatTime(column.systemType, t)), nil
+		}
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case string:
+		return quoteString(v), nil
+	case []byte:
+		return fmt.Sprintf("0x%s", hex.EncodeToString(v)), nil
+	case time.Time:
+		return fmt.Sprintf("'%s'", formatTime("datetimeoffset", v)), nil
+	}
+	return "", errors.Errorf("unexpected value type %T for %s", value, column.systemType)
+}
+
+func formatTime(systemType string, t time.Time) string {
+	switch systemType {
+	case "date":
+		return t.Format("2006-01-02")
+	case "time":
+		return t.Format("15:04:05.0000000")
+	case "datetime":
+		return t.Format("2006-01-02T15:04:05.000")
+	case "smalldatetime":
+		return t.Format("2006-01-02T15:04:05")
+	case "datetime2":
+		return t.Format("2006-01-02T15:04:05.0000000")
+	default:
+		return t.Format("2006-01-02T15:04:05.0000000-07:00")
+	}
+}
+
+func formatColumnType(typeName, typeSchema string, userDefined bool, maxLength, precision, scale int) string {
+	if userDefined {
+		return fmt.Sprintf("%s.%s", quoteIdentifier(typeSchema), quoteIdentifier(typeName))
+	}
+	switch typeName {
+	case "varchar", "char", "varbinary", "binary":
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(max)", typeName)
+		}
+		return fmt.Sprintf("%s(%d)", typeName, maxLength)
+	case "nvarchar", "nchar":
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(max)", typeName)
+		}
+		// The max_length is in bytes and each character takes 2 bytes.
+		return fmt.Sprintf("%s(%d)", typeName, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d, %d)", typeName, precision, scale)
+	case "datetime2", "time", "datetimeoffset":
+		return fmt.Sprintf("%s(%d)", typeName, scale)
+	case "float":
+		return fmt.Sprintf("%s(%d)", typeName, precision)
+	}
+	return typeName
+}
+
+func formatIndexKey(columnName string, descending bool) string {
+	if descending {
+		return fmt.Sprintf("%s DESC", quoteIdentifier(columnName))
+	}
+	return fmt.Sprintf("%s ASC", quoteIdentifier(columnName))
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(s, "]", "]]"))
+}
+
+// quoteString quotes the string as a T-SQL literal.
+// The line breaks are emitted as NCHAR(13) and NCHAR(10) so that no line of the literal
+// can be taken as a batch separator by applyBatches.
+func quoteString(s string) string {
+	if !strings.ContainsAny(s, "\r\n") {
+		return fmt.Sprintf("N'%s'", strings.ReplaceAll(s, "'", "''"))
+	}
+	var parts []string
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() > 0 {
+			parts = append(parts, fmt.Sprintf("N'%s'", strings.ReplaceAll(sb.String(), "'", "''")))
+			sb.Reset()
+		}
+	}
+	for _, r := range s {
+		switch r {
+		case '\r':
+			flush()
+			parts = append(parts, "NCHAR(13)")
+		case '\n':
+			flush()
+			parts = append(parts, "NCHAR(10)")
+		default:
+			_, _ = sb.WriteRune(r)
+		}
+	}
+	flush()
+	// Cast the first part to NVARCHAR(MAX) so that the concatenation is not truncated to 4000 characters.
+	parts[0] = fmt.Sprintf("CAST(%s AS NVARCHAR(MAX))", parts[0])
+	return strings.Join(parts, " + ")
+}
+
+func queryRows(ctx context.Context, txn *sql.Tx, query string, f func(rows *sql.Rows) error) error {
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := f(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, backup io.Reader) (err error) {
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	f := func(stmt string) error {
+		if _, err := txn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := applyBatches(backup, f); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+// applyBatches splits the dump by the batch separator lines and applies the batches one by one.
+func applyBatches(backup io.Reader, f func(string) error) error {
+	reader := bufio.NewReader(backup)
+	var sb strings.Builder
+	apply := func() error {
+		s := strings.TrimSpace(sb.String())
+		sb.Reset()
+		if s == "" {
+			return nil
+		}
+		if err := f(s); err != nil {
+			return errors.Wrapf(err, "execute query %q failed", s)
+		}
+		return nil
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if strings.EqualFold(strings.TrimSpace(line), batchSeparator) {
+			if err := apply(); err != nil {
+				return err
+			}
+		} else {
+			_, _ = sb.WriteString(line)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	// Apply the remaining content.
+	return apply()
+}
@@ -0,0 +1,77 @@
+package mssql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBatches(t *testing.T) {
+	a := require.New(t)
+	dump := "SET ANSI_NULLS ON\nGO\n\n" +
+		"CREATE PROCEDURE [dbo].[p] AS\nBEGIN\n    SELECT 1;\n    SELECT 2;\nEND\ngo\n\n" +
+		"INSERT INTO [dbo].[t] ([a]) VALUES\n(N'GO');\n"
+	var got []string
+	err := applyBatches(strings.NewReader(dump), func(stmt string) error {
+		got = append(got, stmt)
+		return nil
+	})
+	a.NoError(err)
+	want := []string{
+		"SET ANSI_NULLS ON",
+		"CREATE PROCEDURE [dbo].[p] AS\nBEGIN\n    SELECT 1;\n    SELECT 2;\nEND",
+		"INSERT INTO [dbo].[t] ([a]) VALUES\n(N'GO');",
+	}
+	a.Equal(want, got)
+}
+
+func TestFormatColumnType(t *testing.T) {
+	tests := []struct {
+		typeName    string
+		userDefined bool
+		maxLength   int
+		precision   int
+		scale       int
+		want        string
+	}{
+		{typeName: "int", maxLength: 4, precision: 10, want: "int"},
+		{typeName: "nvarchar", maxLength: 40, want: "nvarchar(20)"},
+		{typeName: "varbinary", maxLength: -1, want: "varbinary(max)"},
+		{typeName: "decimal", maxLength: 9, precision: 10, scale: 2, want: "decimal(10, 2)"},
+		{typeName: "datetime2", maxLength: 8, precision: 27, scale: 7, want: "datetime2(7)"},
+		{typeName: "phone]number", userDefined: true, maxLength: 20, want: "[dbo].[phone]]number]"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got := formatColumnType(test.typeName, "dbo", test.userDefined, test.maxLength, test.precision, test.scale)
+		a.Equal(test.want, got)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "it's", want: "N'it''s'"},
+		{s: "a\nGO\r\n", want: "CAST(N'a' AS NVARCHAR(MAX)) + NCHAR(10) + N'GO' + NCHAR(13) + NCHAR(10)"},
+		{s: "\n", want: "CAST(NCHAR(10) AS NVARCHAR(MAX))"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, quoteString(test.s))
+	}
+
+	// The dumped module definition must be applied as one batch even if it contains a batch separator line.
+	module := &dumpModule{schemaName: "dbo", name: "p", definition: "CREATE PROCEDURE [dbo].[p] AS\nSELECT N'\nGO\n'"}
+	var got []string
+	err := applyBatches(strings.NewReader(module.createStatement()+"\nGO\n"), func(stmt string) error {
+		got = append(got, stmt)
+		return nil
+	})
+	a.NoError(err)
+	a.Equal([]string{module.createStatement()}, got)
+}
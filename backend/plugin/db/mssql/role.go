@@ -2,33 +2,216 @@ package mssql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/pkg/errors"
-
+	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 )
 
+// The role of MSSQL is the server login if the driver connects without database, otherwise it is the database user.
+// The attribute of the role is the space-separated server roles or database roles that the login or user is a member of.
+
 // CreateRole creates the role.
-func (*Driver) CreateRole(_ context.Context, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("create role for MSSQL is not implemented yet")
+func (driver *Driver) CreateRole(ctx context.Context, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	if err := validateRoleUpsert(upsert); err != nil {
+		return nil, err
+	}
+
+	var statements []string
+	if driver.databaseName == "" {
+		if upsert.Password == nil {
+			return nil, common.Errorf(common.Invalid, "password is required to create the login %s", upsert.Name)
+		}
+		statements = append(statements, fmt.Sprintf("CREATE LOGIN %s WITH PASSWORD = %s", quoteIdentifier(upsert.Name), quoteString(*upsert.Password)))
+	} else {
+		if upsert.Password != nil {
+			// The user with password is only allowed in the contained database.
+			statements = append(statements, fmt.Sprintf("CREATE USER %s WITH PASSWORD = %s", quoteIdentifier(upsert.Name), quoteString(*upsert.Password)))
+		} else {
+			statements = append(statements, fmt.Sprintf("CREATE USER %s FOR LOGIN %s", quoteIdentifier(upsert.Name), quoteIdentifier(upsert.Name)))
+		}
+	}
+	if upsert.Attribute != nil {
+		for _, role := range strings.Fields(*upsert.Attribute) {
+			statements = append(statements, driver.roleMemberStatement(role, upsert.Name, true /* add */))
+		}
+	}
+	if err := driver.execRoleStatements(ctx, statements); err != nil {
+		return nil, err
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
 }
 
 // UpdateRole updates the role.
-func (*Driver) UpdateRole(_ context.Context, _ string, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("update role for MSSQL is not implemented yet")
+func (driver *Driver) UpdateRole(ctx context.Context, roleName string, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	if err := validateRoleUpsert(upsert); err != nil {
+		return nil, err
+	}
+	role, err := driver.FindRole(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := "LOGIN"
+	if driver.databaseName != "" {
+		principal = "USER"
+	}
+	var statements []string
+	if roleName != upsert.Name {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s WITH NAME = %s", principal, quoteIdentifier(roleName), quoteIdentifier(upsert.Name)))
+	}
+	if upsert.Password != nil {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s WITH PASSWORD = %s", principal, quoteIdentifier(upsert.Name), quoteString(*upsert.Password)))
+	}
+	if upsert.Attribute != nil {
+		oldRoles := make(map[string]bool)
+		for _, r := range strings.Fields(*role.Attribute) {
+			oldRoles[r] = true
+		}
+		newRoles := make(map[string]bool)
+		for _, r := range strings.Fields(*upsert.Attribute) {
+			newRoles[r] = true
+			if !oldRoles[r] {
+				statements = append(statements, driver.roleMemberStatement(r, upsert.Name, true /* add */))
+			}
+		}
+		for _, r := range strings.Fields(*role.Attribute) {
+			if !newRoles[r] {
+				statements = append(statements, driver.roleMemberStatement(r, upsert.Name, false /* add */))
+			}
+		}
+	}
+	if err := driver.execRoleStatements(ctx, statements); err != nil {
+		return nil, err
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
 }
 
 // FindRole finds the role by name.
-func (*Driver) FindRole(_ context.Context, _ string) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("find role for MSSQL is not implemented yet")
+func (driver *Driver) FindRole(ctx context.Context, roleName string) (*db.DatabaseRoleMessage, error) {
+	roles, err := driver.findRoleImpl(ctx, &roleName)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, common.Errorf(common.NotFound, "cannot find the role %s", roleName)
+	}
+
+	return roles[0], nil
 }
 
 // ListRole lists the role.
-func (*Driver) ListRole(_ context.Context) ([]*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("list role for MSSQL is not implemented yet")
+func (driver *Driver) ListRole(ctx context.Context) ([]*db.DatabaseRoleMessage, error) {
+	return driver.findRoleImpl(ctx, nil)
 }
 
 // DeleteRole deletes the role by name.
-func (*Driver) DeleteRole(_ context.Context, _ string) error {
-	return errors.Errorf("delete role for MSSQL is not implemented yet")
+func (driver *Driver) DeleteRole(ctx context.Context, roleName string) error {
+	statement := fmt.Sprintf("DROP USER IF EXISTS %s", quoteIdentifier(roleName))
+	if driver.databaseName == "" {
+		statement = fmt.Sprintf("IF EXISTS (SELECT 1 FROM sys.server_principals WHERE name = %s) DROP LOGIN %s", quoteString(roleName), quoteIdentifier(roleName))
+	}
+	if _, err := driver.db.ExecContext(ctx, statement); err != nil {
+		return util.FormatErrorWithQuery(err, statement)
+	}
+
+	return nil
+}
+
+func (driver *Driver) findRoleImpl(ctx context.Context, name *string) ([]*db.DatabaseRoleMessage, error) {
+	// S: SQL login or user, U: Windows login or user, G: Windows group, E: external user, X: external group.
+	query := `
+		SELECT p.name, r.name
+		FROM sys.server_principals p
+		LEFT JOIN sys.server_role_members m ON m.member_principal_id = p.principal_id
+		LEFT JOIN sys.server_principals r ON r.principal_id = m.role_principal_id
+		WHERE p.type IN ('S', 'U', 'G', 'E', 'X') AND p.name NOT LIKE '##%##'`
+	if driver.databaseName != "" {
+		query = `
+		SELECT p.name, r.name
+		FROM sys.database_principals p
+		LEFT JOIN sys.database_role_members m ON m.member_principal_id = p.principal_id
+		LEFT JOIN sys.database_principals r ON r.principal_id = m.role_principal_id
+		WHERE p.type IN ('S', 'U', 'G', 'E', 'X') AND p.name NOT IN ('guest', 'INFORMATION_SCHEMA', 'sys') AND p.name NOT LIKE '##%##'`
+	}
+	var args []any
+	if name != nil {
+		query += " AND p.name = @p1"
+		args = append(args, *name)
+	}
+	query += " ORDER BY p.name, r.name"
+
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var names []string
+	memberOf := make(map[string][]string)
+	for rows.Next() {
+		var principal string
+		var role sql.NullString
+		if err := rows.Scan(&principal, &role); err != nil {
+			return nil, err
+		}
+		if _, ok := memberOf[principal]; !ok {
+			names = append(names, principal)
+			memberOf[principal] = []string{}
+		}
+		if role.Valid {
+			memberOf[principal] = append(memberOf[principal], role.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	var roles []*db.DatabaseRoleMessage
+	for _, principal := range names {
+		sort.Strings(memberOf[principal])
+		attribute := strings.Join(memberOf[principal], " ")
+		roles = append(roles, &db.DatabaseRoleMessage{
+			Name:      principal,
+			Attribute: &attribute,
+		})
+	}
+	return roles, nil
+}
+
+func (driver *Driver) roleMemberStatement(role, member string, add bool) string {
+	action := "ADD"
+	if !add {
+		action = "DROP"
+	}
+	if driver.databaseName == "" {
+		return fmt.Sprintf("ALTER SERVER ROLE %s %s MEMBER %s", quoteIdentifier(role), action, quoteIdentifier(member))
+	}
+	return fmt.Sprintf("ALTER ROLE %s %s MEMBER %s", quoteIdentifier(role), action, quoteIdentifier(member))
+}
+
+func (driver *Driver) execRoleStatements(ctx context.Context, statements []string) error {
+	// Each statement is executed in its own batch.
+	for _, statement := range statements {
+		if _, err := driver.db.ExecContext(ctx, statement); err != nil {
+			return util.FormatErrorWithQuery(err, statement)
+		}
+	}
+	return nil
+}
+
+func validateRoleUpsert(upsert *db.DatabaseRoleUpsertMessage) error {
+	if upsert.ConnectionLimit != nil {
+		return common.Errorf(common.Invalid, "connection limit is not supported for MSSQL")
+	}
+	if upsert.ValidUntil != nil {
+		return common.Errorf(common.Invalid, "valid until is not supported for MSSQL")
+	}
+	return nil
 }
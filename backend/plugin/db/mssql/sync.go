@@ -9,13 +9,19 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
+// systemDatabaseClause is the list of the system databases used in the NOT IN clause.
+const systemDatabaseClause = "'master', 'model', 'msdb', 'tempdb', 'rdscore'"
+
 // SyncInstance syncs the instance.
 func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMetadata, error) {
 	var version, fullVersion string
@@ -31,7 +37,7 @@ func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMetadata, e
 	}
 
 	var databases []*storepb.DatabaseMetadata
-	rows, err := driver.db.QueryContext(ctx, "SELECT name, collation_name FROM master.sys.databases WHERE name NOT IN ('master', 'model', 'msdb', 'tempdb', 'rdscore')")
+	rows, err := driver.db.QueryContext(ctx, fmt.Sprintf("SELECT name, collation_name FROM master.sys.databases WHERE name NOT IN (%s)", systemDatabaseClause))
 	if err != nil {
 		return nil, err
 	}
@@ -325,12 +331,91 @@ func getViews(txn *sql.Tx) (map[string][]*storepb.ViewMetadata, error) {
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the slow query statistics from sys.dm_exec_query_stats.
+// The statistics are accumulated since the plan is cached,
+// so we collect the statements executed since logDateTs with the maximum elapsed time over one second.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			DB_NAME(pa.dbid),
+			SUBSTRING(st.text, (qs.statement_start_offset / 2) + 1, ((CASE qs.statement_end_offset WHEN -1 THEN DATALENGTH(st.text) ELSE qs.statement_end_offset END - qs.statement_start_offset) / 2) + 1),
+			qs.execution_count,
+			qs.total_elapsed_time,
+			qs.max_elapsed_time,
+			qs.total_rows,
+			qs.max_rows,
+			qs.last_execution_time
+		FROM sys.dm_exec_query_stats AS qs
+		CROSS APPLY sys.dm_exec_sql_text(qs.sql_handle) AS st
+		CROSS APPLY (
+			SELECT CONVERT(INT, value) AS dbid FROM sys.dm_exec_plan_attributes(qs.plan_handle) WHERE attribute = 'dbid'
+		) AS pa
+		WHERE qs.max_elapsed_time >= 1000000 AND qs.last_execution_time >= @p1 AND DB_NAME(pa.dbid) NOT IN (%s);`, systemDatabaseClause)
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	statisticsMap := make(map[string]map[string]*storepb.SlowQueryStatisticsItem)
+	for rows.Next() {
+		var databaseName sql.NullString
+		var sqlText string
+		var executionCount, totalElapsedTime, maxElapsedTime, totalRows, maxRows int64
+		var lastExecutionTime time.Time
+		if err := rows.Scan(&databaseName, &sqlText, &executionCount, &totalElapsedTime, &maxElapsedTime, &totalRows, &maxRows, &lastExecutionTime); err != nil {
+			return nil, err
+		}
+		// The database of the plan is dropped.
+		if !databaseName.Valid {
+			continue
+		}
+		fingerprint, err := parser.GetSQLFingerprint(parser.MSSQL, sqlText)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get sql fingerprint failed, sql: %s", sqlText)
+		}
+		if len(fingerprint) > db.SlowQueryMaxLen {
+			fingerprint = fingerprint[:db.SlowQueryMaxLen]
+		}
+		if _, ok := statisticsMap[databaseName.String]; !ok {
+			statisticsMap[databaseName.String] = make(map[string]*storepb.SlowQueryStatisticsItem)
+		}
+		databaseStatistics := statisticsMap[databaseName.String]
+		// The elapsed time is in microseconds.
+		databaseStatistics[fingerprint] = util.MergeSlowQueryStatisticsItem(databaseStatistics[fingerprint], &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   fingerprint,
+			Count:            executionCount,
+			LatestLogTime:    timestamppb.New(lastExecutionTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalElapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    totalRows,
+			MaximumRowsSent:  maxRows,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for databaseName, databaseStatistics := range statisticsMap {
+		statistics := &storepb.SlowQueryStatistics{}
+		for _, item := range databaseStatistics {
+			statistics.Items = append(statistics.Items, item)
+		}
+		result[databaseName] = statistics
+	}
+	return result, nil
 }
 
-// CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// CheckSlowQueryLogEnabled checks if the user has the VIEW SERVER STATE permission to read sys.dm_exec_query_stats.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	checkPermission := "SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'VIEW SERVER STATE')"
+	var hasPermission sql.NullInt64
+	if err := driver.db.QueryRowContext(ctx, checkPermission).Scan(&hasPermission); err != nil {
+		return util.FormatErrorWithQuery(err, checkPermission)
+	}
+	if hasPermission.Int64 != 1 {
+		return errors.New("VIEW SERVER STATE permission is required to read sys.dm_exec_query_stats")
+	}
+	return nil
 }
@@ -109,13 +109,14 @@ func (driver *Driver) DeleteRole(ctx context.Context, roleName string) error {
 
 func (driver *Driver) findRoleImpl(ctx context.Context, name *string) ([]*db.DatabaseRoleMessage, error) {
 	if name != nil {
-		attribute, err := driver.findRoleGrant(ctx, *name)
+		// Find the user first so that it returns the NotFound error for the nonexistent user.
+		user, host := parseNameAndHost(*name)
+		maxUserConnection, lifetime, err := driver.findConnectionLimitAndExpiration(ctx, user, host)
 		if err != nil {
 			return nil, err
 		}
 
-		user, host := parseNameAndHost(*name)
-		maxUserConnection, lifetime, err := driver.findConnectionLimitAndExpiration(ctx, user, host)
+		attribute, err := driver.findRoleGrant(ctx, *name)
 		if err != nil {
 			return nil, err
 		}
@@ -193,13 +194,20 @@ func (driver *Driver) findRoleImpl(ctx context.Context, name *string) ([]*db.Dat
 }
 
 func (driver *Driver) findConnectionLimitAndExpiration(ctx context.Context, user string, host string) (int32, *int32, error) {
-	statement := fmt.Sprintf(`SELECT max_user_connections, password_lifetime FROM mysql.user WHERE user = '%s' AND host = '%s'`, user, host)
-	rows, err := driver.db.QueryContext(ctx, statement)
+	// The columns of mysql.user vary between MySQL, MariaDB and TiDB versions, e.g. TiDB adds password_lifetime in 6.5
+	// and max_user_connections in 7.0, so we select all columns and read them by name.
+	statement := `SELECT * FROM mysql.user WHERE user = ? AND host = ?`
+	rows, err := driver.db.QueryContext(ctx, statement, user, host)
 	if err != nil {
 		return 0, nil, util.FormatErrorWithQuery(err, statement)
 	}
 	defer rows.Close()
 
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, nil, util.FormatErrorWithQuery(err, statement)
+	}
+
 	type result struct {
 		maxUserConnection int32
 		lifetime          sql.NullInt32
@@ -207,13 +215,34 @@ func (driver *Driver) findConnectionLimitAndExpiration(ctx context.Context, user
 	var list []result
 
 	for rows.Next() {
-		var row result
-		if err := rows.Scan(
-			&row.maxUserConnection,
-			&row.lifetime,
-		); err != nil {
+		values := make([]sql.RawBytes, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return 0, nil, util.FormatErrorWithQuery(err, statement)
 		}
+		var row result
+		for i, column := range columns {
+			if values[i] == nil {
+				continue
+			}
+			switch strings.ToLower(column) {
+			case "max_user_connections":
+				v, err := strconv.ParseInt(string(values[i]), 10, 32)
+				if err != nil {
+					return 0, nil, errors.Wrapf(err, "failed to parse max_user_connections %q", string(values[i]))
+				}
+				row.maxUserConnection = int32(v)
+			case "password_lifetime":
+				v, err := strconv.ParseInt(string(values[i]), 10, 32)
+				if err != nil {
+					return 0, nil, errors.Wrapf(err, "failed to parse password_lifetime %q", string(values[i]))
+				}
+				row.lifetime = sql.NullInt32{Int32: int32(v), Valid: true}
+			}
+		}
 		list = append(list, row)
 	}
 	if err := rows.Err(); err != nil {
@@ -1,18 +1,767 @@
 package oracle
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+// Dump and restore.
+const (
+	// statementTerminator is the SQL*Plus statement terminator.
+	// Every statement in the dump is followed by a terminator line so that
+	// the PL/SQL blocks, which contain semicolons, can be replayed as a whole.
+	statementTerminator = "/"
+	sequenceStmtFmt     = "" +
+		"--\n" +
+		"-- Sequence structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		statementTerminator + "\n\n"
+	tableStmtFmt = "" +
+		"--\n" +
+		"-- Table structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		statementTerminator + "\n\n"
+	dataStmtFmt = "" +
+		"--\n" +
+		"-- Data for %s\n" +
+		"--\n"
+	indexStmtFmt = "" +
+		"--\n" +
+		"-- Index structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		statementTerminator + "\n\n"
+	foreignKeyStmtFmt = "" +
+		"--\n" +
+		"-- Foreign key structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		statementTerminator + "\n\n"
+	objectStmtFmt = "" +
+		"--\n" +
+		"-- %s structure for %s\n" +
+		"--\n" +
+		"%s\n" +
+		statementTerminator + "\n\n"
+
+	// setTransformStmt sets the DBMS_METADATA transform parameters for the session,
+	// so that the generated DDLs don't contain the terminators, storage attributes and foreign keys.
+	setTransformStmt = `
+		BEGIN
+			DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SQLTERMINATOR', FALSE);
+			DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'PRETTY', TRUE);
+			DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SEGMENT_ATTRIBUTES', FALSE);
+			DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'REF_CONSTRAINTS', FALSE);
+		END;`
+
+	// dataBatchSize is the number of rows in one INSERT ALL statement.
+	dataBatchSize = 100
+	// stringChunkSize is the max number of characters in one string literal,
+	// which keeps the literal within the 4000 bytes limit for multi-byte characters.
+	stringChunkSize = 1000
+	// maxBlobLiteralSize is the max number of bytes of a BLOB value which can be written as a RAW literal.
+	// The larger BLOB values are appended to a temporary LOB chunk by chunk.
+	maxBlobLiteralSize = 2000
 )
 
 // Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	// The DBMS_METADATA transform parameters are session scoped, so we use a dedicated connection.
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	txn, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out, schemaOnly); err != nil {
+		return "", err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
-// Restore restores a database.
-func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
-	// TODO(d): implement it.
+// dumpTxn dumps the database in the order of
+// sequences, tables, data, indexes, foreign keys, and views, functions, procedures, packages and triggers,
+// so that the data is loaded before the indexes are built and the triggers are created.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer, schemaOnly bool) error {
+	if _, err := txn.ExecContext(ctx, setTransformStmt); err != nil {
+		return errors.Wrap(err, "failed to set metadata transform parameters")
+	}
+
+	sequences, err := getDumpObjects(ctx, txn, fmt.Sprintf(`
+		SELECT SEQUENCE_OWNER, SEQUENCE_NAME, 'SEQUENCE'
+		FROM all_sequences
+		WHERE SEQUENCE_OWNER NOT IN (%s) AND SEQUENCE_OWNER NOT LIKE 'APEX_%%' AND SEQUENCE_NAME NOT LIKE 'ISEQ$$_%%'
+		ORDER BY SEQUENCE_OWNER, SEQUENCE_NAME`, systemSchema))
+	if err != nil {
+		return errors.Wrap(err, "failed to get sequences")
+	}
+	for _, sequence := range sequences {
+		ddl, err := getDDL(ctx, txn, sequence)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, sequenceStmtFmt, sequence.fullName(), ddl); err != nil {
+			return err
+		}
+	}
+
+	tables, err := getDumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tables")
+	}
+	for _, table := range tables {
+		ddl, err := getDDL(ctx, txn, &dumpObject{schemaName: table.schemaName, name: table.name, objectType: "TABLE"})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, tableStmtFmt, table.fullName(), ddl); err != nil {
+			return err
+		}
+	}
+
+	if !schemaOnly {
+		for _, table := range tables {
+			if err := exportTableData(ctx, txn, table, out); err != nil {
+				return errors.Wrapf(err, "failed to export data of table %s", table.fullName())
+			}
+		}
+	}
+
+	tableMap := make(map[string]bool)
+	for _, table := range tables {
+		tableMap[table.fullName()] = true
+	}
+
+	// The indexes of the primary key and unique constraints are created along with the tables.
+	indexes, err := getDumpObjects(ctx, txn, fmt.Sprintf(`
+		SELECT i.OWNER, i.INDEX_NAME, 'INDEX', i.TABLE_OWNER, i.TABLE_NAME
+		FROM all_indexes i
+		WHERE i.TABLE_OWNER NOT IN (%s) AND i.TABLE_OWNER NOT LIKE 'APEX_%%'
+			AND i.GENERATED = 'N' AND i.INDEX_TYPE NOT IN ('LOB', 'IOT - TOP')
+			AND NOT EXISTS (
+				SELECT 1 FROM all_constraints c
+				WHERE c.INDEX_OWNER = i.OWNER AND c.INDEX_NAME = i.INDEX_NAME AND c.CONSTRAINT_TYPE IN ('P', 'U')
+			)
+		ORDER BY i.TABLE_OWNER, i.TABLE_NAME, i.INDEX_NAME`, systemSchema))
+	if err != nil {
+		return errors.Wrap(err, "failed to get indexes")
+	}
+	for _, index := range indexes {
+		if !tableMap[index.tableFullName()] {
+			continue
+		}
+		ddl, err := getDDL(ctx, txn, index)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, indexStmtFmt, index.fullName(), ddl); err != nil {
+			return err
+		}
+	}
+
+	foreignKeys, err := getDumpObjects(ctx, txn, fmt.Sprintf(`
+		SELECT OWNER, CONSTRAINT_NAME, 'REF_CONSTRAINT', OWNER, TABLE_NAME
+		FROM all_constraints
+		WHERE OWNER NOT IN (%s) AND OWNER NOT LIKE 'APEX_%%' AND CONSTRAINT_TYPE = 'R'
+		ORDER BY OWNER, TABLE_NAME, CONSTRAINT_NAME`, systemSchema))
+	if err != nil {
+		return errors.Wrap(err, "failed to get foreign keys")
+	}
+	for _, foreignKey := range foreignKeys {
+		if !tableMap[foreignKey.tableFullName()] {
+			continue
+		}
+		ddl, err := getDDL(ctx, txn, foreignKey)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, foreignKeyStmtFmt, foreignKey.fullName(), ddl); err != nil {
+			return err
+		}
+	}
+
+	// The objects are dumped in the order of creation so that the dependencies are created first,
+	// and the triggers are created at last.
+	objects, err := getDumpObjects(ctx, txn, fmt.Sprintf(`
+		SELECT OWNER, OBJECT_NAME, OBJECT_TYPE
+		FROM all_objects
+		WHERE OWNER NOT IN (%s) AND OWNER NOT LIKE 'APEX_%%' AND OBJECT_NAME NOT LIKE 'BIN$%%'
+			AND OBJECT_TYPE IN ('VIEW', 'MATERIALIZED VIEW', 'FUNCTION', 'PROCEDURE', 'PACKAGE', 'PACKAGE BODY', 'TRIGGER')
+		ORDER BY CASE WHEN OBJECT_TYPE = 'TRIGGER' THEN 1 ELSE 0 END, CREATED, OBJECT_ID`, systemSchema))
+	if err != nil {
+		return errors.Wrap(err, "failed to get views, functions, procedures, packages and triggers")
+	}
+	for _, object := range objects {
+		ddl, err := getDDL(ctx, txn, object)
+		if err != nil {
+			return err
+		}
+		// The trigger DDL is followed by an ALTER TRIGGER statement to enable or disable the trigger.
+		for _, stmt := range splitTriggerDDL(ddl) {
+			if _, err := fmt.Fprintf(out, objectStmtFmt, object.objectType, object.fullName(), stmt); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
+
+// dumpObject is an object which DDL is generated by DBMS_METADATA.
+type dumpObject struct {
+	schemaName string
+	name       string
+	objectType string
+	// tableSchemaName and tableName are the table which the index or constraint belongs to.
+	tableSchemaName string
+	tableName       string
+}
+
+func (o *dumpObject) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(o.schemaName), quoteIdentifier(o.name))
+}
+
+func (o *dumpObject) tableFullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(o.tableSchemaName), quoteIdentifier(o.tableName))
+}
+
+// metadataObjectType returns the object type used by DBMS_METADATA.
+func (o *dumpObject) metadataObjectType() string {
+	switch o.objectType {
+	case "PACKAGE":
+		return "PACKAGE_SPEC"
+	default:
+		return strings.ReplaceAll(o.objectType, " ", "_")
+	}
+}
+
+// getDumpObjects gets the objects by the query which returns the owner, name, type,
+// and optionally the table owner and table name of the objects.
+func getDumpObjects(ctx context.Context, txn *sql.Tx, query string) ([]*dumpObject, error) {
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var objects []*dumpObject
+	for rows.Next() {
+		object := &dumpObject{}
+		dest := []any{&object.schemaName, &object.name, &object.objectType}
+		if len(columns) == 5 {
+			dest = append(dest, &object.tableSchemaName, &object.tableName)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func getDDL(ctx context.Context, txn *sql.Tx, object *dumpObject) (string, error) {
+	var ddl string
+	if err := txn.QueryRowContext(ctx, "SELECT DBMS_METADATA.GET_DDL(:1, :2, :3) FROM DUAL", object.metadataObjectType(), object.name, object.schemaName).Scan(&ddl); err != nil {
+		return "", errors.Wrapf(err, "failed to get DDL of %s %s", object.objectType, object.fullName())
+	}
+	return strings.TrimSpace(ddl), nil
+}
+
+// splitTriggerDDL splits the ALTER TRIGGER statements from the trigger DDL.
+func splitTriggerDDL(ddl string) []string {
+	var stmts []string
+	for {
+		i := strings.Index(ddl, "\nALTER TRIGGER ")
+		if i < 0 {
+			break
+		}
+		if stmt := strings.TrimSpace(ddl[:i]); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		ddl = ddl[i+1:]
+	}
+	if stmt := strings.TrimSpace(ddl); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// dumpTable is the dump structure of a table.
+type dumpTable struct {
+	schemaName string
+	name       string
+	columns    []*dumpColumn
+}
+
+func (t *dumpTable) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schemaName), quoteIdentifier(t.name))
+}
+
+// dumpColumn is the dump structure of a column.
+type dumpColumn struct {
+	name     string
+	dataType string
+	// identityGeneration is ALWAYS or BY DEFAULT for the identity column, otherwise empty.
+	identityGeneration string
+}
+
+// getDumpTables gets the tables with the insertable columns.
+// The dropped tables in the recycle bin, the nested tables, the IOT overflow segments and the materialized view containers are excluded.
+func getDumpTables(ctx context.Context, txn *sql.Tx) ([]*dumpTable, error) {
+	tableQuery := fmt.Sprintf(`
+		SELECT t.OWNER, t.TABLE_NAME
+		FROM all_tables t
+		WHERE t.OWNER NOT IN (%s) AND t.OWNER NOT LIKE 'APEX_%%'
+			AND t.DROPPED = 'NO' AND t.NESTED = 'NO' AND t.SECONDARY = 'N' AND (t.IOT_TYPE IS NULL OR t.IOT_TYPE = 'IOT')
+			AND NOT EXISTS (SELECT 1 FROM all_mviews m WHERE m.OWNER = t.OWNER AND m.MVIEW_NAME = t.TABLE_NAME)
+		ORDER BY t.OWNER, t.TABLE_NAME`, systemSchema)
+	rows, err := txn.QueryContext(ctx, tableQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []*dumpTable
+	tableMap := make(map[string]*dumpTable)
+	for rows.Next() {
+		table := &dumpTable{}
+		if err := rows.Scan(&table.schemaName, &table.name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		tableMap[table.fullName()] = table
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	columnQuery := fmt.Sprintf(`
+		SELECT c.OWNER, c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE, NVL(i.GENERATION_TYPE, '')
+		FROM all_tab_cols c
+		LEFT JOIN all_tab_identity_cols i ON i.OWNER = c.OWNER AND i.TABLE_NAME = c.TABLE_NAME AND i.COLUMN_NAME = c.COLUMN_NAME
+		WHERE c.OWNER NOT IN (%s) AND c.OWNER NOT LIKE 'APEX_%%' AND c.HIDDEN_COLUMN = 'NO' AND c.VIRTUAL_COLUMN = 'NO'
+		ORDER BY c.OWNER, c.TABLE_NAME, c.COLUMN_ID`, systemSchema)
+	columnRows, err := txn.QueryContext(ctx, columnQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer columnRows.Close()
+	for columnRows.Next() {
+		var schemaName, tableName string
+		var identityGeneration sql.NullString
+		column := &dumpColumn{}
+		if err := columnRows.Scan(&schemaName, &tableName, &column.name, &column.dataType, &identityGeneration); err != nil {
+			return nil, err
+		}
+		column.identityGeneration = identityGeneration.String
+		if table, ok := tableMap[fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName))]; ok {
+			table.columns = append(table.columns, column)
+		}
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// exportTableData exports the data of the table in INSERT ALL batches.
+// The columns of the unsupported data types are skipped.
+func exportTableData(ctx context.Context, txn *sql.Tx, table *dumpTable, out io.Writer) error {
+	var columns []*dumpColumn
+	var columnNames, selectItems []string
+	var identityColumn *dumpColumn
+	for _, column := range table.columns {
+		item, ok := selectItem(column)
+		if !ok {
+			log.Warn("skip dumping the column of unsupported data type",
+				zap.String("table", table.fullName()),
+				zap.String("column", column.name),
+				zap.String("dataType", column.dataType))
+			continue
+		}
+		columns = append(columns, column)
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+		selectItems = append(selectItems, item)
+		if column.identityGeneration != "" {
+			identityColumn = column
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	rows, err := txn.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectItems, ", "), table.fullName()))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if _, err := fmt.Fprintf(out, dataStmtFmt, table.fullName()); err != nil {
+		return err
+	}
+	// The values can't be inserted into the GENERATED ALWAYS identity column explicitly.
+	if identityColumn != nil && identityColumn.identityGeneration == "ALWAYS" {
+		if _, err := fmt.Fprintf(out, "ALTER TABLE %s MODIFY (%s GENERATED BY DEFAULT AS IDENTITY)\n%s\n\n", table.fullName(), quoteIdentifier(identityColumn.name), statementTerminator); err != nil {
+			return err
+		}
+	}
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		if column.dataType == "BLOB" {
+			values[i] = &[]byte{}
+		} else {
+			values[i] = &sql.NullString{}
+		}
+	}
+	into := fmt.Sprintf("    INTO %s (%s) VALUES", table.fullName(), strings.Join(columnNames, ", "))
+	var batch []string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		stmt := fmt.Sprintf("INSERT ALL\n%s\nSELECT 1 FROM DUAL\n%s\n\n", strings.Join(batch, "\n"), statementTerminator)
+		batch = nil
+		_, err := io.WriteString(out, stmt)
+		return err
+	}
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return err
+		}
+		tokens := make([]string, len(columns))
+		largeBlobs := make(map[int][]byte)
+		for i, column := range columns {
+			if b, ok := values[i].(*[]byte); ok && len(*b) > maxBlobLiteralSize {
+				largeBlobs[i] = *b
+				continue
+			}
+			token, err := formatValue(column, values[i])
+			if err != nil {
+				return errors.Wrapf(err, "failed to format value of column %s", quoteIdentifier(column.name))
+			}
+			tokens[i] = token
+		}
+		if len(largeBlobs) > 0 {
+			// Keep the order of the rows.
+			if err := flush(); err != nil {
+				return err
+			}
+			stmt := largeBlobInsertStatement(table, columnNames, tokens, largeBlobs)
+			if _, err := fmt.Fprintf(out, "%s\n%s\n\n", stmt, statementTerminator); err != nil {
+				return err
+			}
+			continue
+		}
+		batch = append(batch, fmt.Sprintf("%s (%s)", into, strings.Join(tokens, ", ")))
+		if len(batch) >= dataBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Restart the identity column after the max value so that the restored identity column won't generate duplicate values.
+	if identityColumn != nil {
+		if _, err := fmt.Fprintf(out, "ALTER TABLE %s MODIFY (%s GENERATED %s AS IDENTITY (START WITH LIMIT VALUE))\n%s\n\n", table.fullName(), quoteIdentifier(identityColumn.name), identityColumn.identityGeneration, statementTerminator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// largeBlobInsertStatement returns the PL/SQL block which inserts a row with the BLOB values larger than maxBlobLiteralSize.
+// Each large BLOB value is built in a temporary LOB by appending the RAW literal chunks.
+func largeBlobInsertStatement(table *dumpTable, columnNames, tokens []string, largeBlobs map[int][]byte) string {
+	var declares, appends strings.Builder
+	values := make([]string, len(tokens))
+	copy(values, tokens)
+	for i := range tokens {
+		b, ok := largeBlobs[i]
+		if !ok {
+			continue
+		}
+		variable := fmt.Sprintf("v%d", i)
+		values[i] = variable
+		_, _ = fmt.Fprintf(&declares, "  %s BLOB;\n", variable)
+		_, _ = fmt.Fprintf(&appends, "  DBMS_LOB.CREATETEMPORARY(%s, TRUE);\n", variable)
+		for j := 0; j < len(b); j += maxBlobLiteralSize {
+			end := j + maxBlobLiteralSize
+			if end > len(b) {
+				end = len(b)
+			}
+			_, _ = fmt.Fprintf(&appends, "  DBMS_LOB.APPEND(%s, TO_BLOB(HEXTORAW('%X')));\n", variable, b[j:end])
+		}
+	}
+	return fmt.Sprintf("DECLARE\n%sBEGIN\n%s  INSERT INTO %s (%s) VALUES (%s);\nEND;", declares.String(), appends.String(), table.fullName(), strings.Join(columnNames, ", "), strings.Join(values, ", "))
+}
+
+// selectItem returns the select item which converts the column value to the text used in the literal,
+// or false if the data type is not supported.
+func selectItem(column *dumpColumn) (string, bool) {
+	name := quoteIdentifier(column.name)
+	dataType := column.dataType
+	switch {
+	case dataType == "NUMBER" || dataType == "FLOAT" || dataType == "BINARY_FLOAT" || dataType == "BINARY_DOUBLE":
+		return fmt.Sprintf("TO_CHAR(%s, 'TM9', 'NLS_NUMERIC_CHARACTERS=''.,''')", name), true
+	case dataType == "DATE":
+		return fmt.Sprintf("TO_CHAR(%s, 'SYYYY-MM-DD HH24:MI:SS')", name), true
+	case strings.HasPrefix(dataType, "TIMESTAMP") && strings.HasSuffix(dataType, "TIME ZONE"):
+		return fmt.Sprintf("TO_CHAR(%s, 'SYYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')", name), true
+	case strings.HasPrefix(dataType, "TIMESTAMP"):
+		return fmt.Sprintf("TO_CHAR(%s, 'SYYYY-MM-DD HH24:MI:SS.FF9')", name), true
+	case strings.HasPrefix(dataType, "INTERVAL"):
+		return fmt.Sprintf("TO_CHAR(%s)", name), true
+	case dataType == "RAW":
+		return fmt.Sprintf("RAWTOHEX(%s)", name), true
+	case dataType == "ROWID" || dataType == "UROWID":
+		return fmt.Sprintf("ROWIDTOCHAR(%s)", name), true
+	case dataType == "CHAR" || dataType == "VARCHAR2" || dataType == "NCHAR" || dataType == "NVARCHAR2" || dataType == "CLOB" || dataType == "NCLOB" || dataType == "LONG" || dataType == "BLOB":
+		return name, true
+	}
+	return "", false
+}
+
+// formatValue formats the value scanned from the select item into an Oracle literal.
+func formatValue(column *dumpColumn, value any) (string, error) {
+	dataType := column.dataType
+	if b, ok := value.(*[]byte); ok {
+		if *b == nil {
+			return "NULL", nil
+		}
+		return fmt.Sprintf("TO_BLOB(HEXTORAW('%X'))", *b), nil
+	}
+	s, ok := value.(*sql.NullString)
+	if !ok {
+		return "", errors.Errorf("unexpected value type %T", value)
+	}
+	if !s.Valid {
+		return "NULL", nil
+	}
+	v := strings.TrimSpace(s.String)
+	switch {
+	case dataType == "NUMBER" || dataType == "FLOAT" || dataType == "BINARY_FLOAT" || dataType == "BINARY_DOUBLE":
+		return v, nil
+	case dataType == "DATE":
+		return fmt.Sprintf("TO_DATE('%s', 'SYYYY-MM-DD HH24:MI:SS')", v), nil
+	case strings.HasPrefix(dataType, "TIMESTAMP") && strings.HasSuffix(dataType, "TIME ZONE"):
+		return fmt.Sprintf("TO_TIMESTAMP_TZ('%s', 'SYYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')", v), nil
+	case strings.HasPrefix(dataType, "TIMESTAMP"):
+		return fmt.Sprintf("TO_TIMESTAMP('%s', 'SYYYY-MM-DD HH24:MI:SS.FF9')", v), nil
+	case strings.HasPrefix(dataType, "INTERVAL YEAR"):
+		return fmt.Sprintf("TO_YMINTERVAL('%s')", v), nil
+	case strings.HasPrefix(dataType, "INTERVAL DAY"):
+		return fmt.Sprintf("TO_DSINTERVAL('%s')", v), nil
+	case dataType == "RAW":
+		return fmt.Sprintf("HEXTORAW('%s')", v), nil
+	case dataType == "ROWID" || dataType == "UROWID":
+		return fmt.Sprintf("CHARTOROWID('%s')", v), nil
+	case dataType == "NCHAR" || dataType == "NVARCHAR2" || dataType == "NCLOB":
+		return quoteString(s.String, true), nil
+	}
+	return quoteString(s.String, false), nil
+}
+
+// quoteString quotes the string into literals,
+// the long string is split into chunks and concatenated as CLOB to avoid exceeding the max literal length.
+func quoteString(s string, national bool) string {
+	prefix, toLOB := "", "TO_CLOB"
+	if national {
+		prefix, toLOB = "N", "TO_NCLOB"
+	}
+	runes := []rune(s)
+	if len(runes) <= stringChunkSize {
+		return fmt.Sprintf("%s'%s'", prefix, strings.ReplaceAll(s, "'", "''"))
+	}
+	var chunks []string
+	for i := 0; i < len(runes); i += stringChunkSize {
+		end := i + stringChunkSize
+		if end > len(runes) {
+			end = len(runes)
+		}
+		chunks = append(chunks, fmt.Sprintf("%s(%s'%s')", toLOB, prefix, strings.ReplaceAll(string(runes[i:end]), "'", "''")))
+	}
+	return strings.Join(chunks, " || ")
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, s)
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, backup io.Reader) (err error) {
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	// The DDL statements in Oracle are committed implicitly, so the statements are applied without transaction.
+	f := func(stmt string) error {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	return applyStatements(backup, f)
+}
+
+// applyStatements splits the dump by the statement terminator lines and applies the statements one by one.
+// A terminator line inside a quoted string, a quoted identifier or a block comment is not taken as the terminator.
+func applyStatements(backup io.Reader, f func(string) error) error {
+	reader := bufio.NewReader(backup)
+	var sb strings.Builder
+	apply := func() error {
+		s := trimComments(sb.String())
+		sb.Reset()
+		if s == "" {
+			return nil
+		}
+		if err := f(s); err != nil {
+			return errors.Wrapf(err, "execute query %q failed", s)
+		}
+		return nil
+	}
+	scanner := &quoteScanner{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if !scanner.inQuote() && strings.TrimSpace(line) == statementTerminator {
+			if err := apply(); err != nil {
+				return err
+			}
+		} else {
+			_, _ = sb.WriteString(line)
+			scanner.scan(line)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	// Apply the remaining content.
+	return apply()
+}
+
+// quoteScanner tracks whether the scanned text ends inside a string literal, including the q'[...]' alternative quoting,
+// a quoted identifier or a block comment.
+type quoteScanner struct {
+	// quote is the closing quote of the current string literal or quoted identifier, or 0 if not inside one.
+	quote rune
+	// qDelimiter is the closing delimiter of the current q'[...]' literal, or 0 if not inside one.
+	qDelimiter   rune
+	blockComment bool
+}
+
+func (s *quoteScanner) inQuote() bool {
+	return s.quote != 0 || s.blockComment
+}
+
+// scan scans one line, the line comment ends at the end of the line.
+func (s *quoteScanner) scan(line string) {
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		switch {
+		case s.blockComment:
+			if r == '*' && next == '/' {
+				s.blockComment = false
+				i++
+			}
+		case s.qDelimiter != 0:
+			if r == s.qDelimiter && next == '\'' {
+				s.quote, s.qDelimiter = 0, 0
+				i++
+			}
+		case s.quote != 0:
+			// The escaped quote '' is scanned as the closing and the opening quotes.
+			if r == s.quote {
+				s.quote = 0
+			}
+		case r == '-' && next == '-':
+			return
+		case r == '/' && next == '*':
+			s.blockComment = true
+			i++
+		case (r == 'q' || r == 'Q') && next == '\'' && i+2 < len(runes) && (i == 0 || !isIdentifierRune(runes[i-1]) || runes[i-1] == 'n' || runes[i-1] == 'N'):
+			s.quote, s.qDelimiter = '\'', closingQDelimiter(runes[i+2])
+			i += 2
+		case r == '\'' || r == '"':
+			s.quote = r
+		}
+	}
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || r == '#' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func closingQDelimiter(r rune) rune {
+	switch r {
+	case '[':
+		return ']'
+	case '{':
+		return '}'
+	case '(':
+		return ')'
+	case '<':
+		return '>'
+	default:
+		return r
+	}
+}
+
+// trimComments trims the leading comment lines and the surrounding spaces of the statement.
+func trimComments(s string) string {
+	s = strings.TrimSpace(s)
+	for strings.HasPrefix(s, "--") {
+		i := strings.Index(s, "\n")
+		if i < 0 {
+			return ""
+		}
+		s = strings.TrimSpace(s[i+1:])
+	}
+	return s
+}
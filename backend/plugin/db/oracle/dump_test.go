@@ -0,0 +1,64 @@
+package oracle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStatements(t *testing.T) {
+	a := require.New(t)
+	dump := "--\n-- Table structure for \"S\".\"T\"\n--\nCREATE TABLE \"S\".\"T\" (\"A\" VARCHAR2(10))\n/\n\n" +
+		"--\n-- PROCEDURE structure for \"S\".\"P\"\n--\nCREATE OR REPLACE PROCEDURE \"S\".\"P\" AS\nBEGIN\n  NULL;\nEND;\n/\n\n" +
+		"INSERT ALL\n    INTO \"S\".\"T\" (\"A\") VALUES ('a/b')\nSELECT 1 FROM DUAL\n/\n" +
+		"INSERT ALL\n    INTO \"S\".\"T\" (\"A\") VALUES ('it''s\n/\n')\nSELECT 1 FROM DUAL\n/\n" +
+		"CREATE OR REPLACE PROCEDURE \"S\".\"Q\" AS\nBEGIN\n  -- don't split\n  /* it's\n/\n*/\n  DBMS_OUTPUT.PUT_LINE(q'[it's\n/\n]');\nEND;\n/\n"
+	var got []string
+	err := applyStatements(strings.NewReader(dump), func(stmt string) error {
+		got = append(got, stmt)
+		return nil
+	})
+	a.NoError(err)
+	want := []string{
+		"CREATE TABLE \"S\".\"T\" (\"A\" VARCHAR2(10))",
+		"CREATE OR REPLACE PROCEDURE \"S\".\"P\" AS\nBEGIN\n  NULL;\nEND;",
+		"INSERT ALL\n    INTO \"S\".\"T\" (\"A\") VALUES ('a/b')\nSELECT 1 FROM DUAL",
+		"INSERT ALL\n    INTO \"S\".\"T\" (\"A\") VALUES ('it''s\n/\n')\nSELECT 1 FROM DUAL",
+		"CREATE OR REPLACE PROCEDURE \"S\".\"Q\" AS\nBEGIN\n  -- don't split\n  /* it's\n/\n*/\n  DBMS_OUTPUT.PUT_LINE(q'[it's\n/\n]');\nEND;",
+	}
+	a.Equal(want, got)
+}
+
+func TestSplitTriggerDDL(t *testing.T) {
+	a := require.New(t)
+	ddl := "CREATE OR REPLACE EDITIONABLE TRIGGER \"S\".\"TR\"\nBEFORE INSERT ON \"S\".\"T\"\nBEGIN\n  NULL;\nEND;\n\nALTER TRIGGER \"S\".\"TR\" ENABLE"
+	want := []string{
+		"CREATE OR REPLACE EDITIONABLE TRIGGER \"S\".\"TR\"\nBEFORE INSERT ON \"S\".\"T\"\nBEGIN\n  NULL;\nEND;",
+		"ALTER TRIGGER \"S\".\"TR\" ENABLE",
+	}
+	a.Equal(want, splitTriggerDDL(ddl))
+}
+
+func TestQuoteString(t *testing.T) {
+	a := require.New(t)
+	a.Equal("'it''s'", quoteString("it's", false))
+	a.Equal("N'it''s'", quoteString("it's", true))
+
+	long := strings.Repeat("a", stringChunkSize) + "b"
+	want := "TO_CLOB('" + strings.Repeat("a", stringChunkSize) + "') || TO_CLOB('b')"
+	a.Equal(want, quoteString(long, false))
+}
+
+func TestLargeBlobInsertStatement(t *testing.T) {
+	a := require.New(t)
+	table := &dumpTable{schemaName: "S", name: "T"}
+	blob := make([]byte, maxBlobLiteralSize+1)
+	got := largeBlobInsertStatement(table, []string{`"A"`, `"B"`}, []string{"1", ""}, map[int][]byte{1: blob})
+	want := "DECLARE\n  v1 BLOB;\nBEGIN\n" +
+		"  DBMS_LOB.CREATETEMPORARY(v1, TRUE);\n" +
+		"  DBMS_LOB.APPEND(v1, TO_BLOB(HEXTORAW('" + strings.Repeat("00", maxBlobLiteralSize) + "')));\n" +
+		"  DBMS_LOB.APPEND(v1, TO_BLOB(HEXTORAW('00')));\n" +
+		"  INSERT INTO \"S\".\"T\" (\"A\", \"B\") VALUES (1, v1);\nEND;"
+	a.Equal(want, got)
+}
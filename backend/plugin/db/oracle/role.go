@@ -2,33 +2,189 @@ package oracle
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/pkg/errors"
-
+	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 )
 
+// The role of Oracle is the user.
+// The attribute of the role is the clauses of ALTER USER statement, such as `PROFILE "DEFAULT" DEFAULT TABLESPACE "USERS" ACCOUNT UNLOCK`.
+// The connection limit is the SESSIONS_PER_USER of the user profile, and the valid until is the password expiry date,
+// both of them are managed by the profile.
+
 // CreateRole creates the role.
-func (*Driver) CreateRole(_ context.Context, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("create role for Oracle is not implemented yet")
+func (driver *Driver) CreateRole(ctx context.Context, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	if err := validateRoleUpsert(upsert); err != nil {
+		return nil, err
+	}
+	if upsert.Password == nil {
+		return nil, common.Errorf(common.Invalid, "password is required to create the user %s", upsert.Name)
+	}
+
+	statement := fmt.Sprintf("CREATE USER %s %s", quoteIdentifier(upsert.Name), convertToUserClause(upsert))
+	if _, err := driver.db.ExecContext(ctx, statement); err != nil {
+		return nil, util.FormatErrorWithQuery(err, statement)
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
 }
 
 // UpdateRole updates the role.
-func (*Driver) UpdateRole(_ context.Context, _ string, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("update role for Oracle is not implemented yet")
+func (driver *Driver) UpdateRole(ctx context.Context, roleName string, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	if err := validateRoleUpsert(upsert); err != nil {
+		return nil, err
+	}
+	if roleName != upsert.Name {
+		return nil, common.Errorf(common.Invalid, "cannot rename the user %s, Oracle does not support renaming users", roleName)
+	}
+
+	if clause := convertToUserClause(upsert); clause != "" {
+		statement := fmt.Sprintf("ALTER USER %s %s", quoteIdentifier(upsert.Name), clause)
+		if _, err := driver.db.ExecContext(ctx, statement); err != nil {
+			return nil, util.FormatErrorWithQuery(err, statement)
+		}
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
 }
 
 // FindRole finds the role by name.
-func (*Driver) FindRole(_ context.Context, _ string) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("find role for Oracle is not implemented yet")
+func (driver *Driver) FindRole(ctx context.Context, roleName string) (*db.DatabaseRoleMessage, error) {
+	roles, err := driver.findRoleImpl(ctx, &roleName)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, common.Errorf(common.NotFound, "cannot find the role %s", roleName)
+	}
+
+	return roles[0], nil
 }
 
 // ListRole lists the role.
-func (*Driver) ListRole(_ context.Context) ([]*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("list role for Oracle is not implemented yet")
+func (driver *Driver) ListRole(ctx context.Context) ([]*db.DatabaseRoleMessage, error) {
+	return driver.findRoleImpl(ctx, nil)
 }
 
 // DeleteRole deletes the role by name.
-func (*Driver) DeleteRole(_ context.Context, _ string) error {
-	return errors.Errorf("delete role for Oracle is not implemented yet")
+// The user in Oracle is also a schema, so the user owning objects is refused rather than dropping the objects along with it.
+func (driver *Driver) DeleteRole(ctx context.Context, roleName string) error {
+	var objectCount int
+	if err := driver.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM DBA_OBJECTS WHERE OWNER = :1", roleName).Scan(&objectCount); err != nil {
+		return err
+	}
+	if objectCount > 0 {
+		return common.Errorf(common.Invalid, "cannot delete the role %s because it owns %d objects", roleName, objectCount)
+	}
+
+	statement := fmt.Sprintf("DROP USER %s", quoteIdentifier(roleName))
+	if _, err := driver.db.ExecContext(ctx, statement); err != nil {
+		return util.FormatErrorWithQuery(err, statement)
+	}
+
+	return nil
+}
+
+func (driver *Driver) findRoleImpl(ctx context.Context, name *string) ([]*db.DatabaseRoleMessage, error) {
+	// The DEFAULT limit of the profile refers to the limit of the DEFAULT profile.
+	query := fmt.Sprintf(`
+		SELECT
+			u.USERNAME,
+			u.ACCOUNT_STATUS,
+			u.EXPIRY_DATE,
+			u.PROFILE,
+			u.DEFAULT_TABLESPACE,
+			CASE WHEN p.LIMIT = 'DEFAULT' THEN d.LIMIT ELSE p.LIMIT END
+		FROM DBA_USERS u
+		LEFT JOIN DBA_PROFILES p ON p.PROFILE = u.PROFILE AND p.RESOURCE_NAME = 'SESSIONS_PER_USER'
+		LEFT JOIN DBA_PROFILES d ON d.PROFILE = 'DEFAULT' AND d.RESOURCE_NAME = 'SESSIONS_PER_USER'
+		WHERE u.USERNAME NOT IN (%s)`, systemSchema)
+	var args []any
+	if name != nil {
+		query += " AND u.USERNAME = :1"
+		args = append(args, *name)
+	}
+	query += " ORDER BY u.USERNAME"
+
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var roles []*db.DatabaseRoleMessage
+	for rows.Next() {
+		var username, accountStatus, profile, tablespace string
+		var expiryDate sql.NullTime
+		var sessionsPerUser sql.NullString
+		if err := rows.Scan(&username, &accountStatus, &expiryDate, &profile, &tablespace, &sessionsPerUser); err != nil {
+			return nil, err
+		}
+		attribute := convertToAttribute(accountStatus, profile, tablespace)
+		role := &db.DatabaseRoleMessage{
+			Name:            username,
+			ConnectionLimit: convertToConnectionLimit(sessionsPerUser),
+			Attribute:       &attribute,
+		}
+		if expiryDate.Valid {
+			validUntil := expiryDate.Time.Format(time.RFC3339)
+			role.ValidUntil = &validUntil
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	return roles, nil
+}
+
+// convertToAttribute converts the user properties into the clauses of ALTER USER statement.
+func convertToAttribute(accountStatus, profile, tablespace string) string {
+	lock := "ACCOUNT UNLOCK"
+	// The account status can be OPEN, LOCKED, LOCKED(TIMED), EXPIRED & LOCKED and so on.
+	if strings.Contains(accountStatus, "LOCKED") {
+		lock = "ACCOUNT LOCK"
+	}
+	return fmt.Sprintf("PROFILE %s DEFAULT TABLESPACE %s %s", quoteIdentifier(profile), quoteIdentifier(tablespace), lock)
+}
+
+// convertToConnectionLimit converts the SESSIONS_PER_USER limit into the connection limit, -1 means no limit.
+func convertToConnectionLimit(sessionsPerUser sql.NullString) int32 {
+	if !sessionsPerUser.Valid {
+		return -1
+	}
+	limit, err := strconv.ParseInt(sessionsPerUser.String, 10, 32)
+	if err != nil {
+		// UNLIMITED.
+		return -1
+	}
+	return int32(limit)
+}
+
+func convertToUserClause(upsert *db.DatabaseRoleUpsertMessage) string {
+	var clauses []string
+	if upsert.Password != nil {
+		clauses = append(clauses, fmt.Sprintf("IDENTIFIED BY %s", quoteIdentifier(*upsert.Password)))
+	}
+	if upsert.Attribute != nil {
+		clauses = append(clauses, *upsert.Attribute)
+	}
+	return strings.Join(clauses, " ")
+}
+
+func validateRoleUpsert(upsert *db.DatabaseRoleUpsertMessage) error {
+	if upsert.ConnectionLimit != nil {
+		return common.Errorf(common.Invalid, "connection limit of Oracle is managed by the SESSIONS_PER_USER of the profile, please set the PROFILE in the attribute")
+	}
+	if upsert.ValidUntil != nil {
+		return common.Errorf(common.Invalid, "password expiry of Oracle is managed by the PASSWORD_LIFE_TIME of the profile, please set the PROFILE in the attribute")
+	}
+	return nil
 }
@@ -0,0 +1,21 @@
+package oracle
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToAttribute(t *testing.T) {
+	a := require.New(t)
+	a.Equal(`PROFILE "DEFAULT" DEFAULT TABLESPACE "USERS" ACCOUNT UNLOCK`, convertToAttribute("OPEN", "DEFAULT", "USERS"))
+	a.Equal(`PROFILE "APP" DEFAULT TABLESPACE "DATA" ACCOUNT LOCK`, convertToAttribute("EXPIRED & LOCKED", "APP", "DATA"))
+}
+
+func TestConvertToConnectionLimit(t *testing.T) {
+	a := require.New(t)
+	a.Equal(int32(-1), convertToConnectionLimit(sql.NullString{}))
+	a.Equal(int32(-1), convertToConnectionLimit(sql.NullString{String: "UNLIMITED", Valid: true}))
+	a.Equal(int32(10), convertToConnectionLimit(sql.NullString{String: "10", Valid: true}))
+}
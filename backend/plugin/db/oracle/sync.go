@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -343,12 +346,82 @@ func getViews(txn *sql.Tx) (map[string][]*storepb.ViewMetadata, error) {
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the slow query statistics from V$SQL.
+// The statistics in V$SQL are accumulated since the cursor is loaded into the shared pool,
+// so we collect the statements executed since logDateTs with the average elapsed time over one second.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	var databaseName string
+	getDatabaseName := "SELECT name FROM v$database"
+	if err := driver.db.QueryRowContext(ctx, getDatabaseName).Scan(&databaseName); err != nil {
+		return nil, util.FormatErrorWithQuery(err, getDatabaseName)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			MAX(DBMS_LOB.SUBSTR(SQL_FULLTEXT, %d, 1)),
+			SUM(EXECUTIONS),
+			SUM(ELAPSED_TIME),
+			MAX(ELAPSED_TIME / EXECUTIONS),
+			SUM(ROWS_PROCESSED),
+			MAX(ROWS_PROCESSED / EXECUTIONS),
+			MAX(LAST_ACTIVE_TIME)
+		FROM v$sql
+		WHERE EXECUTIONS > 0 AND PARSING_SCHEMA_NAME NOT IN (%s) AND LAST_ACTIVE_TIME >= :1
+		GROUP BY SQL_ID
+		HAVING MAX(ELAPSED_TIME / EXECUTIONS) >= 1000000`, db.SlowQueryMaxLen, systemSchema)
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	statisticsMap := make(map[string]*storepb.SlowQueryStatisticsItem)
+	for rows.Next() {
+		var sqlText string
+		var executions, rowsProcessed int64
+		var elapsedTime, maxElapsedTime, maxRowsProcessed float64
+		var lastActiveTime time.Time
+		if err := rows.Scan(&sqlText, &executions, &elapsedTime, &maxElapsedTime, &rowsProcessed, &maxRowsProcessed, &lastActiveTime); err != nil {
+			return nil, err
+		}
+		fingerprint, err := parser.GetSQLFingerprint(parser.Oracle, sqlText)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get sql fingerprint failed, sql: %s", sqlText)
+		}
+		if len(fingerprint) > db.SlowQueryMaxLen {
+			fingerprint = fingerprint[:db.SlowQueryMaxLen]
+		}
+		// The elapsed time is in microseconds.
+		statisticsMap[fingerprint] = util.MergeSlowQueryStatisticsItem(statisticsMap[fingerprint], &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   fingerprint,
+			Count:            executions,
+			LatestLogTime:    timestamppb.New(lastActiveTime),
+			TotalQueryTime:   durationpb.New(time.Duration(elapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    rowsProcessed,
+			MaximumRowsSent:  int64(maxRowsProcessed),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for _, item := range statisticsMap {
+		if _, ok := result[databaseName]; !ok {
+			result[databaseName] = &storepb.SlowQueryStatistics{}
+		}
+		result[databaseName].Items = append(result[databaseName].Items, item)
+	}
+	return result, nil
 }
 
-// CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// CheckSlowQueryLogEnabled checks if the user has the privilege to read V$SQL.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	checkPrivilege := "SELECT COUNT(*) FROM v$sql WHERE ROWNUM = 1"
+	var count int
+	if err := driver.db.QueryRowContext(ctx, checkPrivilege).Scan(&count); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, checkPrivilege), "cannot read V$SQL, please grant SELECT_CATALOG_ROLE or SELECT ON V_$SQL to the user")
+	}
+	return nil
 }
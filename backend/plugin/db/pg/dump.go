@@ -91,6 +91,8 @@ func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database st
 	args = append(args, "--no-owner")
 	// Avoid pg_dump v15 generate REVOKE/GRANT statement.
 	args = append(args, "--no-privileges")
+	// Skip the rows backed up for the rollback SQL generation.
+	args = append(args, fmt.Sprintf("--exclude-schema=%s", BackupSchemaName))
 	args = append(args, database)
 
 	pgDumpPath := filepath.Join(driver.dbBinDir, "pg_dump")
@@ -0,0 +1,370 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+const (
+	// BackupSchemaName is the Bytebase-managed schema storing the rows changed by the data change tasks.
+	BackupSchemaName = "bbdataarchive"
+	// changedRowsCTEName is the CTE name of the DML statement returning the changed rows.
+	changedRowsCTEName = "bb_changed_rows"
+
+	operationInsert = "INSERT"
+	operationUpdate = "UPDATE"
+	operationDelete = "DELETE"
+)
+
+// ExecuteWithDataBackup executes the statements in one transaction, and backs up the rows changed by each DML statement into the backup schema.
+// The backup tables are named as "<backupPrefix>_<index>", and it returns the transaction ID and the backup tables in the execution order.
+func (driver *Driver) ExecuteWithDataBackup(ctx context.Context, statement string, backupPrefix string) (string, []*api.DataBackup, error) {
+	owner, err := driver.GetCurrentDatabaseOwner()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var stmts []string
+	f := func(stmt string) error {
+		if isSuperuserStatement(stmt) || isNonTransactionStatement(stmt) {
+			return errors.Errorf("cannot back up the changed rows for statement %q, please disable the rollback SQL generation", stmt)
+		}
+		if !isIgnoredStatement(stmt) {
+			stmts = append(stmts, stmt)
+		}
+		return nil
+	}
+	if _, err := parser.SplitMultiSQLStream(parser.Postgres, strings.NewReader(statement), f); err != nil {
+		return "", nil, err
+	}
+
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	defer tx.Rollback()
+
+	// Set the current transaction role to the database owner so that the owner of created database will be the same as the database owner.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL ROLE '%s'", owner)); err != nil {
+		return "", nil, err
+	}
+	var transactionID string
+	if err := tx.QueryRowContext(ctx, "SELECT txid_current()::text").Scan(&transactionID); err != nil {
+		return "", nil, err
+	}
+	createSchema := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", quoteIdentifier(BackupSchemaName))
+	if _, err := tx.ExecContext(ctx, createSchema); err != nil {
+		return "", nil, util.FormatErrorWithQuery(err, createSchema)
+	}
+
+	var backupList []*api.DataBackup
+	for _, stmt := range stmts {
+		backupTable := fmt.Sprintf("%s_%d", backupPrefix, len(backupList))
+		backup, execList, err := buildDataBackupStatements(stmt, backupTable)
+		if err != nil {
+			return "", nil, err
+		}
+		if backup != nil {
+			// Resolve the schema of the table by the search path.
+			if err := tx.QueryRowContext(ctx, `
+				SELECT n.nspname, c.relname
+				FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+				WHERE c.oid = $1::regclass`,
+				quoteTableName(backup.Schema, backup.Table),
+			).Scan(&backup.Schema, &backup.Table); err != nil {
+				return "", nil, errors.Wrapf(err, "failed to find table %q", backup.Table)
+			}
+			backupList = append(backupList, backup)
+		}
+		for _, exec := range execList {
+			if _, err := tx.ExecContext(ctx, exec); err != nil {
+				return "", nil, util.FormatErrorWithQuery(err, exec)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", nil, err
+	}
+	return transactionID, backupList, nil
+}
+
+// buildDataBackupStatements builds the statements executing the DML statement and backing up the changed rows.
+// The inserted and deleted rows are backed up by the RETURNING clause of the DML statement,
+// and the rows before UPDATE are backed up before executing the UPDATE statement.
+// It returns nil backup and the statement itself for the non-DML statement.
+func buildDataBackupStatements(stmt string, backupTable string) (*api.DataBackup, []string, error) {
+	res, err := pgquery.Parse(stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(res.Stmts) != 1 {
+		return nil, []string{stmt}, nil
+	}
+
+	var relation *pgquery.RangeVar
+	var withClause *pgquery.WithClause
+	backup := &api.DataBackup{BackupTable: backupTable}
+	node := res.Stmts[0].Stmt
+	switch n := node.Node.(type) {
+	case *pgquery.Node_InsertStmt:
+		if n.InsertStmt.OnConflictClause != nil && n.InsertStmt.OnConflictClause.Action == pgquery.OnConflictAction_ONCONFLICT_UPDATE {
+			return nil, nil, errors.Errorf("cannot back up the changed rows for INSERT ... ON CONFLICT DO UPDATE statement %q, please disable the rollback SQL generation", stmt)
+		}
+		backup.Operation = operationInsert
+		relation, withClause = n.InsertStmt.Relation, n.InsertStmt.WithClause
+	case *pgquery.Node_UpdateStmt:
+		backup.Operation = operationUpdate
+		relation, withClause = n.UpdateStmt.Relation, n.UpdateStmt.WithClause
+		for _, target := range n.UpdateStmt.TargetList {
+			if resTarget, ok := target.Node.(*pgquery.Node_ResTarget); ok {
+				backup.UpdatedColumnList = append(backup.UpdatedColumnList, resTarget.ResTarget.Name)
+			}
+		}
+	case *pgquery.Node_DeleteStmt:
+		backup.Operation = operationDelete
+		relation, withClause = n.DeleteStmt.Relation, n.DeleteStmt.WithClause
+	default:
+		return nil, []string{stmt}, nil
+	}
+	backup.Schema, backup.Table = relation.Schemaname, relation.Relname
+	if withClause != nil {
+		for _, cte := range withClause.Ctes {
+			if _, ok := cte.GetCommonTableExpr().GetCtequery().Node.(*pgquery.Node_SelectStmt); !ok {
+				return nil, nil, errors.Errorf("cannot back up the changed rows for the DML statement with data-modifying WITH clause %q, please disable the rollback SQL generation", stmt)
+			}
+		}
+	}
+
+	// The rows of the table are referred by the alias if exists.
+	ref := relation.Relname
+	if relation.Alias != nil && relation.Alias.Aliasname != "" {
+		ref = relation.Alias.Aliasname
+	}
+	tableName := quoteTableName(relation.Schemaname, relation.Relname)
+	backupTableName := quoteTableName(BackupSchemaName, backupTable)
+	execList := []string{
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s)", backupTableName, tableName),
+	}
+
+	if backup.Operation == operationUpdate {
+		// Back up the rows matched by the UPDATE statement. The (tableoid, ctid) identifies the row in the inherited or partitioned tables.
+		update := node.GetUpdateStmt()
+		matchedRows := &pgquery.SelectStmt{
+			TargetList: []*pgquery.Node{
+				pgquery.MakeResTargetNodeWithVal(pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(ref), pgquery.MakeStrNode("tableoid")}, 0), 0),
+				pgquery.MakeResTargetNodeWithVal(pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(ref), pgquery.MakeStrNode("ctid")}, 0), 0),
+			},
+			FromClause:  append([]*pgquery.Node{{Node: &pgquery.Node_RangeVar{RangeVar: relation}}}, update.FromClause...),
+			WhereClause: update.WhereClause,
+			WithClause:  update.WithClause,
+			Op:          pgquery.SetOperation_SETOP_NONE,
+			LimitOption: pgquery.LimitOption_LIMIT_OPTION_DEFAULT,
+		}
+		matchedRowsQuery, err := deparseStatement(&pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: matchedRows}})
+		if err != nil {
+			return nil, nil, err
+		}
+		execList = append(execList,
+			fmt.Sprintf("INSERT INTO %s SELECT * FROM %s WHERE (tableoid, ctid) IN (%s)", backupTableName, tableName, matchedRowsQuery),
+			stmt,
+		)
+		return backup, execList, nil
+	}
+
+	// Back up the inserted or deleted rows returned by the DML statement in the same statement, such as:
+	//
+	//  WITH bb_changed_rows AS (DELETE FROM t WHERE a > 1 RETURNING t.*)
+	//  INSERT INTO bbdataarchive.task_1_0 SELECT * FROM bb_changed_rows
+	//
+	// The WITH clause containing a data-modifying statement must be at the top level, so the WITH clause of the DML statement is hoisted.
+	returning := []*pgquery.Node{
+		pgquery.MakeResTargetNodeWithVal(pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(ref), pgquery.MakeAStarNode()}, 0), 0),
+	}
+	hoisted := &pgquery.WithClause{}
+	if withClause != nil {
+		hoisted.Ctes, hoisted.Recursive = withClause.Ctes, withClause.Recursive
+	}
+	switch n := node.Node.(type) {
+	case *pgquery.Node_InsertStmt:
+		n.InsertStmt.WithClause, n.InsertStmt.ReturningList = nil, returning
+	case *pgquery.Node_DeleteStmt:
+		n.DeleteStmt.WithClause, n.DeleteStmt.ReturningList = nil, returning
+	}
+	hoisted.Ctes = append(hoisted.Ctes, &pgquery.Node{Node: &pgquery.Node_CommonTableExpr{CommonTableExpr: &pgquery.CommonTableExpr{
+		Ctename:  changedRowsCTEName,
+		Ctequery: node,
+	}}})
+	backupStmt, err := deparseStatement(&pgquery.Node{Node: &pgquery.Node_InsertStmt{InsertStmt: &pgquery.InsertStmt{
+		Relation: &pgquery.RangeVar{Schemaname: BackupSchemaName, Relname: backupTable, Inh: true, Relpersistence: "p"},
+		SelectStmt: &pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: &pgquery.SelectStmt{
+			TargetList:  []*pgquery.Node{pgquery.MakeResTargetNodeWithVal(pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeAStarNode()}, 0), 0)},
+			FromClause:  []*pgquery.Node{pgquery.MakeSimpleRangeVarNode(changedRowsCTEName, 0)},
+			Op:          pgquery.SetOperation_SETOP_NONE,
+			LimitOption: pgquery.LimitOption_LIMIT_OPTION_DEFAULT,
+		}}},
+		WithClause: hoisted,
+		Override:   pgquery.OverridingKind_OVERRIDING_NOT_SET,
+	}}})
+	if err != nil {
+		return nil, nil, err
+	}
+	execList = append(execList, backupStmt)
+	return backup, execList, nil
+}
+
+// GenerateRollbackSQL generates the rollback SQL statements from the backup tables, the later change is rolled back first.
+func (driver *Driver) GenerateRollbackSQL(ctx context.Context, backupList []*api.DataBackup) (string, error) {
+	var buf strings.Builder
+	for i := len(backupList) - 1; i >= 0; i-- {
+		backup := backupList[i]
+		columnList, err := driver.getInsertableColumnList(ctx, backup.Schema, backup.Table)
+		if err != nil {
+			return "", err
+		}
+		primaryKey, err := driver.getPrimaryKey(ctx, backup.Schema, backup.Table)
+		if err != nil {
+			return "", err
+		}
+		if backup.Operation != operationDelete && len(primaryKey) == 0 {
+			return "", errors.Errorf("cannot generate rollback SQL statement for %s on table %q without primary key", backup.Operation, backup.Table)
+		}
+		for _, column := range backup.UpdatedColumnList {
+			for _, key := range primaryKey {
+				if column == key {
+					return "", errors.Errorf("cannot generate rollback SQL statement for UPDATE on the primary key column %q of table %q", column, backup.Table)
+				}
+			}
+		}
+
+		var selectList []string
+		for _, column := range columnList {
+			selectList = append(selectList, fmt.Sprintf("quote_nullable(%s)", quoteIdentifier(column)))
+		}
+		// The latest backed up row is rolled back first.
+		query := fmt.Sprintf("SELECT %s FROM %s ORDER BY ctid DESC", strings.Join(selectList, ", "), quoteTableName(BackupSchemaName, backup.BackupTable))
+		if err := func() error {
+			rows, err := driver.db.QueryContext(ctx, query)
+			if err != nil {
+				return util.FormatErrorWithQuery(err, query)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				values := make([]string, len(columnList))
+				scanArgs := make([]any, len(columnList))
+				for i := range values {
+					scanArgs[i] = &values[i]
+				}
+				if err := rows.Scan(scanArgs...); err != nil {
+					return err
+				}
+				if _, err := buf.WriteString(generateRollbackStatement(backup, columnList, primaryKey, values)); err != nil {
+					return err
+				}
+			}
+			return rows.Err()
+		}(); err != nil {
+			return "", errors.Wrapf(err, "failed to read backup table %q", backup.BackupTable)
+		}
+	}
+	return buf.String(), nil
+}
+
+// generateRollbackStatement generates the statement rolling back one backed up row, the values are the quoted literals or NULL.
+func generateRollbackStatement(backup *api.DataBackup, columnList []string, primaryKey []string, values []string) string {
+	tableName := quoteTableName(backup.Schema, backup.Table)
+	valueMap := make(map[string]string)
+	for i, column := range columnList {
+		valueMap[column] = values[i]
+	}
+	keyMap := make(map[string]bool)
+	var conditionList []string
+	for _, key := range primaryKey {
+		keyMap[key] = true
+		conditionList = append(conditionList, fmt.Sprintf("%s = %s", quoteIdentifier(key), valueMap[key]))
+	}
+
+	switch backup.Operation {
+	case operationInsert:
+		return fmt.Sprintf("DELETE FROM %s WHERE %s;\n", tableName, strings.Join(conditionList, " AND "))
+	case operationUpdate:
+		var setList []string
+		for _, column := range columnList {
+			if keyMap[column] {
+				continue
+			}
+			setList = append(setList, fmt.Sprintf("%s = %s", quoteIdentifier(column), valueMap[column]))
+		}
+		return fmt.Sprintf("UPDATE %s SET %s WHERE %s;\n", tableName, strings.Join(setList, ", "), strings.Join(conditionList, " AND "))
+	default:
+		var quotedColumnList []string
+		for _, column := range columnList {
+			quotedColumnList = append(quotedColumnList, quoteIdentifier(column))
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);\n", tableName, strings.Join(quotedColumnList, ", "), strings.Join(values, ", "))
+	}
+}
+
+// getInsertableColumnList returns the columns of the table except the generated columns.
+func (driver *Driver) getInsertableColumnList(ctx context.Context, schemaName string, tableName string) ([]string, error) {
+	const query = `
+		SELECT column_name
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2 AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`
+	return driver.queryNameList(ctx, query, schemaName, tableName)
+}
+
+func (driver *Driver) getPrimaryKey(ctx context.Context, schemaName string, tableName string) ([]string, error) {
+	const query = `
+		SELECT a.attname
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::int2[], a.attnum)`
+	return driver.queryNameList(ctx, query, quoteTableName(schemaName, tableName))
+}
+
+func (driver *Driver) queryNameList(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+	var nameList []string
+	for rows.Next() {
+		var name sql.NullString
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		nameList = append(nameList, name.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return nameList, nil
+}
+
+func deparseStatement(node *pgquery.Node) (string, error) {
+	return pgquery.Deparse(&pgquery.ParseResult{Stmts: []*pgquery.RawStmt{{Stmt: node}}})
+}
+
+func quoteTableName(schemaName string, tableName string) string {
+	if schemaName == "" {
+		return quoteIdentifier(tableName)
+	}
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName))
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+func TestBuildDataBackupStatements(t *testing.T) {
+	tests := []struct {
+		statement string
+		backup    *api.DataBackup
+		execList  []string
+		wantErr   bool
+	}{
+		{
+			statement: `INSERT INTO t (a, b) VALUES (1, 2)`,
+			backup:    &api.DataBackup{Operation: "INSERT", Table: "t", BackupTable: "task_1_0"},
+			execList: []string{
+				`CREATE TABLE "bbdataarchive"."task_1_0" (LIKE "t")`,
+				`WITH bb_changed_rows AS (INSERT INTO t (a, b) VALUES (1, 2) RETURNING t.*) INSERT INTO bbdataarchive.task_1_0 SELECT * FROM bb_changed_rows`,
+			},
+		},
+		{
+			statement: `DELETE FROM public.t AS x WHERE x.a > 1`,
+			backup:    &api.DataBackup{Operation: "DELETE", Schema: "public", Table: "t", BackupTable: "task_1_0"},
+			execList: []string{
+				`CREATE TABLE "bbdataarchive"."task_1_0" (LIKE "public"."t")`,
+				`WITH bb_changed_rows AS (DELETE FROM public.t x WHERE x.a > 1 RETURNING x.*) INSERT INTO bbdataarchive.task_1_0 SELECT * FROM bb_changed_rows`,
+			},
+		},
+		{
+			statement: `WITH c AS (SELECT 1 AS id) DELETE FROM t USING c WHERE t.id = c.id`,
+			backup:    &api.DataBackup{Operation: "DELETE", Table: "t", BackupTable: "task_1_0"},
+			execList: []string{
+				`CREATE TABLE "bbdataarchive"."task_1_0" (LIKE "t")`,
+				`WITH c AS (SELECT 1 AS id), bb_changed_rows AS (DELETE FROM t USING c WHERE t.id = c.id RETURNING t.*) INSERT INTO bbdataarchive.task_1_0 SELECT * FROM bb_changed_rows`,
+			},
+		},
+		{
+			statement: `UPDATE "T" AS x SET b = 1 FROM s WHERE x.a = s.a`,
+			backup:    &api.DataBackup{Operation: "UPDATE", Table: "T", BackupTable: "task_1_0", UpdatedColumnList: []string{"b"}},
+			execList: []string{
+				`CREATE TABLE "bbdataarchive"."task_1_0" (LIKE "T")`,
+				`INSERT INTO "bbdataarchive"."task_1_0" SELECT * FROM "T" WHERE (tableoid, ctid) IN (SELECT x.tableoid, x.ctid FROM "T" x, s WHERE x.a = s.a)`,
+				`UPDATE "T" AS x SET b = 1 FROM s WHERE x.a = s.a`,
+			},
+		},
+		{
+			statement: `CREATE TABLE t (a int)`,
+			execList:  []string{`CREATE TABLE t (a int)`},
+		},
+		{
+			statement: `WITH c AS (DELETE FROM s RETURNING *) INSERT INTO t SELECT * FROM c`,
+			wantErr:   true,
+		},
+		{
+			statement: `INSERT INTO t VALUES (1) ON CONFLICT (a) DO UPDATE SET b = 1`,
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		backup, execList, err := buildDataBackupStatements(test.statement, "task_1_0")
+		if test.wantErr {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.backup, backup, test.statement)
+		a.Equal(test.execList, execList, test.statement)
+	}
+}
+
+func TestGenerateRollbackStatement(t *testing.T) {
+	columnList := []string{"id", "name", "age"}
+	primaryKey := []string{"id"}
+	values := []string{"'1'", "'alice'", "NULL"}
+	tests := []struct {
+		operation string
+		want      string
+	}{
+		{
+			operation: "INSERT",
+			want:      "DELETE FROM \"public\".\"t\" WHERE \"id\" = '1';\n",
+		},
+		{
+			operation: "UPDATE",
+			want:      "UPDATE \"public\".\"t\" SET \"name\" = 'alice', \"age\" = NULL WHERE \"id\" = '1';\n",
+		},
+		{
+			operation: "DELETE",
+			want:      "INSERT INTO \"public\".\"t\" (\"id\", \"name\", \"age\") VALUES ('1', 'alice', NULL);\n",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		backup := &api.DataBackup{Operation: test.operation, Schema: "public", Table: "t"}
+		a.Equal(test.want, generateRollbackStatement(backup, columnList, primaryKey, values), test.operation)
+	}
+}
@@ -3,17 +3,639 @@ package redshift
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// Dump and restore.
+const (
+	schemaStmtFmt = "" +
+		"--\n" +
+		"-- Schema structure for %s\n" +
+		"--\n" +
+		"%s;\n\n"
+	tableStmtFmt = "" +
+		"--\n" +
+		"-- Table structure for %s\n" +
+		"--\n" +
+		"%s;\n\n"
+	foreignKeyStmtFmt = "" +
+		"--\n" +
+		"-- Foreign key structure for %s\n" +
+		"--\n" +
+		"%s;\n\n"
+	viewStmtFmt = "" +
+		"--\n" +
+		"-- View structure for %s\n" +
+		"--\n" +
+		"%s;\n\n"
+	commentStmtFmt = "" +
+		"--\n" +
+		"-- Comments for %s\n" +
+		"--\n"
+	grantStmtFmt = "" +
+		"--\n" +
+		"-- Privileges for %s\n" +
+		"--\n"
+)
+
+var (
+	// identityDefaultRegexp matches the column default of the identity column, e.g. "identity"(100167, 0, '1,1'::text).
+	identityDefaultRegexp = regexp.MustCompile(`^"(identity|default_identity)"\(\d+, \d+, '(-?\d+),(-?\d+)'::text\)$`)
+	// createViewRegexp matches the leading CREATE VIEW of the late-binding view definition.
+	createViewRegexp = regexp.MustCompile(`(?i)^create\s+(or\s+replace\s+)?view\s+`)
 )
 
-// Dump dumps the database to the writer. But not implemented yet.
-func (*Driver) Dump(context.Context, io.Writer, bool) (string, error) {
+// Dump dumps the schema of the database. Dumping the data isn't supported.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	if !schemaOnly {
+		return "", errors.Errorf("dumping the data of Redshift is not supported")
+	}
+	if driver.databaseName == "" {
+		return "", errors.Errorf("cannot dump the instance without database")
+	}
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
-// Restore the database from src, which is a full backup.
-func (*Driver) Restore(context.Context, io.Reader) error {
-	return errors.Errorf("not implemented")
+// dumpTxn dumps the database schema in the order of schemas, tables, foreign keys, views, comments and privileges.
+// The tables and views are collected by the same queries as syncing, and the Redshift specific table attributes,
+// such as DISTKEY, SORTKEY and ENCODE, are collected from the catalog.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	schemaNames, err := getSchemas(txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get schemas")
+	}
+	tableMap, err := getTables(txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tables")
+	}
+	viewMap, err := getViews(txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get views")
+	}
+	dumpTableMap, err := getDumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get table attributes")
+	}
+	privilegeMap, err := getRelationPrivileges(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get relation privileges")
+	}
+	schemaPrivilegeMap, err := getSchemaPrivileges(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get schema privileges")
+	}
+
+	sort.Strings(schemaNames)
+	for _, schemaName := range schemaNames {
+		// The public schema is created by default.
+		if schemaName == "public" {
+			continue
+		}
+		stmt := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", quoteIdentifier(schemaName))
+		if _, err := fmt.Fprintf(out, schemaStmtFmt, quoteIdentifier(schemaName), stmt); err != nil {
+			return err
+		}
+	}
+
+	var tables []*dumpTable
+	for _, schemaName := range sortedKeys(tableMap) {
+		schemaTables := tableMap[schemaName]
+		sort.Slice(schemaTables, func(i, j int) bool { return schemaTables[i].Name < schemaTables[j].Name })
+		for _, tableMetadata := range schemaTables {
+			table, ok := dumpTableMap[db.TableKey{Schema: schemaName, Table: tableMetadata.Name}]
+			if !ok {
+				continue
+			}
+			table.metadata = tableMetadata
+			tables = append(tables, table)
+		}
+	}
+
+	for _, table := range tables {
+		if _, err := fmt.Fprintf(out, tableStmtFmt, table.fullName(), table.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	// The foreign keys are added after all tables are created because they may reference each other.
+	for _, table := range tables {
+		for _, foreignKey := range table.foreignKeys {
+			stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", table.fullName(), quoteIdentifier(foreignKey.name), foreignKey.definition)
+			if _, err := fmt.Fprintf(out, foreignKeyStmtFmt, quoteIdentifier(foreignKey.name), stmt); err != nil {
+				return err
+			}
+		}
+	}
+
+	var views []*dumpView
+	for _, schemaName := range sortedKeys(viewMap) {
+		schemaViews := viewMap[schemaName]
+		sort.Slice(schemaViews, func(i, j int) bool { return schemaViews[i].Name < schemaViews[j].Name })
+		for _, view := range schemaViews {
+			views = append(views, &dumpView{schemaName: schemaName, metadata: view})
+		}
+	}
+	for _, view := range views {
+		if _, err := fmt.Fprintf(out, viewStmtFmt, view.fullName(), view.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range tables {
+		if err := writeStatements(out, commentStmtFmt, table.fullName(), table.commentStatements()); err != nil {
+			return err
+		}
+	}
+	for _, view := range views {
+		if err := writeStatements(out, commentStmtFmt, view.fullName(), view.commentStatements()); err != nil {
+			return err
+		}
+	}
+
+	for _, schemaName := range schemaNames {
+		if err := writeStatements(out, grantStmtFmt, quoteIdentifier(schemaName), schemaPrivilegeMap[schemaName]); err != nil {
+			return err
+		}
+	}
+	for _, table := range tables {
+		if err := writeStatements(out, grantStmtFmt, table.fullName(), privilegeMap[db.TableKey{Schema: table.schemaName, Table: table.name}]); err != nil {
+			return err
+		}
+	}
+	for _, view := range views {
+		if err := writeStatements(out, grantStmtFmt, view.fullName(), privilegeMap[db.TableKey{Schema: view.schemaName, Table: view.metadata.Name}]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeStatements writes the statements with the header for the object if there is any statement.
+func writeStatements(out io.Writer, headerFmt, name string, stmts []string) error {
+	if len(stmts) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(out, headerFmt, name); err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if _, err := fmt.Fprintf(out, "%s;\n", stmt); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+// dumpTable is the dump structure of a table.
+type dumpTable struct {
+	schemaName string
+	name       string
+	// diststyle is the distribution style of the table, i.e. AUTO, EVEN, KEY or ALL.
+	diststyle   string
+	columns     []*dumpColumn
+	constraints []string
+	foreignKeys []*dumpForeignKey
+	metadata    *storepb.TableMetadata
+}
+
+func (t *dumpTable) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schemaName), quoteIdentifier(t.name))
+}
+
+func (t *dumpTable) createStatement() string {
+	var lines []string
+	for _, column := range t.columns {
+		lines = append(lines, fmt.Sprintf("    %s", column.definition()))
+	}
+	for _, constraint := range t.constraints {
+		lines = append(lines, fmt.Sprintf("    %s", constraint))
+	}
+
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "CREATE TABLE IF NOT EXISTS %s (\n%s\n)", t.fullName(), strings.Join(lines, ",\n"))
+	if t.diststyle != "" {
+		_, _ = fmt.Fprintf(&buf, "\nDISTSTYLE %s", t.diststyle)
+	}
+	if t.diststyle == "KEY" {
+		for _, column := range t.columns {
+			if column.distkey {
+				_, _ = fmt.Fprintf(&buf, "\nDISTKEY (%s)", quoteIdentifier(column.name))
+				break
+			}
+		}
+	}
+	if sortkey := t.sortkey(); sortkey != "" {
+		_, _ = fmt.Fprintf(&buf, "\n%s", sortkey)
+	}
+	return buf.String()
+}
+
+// sortkey returns the COMPOUND or INTERLEAVED SORTKEY clause of the table.
+// The sort key order is positive for compound sort keys and negative for interleaved sort keys.
+func (t *dumpTable) sortkey() string {
+	var columns []*dumpColumn
+	interleaved := false
+	for _, column := range t.columns {
+		if column.sortkeyOrder == 0 {
+			continue
+		}
+		if column.sortkeyOrder < 0 {
+			interleaved = true
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return ""
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		return abs(columns[i].sortkeyOrder) < abs(columns[j].sortkeyOrder)
+	})
+	var names []string
+	for _, column := range columns {
+		names = append(names, quoteIdentifier(column.name))
+	}
+	style := "COMPOUND"
+	if interleaved {
+		style = "INTERLEAVED"
+	}
+	return fmt.Sprintf("%s SORTKEY (%s)", style, strings.Join(names, ", "))
+}
+
+func (t *dumpTable) commentStatements() []string {
+	if t.metadata == nil {
+		return nil
+	}
+	var stmts []string
+	if t.metadata.Comment != "" {
+		stmts = append(stmts, fmt.Sprintf("COMMENT ON TABLE %s IS %s", t.fullName(), quoteString(t.metadata.Comment)))
+	}
+	for _, column := range t.metadata.Columns {
+		if column.Comment != "" {
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", t.fullName(), quoteIdentifier(column.Name), quoteString(column.Comment)))
+		}
+	}
+	return stmts
+}
+
+// dumpColumn is the dump structure of a column.
+type dumpColumn struct {
+	name         string
+	dataType     string
+	notNull      bool
+	defaultExpr  sql.NullString
+	collation    sql.NullString
+	encoding     string
+	distkey      bool
+	sortkeyOrder int
+}
+
+func (c *dumpColumn) definition() string {
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "%s %s", quoteIdentifier(c.name), c.dataType)
+	if c.defaultExpr.Valid {
+		if matches := identityDefaultRegexp.FindStringSubmatch(c.defaultExpr.String); matches != nil {
+			if matches[1] == "default_identity" {
+				_, _ = fmt.Fprintf(&buf, " GENERATED BY DEFAULT AS IDENTITY(%s, %s)", matches[2], matches[3])
+			} else {
+				_, _ = fmt.Fprintf(&buf, " IDENTITY(%s, %s)", matches[2], matches[3])
+			}
+		} else {
+			_, _ = fmt.Fprintf(&buf, " DEFAULT %s", c.defaultExpr.String)
+		}
+	}
+	if c.collation.Valid && c.collation.String != "" && c.collation.String != "default" {
+		_, _ = fmt.Fprintf(&buf, " COLLATE %s", c.collation.String)
+	}
+	if c.notNull {
+		_, _ = buf.WriteString(" NOT NULL")
+	}
+	// The columns without compression are created with the default encoding.
+	if c.encoding != "" && c.encoding != "none" {
+		_, _ = fmt.Fprintf(&buf, " ENCODE %s", strings.ToUpper(c.encoding))
+	}
+	return buf.String()
+}
+
+// dumpForeignKey is the dump structure of a foreign key.
+type dumpForeignKey struct {
+	name       string
+	definition string
+}
+
+// dumpView is the dump structure of a view.
+type dumpView struct {
+	schemaName string
+	metadata   *storepb.ViewMetadata
+}
+
+func (v *dumpView) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(v.schemaName), quoteIdentifier(v.metadata.Name))
+}
+
+// createStatement returns the CREATE VIEW statement of the view.
+// The definition of the late-binding view is the whole CREATE VIEW ... WITH NO SCHEMA BINDING statement,
+// while the definition of the regular view is the SELECT statement only.
+func (v *dumpView) createStatement() string {
+	definition := strings.TrimRight(strings.TrimSpace(v.metadata.Definition), ";")
+	if loc := createViewRegexp.FindStringIndex(definition); loc != nil {
+		return fmt.Sprintf("CREATE OR REPLACE VIEW %s", definition[loc[1]:])
+	}
+	return fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s", v.fullName(), definition)
+}
+
+func (v *dumpView) commentStatements() []string {
+	if v.metadata.Comment == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("COMMENT ON VIEW %s IS %s", v.fullName(), quoteString(v.metadata.Comment))}
+}
+
+// getDumpTables gets the column definitions, distribution styles, sort keys and constraints of the tables.
+func getDumpTables(ctx context.Context, txn *sql.Tx) (map[db.TableKey]*dumpTable, error) {
+	tableMap := make(map[db.TableKey]*dumpTable)
+
+	tableQuery := `
+	SELECT
+		n.nspname,
+		c.relname,
+		c.reldiststyle
+	FROM pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	WHERE c.relkind = 'r' AND n.nspname NOT IN ('pg_catalog', 'information_schema');`
+	if err := queryRows(ctx, txn, tableQuery, func(rows *sql.Rows) error {
+		table := &dumpTable{}
+		var diststyle int
+		if err := rows.Scan(&table.schemaName, &table.name, &diststyle); err != nil {
+			return err
+		}
+		table.diststyle = convertDiststyle(diststyle)
+		tableMap[db.TableKey{Schema: table.schemaName, Table: table.name}] = table
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	columnQuery := `
+	SELECT
+		n.nspname,
+		c.relname,
+		a.attname,
+		pg_catalog.format_type(a.atttypid, a.atttypmod),
+		a.attnotnull,
+		pg_catalog.pg_get_expr(d.adbin, d.adrelid),
+		co.collname,
+		pg_catalog.format_encoding(a.attencodingtype),
+		a.attisdistkey,
+		a.attsortkeyord
+	FROM pg_catalog.pg_attribute AS a
+	JOIN pg_catalog.pg_class AS c ON c.oid = a.attrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	LEFT JOIN pg_catalog.pg_attrdef AS d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+	LEFT JOIN pg_catalog.pg_collation AS co ON co.oid = a.attcollation
+	WHERE c.relkind = 'r' AND a.attnum > 0 AND NOT a.attisdropped AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY n.nspname, c.relname, a.attnum;`
+	if err := queryRows(ctx, txn, columnQuery, func(rows *sql.Rows) error {
+		column := &dumpColumn{}
+		var schemaName, tableName string
+		var encoding sql.NullString
+		if err := rows.Scan(&schemaName, &tableName, &column.name, &column.dataType, &column.notNull, &column.defaultExpr, &column.collation, &encoding, &column.distkey, &column.sortkeyOrder); err != nil {
+			return err
+		}
+		column.encoding = encoding.String
+		if table, ok := tableMap[db.TableKey{Schema: schemaName, Table: tableName}]; ok {
+			table.columns = append(table.columns, column)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// The primary key and unique constraints are created along with the tables, and the foreign keys are added later.
+	constraintQuery := `
+	SELECT
+		n.nspname,
+		cl.relname,
+		c.conname,
+		c.contype,
+		pg_catalog.pg_get_constraintdef(c.oid)
+	FROM pg_catalog.pg_constraint AS c
+	JOIN pg_catalog.pg_class AS cl ON cl.oid = c.conrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = cl.relnamespace
+	WHERE c.contype IN ('p', 'u', 'f') AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY n.nspname, cl.relname, c.contype, c.conname;`
+	if err := queryRows(ctx, txn, constraintQuery, func(rows *sql.Rows) error {
+		var schemaName, tableName, name, constraintType, definition string
+		if err := rows.Scan(&schemaName, &tableName, &name, &constraintType, &definition); err != nil {
+			return err
+		}
+		table, ok := tableMap[db.TableKey{Schema: schemaName, Table: tableName}]
+		if !ok {
+			return nil
+		}
+		if constraintType == "f" {
+			table.foreignKeys = append(table.foreignKeys, &dumpForeignKey{name: name, definition: definition})
+		} else {
+			table.constraints = append(table.constraints, fmt.Sprintf("CONSTRAINT %s %s", quoteIdentifier(name), definition))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tableMap, nil
+}
+
+// convertDiststyle converts the reldiststyle of pg_class to the DISTSTYLE option.
+// https://docs.aws.amazon.com/redshift/latest/dg/r_PG_CLASS_INFO.html
+func convertDiststyle(diststyle int) string {
+	switch diststyle {
+	case 0:
+		return "EVEN"
+	case 1:
+		return "KEY"
+	case 8:
+		return "ALL"
+	case 10, 11, 12:
+		return "AUTO"
+	default:
+		return ""
+	}
+}
+
+// getRelationPrivileges gets the GRANT statements of the tables and views.
+func getRelationPrivileges(ctx context.Context, txn *sql.Tx) (map[db.TableKey][]string, error) {
+	query := `
+	SELECT
+		namespace_name,
+		relation_name,
+		privilege_type,
+		identity_name,
+		identity_type,
+		admin_option
+	FROM svv_relation_privileges
+	WHERE namespace_name NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY namespace_name, relation_name, identity_type, identity_name, privilege_type;`
+	privilegeMap := make(map[db.TableKey][]string)
+	if err := queryRows(ctx, txn, query, func(rows *sql.Rows) error {
+		var schemaName, relationName, privilegeType, identityName, identityType string
+		var grantOption bool
+		if err := rows.Scan(&schemaName, &relationName, &privilegeType, &identityName, &identityType, &grantOption); err != nil {
+			return err
+		}
+		on := fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(relationName))
+		key := db.TableKey{Schema: schemaName, Table: relationName}
+		privilegeMap[key] = append(privilegeMap[key], grantStatement(privilegeType, on, identityName, identityType, grantOption))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return privilegeMap, nil
+}
+
+// getSchemaPrivileges gets the GRANT statements of the schemas.
+func getSchemaPrivileges(ctx context.Context, txn *sql.Tx) (map[string][]string, error) {
+	query := `
+	SELECT
+		namespace_name,
+		privilege_type,
+		identity_name,
+		identity_type,
+		admin_option
+	FROM svv_schema_privileges
+	WHERE namespace_name NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY namespace_name, identity_type, identity_name, privilege_type;`
+	privilegeMap := make(map[string][]string)
+	if err := queryRows(ctx, txn, query, func(rows *sql.Rows) error {
+		var schemaName, privilegeType, identityName, identityType string
+		var grantOption bool
+		if err := rows.Scan(&schemaName, &privilegeType, &identityName, &identityType, &grantOption); err != nil {
+			return err
+		}
+		on := fmt.Sprintf("SCHEMA %s", quoteIdentifier(schemaName))
+		privilegeMap[schemaName] = append(privilegeMap[schemaName], grantStatement(privilegeType, on, identityName, identityType, grantOption))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return privilegeMap, nil
+}
+
+// grantStatement returns the GRANT statement of the privilege to the user, group, role or public.
+func grantStatement(privilegeType, on, identityName, identityType string, grantOption bool) string {
+	var grantee string
+	switch identityType {
+	case "public":
+		grantee = "PUBLIC"
+	case "group":
+		grantee = fmt.Sprintf("GROUP %s", quoteIdentifier(identityName))
+	case "role":
+		grantee = fmt.Sprintf("ROLE %s", quoteIdentifier(identityName))
+	default:
+		grantee = quoteIdentifier(identityName)
+	}
+	stmt := fmt.Sprintf("GRANT %s ON %s TO %s", privilegeType, on, grantee)
+	if grantOption {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt
+}
+
+func queryRows(ctx context.Context, txn *sql.Tx, query string, f func(rows *sql.Rows) error) error {
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := f(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func quoteString(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// Restore restores the database schema from src, which is a dump generated by Dump.
+func (driver *Driver) Restore(ctx context.Context, src io.Reader) error {
+	owner, err := driver.GetCurrentDatabaseOwner()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the OWNER of the current database")
+	}
+
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	// Set the current transaction role to the database owner so that the owner of restored objects will be the same as the database owner.
+	if _, err := txn.ExecContext(ctx, fmt.Sprintf("SET SESSION AUTHORIZATION '%s'", owner)); err != nil {
+		return errors.Wrapf(err, "failed to set session authorization to %q", owner)
+	}
+
+	f := func(stmt string) error {
+		if _, err := txn.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrapf(err, "execute query %q failed", stmt)
+		}
+		return nil
+	}
+	if _, err := parser.SplitMultiSQLStream(parser.Redshift, src, f); err != nil {
+		return err
+	}
+
+	if _, err := txn.ExecContext(ctx, "SET SESSION AUTHORIZATION DEFAULT"); err != nil {
+		return errors.Wrap(err, "failed to restore the session authorization")
+	}
+
+	return txn.Commit()
 }
@@ -0,0 +1,78 @@
+package redshift
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestTableCreateStatement(t *testing.T) {
+	a := require.New(t)
+	table := &dumpTable{
+		schemaName: "public",
+		name:       "orders",
+		diststyle:  "KEY",
+		columns: []*dumpColumn{
+			{name: "id", dataType: "bigint", notNull: true, defaultExpr: sql.NullString{String: `"identity"(100167, 0, '1,1'::text)`, Valid: true}, encoding: "az64", sortkeyOrder: 2},
+			{name: "customer_id", dataType: "integer", notNull: true, encoding: "none", distkey: true, sortkeyOrder: 1},
+			{name: "note", dataType: "character varying(256)", defaultExpr: sql.NullString{String: "'n/a'::character varying", Valid: true}, encoding: "lzo"},
+		},
+		constraints: []string{`CONSTRAINT "orders_pkey" PRIMARY KEY (id)`},
+	}
+	want := `CREATE TABLE IF NOT EXISTS "public"."orders" (
+    "id" bigint IDENTITY(1, 1) NOT NULL ENCODE AZ64,
+    "customer_id" integer NOT NULL,
+    "note" character varying(256) DEFAULT 'n/a'::character varying ENCODE LZO,
+    CONSTRAINT "orders_pkey" PRIMARY KEY (id)
+)
+DISTSTYLE KEY
+DISTKEY ("customer_id")
+COMPOUND SORTKEY ("customer_id", "id")`
+	a.Equal(want, table.createStatement())
+
+	table.diststyle = "AUTO"
+	table.columns[0].sortkeyOrder = -1
+	table.columns[1].sortkeyOrder = -2
+	want = `CREATE TABLE IF NOT EXISTS "public"."orders" (
+    "id" bigint IDENTITY(1, 1) NOT NULL ENCODE AZ64,
+    "customer_id" integer NOT NULL,
+    "note" character varying(256) DEFAULT 'n/a'::character varying ENCODE LZO,
+    CONSTRAINT "orders_pkey" PRIMARY KEY (id)
+)
+DISTSTYLE AUTO
+INTERLEAVED SORTKEY ("id", "customer_id")`
+	a.Equal(want, table.createStatement())
+}
+
+func TestViewCreateStatement(t *testing.T) {
+	tests := []struct {
+		definition string
+		want       string
+	}{
+		{
+			definition: " SELECT orders.id FROM orders;",
+			want:       "CREATE OR REPLACE VIEW \"public\".\"v\" AS\nSELECT orders.id FROM orders",
+		},
+		{
+			// The definition of the late-binding view is the whole statement.
+			definition: "create view public.v as select orders.id from public.orders with no schema binding;",
+			want:       "CREATE OR REPLACE VIEW public.v as select orders.id from public.orders with no schema binding",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		view := &dumpView{schemaName: "public", metadata: &storepb.ViewMetadata{Name: "v", Definition: test.definition}}
+		a.Equal(test.want, view.createStatement())
+	}
+}
+
+func TestGrantStatement(t *testing.T) {
+	a := require.New(t)
+	a.Equal(`GRANT SELECT ON "public"."t" TO PUBLIC`, grantStatement("SELECT", `"public"."t"`, "public", "public", false))
+	a.Equal(`GRANT USAGE ON SCHEMA "s" TO GROUP "analysts"`, grantStatement("USAGE", `SCHEMA "s"`, "analysts", "group", false))
+	a.Equal(`GRANT INSERT ON "public"."t" TO "bob" WITH GRANT OPTION`, grantStatement("INSERT", `"public"."t"`, "bob", "user", true))
+}
@@ -167,9 +167,15 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 		return nil, err
 	}
 
-	fieldList, err := extractSensitiveField(dbType, statement, queryContext.CurrentDatabase, queryContext.SensitiveSchemaInfo)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to extract sensitive fields: %q", statement)
+	var fieldList []db.SensitiveField
+	if dbType == db.MSSQL || dbType == db.Snowflake {
+		// There is no T-SQL or Snowflake parser to extract the sensitive fields yet.
+		fieldList = extractTableLevelSensitiveField(statement, queryContext.SensitiveSchemaInfo, columnNames)
+	} else {
+		fieldList, err = extractSensitiveField(dbType, statement, queryContext.CurrentDatabase, queryContext.SensitiveSchemaInfo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to extract sensitive fields: %q", statement)
+		}
 	}
 
 	if len(fieldList) != 0 && len(fieldList) != len(columnNames) {
@@ -283,7 +289,7 @@ func readRows(rows *sql.Rows, dbType db.Type, columnTypes []*sql.ColumnType, col
 		rowData := []any{}
 		for i := range columnTypes {
 			if len(fieldList) > 0 && fieldList[i].Sensitive {
-				rowData = append(rowData, "******")
+				rowData = append(rowData, maskValue(fieldList[i].Mask, scanArgs[i]))
 				continue
 			}
 			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
@@ -500,7 +506,7 @@ func readRowsForClickhouse(rows *sql.Rows, columnTypes []*sql.ColumnType, column
 		rowData := []any{}
 		for i := range cols {
 			if len(fieldList) > 0 && fieldList[i].Sensitive {
-				rowData = append(rowData, "******")
+				rowData = append(rowData, maskValue(fieldList[i].Mask, cols[i]))
 				continue
 			}
 
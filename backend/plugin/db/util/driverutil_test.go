@@ -1217,3 +1217,297 @@ func TestPostgreSQLExtractSensitiveField(t *testing.T) {
 		require.Equal(t, test.fieldList, res, test.statement)
 	}
 }
+
+func TestExtractSensitiveFieldMask(t *testing.T) {
+	const (
+		defaultDatabase = "db"
+	)
+	hash := &db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeHash, Salt: "salt"}
+	email := &db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeEmail}
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: defaultDatabase,
+				TableList: []db.TableSchema{
+					{
+						Name: "t",
+						ColumnList: []db.ColumnInfo{
+							{Name: "id", Sensitive: true, Mask: hash},
+							{Name: "email", Sensitive: true, Mask: email},
+							{Name: "name", Sensitive: false},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		statement string
+		fieldList []db.SensitiveField
+	}{
+		{
+			// The column references keep the mask algorithm.
+			statement: `select * from (select t.id, email as e, name from t) x`,
+			fieldList: []db.SensitiveField{
+				{Name: "id", Sensitive: true, Mask: hash},
+				{Name: "e", Sensitive: true, Mask: email},
+				{Name: "name", Sensitive: false},
+			},
+		},
+		{
+			// The expressions fall back to the default mask.
+			statement: `select concat(id, name), lower(email) from t`,
+			fieldList: []db.SensitiveField{
+				{Name: "concat(id, name)", Sensitive: true},
+				{Name: "lower(email)", Sensitive: true},
+			},
+		},
+		{
+			// The union of the columns with different algorithms falls back to the default mask.
+			statement: `select id, name from t union select email, id from t`,
+			fieldList: []db.SensitiveField{
+				{Name: "id", Sensitive: true},
+				{Name: "name", Sensitive: true, Mask: hash},
+			},
+		},
+		{
+			// The CTE keeps the mask algorithm.
+			statement: `with c as (select id from t) select c.id from c`,
+			fieldList: []db.SensitiveField{
+				{Name: "id", Sensitive: true, Mask: hash},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := extractSensitiveField(db.MySQL, test.statement, defaultDatabase, schemaInfo)
+		require.NoError(t, err)
+		require.Equal(t, test.fieldList, res, test.statement)
+	}
+}
+
+func TestOracleExtractSensitiveField(t *testing.T) {
+	const (
+		defaultSchema = "ROOT"
+	)
+	hash := &db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeHash, Salt: "salt"}
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: defaultSchema,
+				TableList: []db.TableSchema{
+					{
+						Name: "T",
+						ColumnList: []db.ColumnInfo{
+							{Name: "A", Sensitive: true, Mask: hash},
+							{Name: "B", Sensitive: false},
+							{Name: "C", Sensitive: false},
+							{Name: "D", Sensitive: true},
+						},
+					},
+				},
+			},
+			{
+				Name: "HR",
+				TableList: []db.TableSchema{
+					{
+						Name: "EMP",
+						ColumnList: []db.ColumnInfo{
+							{Name: "ID", Sensitive: false},
+							{Name: "A", Sensitive: false},
+							{Name: "SALARY", Sensitive: true},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		statement string
+		fieldList []db.SensitiveField
+	}{
+		{
+			// Test for the asterisk and the column references.
+			statement: `select * from t`,
+			fieldList: []db.SensitiveField{
+				{Name: "A", Sensitive: true, Mask: hash},
+				{Name: "B", Sensitive: false},
+				{Name: "C", Sensitive: false},
+				{Name: "D", Sensitive: true},
+			},
+		},
+		{
+			// Test for the quoted identifiers, aliases and expressions.
+			statement: `SELECT "A" AS x, t.b y, a || c, upper(b), root.t.d FROM root.t;`,
+			fieldList: []db.SensitiveField{
+				{Name: "X", Sensitive: true, Mask: hash},
+				{Name: "Y", Sensitive: false},
+				{Name: "A||C", Sensitive: true},
+				{Name: "UPPER(B)", Sensitive: false},
+				{Name: "D", Sensitive: true},
+			},
+		},
+		{
+			// Test for the table in the other schema and the table alias.
+			statement: `SELECT e.*, t.a FROM hr.emp e JOIN t ON e.id = t.b`,
+			fieldList: []db.SensitiveField{
+				{Name: "ID", Sensitive: false},
+				{Name: "A", Sensitive: false},
+				{Name: "SALARY", Sensitive: true},
+				{Name: "A", Sensitive: true, Mask: hash},
+			},
+		},
+		{
+			// Test for JOIN USING, which merges the columns in USING.
+			statement: `SELECT * FROM hr.emp INNER JOIN t USING (a)`,
+			fieldList: []db.SensitiveField{
+				{Name: "ID", Sensitive: false},
+				{Name: "A", Sensitive: true, Mask: hash},
+				{Name: "SALARY", Sensitive: true},
+				{Name: "B", Sensitive: false},
+				{Name: "C", Sensitive: false},
+				{Name: "D", Sensitive: true},
+			},
+		},
+		{
+			// Test for the subquery in the FROM clause.
+			statement: `SELECT x.c, x.y FROM (SELECT b c, a y FROM t) x`,
+			fieldList: []db.SensitiveField{
+				{Name: "C", Sensitive: false},
+				{Name: "Y", Sensitive: true, Mask: hash},
+			},
+		},
+		{
+			// Test for the associated subquery in the select list.
+			statement: `SELECT b, (SELECT max(salary) FROM hr.emp WHERE emp.id = t.c) m, (SELECT max(t.d) FROM hr.emp) n FROM t`,
+			fieldList: []db.SensitiveField{
+				{Name: "B", Sensitive: false},
+				{Name: "M", Sensitive: true},
+				{Name: "N", Sensitive: true},
+			},
+		},
+		{
+			// Test for the set operations.
+			statement: `SELECT b, c FROM t UNION ALL SELECT id, salary FROM hr.emp MINUS SELECT 1, 2 FROM dual`,
+			fieldList: []db.SensitiveField{
+				{Name: "B", Sensitive: false},
+				{Name: "C", Sensitive: true},
+			},
+		},
+		{
+			// Test for the CTE with the column list.
+			statement: `WITH t1 (x, y) AS (SELECT a, b FROM t), t2 AS (SELECT y FROM t1) SELECT t1.x, t2.y FROM t1, t2`,
+			fieldList: []db.SensitiveField{
+				{Name: "X", Sensitive: true, Mask: hash},
+				{Name: "Y", Sensitive: false},
+			},
+		},
+		{
+			// Test for the recursive CTE, the sensitive field taints the column in the recursive part.
+			statement: `WITH r (n, m) AS (SELECT a, b FROM t UNION ALL SELECT m, n FROM r) SELECT * FROM r`,
+			fieldList: []db.SensitiveField{
+				{Name: "N", Sensitive: true, Mask: hash},
+				{Name: "M", Sensitive: true, Mask: hash},
+			},
+		},
+		{
+			// Test for no sensitive table.
+			statement: `SELECT sysdate FROM dual`,
+			fieldList: []db.SensitiveField{
+				{Name: "SYSDATE", Sensitive: false},
+			},
+		},
+		{
+			// Test for the system schema.
+			statement: `SELECT * FROM sys.all_tables`,
+			fieldList: nil,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := extractSensitiveField(db.Oracle, test.statement, defaultSchema, schemaInfo)
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.fieldList, res, test.statement)
+	}
+}
+
+func TestExtractTableLevelSensitiveField(t *testing.T) {
+	hashMask := &db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeHash}
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: "db",
+				TableList: []db.TableSchema{
+					{
+						Name: "Salary",
+						ColumnList: []db.ColumnInfo{
+							{Name: "id", Sensitive: false},
+							{Name: "amount", Sensitive: true, Mask: hashMask},
+							{Name: "ssn", Sensitive: true},
+						},
+					},
+					{
+						Name: "t",
+						ColumnList: []db.ColumnInfo{
+							{Name: "a", Sensitive: false},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		statement   string
+		columnNames []string
+		fieldList   []db.SensitiveField
+	}{
+		{
+			statement:   `SELECT a FROM t`,
+			columnNames: []string{"a"},
+			fieldList:   nil,
+		},
+		{
+			statement:   `SELECT * FROM dbo.salary`,
+			columnNames: []string{"id", "amount", "ssn"},
+			fieldList: []db.SensitiveField{
+				{Name: "id", Sensitive: false},
+				{Name: "amount", Sensitive: true, Mask: hashMask},
+				{Name: "ssn", Sensitive: true},
+			},
+		},
+		{
+			statement:   `SELECT s.[AMOUNT], t.a FROM [dbo].[SALARY] s JOIN t ON s.id = t.a`,
+			columnNames: []string{"AMOUNT", "a"},
+			fieldList: []db.SensitiveField{
+				{Name: "AMOUNT", Sensitive: true, Mask: hashMask},
+				{Name: "a", Sensitive: false},
+			},
+		},
+		{
+			// The sensitive column is selected under an alias.
+			statement:   `SELECT "SSN" AS "ID", "ID" AS x FROM "DB"."PUBLIC"."SALARY"`,
+			columnNames: []string{"ID", "X"},
+			fieldList: []db.SensitiveField{
+				{Name: "ID", Sensitive: true},
+				{Name: "X", Sensitive: true},
+			},
+		},
+		{
+			statement:   `SELECT COUNT(*) AS cnt FROM salary`,
+			columnNames: []string{"cnt"},
+			fieldList: []db.SensitiveField{
+				{Name: "cnt", Sensitive: true},
+			},
+		},
+		{
+			statement:   `SELECT a FROM salary_history`,
+			columnNames: []string{"a"},
+			fieldList:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		res := extractTableLevelSensitiveField(test.statement, schemaInfo, test.columnNames)
+		require.Equal(t, test.fieldList, res, test.statement)
+	}
+}
@@ -44,7 +44,7 @@ func extractSensitiveField(dbType db.Type, statement string, currentDatabase str
 			schemaInfo:      schemaInfo,
 		}
 		return extractor.extractMySQLSensitiveField(statement)
-	case db.Postgres:
+	case db.Postgres, db.Redshift:
 		extractor := &sensitiveFieldExtractor{
 			schemaInfo: schemaInfo,
 		}
@@ -59,11 +59,107 @@ func extractSensitiveField(dbType db.Type, statement string, currentDatabase str
 			return nil, err
 		}
 		return result, nil
+	case db.Oracle:
+		extractor := &sensitiveFieldExtractor{
+			currentDatabase: currentDatabase,
+			schemaInfo:      schemaInfo,
+		}
+		result, err := extractor.extractOracleSensitiveField(statement)
+		if err != nil {
+			tableNotFound := regexp.MustCompile("^Table \"(.*)\"\\.\"(.*)\" not found$")
+			content := tableNotFound.FindStringSubmatch(err.Error())
+			if len(content) == 3 && isOracleSystemSchema(content[1]) {
+				// skip for system schema
+				return nil, nil
+			}
+			return nil, err
+		}
+		return result, nil
 	default:
 		return nil, nil
 	}
 }
 
+// sensitiveTableReferenceIdentifierRegexp matches the bare and quoted identifiers in the statement.
+var sensitiveTableReferenceIdentifierRegexp = regexp.MustCompile(`\[[^\]]+\]|"[^"]+"|[A-Za-z0-9_$#@]+`)
+
+// extractTableLevelSensitiveField returns the sensitive fields of the query result for the engines without a parser
+// to extract the column lineage, i.e. MSSQL and Snowflake.
+// The tables are taken as referenced if any identifier in the statement matches the table name case-insensitively,
+// and the result columns are matched to the columns of the referenced tables by name:
+//  1. the result column matching a sensitive column is masked with the mask of the column.
+//  2. the result column matching only non-sensitive columns is not masked, unless the statement mentions a sensitive column
+//     that is not in the result under its own name, because the sensitive column may be selected under an alias.
+//  3. the other result columns, e.g. the aliases and the expressions, are masked with the default mask.
+//
+// Views and tables of other databases are not in the schema info, so the statements only referencing them are not masked.
+func extractTableLevelSensitiveField(statement string, schemaInfo *db.SensitiveSchemaInfo, columnNames []string) []db.SensitiveField {
+	if schemaInfo == nil {
+		return nil
+	}
+	identifiers := make(map[string]bool)
+	for _, identifier := range sensitiveTableReferenceIdentifierRegexp.FindAllString(statement, -1) {
+		if strings.HasPrefix(identifier, "[") || strings.HasPrefix(identifier, `"`) {
+			identifier = identifier[1 : len(identifier)-1]
+		}
+		identifiers[strings.ToLower(identifier)] = true
+	}
+
+	// columnMap maps the lower-case column name to the columns of the referenced tables.
+	columnMap := make(map[string][]db.ColumnInfo)
+	hasSensitiveColumn := false
+	for _, database := range schemaInfo.DatabaseList {
+		for _, table := range database.TableList {
+			if !identifiers[strings.ToLower(table.Name)] {
+				continue
+			}
+			for _, column := range table.ColumnList {
+				name := strings.ToLower(column.Name)
+				columnMap[name] = append(columnMap[name], column)
+				if column.Sensitive {
+					hasSensitiveColumn = true
+				}
+			}
+		}
+	}
+	if !hasSensitiveColumn {
+		return nil
+	}
+
+	resultColumns := make(map[string]bool)
+	for _, name := range columnNames {
+		resultColumns[strings.ToLower(name)] = true
+	}
+	// aliasedSensitiveColumn is true if the statement mentions a sensitive column that is not in the result under its own name.
+	aliasedSensitiveColumn := false
+	for name, columnList := range columnMap {
+		for _, column := range columnList {
+			if column.Sensitive && identifiers[name] && !resultColumns[name] {
+				aliasedSensitiveColumn = true
+			}
+		}
+	}
+
+	var result []db.SensitiveField
+	for _, name := range columnNames {
+		columnList, ok := columnMap[strings.ToLower(name)]
+		if !ok {
+			result = append(result, db.SensitiveField{Name: name, Sensitive: true})
+			continue
+		}
+		field := db.SensitiveField{Name: name, Sensitive: aliasedSensitiveColumn}
+		for _, column := range columnList {
+			if column.Sensitive {
+				field.Sensitive = true
+				field.Mask = column.Mask
+				break
+			}
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
 func isPostgreSQLSystemSchema(schema string) bool {
 	switch schema {
 	case "information_schema", "pg_catalog":
@@ -101,6 +197,7 @@ func (extractor *sensitiveFieldExtractor) extractPostgreSQLSensitiveField(statem
 		result = append(result, db.SensitiveField{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -150,8 +247,8 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 		// Natural Join will merge the same column name field.
 		for _, field := range leftField {
 			// Merge the sensitive attribute for the same column name field.
-			if rField, exists := rightFieldMap[field.name]; exists && rField.sensitive {
-				field.sensitive = true
+			if rField, exists := rightFieldMap[field.name]; exists {
+				field = mergeSensitiveField(field, rField)
 			}
 			result = append(result, field)
 		}
@@ -181,8 +278,8 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 				_, existsInUsingMap := usingMap[field.name]
 				rField, existsInRightField := rightFieldMap[field.name]
 				// Merge the sensitive attribute for the column name field in USING.
-				if existsInUsingMap && existsInRightField && rField.sensitive {
-					field.sensitive = true
+				if existsInUsingMap && existsInRightField {
+					field = mergeSensitiveField(field, rField)
 				}
 				result = append(result, field)
 			}
@@ -227,6 +324,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeSubselect(node *pgquery.
 				table:     fmt.Sprintf("public.%s", aliasName),
 				name:      columnName,
 				sensitive: item.sensitive,
+				mask:      item.mask,
 			})
 		}
 		return result, nil
@@ -272,6 +370,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:      column.Name,
 				table:     tableSchema.Name,
 				sensitive: column.Sensitive,
+				mask:      column.Mask,
 			})
 		}
 	} else {
@@ -293,6 +392,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:      columnName,
 				table:     tableName,
 				sensitive: column.Sensitive,
+				mask:      column.Mask,
 			})
 		}
 	}
@@ -357,6 +457,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:      field.name,
 				Sensitive: field.sensitive,
+				Mask:      field.mask,
 			})
 		}
 
@@ -385,9 +486,12 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 
 			changed := false
 			for i, field := range fieldList {
-				if field.sensitive && !cteInfo.ColumnList[i].Sensitive {
+				column := &cteInfo.ColumnList[i]
+				merged := mergeSensitiveField(fieldInfo{sensitive: column.Sensitive, mask: column.Mask}, field)
+				if merged.sensitive != column.Sensitive || !sameMask(merged.mask, column.Mask) {
 					changed = true
-					cteInfo.ColumnList[i].Sensitive = true
+					column.Sensitive = merged.sensitive
+					column.Mask = merged.mask
 				}
 			}
 
@@ -432,6 +536,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractNonRecursiveCTE(node *pgquery
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 
@@ -506,10 +611,12 @@ func (extractor *sensitiveFieldExtractor) pgExtractSelect(node *pgquery.Node_Sel
 		}
 		var result []fieldInfo
 		for i, field := range leftField {
+			merged := mergeSensitiveField(field, rightField[i])
 			result = append(result, fieldInfo{
 				name:      field.name,
 				table:     field.table,
-				sensitive: field.sensitive || rightField[i].sensitive,
+				sensitive: merged.sensitive,
+				mask:      merged.mask,
 			})
 		}
 		return result, nil
@@ -568,9 +675,14 @@ func (extractor *sensitiveFieldExtractor) pgExtractSelect(node *pgquery.Node_Sel
 				if resTarget.ResTarget.Name != "" {
 					columnName = resTarget.ResTarget.Name
 				}
+				var mask *db.SensitiveDataMask
+				if field, ok := extractor.pgFindField(pgNormalizeColumnName(columnRef)); ok && sensitive {
+					mask = field.mask
+				}
 				result = append(result, fieldInfo{
 					name:      columnName,
 					sensitive: sensitive,
+					mask:      mask,
 				})
 			}
 		default:
@@ -750,6 +862,11 @@ func pgNormalizeColumnName(columnName *ast.ColumnNameDef) (string, string) {
 }
 
 func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string, fieldName string) bool {
+	field, ok := extractor.pgFindField(tableName, fieldName)
+	return ok && field.sensitive
+}
+
+func (extractor *sensitiveFieldExtractor) pgFindField(tableName string, fieldName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -768,7 +885,7 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameTable && sameField {
-			return field.sensitive
+			return field, true
 		}
 	}
 
@@ -776,11 +893,11 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameTable && sameField {
-			return field.sensitive
+			return field, true
 		}
 	}
 
-	return false
+	return fieldInfo{}, false
 }
 
 func (extractor *sensitiveFieldExtractor) pgExtractColumnRefFromExpressionNode(in *pgquery.Node) (bool, error) {
@@ -941,6 +1058,7 @@ func (extractor *sensitiveFieldExtractor) extractMySQLSensitiveField(statement s
 		result = append(result, db.SensitiveField{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -951,6 +1069,29 @@ type fieldInfo struct {
 	table     string
 	database  string
 	sensitive bool
+	// mask is the mask algorithm of the sensitive field, nil means the default mask.
+	mask *db.SensitiveDataMask
+}
+
+// mergeSensitiveField merges the sensitive attribute of the other field sharing the same output column into the field.
+// The merged field falls back to the default mask if the two fields are masked by different algorithms.
+func mergeSensitiveField(field fieldInfo, other fieldInfo) fieldInfo {
+	switch {
+	case !other.sensitive:
+	case !field.sensitive:
+		field.sensitive = true
+		field.mask = other.mask
+	case !sameMask(field.mask, other.mask):
+		field.mask = nil
+	}
+	return field
+}
+
+func sameMask(a, b *db.SensitiveDataMask) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func (extractor *sensitiveFieldExtractor) extractNode(in tidbast.Node) ([]fieldInfo, error) {
@@ -1002,9 +1143,7 @@ func (extractor *sensitiveFieldExtractor) extractSetOpr(node *tidbast.SetOprStmt
 				return nil, errors.Errorf("The used SELECT statements have a different number of columns")
 			}
 			for index := 0; index < len(result); index++ {
-				if fieldList[index].sensitive {
-					result[index].sensitive = true
-				}
+				result[index] = mergeSensitiveField(result[index], fieldList[index])
 			}
 		}
 	}
@@ -1082,6 +1221,7 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:      field.name,
 				Sensitive: field.sensitive,
+				Mask:      field.mask,
 			})
 		}
 
@@ -1115,9 +1255,12 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 
 			changed := false
 			for i, field := range fieldList {
-				if field.sensitive && !cteInfo.ColumnList[i].Sensitive {
+				column := &cteInfo.ColumnList[i]
+				merged := mergeSensitiveField(fieldInfo{sensitive: column.Sensitive, mask: column.Mask}, field)
+				if merged.sensitive != column.Sensitive || !sameMask(merged.mask, column.Mask) {
 					changed = true
-					cteInfo.ColumnList[i].Sensitive = true
+					column.Sensitive = merged.sensitive
+					column.Mask = merged.mask
 				}
 			}
 
@@ -1154,6 +1297,7 @@ func (extractor *sensitiveFieldExtractor) extractNonRecursiveCTE(node *tidbast.C
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -1215,12 +1359,19 @@ func (extractor *sensitiveFieldExtractor) extractSelect(node *tidbast.SelectStmt
 				if err != nil {
 					return nil, err
 				}
+				var mask *db.SensitiveDataMask
+				if column, ok := field.Expr.(*tidbast.ColumnNameExpr); ok && sensitive {
+					if fromField, ok := extractor.findField(column.Name.Schema.O, column.Name.Table.O, column.Name.Name.O); ok {
+						mask = fromField.mask
+					}
+				}
 				fieldName := extractFieldName(field)
 				result = append(result, fieldInfo{
 					database:  "",
 					table:     "",
 					name:      fieldName,
 					sensitive: sensitive,
+					mask:      mask,
 				})
 			}
 		}
@@ -1244,6 +1395,11 @@ func extractFieldName(in *tidbast.SelectField) string {
 }
 
 func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName string, tableName string, fieldName string) bool {
+	field, ok := extractor.findField(databaseName, tableName, fieldName)
+	return ok && field.sensitive
+}
+
+func (extractor *sensitiveFieldExtractor) findField(databaseName string, tableName string, fieldName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -1263,7 +1419,7 @@ func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName strin
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.sensitive
+			return field, true
 		}
 	}
 
@@ -1272,11 +1428,11 @@ func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName strin
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.sensitive
+			return field, true
 		}
 	}
 
-	return false
+	return fieldInfo{}, false
 }
 
 func (extractor *sensitiveFieldExtractor) extractColumnFromExprNode(in tidbast.ExprNode) (sensitive bool, err error) {
@@ -1398,6 +1554,7 @@ func (extractor *sensitiveFieldExtractor) extractTableSource(node *tidbast.Table
 				table:     node.AsName.O,
 				database:  field.database,
 				sensitive: field.sensitive,
+				mask:      field.mask,
 			})
 		}
 	} else {
@@ -1452,6 +1609,7 @@ func (extractor *sensitiveFieldExtractor) extractTableName(node *tidbast.TableNa
 			table:     tableSchema.Name,
 			database:  databaseName,
 			sensitive: column.Sensitive,
+			mask:      column.Mask,
 		})
 	}
 	return res, nil
@@ -1489,8 +1647,8 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 		// Natural Join will merge the same column name field.
 		for _, field := range leftField {
 			// Merge the sensitive attribute for the same column name field.
-			if rField, exists := rightFieldMap[strings.ToLower(field.name)]; exists && rField.sensitive {
-				field.sensitive = true
+			if rField, exists := rightFieldMap[strings.ToLower(field.name)]; exists {
+				field = mergeSensitiveField(field, rField)
 			}
 			result = append(result, field)
 		}
@@ -1513,8 +1671,8 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 				_, existsInUsingMap := usingMap[strings.ToLower(field.name)]
 				rField, existsInRightField := rightFieldMap[strings.ToLower(field.name)]
 				// Merge the sensitive attribute for the column name field in USING.
-				if existsInUsingMap && existsInRightField && rField.sensitive {
-					field.sensitive = true
+				if existsInUsingMap && existsInRightField {
+					field = mergeSensitiveField(field, rField)
 				}
 				result = append(result, field)
 			}
@@ -0,0 +1,585 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+	"github.com/pkg/errors"
+
+	plsql "github.com/bytebase/plsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+const (
+	// plsqlDualTable is the special one-row table, which is the public synonym of SYS.DUAL.
+	plsqlDualTable = "DUAL"
+)
+
+// isOracleSystemSchema returns true if the schema is an Oracle-maintained schema.
+// These schemas are excluded by the schema sync, so that the sensitive field extractor cannot find their tables.
+func isOracleSystemSchema(schema string) bool {
+	switch schema {
+	case "SYS", "SYSTEM", "PUBLIC", "XDB", "MDSYS", "CTXSYS", "ORDSYS", "OLAPSYS", "WMSYS", "LBACSYS", "DVSYS", "AUDSYS", "OUTLN", "DBSNMP", "APPQOSSYS", "GSMADMIN_INTERNAL":
+		return true
+	}
+	return false
+}
+
+// extractOracleSensitiveField extracts the sensitive fields of the Oracle query.
+// The extractor takes the Oracle schema as the database, and the current database is the current schema.
+func (extractor *sensitiveFieldExtractor) extractOracleSensitiveField(statement string) ([]db.SensitiveField, error) {
+	statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+	tree, err := parser.ParsePLSQL(statement + ";")
+	if err != nil {
+		return nil, err
+	}
+	script, ok := tree.(*plsql.Sql_scriptContext)
+	if !ok {
+		return nil, errors.Errorf("expect Sql_scriptContext but found %T", tree)
+	}
+	if len(script.AllUnit_statement()) != 1 {
+		return nil, errors.Errorf("expect one statement but found %d", len(script.AllUnit_statement()))
+	}
+	dml := script.Unit_statement(0).Data_manipulation_language_statements()
+	if dml == nil {
+		return nil, errors.Errorf("expect a query statement but found %q", statement)
+	}
+	if dml.Explain_statement() != nil {
+		// Skip the EXPLAIN PLAN statement.
+		return nil, nil
+	}
+	if dml.Select_statement() == nil {
+		return nil, errors.Errorf("expect a query statement but found %q", statement)
+	}
+
+	fieldList, err := extractor.plsqlExtractSelect(dml.Select_statement())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []db.SensitiveField{}
+	for _, field := range fieldList {
+		result = append(result, db.SensitiveField{
+			Name:      field.name,
+			Sensitive: field.sensitive,
+			Mask:      field.mask,
+		})
+	}
+	return result, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractSelect(ctx plsql.ISelect_statementContext) ([]fieldInfo, error) {
+	selectOnly := ctx.Select_only_statement()
+	if factoring := selectOnly.Subquery_factoring_clause(); factoring != nil {
+		cteOuterLength := len(extractor.cteOuterSchemaInfo)
+		defer func() {
+			extractor.cteOuterSchemaInfo = extractor.cteOuterSchemaInfo[:cteOuterLength]
+		}()
+		for _, element := range factoring.AllFactoring_element() {
+			cteTable, err := extractor.plsqlExtractFactoringElement(element)
+			if err != nil {
+				return nil, err
+			}
+			extractor.cteOuterSchemaInfo = append(extractor.cteOuterSchemaInfo, cteTable)
+		}
+	}
+	return extractor.plsqlExtractSubquery(selectOnly.Subquery())
+}
+
+// plsqlExtractFactoringElement extracts the CTE in the WITH clause.
+// Oracle has no RECURSIVE keyword, the CTE referring to itself in the set operation parts is the recursive one.
+// So we take the first part as the initial result, and merge the other parts with the temporary CTE table until the result is stable.
+func (extractor *sensitiveFieldExtractor) plsqlExtractFactoringElement(ctx plsql.IFactoring_elementContext) (db.TableSchema, error) {
+	cteName := plsqlNormalizeIdentifier(ctx.Query_name().Identifier())
+	var columnNameList []string
+	if ctx.Paren_column_list() != nil {
+		for _, column := range ctx.Paren_column_list().Column_list().AllColumn_name() {
+			columnNameList = append(columnNameList, plsqlNormalizeIdentifier(column.Identifier()))
+		}
+	}
+
+	subquery := ctx.Subquery()
+	initialFieldList, err := extractor.plsqlExtractSubqueryBasicElements(subquery.Subquery_basic_elements())
+	if err != nil {
+		return db.TableSchema{}, err
+	}
+	if len(columnNameList) > 0 {
+		if len(columnNameList) != len(initialFieldList) {
+			// The error content comes from Oracle.
+			return db.TableSchema{}, errors.Errorf("ORA-32038: number of WITH clause column names does not match number of elements in select list")
+		}
+		for i := range initialFieldList {
+			initialFieldList[i].name = columnNameList[i]
+		}
+	}
+
+	cteTable := db.TableSchema{
+		Name:       cteName,
+		ColumnList: []db.ColumnInfo{},
+	}
+	for _, field := range initialFieldList {
+		cteTable.ColumnList = append(cteTable.ColumnList, db.ColumnInfo{
+			Name:      field.name,
+			Sensitive: field.sensitive,
+			Mask:      field.mask,
+		})
+	}
+	if len(subquery.AllSubquery_operation_part()) == 0 {
+		return cteTable, nil
+	}
+
+	for {
+		// The temporary CTE table is only visible in the CTE itself.
+		cteOuterLength := len(extractor.cteOuterSchemaInfo)
+		extractor.cteOuterSchemaInfo = append(extractor.cteOuterSchemaInfo, cteTable)
+		changed := false
+		for _, part := range subquery.AllSubquery_operation_part() {
+			fieldList, err := extractor.plsqlExtractSubqueryBasicElements(part.Subquery_basic_elements())
+			if err != nil {
+				extractor.cteOuterSchemaInfo = extractor.cteOuterSchemaInfo[:cteOuterLength]
+				return db.TableSchema{}, err
+			}
+			if len(fieldList) != len(cteTable.ColumnList) {
+				extractor.cteOuterSchemaInfo = extractor.cteOuterSchemaInfo[:cteOuterLength]
+				// The error content comes from Oracle.
+				return db.TableSchema{}, errors.Errorf("ORA-01789: query block has incorrect number of result columns")
+			}
+			for i, field := range fieldList {
+				column := cteTable.ColumnList[i]
+				merged := mergeSensitiveField(fieldInfo{sensitive: column.Sensitive, mask: column.Mask}, field)
+				if merged.sensitive != column.Sensitive || !sameMask(merged.mask, column.Mask) {
+					changed = true
+					cteTable.ColumnList[i].Sensitive = merged.sensitive
+					cteTable.ColumnList[i].Mask = merged.mask
+				}
+			}
+		}
+		extractor.cteOuterSchemaInfo = extractor.cteOuterSchemaInfo[:cteOuterLength]
+		if !changed {
+			break
+		}
+	}
+	return cteTable, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractSubquery(ctx plsql.ISubqueryContext) ([]fieldInfo, error) {
+	result, err := extractor.plsqlExtractSubqueryBasicElements(ctx.Subquery_basic_elements())
+	if err != nil {
+		return nil, err
+	}
+	return extractor.plsqlMergeSubqueryOperationPart(result, ctx.AllSubquery_operation_part())
+}
+
+// plsqlMergeSubqueryOperationPart merges the fields of the UNION, INTERSECT and MINUS parts into the result.
+func (extractor *sensitiveFieldExtractor) plsqlMergeSubqueryOperationPart(result []fieldInfo, partList []plsql.ISubquery_operation_partContext) ([]fieldInfo, error) {
+	for _, part := range partList {
+		fieldList, err := extractor.plsqlExtractSubqueryBasicElements(part.Subquery_basic_elements())
+		if err != nil {
+			return nil, err
+		}
+		if len(result) != len(fieldList) {
+			// The error content comes from Oracle.
+			return nil, errors.Errorf("ORA-01789: query block has incorrect number of result columns")
+		}
+		for i := range result {
+			result[i] = mergeSensitiveField(result[i], fieldList[i])
+		}
+	}
+	return result, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractSubqueryBasicElements(ctx plsql.ISubquery_basic_elementsContext) ([]fieldInfo, error) {
+	if ctx.Query_block() != nil {
+		return extractor.plsqlExtractQueryBlock(ctx.Query_block())
+	}
+	return extractor.plsqlExtractSubquery(ctx.Subquery())
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractQueryBlock(ctx plsql.IQuery_blockContext) ([]fieldInfo, error) {
+	var fromFieldList []fieldInfo
+	if ctx.From_clause() != nil {
+		for _, tableRef := range ctx.From_clause().Table_ref_list().AllTable_ref() {
+			fieldList, err := extractor.plsqlExtractTableRef(tableRef)
+			if err != nil {
+				return nil, err
+			}
+			fromFieldList = append(fromFieldList, fieldList...)
+		}
+		extractor.fromFieldList = fromFieldList
+		defer func() {
+			extractor.fromFieldList = nil
+		}()
+	}
+
+	selectedList := ctx.Selected_list()
+	if selectedList.ASTERISK() != nil {
+		return fromFieldList, nil
+	}
+
+	var result []fieldInfo
+	for _, element := range selectedList.AllSelect_list_elements() {
+		if element.ASTERISK() != nil {
+			schemaName, tableName := plsqlNormalizeTableviewName(element.Tableview_name())
+			for _, fromField := range fromFieldList {
+				sameSchema := (schemaName == "" || schemaName == fromField.database)
+				sameTable := (tableName == fromField.table)
+				if sameSchema && sameTable {
+					result = append(result, fromField)
+				}
+			}
+			continue
+		}
+
+		sensitive, err := extractor.plsqlExtractColumnFromExpression(element.Expression())
+		if err != nil {
+			return nil, err
+		}
+		fieldName := strings.ToUpper(element.Expression().GetText())
+		var mask *db.SensitiveDataMask
+		if schemaName, tableName, columnName, ok := plsqlExtractColumnRef(element.Expression()); ok {
+			fieldName = columnName
+			if fromField, ok := extractor.plsqlFindField(schemaName, tableName, columnName); ok && sensitive {
+				mask = fromField.mask
+			}
+		}
+		if alias := element.Column_alias(); alias != nil {
+			if alias.Identifier() != nil {
+				fieldName = plsqlNormalizeIdentifier(alias.Identifier())
+			} else if alias.Quoted_string() != nil {
+				fieldName = strings.Trim(alias.Quoted_string().GetText(), "'")
+			}
+		}
+		result = append(result, fieldInfo{
+			name:      fieldName,
+			sensitive: sensitive,
+			mask:      mask,
+		})
+	}
+	return result, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractTableRef(ctx plsql.ITable_refContext) ([]fieldInfo, error) {
+	result, err := extractor.plsqlExtractTableRefAux(ctx.Table_ref_aux())
+	if err != nil {
+		return nil, err
+	}
+	for _, join := range ctx.AllJoin_clause() {
+		rightField, err := extractor.plsqlExtractTableRefAux(join.Table_ref_aux())
+		if err != nil {
+			return nil, err
+		}
+		result = plsqlMergeJoinField(join, result, rightField)
+	}
+	return result, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractTableRefAux(ctx plsql.ITable_ref_auxContext) ([]fieldInfo, error) {
+	var fieldList []fieldInfo
+	var err error
+	switch internal := ctx.Table_ref_aux_internal().(type) {
+	case *plsql.Table_ref_aux_internal_oneContext:
+		fieldList, err = extractor.plsqlExtractDMLTableExpression(internal.Dml_table_expression_clause())
+	case *plsql.Table_ref_aux_internal_twoContext:
+		fieldList, err = extractor.plsqlExtractTableRef(internal.Table_ref())
+		if err == nil {
+			fieldList, err = extractor.plsqlMergeSubqueryOperationPart(fieldList, internal.AllSubquery_operation_part())
+		}
+	case *plsql.Table_ref_aux_internal_threeContext:
+		fieldList, err = extractor.plsqlExtractDMLTableExpression(internal.Dml_table_expression_clause())
+	default:
+		return nil, errors.Errorf("unsupported table reference %q", ctx.GetText())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Table_alias() == nil {
+		return fieldList, nil
+	}
+	var aliasName string
+	if ctx.Table_alias().Identifier() != nil {
+		aliasName = plsqlNormalizeIdentifier(ctx.Table_alias().Identifier())
+	} else {
+		aliasName = strings.Trim(ctx.Table_alias().Quoted_string().GetText(), "'")
+	}
+	var result []fieldInfo
+	for _, field := range fieldList {
+		result = append(result, fieldInfo{
+			name:      field.name,
+			table:     aliasName,
+			sensitive: field.sensitive,
+			mask:      field.mask,
+		})
+	}
+	return result, nil
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlExtractDMLTableExpression(ctx plsql.IDml_table_expression_clauseContext) ([]fieldInfo, error) {
+	if ctx.Select_statement() != nil {
+		// The subquery in the FROM clause cannot access the fields of the outer query block.
+		subqueryExtractor := &sensitiveFieldExtractor{
+			currentDatabase:    extractor.currentDatabase,
+			schemaInfo:         extractor.schemaInfo,
+			outerSchemaInfo:    extractor.outerSchemaInfo,
+			cteOuterSchemaInfo: extractor.cteOuterSchemaInfo,
+		}
+		fieldList, err := subqueryExtractor.plsqlExtractSelect(ctx.Select_statement())
+		if err != nil {
+			return nil, err
+		}
+		// The fields of the subquery without alias cannot be referred by the table name.
+		var result []fieldInfo
+		for _, field := range fieldList {
+			result = append(result, fieldInfo{
+				name:      field.name,
+				sensitive: field.sensitive,
+				mask:      field.mask,
+			})
+		}
+		return result, nil
+	}
+	if ctx.Tableview_name() != nil {
+		schemaName, tableName := plsqlNormalizeTableviewName(ctx.Tableview_name())
+		schemaName, tableSchema, err := extractor.plsqlFindTableSchema(schemaName, tableName)
+		if err != nil {
+			return nil, err
+		}
+		var result []fieldInfo
+		for _, column := range tableSchema.ColumnList {
+			result = append(result, fieldInfo{
+				name:      column.Name,
+				table:     tableSchema.Name,
+				database:  schemaName,
+				sensitive: column.Sensitive,
+				mask:      column.Mask,
+			})
+		}
+		return result, nil
+	}
+	return nil, errors.Errorf("unsupported table expression %q", ctx.GetText())
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlFindTableSchema(schemaName string, tableName string) (string, db.TableSchema, error) {
+	// The closer CTE shadows the outer one with the same name, so we loop the slice in reversed order.
+	for i := len(extractor.cteOuterSchemaInfo) - 1; i >= 0; i-- {
+		table := extractor.cteOuterSchemaInfo[i]
+		if schemaName == "" && table.Name == tableName {
+			return "", table, nil
+		}
+	}
+
+	explicitSchema := schemaName
+	if explicitSchema == "" {
+		explicitSchema = extractor.currentDatabase
+	}
+	for _, schema := range extractor.schemaInfo.DatabaseList {
+		if schema.Name != explicitSchema {
+			continue
+		}
+		for _, table := range schema.TableList {
+			if table.Name == tableName {
+				return explicitSchema, table, nil
+			}
+		}
+	}
+
+	if (schemaName == "" || schemaName == "SYS") && tableName == plsqlDualTable {
+		return "SYS", db.TableSchema{
+			Name:       plsqlDualTable,
+			ColumnList: []db.ColumnInfo{{Name: "DUMMY"}},
+		}, nil
+	}
+	return "", db.TableSchema{}, errors.Errorf("Table %q.%q not found", explicitSchema, tableName)
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlCheckFieldSensitive(schemaName string, tableName string, fieldName string) bool {
+	field, ok := extractor.plsqlFindField(schemaName, tableName, fieldName)
+	return ok && field.sensitive
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlFindField(schemaName string, tableName string, fieldName string) (fieldInfo, bool) {
+	// The expression in the sub-query refers to the closer table with the same name, so we loop the slice in reversed order.
+	for i := len(extractor.outerSchemaInfo) - 1; i >= 0; i-- {
+		field := extractor.outerSchemaInfo[i]
+		sameSchema := (schemaName == "" || schemaName == field.database)
+		sameTable := (tableName == "" || tableName == field.table)
+		if sameSchema && sameTable && fieldName == field.name {
+			return field, true
+		}
+	}
+
+	for _, field := range extractor.fromFieldList {
+		sameSchema := (schemaName == "" || schemaName == field.database)
+		sameTable := (tableName == "" || tableName == field.table)
+		if sameSchema && sameTable && fieldName == field.name {
+			return field, true
+		}
+	}
+
+	return fieldInfo{}, false
+}
+
+// plsqlExtractColumnFromExpression returns true if the expression refers to any sensitive field.
+// The PL/SQL grammar has too many expression rules to list, so we walk the parse tree and check the column references and the sub-queries.
+func (extractor *sensitiveFieldExtractor) plsqlExtractColumnFromExpression(ctx antlr.Tree) (bool, error) {
+	if ctx == nil {
+		return false, nil
+	}
+
+	switch node := ctx.(type) {
+	case *plsql.General_elementContext, *plsql.Variable_nameContext:
+		if schemaName, tableName, columnName, ok := plsqlExtractElementColumn(node); ok {
+			return extractor.plsqlCheckFieldSensitive(schemaName, tableName, columnName), nil
+		}
+	case *plsql.SubqueryContext:
+		// The associated subquery can access the fields of the outer query block.
+		// The reason for new extractor is that we still need the current fromFieldList, overriding it is not expected.
+		subqueryExtractor := &sensitiveFieldExtractor{
+			currentDatabase:    extractor.currentDatabase,
+			schemaInfo:         extractor.schemaInfo,
+			outerSchemaInfo:    append(extractor.outerSchemaInfo, extractor.fromFieldList...),
+			cteOuterSchemaInfo: extractor.cteOuterSchemaInfo,
+		}
+		fieldList, err := subqueryExtractor.plsqlExtractSubquery(node)
+		if err != nil {
+			return false, err
+		}
+		for _, field := range fieldList {
+			if field.sensitive {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	for i := 0; i < ctx.GetChildCount(); i++ {
+		sensitive, err := extractor.plsqlExtractColumnFromExpression(ctx.GetChild(i))
+		if err != nil {
+			return false, err
+		}
+		if sensitive {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// plsqlExtractColumnRef returns the column reference if the expression is a bare column, such as "SCHEMA"."TABLE"."COLUMN".
+func plsqlExtractColumnRef(ctx antlr.Tree) (string, string, string, bool) {
+	for ctx != nil {
+		switch ctx.(type) {
+		case *plsql.General_elementContext, *plsql.Variable_nameContext:
+			return plsqlExtractElementColumn(ctx)
+		}
+		if ctx.GetChildCount() != 1 {
+			return "", "", "", false
+		}
+		ctx = ctx.GetChild(0)
+	}
+	return "", "", "", false
+}
+
+// plsqlExtractElementColumn returns the schema, table and column name of the general element or the variable name.
+// The single column name, such as "A" and "T.A", is parsed as the variable name.
+// It returns false if the element is a function call, a bind variable or a database link reference.
+func plsqlExtractElementColumn(ctx antlr.Tree) (string, string, string, bool) {
+	var nameList []string
+	switch node := ctx.(type) {
+	case *plsql.General_elementContext:
+		for _, part := range node.AllGeneral_element_part() {
+			if part.Function_argument() != nil || part.AT_SIGN() != nil {
+				return "", "", "", false
+			}
+			for _, idExpression := range part.AllId_expression() {
+				nameList = append(nameList, plsqlNormalizeIDExpression(idExpression))
+			}
+		}
+	case *plsql.Variable_nameContext:
+		if node.Bind_variable() != nil {
+			return "", "", "", false
+		}
+		for _, idExpression := range node.AllId_expression() {
+			nameList = append(nameList, plsqlNormalizeIDExpression(idExpression))
+		}
+	}
+	switch len(nameList) {
+	case 1:
+		return "", "", nameList[0], true
+	case 2:
+		return "", nameList[0], nameList[1], true
+	case 3:
+		return nameList[0], nameList[1], nameList[2], true
+	}
+	return "", "", "", false
+}
+
+func plsqlMergeJoinField(join plsql.IJoin_clauseContext, leftField []fieldInfo, rightField []fieldInfo) []fieldInfo {
+	leftFieldMap := make(map[string]bool)
+	rightFieldMap := make(map[string]fieldInfo)
+	for _, field := range leftField {
+		leftFieldMap[field.name] = true
+	}
+	for _, field := range rightField {
+		rightFieldMap[field.name] = field
+	}
+
+	// NATURAL JOIN merges all the same name columns, and ... JOIN ... USING (...) merges the columns in USING.
+	mergedMap := make(map[string]bool)
+	if join.NATURAL() != nil {
+		for _, field := range leftField {
+			if _, exists := rightFieldMap[field.name]; exists {
+				mergedMap[field.name] = true
+			}
+		}
+	}
+	for _, using := range join.AllJoin_using_part() {
+		for _, column := range using.Paren_column_list().Column_list().AllColumn_name() {
+			mergedMap[plsqlNormalizeIdentifier(column.Identifier())] = true
+		}
+	}
+
+	var result []fieldInfo
+	for _, field := range leftField {
+		if rField, exists := rightFieldMap[field.name]; exists && mergedMap[field.name] {
+			field = mergeSensitiveField(field, rField)
+		}
+		result = append(result, field)
+	}
+	for _, field := range rightField {
+		if mergedMap[field.name] && leftFieldMap[field.name] {
+			continue
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+func plsqlNormalizeTableviewName(ctx plsql.ITableview_nameContext) (string, string) {
+	if ctx.Id_expression() != nil {
+		return plsqlNormalizeIdentifier(ctx.Identifier()), plsqlNormalizeIDExpression(ctx.Id_expression())
+	}
+	return "", plsqlNormalizeIdentifier(ctx.Identifier())
+}
+
+func plsqlNormalizeIdentifier(ctx plsql.IIdentifierContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return plsqlNormalizeIDExpression(ctx.Id_expression())
+}
+
+// plsqlNormalizeIDExpression returns the upper case for the regular identifier, and the content in quotes for the delimited identifier.
+func plsqlNormalizeIDExpression(ctx plsql.IId_expressionContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if regularID := ctx.Regular_id(); regularID != nil {
+		return strings.ToUpper(regularID.GetText())
+	}
+	if delimitedID := ctx.DELIMITED_ID(); delimitedID != nil {
+		return strings.Trim(delimitedID.GetText(), "\"")
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+const (
+	// defaultMaskString is the string to replace the sensitive data with the default mask.
+	defaultMaskString = "******"
+	maskRune          = '*'
+	// phoneKeepDigits is the number of the trailing digits kept by the PHONE mask.
+	phoneKeepDigits = 4
+	// phoneSeparators are the characters kept by the PHONE mask.
+	phoneSeparators = " +-()./"
+)
+
+// dateLayouts are the layouts used to parse the date and time values for the RANGE mask.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// maskValue masks the scanned value with the mask algorithm.
+// NULL is always masked with the default mask string, so that it cannot be told from other values.
+func maskValue(mask *db.SensitiveDataMask, scanArg any) any {
+	value, ok := scannedString(scanArg)
+	if !ok || mask == nil {
+		return defaultMaskString
+	}
+	switch mask.Type {
+	case db.SensitiveDataMaskTypeFull:
+		return strings.Repeat(string(maskRune), len([]rune(value)))
+	case db.SensitiveDataMaskTypePartial:
+		return maskPartial(value, mask.PrefixLength, mask.SuffixLength)
+	case db.SensitiveDataMaskTypeHash:
+		sum := sha256.Sum256([]byte(mask.Salt + value))
+		return hex.EncodeToString(sum[:])
+	case db.SensitiveDataMaskTypeRange:
+		return maskRange(value, mask.BucketSize, mask.DateUnit)
+	case db.SensitiveDataMaskTypeEmail:
+		return maskEmail(value)
+	case db.SensitiveDataMaskTypePhone:
+		return maskPhone(value)
+	default:
+		return defaultMaskString
+	}
+}
+
+// scannedString returns the string representation of the scanned value, and false for NULL.
+// Besides the sql.NullType used by readRows, it accepts the pointers to the values scanned by readRowsForClickhouse.
+func scannedString(scanArg any) (string, bool) {
+	switch v := scanArg.(type) {
+	case *sql.NullString:
+		return v.String, v.Valid
+	case *sql.NullBool:
+		return strconv.FormatBool(v.Bool), v.Valid
+	case *sql.NullInt64:
+		return strconv.FormatInt(v.Int64, 10), v.Valid
+	case *sql.NullInt32:
+		return strconv.FormatInt(int64(v.Int32), 10), v.Valid
+	case *sql.NullFloat64:
+		return strconv.FormatFloat(v.Float64, 'f', -1, 64), v.Valid
+	}
+	value := reflect.ValueOf(scanArg)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return "", false
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano), true
+	}
+	return fmt.Sprint(value.Interface()), true
+}
+
+// maskPartial keeps the first prefixLength and the last suffixLength characters, and masks the others.
+// The whole value is masked if it is not longer than the kept characters.
+func maskPartial(value string, prefixLength, suffixLength int) string {
+	runes := []rune(value)
+	if prefixLength < 0 {
+		prefixLength = 0
+	}
+	if suffixLength < 0 {
+		suffixLength = 0
+	}
+	if len(runes) <= prefixLength+suffixLength {
+		return strings.Repeat(string(maskRune), len(runes))
+	}
+	for i := prefixLength; i < len(runes)-suffixLength; i++ {
+		runes[i] = maskRune
+	}
+	return string(runes)
+}
+
+// maskRange replaces the number with the bucket [lower, upper) it falls into, and truncates the date to the date unit.
+// The value which is neither a number nor a date is masked with the default mask string.
+func maskRange(value string, bucketSize float64, dateUnit string) string {
+	if number, err := strconv.ParseFloat(value, 64); err == nil && bucketSize > 0 {
+		lower := math.Floor(number/bucketSize) * bucketSize
+		return fmt.Sprintf("[%s, %s)", strconv.FormatFloat(lower, 'f', -1, 64), strconv.FormatFloat(lower+bucketSize, 'f', -1, 64))
+	}
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		switch strings.ToUpper(dateUnit) {
+		case "YEAR":
+			return t.Format("2006")
+		case "MONTH":
+			return t.Format("2006-01")
+		case "DAY":
+			return t.Format("2006-01-02")
+		}
+		break
+	}
+	return defaultMaskString
+}
+
+// maskEmail keeps the first character of the local part and the domain, e.g. j******@example.com.
+func maskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return defaultMaskString
+	}
+	local := []rune(value[:at])
+	return string(local[0]) + defaultMaskString + value[at:]
+}
+
+// maskPhone masks all digits except the last four, and keeps the separators, e.g. ***-***-1234.
+// The value without digits beyond the last four, such as N/A, is masked with the default mask string,
+// and the other characters than the digits and separators are masked as well.
+func maskPhone(value string) string {
+	runes := []rune(value)
+	digits := 0
+	for _, r := range runes {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if digits <= phoneKeepDigits {
+		return defaultMaskString
+	}
+	keep := phoneKeepDigits
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] < '0' || runes[i] > '9' {
+			if !strings.ContainsRune(phoneSeparators, runes[i]) {
+				runes[i] = maskRune
+			}
+			continue
+		}
+		if keep > 0 {
+			keep--
+			continue
+		}
+		runes[i] = maskRune
+	}
+	return string(runes)
+}
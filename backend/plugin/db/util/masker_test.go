@@ -0,0 +1,50 @@
+package util
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		mask  *db.SensitiveDataMask
+		value any
+		want  any
+	}{
+		{nil, &sql.NullString{String: "secret", Valid: true}, "******"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeFull}, &sql.NullString{}, "******"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeFull}, &sql.NullString{String: "abc", Valid: true}, "***"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial, PrefixLength: 2, SuffixLength: 3}, &sql.NullString{String: "4111111111111111", Valid: true}, "41***********111"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial, PrefixLength: 2, SuffixLength: 3}, &sql.NullString{String: "abc", Valid: true}, "***"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeHash, Salt: "s"}, &sql.NullInt64{Int64: 42, Valid: true}, "e903fcd0a7b9e8f14bd1ad2540112430d3ab460037058c2487a0514f4c679159"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, BucketSize: 10}, &sql.NullInt64{Int64: 42, Valid: true}, "[40, 50)"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, BucketSize: 0.5}, &sql.NullFloat64{Float64: -0.2, Valid: true}, "[-0.5, 0)"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, DateUnit: "MONTH"}, &sql.NullString{String: "2023-05-17 10:11:12", Valid: true}, "2023-05"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, DateUnit: "YEAR"}, &sql.NullString{String: "not a date", Valid: true}, "******"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeEmail}, &sql.NullString{String: "jane.doe@example.com", Valid: true}, "j******@example.com"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePhone}, &sql.NullString{String: "+1 (415) 555-0123", Valid: true}, "+* (***) ***-0123"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePhone}, &sql.NullString{String: "N/A", Valid: true}, "******"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePhone}, &sql.NullString{String: "0123", Valid: true}, "******"},
+		{&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePhone}, &sql.NullString{String: "555-0123 ext Jane", Valid: true}, "***-0123 *** ****"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, maskValue(test.mask, test.value))
+	}
+}
+
+func TestMaskValueForClickHouse(t *testing.T) {
+	a := require.New(t)
+	value := int32(42)
+	a.Equal("[40, 50)", maskValue(&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, BucketSize: 10}, &value))
+	var null *string
+	a.Equal("******", maskValue(&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeFull}, &null))
+	date := time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)
+	a.Equal("2023-05-17", maskValue(&db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, DateUnit: "DAY"}, &date))
+}
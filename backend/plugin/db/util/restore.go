@@ -23,6 +23,13 @@ func GetPITROldDatabaseName(database string, suffixTs int64) string {
 	return GetSafeName(database, suffix)
 }
 
+// GetDrillDatabaseName composes a scratch database name that we use as the target database for backup restore drills.
+// For example, GetDrillDatabaseName("dbfoo", 1653018005) -> "dbfoo_drill_1653018005".
+func GetDrillDatabaseName(database string, suffixTs int64) string {
+	suffix := fmt.Sprintf("drill_%d", suffixTs)
+	return GetSafeName(database, suffix)
+}
+
 // GetSafeName trims the name according to max allowed database name length.
 func GetSafeName(baseName, suffix string) string {
 	name := fmt.Sprintf("%s_%s", baseName, suffix)
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"math/rand"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// MergeSlowQueryDetails merges the slow query details into the statistics of the fingerprint.
+// It returns the new statistics if the statistics is nil.
+func MergeSlowQueryDetails(fingerprint string, statistics *storepb.SlowQueryStatisticsItem, details *storepb.SlowQueryDetails) *storepb.SlowQueryStatisticsItem {
+	if statistics == nil {
+		return &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:      fingerprint,
+			Count:               1,
+			LatestLogTime:       details.StartTime,
+			TotalQueryTime:      details.QueryTime,
+			MaximumQueryTime:    details.QueryTime,
+			TotalRowsSent:       details.RowsSent,
+			MaximumRowsSent:     details.RowsSent,
+			TotalRowsExamined:   details.RowsExamined,
+			MaximumRowsExamined: details.RowsExamined,
+			Samples:             []*storepb.SlowQueryDetails{details},
+		}
+	}
+	statistics.Count++
+	if statistics.LatestLogTime.AsTime().Before(details.StartTime.AsTime()) {
+		statistics.LatestLogTime = details.StartTime
+	}
+	statistics.TotalQueryTime = durationpb.New(statistics.TotalQueryTime.AsDuration() + details.QueryTime.AsDuration())
+	if statistics.MaximumQueryTime.AsDuration() < details.QueryTime.AsDuration() {
+		statistics.MaximumQueryTime = details.QueryTime
+	}
+	statistics.TotalRowsSent += details.RowsSent
+	if statistics.MaximumRowsSent < details.RowsSent {
+		statistics.MaximumRowsSent = details.RowsSent
+	}
+	statistics.TotalRowsExamined += details.RowsExamined
+	if statistics.MaximumRowsExamined < details.RowsExamined {
+		statistics.MaximumRowsExamined = details.RowsExamined
+	}
+	if len(statistics.Samples) < db.SlowQueryMaxSamplePerFingerprint {
+		statistics.Samples = append(statistics.Samples, details)
+	} else {
+		// Use Reservoir Sampling to sample slow logs.
+		pos := rand.Intn(len(statistics.Samples))
+		statistics.Samples[pos] = details
+	}
+	return statistics
+}
+
+// MergeSlowQueryStatisticsItem merges the aggregated statistics item into the statistics of the same fingerprint.
+// It's used by the engines which only provide the aggregated statistics, e.g. Oracle V$SQL and SQL Server sys.dm_exec_query_stats.
+func MergeSlowQueryStatisticsItem(statistics *storepb.SlowQueryStatisticsItem, item *storepb.SlowQueryStatisticsItem) *storepb.SlowQueryStatisticsItem {
+	if statistics == nil {
+		return item
+	}
+	statistics.Count += item.Count
+	if statistics.LatestLogTime.AsTime().Before(item.LatestLogTime.AsTime()) {
+		statistics.LatestLogTime = item.LatestLogTime
+	}
+	statistics.TotalQueryTime = durationpb.New(statistics.TotalQueryTime.AsDuration() + item.TotalQueryTime.AsDuration())
+	if statistics.MaximumQueryTime.AsDuration() < item.MaximumQueryTime.AsDuration() {
+		statistics.MaximumQueryTime = item.MaximumQueryTime
+	}
+	statistics.TotalRowsSent += item.TotalRowsSent
+	if statistics.MaximumRowsSent < item.MaximumRowsSent {
+		statistics.MaximumRowsSent = item.MaximumRowsSent
+	}
+	statistics.TotalRowsExamined += item.TotalRowsExamined
+	if statistics.MaximumRowsExamined < item.MaximumRowsExamined {
+		statistics.MaximumRowsExamined = item.MaximumRowsExamined
+	}
+	return statistics
+}
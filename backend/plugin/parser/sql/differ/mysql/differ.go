@@ -34,7 +34,7 @@ var (
 
 func init() {
 	differ.Register(bbparser.MySQL, &SchemaDiffer{})
-	differ.Register(bbparser.TiDB, &SchemaDiffer{})
+	differ.Register(bbparser.TiDB, &SchemaDiffer{engineType: bbparser.TiDB})
 	differ.Register(bbparser.OceanBase, &SchemaDiffer{})
 }
 
@@ -45,11 +45,16 @@ const (
 
 // SchemaDiffer it the parser for MySQL dialect.
 type SchemaDiffer struct {
+	// engineType is the engine of the schema, the TiDB specific syntax such as clustered primary keys,
+	// AUTO_RANDOM and placement policies are kept and compared for TiDB.
+	engineType bbparser.EngineType
 }
 
 // diffNode defines different modification types as the safe change order.
 // The safe change order means we can change them with no dependency conflicts as this order.
 type diffNode struct {
+	engineType bbparser.EngineType
+
 	dropUnsupportedStatement   []string
 	dropForeignKeyList         []ast.Node
 	dropConstraintExceptFkList []ast.Node
@@ -57,6 +62,8 @@ type diffNode struct {
 	dropViewList               []ast.Node
 	dropTableList              []ast.Node
 
+	createPlacementPolicyList       []ast.Node
+	alterPlacementPolicyList        []ast.Node
 	createTableList                 []ast.Node
 	alterTableOptionList            []ast.Node
 	addAndModifyColumnList          []ast.Node
@@ -66,6 +73,7 @@ type diffNode struct {
 	addConstraintExceptFkList       []ast.Node
 	addForeignKeyList               []ast.Node
 	createViewList                  []ast.Node
+	dropPlacementPolicyList         []ast.Node
 	createUnsupportedStatement      []string
 	inPlaceDropUnsupportedStatement []string
 	inPlaceAddUnsupportedStatement  []string
@@ -140,7 +148,9 @@ func (diff *diffNode) diffSupportedStatement(oldStatement, newStatement string)
 			}
 			continue
 		}
-		diff.diffTable(oldTable, newTable)
+		if err := diff.diffTable(oldTable, newTable); err != nil {
+			return err
+		}
 		delete(oldSchemaInfo.tableMap, tableName)
 	}
 
@@ -159,10 +169,39 @@ func (diff *diffNode) diffSupportedStatement(oldStatement, newStatement string)
 	}
 
 	diff.diffView(oldSchemaInfo.viewMap, newSchemaInfo.viewMap, newViewList)
+	diff.diffPlacementPolicy(oldSchemaInfo.placementPolicyMap, newSchemaInfo.placementPolicyMap)
 
 	return nil
 }
 
+// diffPlacementPolicy compares the TiDB placement policies.
+// The new policies are created before the tables, and the dropped policies are dropped after the tables no longer use them.
+func (diff *diffNode) diffPlacementPolicy(oldPolicyMap, newPolicyMap placementPolicyMap) {
+	for name, newPolicy := range newPolicyMap {
+		oldPolicy, ok := oldPolicyMap[name]
+		if !ok {
+			createPolicy := *newPolicy
+			createPolicy.IfNotExists = true
+			diff.createPlacementPolicyList = append(diff.createPlacementPolicyList, &createPolicy)
+			continue
+		}
+		if !isPlacementPolicyEqual(oldPolicy, newPolicy) {
+			diff.alterPlacementPolicyList = append(diff.alterPlacementPolicyList, &ast.AlterPlacementPolicyStmt{
+				PolicyName:       newPolicy.PolicyName,
+				PlacementOptions: newPolicy.PlacementOptions,
+			})
+		}
+		delete(oldPolicyMap, name)
+	}
+
+	for _, oldPolicy := range oldPolicyMap {
+		diff.dropPlacementPolicyList = append(diff.dropPlacementPolicyList, &ast.DropPlacementPolicyStmt{
+			IfExists:   true,
+			PolicyName: oldPolicy.PolicyName,
+		})
+	}
+}
+
 func (diff *diffNode) diffView(oldViewMap viewMap, newViewMap viewMap, newViewList []*ast.CreateViewStmt) {
 	var tempViewList []ast.Node
 	var viewList []ast.Node
@@ -208,14 +247,14 @@ func (diff *diffNode) diffView(oldViewMap viewMap, newViewMap viewMap, newViewLi
 	}
 }
 
-func (diff *diffNode) diffTable(oldTable, newTable *tableInfo) {
+func (diff *diffNode) diffTable(oldTable, newTable *tableInfo) error {
 	diff.diffTableOption(oldTable, newTable)
 	diff.diffColumn(oldTable, newTable)
 	diff.diffIndex(oldTable, newTable)
-	diff.diffConstraint(oldTable, newTable)
+	return diff.diffConstraint(oldTable, newTable)
 }
 
-func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) {
+func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) error {
 	oldPrimaryKey, oldConstraintMap := buildConstraintMap(oldTable.createTable)
 	// Compare the create definitions.
 	for _, constraint := range newTable.createTable.Constraints {
@@ -223,6 +262,9 @@ func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) {
 		case ast.ConstraintPrimaryKey:
 			if oldPrimaryKey != nil {
 				if !isPrimaryKeyEqual(constraint, oldPrimaryKey) {
+					if err := diff.checkClusteredPrimaryKeyChange(newTable.createTable.Table, oldPrimaryKey, constraint); err != nil {
+						return err
+					}
 					diff.dropConstraintExceptFkList = append(diff.dropConstraintExceptFkList, &ast.AlterTableStmt{
 						Table: newTable.createTable.Table,
 						Specs: []*ast.AlterTableSpec{
@@ -325,6 +367,9 @@ func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) {
 	}
 
 	if oldPrimaryKey != nil {
+		if err := diff.checkClusteredPrimaryKeyChange(newTable.createTable.Table, oldPrimaryKey, nil); err != nil {
+			return err
+		}
 		diff.dropConstraintExceptFkList = append(diff.dropConstraintExceptFkList, &ast.AlterTableStmt{
 			Table: newTable.createTable.Table,
 			Specs: []*ast.AlterTableSpec{
@@ -359,8 +404,21 @@ func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) {
 			})
 		}
 	}
+	return nil
 }
 
+// checkClusteredPrimaryKeyChange returns error if the TiDB clustered primary key is changed or dropped,
+// because TiDB cannot drop or add the clustered primary key without rebuilding the table.
+// https://docs.pingcap.com/tidb/stable/clustered-indexes#limitations
+func (diff *diffNode) checkClusteredPrimaryKeyChange(table *ast.TableName, oldPrimaryKey, newPrimaryKey *ast.Constraint) error {
+	if diff.engineType != bbparser.TiDB {
+		return nil
+	}
+	if isClusteredPrimaryKey(oldPrimaryKey) || isClusteredPrimaryKey(newPrimaryKey) {
+		return errors.Errorf("cannot change the clustered primary key of table `%s`, TiDB requires rebuilding the table to change it", table.Name.O)
+	}
+	return nil
+}
 func (diff *diffNode) diffIndex(oldTable, newTable *tableInfo) {
 	for indexName, newIndex := range newTable.indexMap {
 		if oldIndex, ok := oldTable.indexMap[indexName]; ok {
@@ -460,6 +518,10 @@ func (diff *diffNode) diffTableOption(oldTable, newTable *tableInfo) {
 func (diff *diffNode) deparse() (string, error) {
 	var buf bytes.Buffer
 	flag := format.DefaultRestoreFlags | format.RestoreStringWithoutCharset | format.RestorePrettyFormat
+	if diff.engineType == bbparser.TiDB {
+		// Keep the TiDB specific syntax in the special comments as TiDB dumps, so that the statements are also compatible with the MySQL clients.
+		flag |= format.RestoreTiDBSpecialComment
+	}
 
 	sort.Strings(diff.dropUnsupportedStatement)
 	for _, statement := range diff.dropUnsupportedStatement {
@@ -485,6 +547,12 @@ func (diff *diffNode) deparse() (string, error) {
 	if err := sortAndWriteNodeList(&buf, diff.dropTableList, flag); err != nil {
 		return "", err
 	}
+	if err := sortAndWriteNodeList(&buf, diff.createPlacementPolicyList, flag); err != nil {
+		return "", err
+	}
+	if err := sortAndWriteNodeList(&buf, diff.alterPlacementPolicyList, flag); err != nil {
+		return "", err
+	}
 	if err := sortAndWriteNodeList(&buf, diff.createTableList, flag); err != nil {
 		return "", err
 	}
@@ -512,6 +580,9 @@ func (diff *diffNode) deparse() (string, error) {
 	if err := sortAndWriteNodeList(&buf, diff.createViewList, flag); err != nil {
 		return "", err
 	}
+	if err := sortAndWriteNodeList(&buf, diff.dropPlacementPolicyList, flag); err != nil {
+		return "", err
+	}
 
 	sort.Strings(diff.createUnsupportedStatement)
 	for _, statement := range diff.createUnsupportedStatement {
@@ -555,7 +626,7 @@ type constraintMap map[string]*ast.Constraint
 
 // SchemaDiff returns the schema diff.
 // It only supports schema information from mysqldump.
-func (*SchemaDiffer) SchemaDiff(oldStmt, newStmt string) (string, error) {
+func (d *SchemaDiffer) SchemaDiff(oldStmt, newStmt string) (string, error) {
 	// 1. Preprocessing Stage.
 	// TiDB parser doesn't support some statements like `CREATE EVENT`, so we need to extract them out and diff them based on string compare.
 	oldUnsupportedStmtList, oldSupportedStmt, err := classifyStatement(oldStmt)
@@ -567,7 +638,7 @@ func (*SchemaDiffer) SchemaDiff(oldStmt, newStmt string) (string, error) {
 		return "", err
 	}
 
-	diff := &diffNode{}
+	diff := &diffNode{engineType: d.engineType}
 	if err := diff.diffSupportedStatement(oldSupportedStmt, newSupportedStmt); err != nil {
 		return "", err
 	}
@@ -636,6 +707,12 @@ func getID(node ast.Node) string {
 		return fmt.Sprintf("%s.%s", in.Table.Name.String(), in.IndexName)
 	case *ast.CreateViewStmt:
 		return in.ViewName.Name.String()
+	case *ast.CreatePlacementPolicyStmt:
+		return in.PolicyName.String()
+	case *ast.AlterPlacementPolicyStmt:
+		return in.PolicyName.String()
+	case *ast.DropPlacementPolicyStmt:
+		return in.PolicyName.String()
 	}
 	return ""
 }
@@ -654,12 +731,15 @@ func sortAndWriteNodeList(w io.Writer, ns []ast.Node, flags format.RestoreFlags)
 }
 
 type schemaInfo struct {
-	tableMap tableMap
-	viewMap  viewMap
+	tableMap           tableMap
+	viewMap            viewMap
+	placementPolicyMap placementPolicyMap
 }
 
 type viewMap map[string]*ast.CreateViewStmt
 
+type placementPolicyMap map[string]*ast.CreatePlacementPolicyStmt
+
 type indexInfo struct {
 	createIndex *ast.CreateIndexStmt
 }
@@ -726,8 +806,9 @@ func transformConstraintToIndex(tableName *ast.TableName, constraint *ast.Constr
 // buildSchemaInfo returns schema information built by statements.
 func buildSchemaInfo(nodes []ast.StmtNode) (*schemaInfo, error) {
 	result := &schemaInfo{
-		tableMap: make(tableMap),
-		viewMap:  make(viewMap),
+		tableMap:           make(tableMap),
+		viewMap:            make(viewMap),
+		placementPolicyMap: make(placementPolicyMap),
 	}
 	for _, node := range nodes {
 		switch stmt := node.(type) {
@@ -748,6 +829,8 @@ func buildSchemaInfo(nodes []ast.StmtNode) (*schemaInfo, error) {
 			table.indexMap[stmt.IndexName] = newIndexInfo(stmt)
 		case *ast.CreateViewStmt:
 			result.viewMap[stmt.ViewName.Name.String()] = stmt
+		case *ast.CreatePlacementPolicyStmt:
+			result.placementPolicyMap[stmt.PolicyName.L] = stmt
 		default:
 		}
 	}
@@ -973,19 +1056,23 @@ func isPrimaryKeyEqual(old, new *ast.Constraint) bool {
 	if old.Name != new.Name {
 		return false
 	}
-	if (old.Option == nil) != (new.Option == nil) {
-		return false
+	// The primary key without the index option is the same as the one with the empty index option,
+	// e.g. the TiDB primary key with the clustered attribute only.
+	oldOption, newOption := old.Option, new.Option
+	if oldOption == nil {
+		oldOption = &ast.IndexOption{}
 	}
-	if old.Option != nil && new.Option != nil {
-		if old.Option.Tp != new.Option.Tp {
-			return false
-		}
+	if newOption == nil {
+		newOption = &ast.IndexOption{}
+	}
+	if oldOption.Tp != newOption.Tp {
+		return false
 	}
 
 	if !isKeyPartEqual(old.Keys, new.Keys) {
 		return false
 	}
-	if !isIndexOptionEqual(old.Option, new.Option) {
+	if !isIndexOptionEqual(oldOption, newOption) {
 		return false
 	}
 	return true
@@ -1069,6 +1156,11 @@ func isIndexOptionEqual(old, new *ast.IndexOption) bool {
 	if old.Visibility != new.Visibility {
 		return false
 	}
+	// The TiDB clustered attribute of the primary key, the default type depends on the tidb_enable_clustered_index variable,
+	// so we only compare the explicit types.
+	if old.PrimaryKeyTp != model.PrimaryKeyTypeDefault && new.PrimaryKeyTp != model.PrimaryKeyTypeDefault && old.PrimaryKeyTp != new.PrimaryKeyTp {
+		return false
+	}
 	// TODO(zp): support ENGINE_ATTRIBUTE and SECONDARY_ENGINE_ATTRIBUTE.
 	return true
 }
@@ -1375,6 +1467,22 @@ func dropTableOption(option *ast.TableOption) *ast.TableOption {
 		// TODO(zp): handle the table space
 	case ast.TableOptionUnion:
 		// TODO(zp): handle the union
+	case ast.TableOptionShardRowID:
+		return &ast.TableOption{
+			Tp:        ast.TableOptionShardRowID,
+			UintValue: 0,
+		}
+	case ast.TableOptionPlacementPolicy:
+		// Use the DEFAULT policy to remove the placement policy.
+		// https://docs.pingcap.com/tidb/stable/placement-rules-in-sql#remove-placement-policies
+		return &ast.TableOption{
+			Tp:       ast.TableOptionPlacementPolicy,
+			StrValue: "DEFAULT",
+		}
+	case ast.TableOptionAutoIdCache:
+		// TODO(zp): handle the default auto id cache.
+	case ast.TableOptionPreSplitRegion:
+		// PRE_SPLIT_REGIONS only takes effect when creating the table.
 	}
 	return nil
 }
@@ -1449,6 +1557,18 @@ func isTableOptionValEqual(old, new *ast.TableOption) bool {
 		return old.UintValue == new.UintValue
 	case ast.TableOptionTablespace:
 		return old.StrValue == new.StrValue
+	case ast.TableOptionAutoRandomBase:
+		// Same as the AUTO_INCREMENT, the AUTO_RANDOM_BASE cannot be reset to a value less than the current allocated value.
+		return old.UintValue == new.UintValue
+	case ast.TableOptionAutoIdCache:
+		return old.UintValue == new.UintValue
+	case ast.TableOptionShardRowID:
+		return old.UintValue == new.UintValue
+	case ast.TableOptionPreSplitRegion:
+		// PRE_SPLIT_REGIONS only takes effect when creating the table, and cannot be altered.
+		return true
+	case ast.TableOptionPlacementPolicy:
+		return strings.EqualFold(old.StrValue, new.StrValue)
 	case ast.TableOptionUnion:
 		oldTableNames := old.TableNames
 		newTableNames := new.TableNames
@@ -1513,3 +1633,27 @@ func hasColumnsIntersection(a, b []*ast.ColumnDef) bool {
 	}
 	return false
 }
+
+// isClusteredPrimaryKey returns true if the primary key is the TiDB clustered primary key.
+func isClusteredPrimaryKey(primaryKey *ast.Constraint) bool {
+	return primaryKey != nil && primaryKey.Option != nil && primaryKey.Option.PrimaryKeyTp == model.PrimaryKeyTypeClustered
+}
+
+// isPlacementPolicyEqual returns true if two TiDB placement policies with the same name are the same.
+func isPlacementPolicyEqual(old, new *ast.CreatePlacementPolicyStmt) bool {
+	oldPolicy := *old
+	oldPolicy.OrReplace, oldPolicy.IfNotExists = false, false
+	newPolicy := *new
+	newPolicy.OrReplace, newPolicy.IfNotExists = false, false
+	oldPolicyStr, err := toString(&oldPolicy)
+	if err != nil {
+		log.Error("fail to convert old placement policy to string", zap.Error(err))
+		return false
+	}
+	newPolicyStr, err := toString(&newPolicy)
+	if err != nil {
+		log.Error("fail to convert new placement policy to string", zap.Error(err))
+		return false
+	}
+	return oldPolicyStr == newPolicyStr
+}
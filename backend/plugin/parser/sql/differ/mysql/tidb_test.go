@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	bbparser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+type differTestData struct {
+	OldSchema string `yaml:"oldSchema"`
+	NewSchema string `yaml:"newSchema"`
+	Diff      string `yaml:"diff"`
+}
+
+func runDifferTest(t *testing.T, engineType bbparser.EngineType, file string, record bool) {
+	differ := &SchemaDiffer{engineType: engineType}
+
+	var tests []differTestData
+	filepath := filepath.Join("test-data", file)
+	yamlFile, err := os.Open(filepath)
+	require.NoError(t, err)
+	defer yamlFile.Close()
+
+	byteValue, err := io.ReadAll(yamlFile)
+	require.NoError(t, err)
+	err = yaml.Unmarshal(byteValue, &tests)
+	require.NoError(t, err)
+
+	for i, test := range tests {
+		diff, err := differ.SchemaDiff(test.OldSchema, test.NewSchema)
+		require.NoError(t, err)
+		if record {
+			tests[i].Diff = diff
+		} else {
+			require.Equal(t, test.Diff, diff, test.OldSchema)
+		}
+	}
+
+	if record {
+		err := yamlFile.Close()
+		require.NoError(t, err)
+		byteValue, err = yaml.Marshal(tests)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath, byteValue, 0644)
+		require.NoError(t, err)
+	}
+}
+
+func TestTiDBDiff(t *testing.T) {
+	runDifferTest(t, bbparser.TiDB, "test_differ_tidb.yaml", false /* record */)
+}
+
+func TestTiDBClusteredPrimaryKeyChange(t *testing.T) {
+	differ := &SchemaDiffer{engineType: bbparser.TiDB}
+	tests := []struct {
+		old string
+		new string
+	}{
+		{
+			old: "CREATE TABLE `t` (`a` int NOT NULL, `b` int NOT NULL, PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */);",
+			new: "CREATE TABLE `t` (`a` int NOT NULL, `b` int NOT NULL, PRIMARY KEY (`a`, `b`) /*T![clustered_index] CLUSTERED */);",
+		},
+		{
+			old: "CREATE TABLE `t` (`a` int NOT NULL, PRIMARY KEY (`a`) /*T![clustered_index] NONCLUSTERED */);",
+			new: "CREATE TABLE `t` (`a` int NOT NULL, PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */);",
+		},
+		{
+			old: "CREATE TABLE `t` (`a` int NOT NULL, PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */);",
+			new: "CREATE TABLE `t` (`a` int NOT NULL);",
+		},
+	}
+	for _, test := range tests {
+		_, err := differ.SchemaDiff(test.old, test.new)
+		require.Error(t, err, test.new)
+	}
+
+	// MySQL does not have the clustered attribute, so the primary key is changed as usual.
+	mysqlDiffer := &SchemaDiffer{}
+	_, err := mysqlDiffer.SchemaDiff(tests[0].old, tests[0].new)
+	require.NoError(t, err)
+}
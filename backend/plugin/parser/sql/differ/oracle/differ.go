@@ -0,0 +1,844 @@
+// Package oracle provides the Oracle differ plugin.
+package oracle
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+	"github.com/pkg/errors"
+
+	plsql "github.com/bytebase/plsql-parser"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/differ"
+)
+
+var (
+	_ differ.SchemaDiffer = (*SchemaDiffer)(nil)
+
+	// terminatorLineRegexp matches the SQL*Plus statement terminator line in the schema dumped by Bytebase.
+	terminatorLineRegexp = regexp.MustCompile(`(?m)^\s*/\s*$`)
+	// schemaStatementRegexp matches the statements compared by the differ, the leading comments are skipped.
+	// The other statements such as the PL/SQL objects and the data are ignored.
+	schemaStatementRegexp   = regexp.MustCompile(`(?is)^(\s*--[^\n]*(\n|$))*\s*(CREATE\s+((GLOBAL\s+TEMPORARY\s+)?TABLE|((UNIQUE|BITMAP)\s+)?INDEX|SEQUENCE|(OR\s+REPLACE\s+)?((NO\s+)?FORCE\s+)?((NON)?EDITIONABLE\s+)?(EDITIONING\s+)?VIEW)|ALTER\s+TABLE)\s`)
+	sequenceStatementRegexp = regexp.MustCompile(`(?is)^(\s*--[^\n]*(\n|$))*\s*CREATE\s+SEQUENCE\s`)
+	// sequenceOptionRegexp matches the sequence options dumped by Oracle 18c and later which are not supported by the parser.
+	sequenceOptionRegexp = regexp.MustCompile(`(?i)\s(NO)?(KEEP|SCALE|EXTEND|SHARD)\b|\s(GLOBAL|SESSION)\s*$`)
+)
+
+func init() {
+	differ.Register(parser.Oracle, &SchemaDiffer{})
+}
+
+// SchemaDiffer is the parser for Oracle dialect.
+// It compares the tables, columns, constraints, indexes, sequences and views.
+// The PL/SQL objects such as functions, procedures, packages and triggers are not compared,
+// because the migration statements are split by semicolons when executing.
+type SchemaDiffer struct {
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	dropForeignKeyList         []string
+	dropConstraintExceptFkList []string
+	dropIndexList              []string
+	dropViewList               []string
+	dropTableList              []string
+	dropSequenceList           []string
+
+	createSequenceList        []string
+	alterSequenceList         []string
+	createTableList           []string
+	addColumnList             []string
+	modifyColumnList          []string
+	dropColumnList            []string
+	createIndexList           []string
+	addConstraintExceptFkList []string
+	addForeignKeyList         []string
+	// createViewList is in the order of the new schema, because the views may depend on each other.
+	createViewList []string
+}
+
+type schemaInfo struct {
+	tableMap    map[string]*tableInfo
+	indexMap    map[string]*indexInfo
+	sequenceMap map[string]*sequenceInfo
+	viewMap     map[string]*viewInfo
+	viewList    []*viewInfo
+}
+
+type tableInfo struct {
+	// name is the quoted table name, such as "SCHEMA"."TABLE".
+	name string
+	// createTable is the CREATE TABLE statement without the foreign keys.
+	createTable   string
+	columnList    []*columnInfo
+	constraintMap map[string]*constraintInfo
+}
+
+type columnInfo struct {
+	name string
+	// definition is the original column definition.
+	definition string
+	// dataType, defaultValue and others are normalized for comparison.
+	dataType     string
+	defaultValue string
+	nullable     bool
+	// others are the inline constraints except NULL and NOT NULL, the identity clause and the virtual column expression.
+	others string
+	// dataTypeText and defaultText are the original text used in the MODIFY clause.
+	dataTypeText string
+	defaultText  string
+}
+
+type constraintInfo struct {
+	// definition is the original out-of-line constraint definition.
+	definition string
+	// normalized is the normalized constraint definition without the constraint state for comparison.
+	normalized string
+	// dropClause is the clause to drop the constraint, such as CONSTRAINT "NAME" and PRIMARY KEY.
+	dropClause string
+	foreignKey bool
+}
+
+type indexInfo struct {
+	name        string
+	tableName   string
+	createIndex string
+	normalized  string
+}
+
+type sequenceInfo struct {
+	name           string
+	createSequence string
+	// specList is the original sequence options except START WITH, which is the current value in the dump.
+	specList   []string
+	normalized string
+}
+
+type viewInfo struct {
+	name       string
+	createView string
+	normalized string
+}
+
+// SchemaDiff returns the schema diff.
+// It supports the schema dumped by Bytebase and the statements terminated by semicolons.
+func (*SchemaDiffer) SchemaDiff(oldStmt, newStmt string) (string, error) {
+	oldSchemaInfo, err := buildSchemaInfo(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse old statement")
+	}
+	newSchemaInfo, err := buildSchemaInfo(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse new statement")
+	}
+
+	diff := &diffNode{}
+	if err := diff.diffTable(oldSchemaInfo, newSchemaInfo); err != nil {
+		return "", err
+	}
+	diff.diffIndex(oldSchemaInfo, newSchemaInfo)
+	diff.diffSequence(oldSchemaInfo, newSchemaInfo)
+	diff.diffView(oldSchemaInfo, newSchemaInfo)
+
+	return diff.deparse(), nil
+}
+
+func (diff *diffNode) diffTable(oldSchemaInfo, newSchemaInfo *schemaInfo) error {
+	for name, newTable := range newSchemaInfo.tableMap {
+		oldTable, ok := oldSchemaInfo.tableMap[name]
+		if !ok {
+			diff.createTableList = append(diff.createTableList, newTable.createTable)
+			for _, constraint := range newTable.constraintMap {
+				if constraint.foreignKey {
+					diff.addForeignKeyList = append(diff.addForeignKeyList, fmt.Sprintf("ALTER TABLE %s ADD %s", newTable.name, constraint.definition))
+				}
+			}
+			continue
+		}
+		if err := diff.diffColumn(oldTable, newTable); err != nil {
+			return err
+		}
+		if err := diff.diffConstraint(oldTable, newTable); err != nil {
+			return err
+		}
+	}
+
+	for name, oldTable := range oldSchemaInfo.tableMap {
+		if _, ok := newSchemaInfo.tableMap[name]; ok {
+			continue
+		}
+		// Drop the foreign keys first, because the dropped tables may reference each other.
+		for _, constraint := range oldTable.constraintMap {
+			if !constraint.foreignKey {
+				continue
+			}
+			if constraint.dropClause == "" {
+				return errors.Errorf("cannot drop the unnamed constraint %q of table %s", constraint.definition, oldTable.name)
+			}
+			diff.dropForeignKeyList = append(diff.dropForeignKeyList, fmt.Sprintf("ALTER TABLE %s DROP %s", oldTable.name, constraint.dropClause))
+		}
+		diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s", oldTable.name))
+	}
+	return nil
+}
+
+func (diff *diffNode) diffColumn(oldTable, newTable *tableInfo) error {
+	oldColumnMap := make(map[string]*columnInfo)
+	for _, column := range oldTable.columnList {
+		oldColumnMap[column.name] = column
+	}
+
+	var addColumnList, modifyColumnList []string
+	for _, newColumn := range newTable.columnList {
+		oldColumn, ok := oldColumnMap[newColumn.name]
+		if !ok {
+			addColumnList = append(addColumnList, newColumn.definition)
+			continue
+		}
+		delete(oldColumnMap, newColumn.name)
+
+		if oldColumn.others != newColumn.others {
+			return errors.Errorf("cannot change the inline constraints, identity or expression of column %s in table %s, please use the out-of-line constraints instead", newColumn.name, newTable.name)
+		}
+		modifyList := []string{quoteIdentifier(newColumn.name)}
+		if oldColumn.dataType != newColumn.dataType {
+			modifyList = append(modifyList, newColumn.dataTypeText)
+		}
+		if oldColumn.defaultValue != newColumn.defaultValue {
+			if newColumn.defaultText == "" {
+				modifyList = append(modifyList, "DEFAULT NULL")
+			} else {
+				modifyList = append(modifyList, newColumn.defaultText)
+			}
+		}
+		// Oracle returns error if the column is modified to NOT NULL but it's already NOT NULL, so we only modify the nullability if changed.
+		if oldColumn.nullable != newColumn.nullable {
+			if newColumn.nullable {
+				modifyList = append(modifyList, "NULL")
+			} else {
+				modifyList = append(modifyList, "NOT NULL")
+			}
+		}
+		if len(modifyList) > 1 {
+			modifyColumnList = append(modifyColumnList, strings.Join(modifyList, " "))
+		}
+	}
+
+	if len(addColumnList) > 0 {
+		diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD (%s)", newTable.name, strings.Join(addColumnList, ", ")))
+	}
+	if len(modifyColumnList) > 0 {
+		diff.modifyColumnList = append(diff.modifyColumnList, fmt.Sprintf("ALTER TABLE %s MODIFY (%s)", newTable.name, strings.Join(modifyColumnList, ", ")))
+	}
+	if len(oldColumnMap) > 0 {
+		var dropColumnList []string
+		for _, column := range oldTable.columnList {
+			if _, ok := oldColumnMap[column.name]; ok {
+				dropColumnList = append(dropColumnList, quoteIdentifier(column.name))
+			}
+		}
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP (%s)", newTable.name, strings.Join(dropColumnList, ", ")))
+	}
+	return nil
+}
+
+func (diff *diffNode) diffConstraint(oldTable, newTable *tableInfo) error {
+	for key, newConstraint := range newTable.constraintMap {
+		oldConstraint, ok := oldTable.constraintMap[key]
+		if ok && oldConstraint.normalized == newConstraint.normalized {
+			continue
+		}
+		if ok {
+			if err := diff.dropConstraint(oldTable, oldConstraint); err != nil {
+				return err
+			}
+		}
+		statement := fmt.Sprintf("ALTER TABLE %s ADD %s", newTable.name, newConstraint.definition)
+		if newConstraint.foreignKey {
+			diff.addForeignKeyList = append(diff.addForeignKeyList, statement)
+		} else {
+			diff.addConstraintExceptFkList = append(diff.addConstraintExceptFkList, statement)
+		}
+	}
+
+	for key, oldConstraint := range oldTable.constraintMap {
+		if _, ok := newTable.constraintMap[key]; ok {
+			continue
+		}
+		if err := diff.dropConstraint(oldTable, oldConstraint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (diff *diffNode) dropConstraint(table *tableInfo, constraint *constraintInfo) error {
+	if constraint.dropClause == "" {
+		return errors.Errorf("cannot drop the unnamed constraint %q of table %s", constraint.definition, table.name)
+	}
+	statement := fmt.Sprintf("ALTER TABLE %s DROP %s", table.name, constraint.dropClause)
+	if constraint.foreignKey {
+		diff.dropForeignKeyList = append(diff.dropForeignKeyList, statement)
+	} else {
+		diff.dropConstraintExceptFkList = append(diff.dropConstraintExceptFkList, statement)
+	}
+	return nil
+}
+
+func (diff *diffNode) diffIndex(oldSchemaInfo, newSchemaInfo *schemaInfo) {
+	for name, newIndex := range newSchemaInfo.indexMap {
+		oldIndex, ok := oldSchemaInfo.indexMap[name]
+		if ok && oldIndex.normalized == newIndex.normalized {
+			continue
+		}
+		if ok {
+			diff.dropIndexList = append(diff.dropIndexList, fmt.Sprintf("DROP INDEX %s", oldIndex.name))
+		}
+		diff.createIndexList = append(diff.createIndexList, newIndex.createIndex)
+	}
+
+	for name, oldIndex := range oldSchemaInfo.indexMap {
+		if _, ok := newSchemaInfo.indexMap[name]; ok {
+			continue
+		}
+		// The indexes are dropped along with the tables.
+		if _, ok := newSchemaInfo.tableMap[oldIndex.tableName]; !ok {
+			continue
+		}
+		diff.dropIndexList = append(diff.dropIndexList, fmt.Sprintf("DROP INDEX %s", oldIndex.name))
+	}
+}
+
+func (diff *diffNode) diffSequence(oldSchemaInfo, newSchemaInfo *schemaInfo) {
+	for name, newSequence := range newSchemaInfo.sequenceMap {
+		oldSequence, ok := oldSchemaInfo.sequenceMap[name]
+		if !ok {
+			diff.createSequenceList = append(diff.createSequenceList, newSequence.createSequence)
+			continue
+		}
+		// We alter the sequence instead of recreating it to keep the current value.
+		if oldSequence.normalized != newSequence.normalized && len(newSequence.specList) > 0 {
+			diff.alterSequenceList = append(diff.alterSequenceList, fmt.Sprintf("ALTER SEQUENCE %s %s", newSequence.name, strings.Join(newSequence.specList, " ")))
+		}
+	}
+
+	for name, oldSequence := range oldSchemaInfo.sequenceMap {
+		if _, ok := newSchemaInfo.sequenceMap[name]; !ok {
+			diff.dropSequenceList = append(diff.dropSequenceList, fmt.Sprintf("DROP SEQUENCE %s", oldSequence.name))
+		}
+	}
+}
+
+func (diff *diffNode) diffView(oldSchemaInfo, newSchemaInfo *schemaInfo) {
+	for _, newView := range newSchemaInfo.viewList {
+		if oldView, ok := oldSchemaInfo.viewMap[newView.name]; ok && oldView.normalized == newView.normalized {
+			continue
+		}
+		diff.createViewList = append(diff.createViewList, newView.createView)
+	}
+
+	for name, oldView := range oldSchemaInfo.viewMap {
+		if _, ok := newSchemaInfo.viewMap[name]; !ok {
+			diff.dropViewList = append(diff.dropViewList, fmt.Sprintf("DROP VIEW %s", oldView.name))
+		}
+	}
+}
+
+func (diff *diffNode) deparse() string {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropForeignKeyList,
+		diff.dropConstraintExceptFkList,
+		diff.dropIndexList,
+		diff.dropViewList,
+		diff.dropTableList,
+		diff.dropSequenceList,
+		diff.createSequenceList,
+		diff.alterSequenceList,
+		diff.createTableList,
+		diff.addColumnList,
+		diff.modifyColumnList,
+		diff.dropColumnList,
+		diff.createIndexList,
+		diff.addConstraintExceptFkList,
+		diff.addForeignKeyList,
+	} {
+		sort.Strings(list)
+		writeStatementList(&buf, list)
+	}
+	writeStatementList(&buf, diff.createViewList)
+	return buf.String()
+}
+
+func writeStatementList(buf *strings.Builder, statementList []string) {
+	for _, statement := range statementList {
+		_, _ = buf.WriteString(statement)
+		_, _ = buf.WriteString(";\n\n")
+	}
+}
+
+// buildSchemaInfo returns schema information built by statements.
+func buildSchemaInfo(statements string) (*schemaInfo, error) {
+	result := &schemaInfo{
+		tableMap:    make(map[string]*tableInfo),
+		indexMap:    make(map[string]*indexInfo),
+		sequenceMap: make(map[string]*sequenceInfo),
+		viewMap:     make(map[string]*viewInfo),
+	}
+
+	statementList, err := splitStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	for _, statement := range statementList {
+		if !schemaStatementRegexp.MatchString(statement) {
+			continue
+		}
+		if sequenceStatementRegexp.MatchString(statement) {
+			statement = sequenceOptionRegexp.ReplaceAllString(statement, "")
+		}
+		tree, err := parser.ParsePLSQL(statement + ";")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse statement %q", statement)
+		}
+		script, ok := tree.(*plsql.Sql_scriptContext)
+		if !ok {
+			continue
+		}
+		text := []rune(statement)
+		for _, unit := range script.AllUnit_statement() {
+			switch {
+			case unit.Create_table() != nil:
+				table, err := newTableInfo(text, unit.Create_table())
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := result.tableMap[table.name]; ok {
+					return nil, errors.Errorf("table %s already exists", table.name)
+				}
+				result.tableMap[table.name] = table
+			case unit.Alter_table() != nil:
+				if err := result.addConstraint(text, unit.Alter_table()); err != nil {
+					return nil, err
+				}
+			case unit.Create_index() != nil:
+				index := newIndexInfo(text, unit.Create_index())
+				if index == nil {
+					continue
+				}
+				if _, ok := result.tableMap[index.tableName]; !ok {
+					return nil, errors.Errorf("try to create index %s on table %s, but table not found", index.name, index.tableName)
+				}
+				result.indexMap[index.name] = index
+			case unit.Create_sequence() != nil:
+				sequence := newSequenceInfo(text, unit.Create_sequence())
+				result.sequenceMap[sequence.name] = sequence
+			case unit.Create_view() != nil:
+				view := newViewInfo(text, unit.Create_view())
+				if _, ok := result.viewMap[view.name]; !ok {
+					result.viewList = append(result.viewList, view)
+				}
+				result.viewMap[view.name] = view
+			}
+		}
+	}
+	return result, nil
+}
+
+// splitStatements splits the schema into statements.
+// The schema dumped by Bytebase terminates each statement with a "/" line, otherwise the statements are terminated by semicolons.
+func splitStatements(statements string) ([]string, error) {
+	var result []string
+	if terminatorLineRegexp.MatchString(statements) {
+		for _, statement := range terminatorLineRegexp.Split(statements, -1) {
+			if statement = strings.TrimSpace(statement); statement != "" {
+				result = append(result, strings.TrimSuffix(statement, ";"))
+			}
+		}
+		return result, nil
+	}
+
+	list, err := parser.SplitMultiSQL(parser.Oracle, statements)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to split statements")
+	}
+	for _, sql := range list {
+		if statement := strings.TrimSpace(sql.Text); statement != "" {
+			result = append(result, statement)
+		}
+	}
+	return result, nil
+}
+
+func newTableInfo(text []rune, ctx plsql.ICreate_tableContext) (*tableInfo, error) {
+	table := &tableInfo{
+		name:          quoteName(normalizeSchemaName(ctx.Schema_name()), normalizeIdentifier(ctx.Table_name().Identifier())),
+		constraintMap: make(map[string]*constraintInfo),
+	}
+	relationalTable := ctx.Relational_table()
+	if relationalTable == nil {
+		return nil, errors.Errorf("only relational table is supported, but found %q", getText(text, ctx))
+	}
+
+	propertyList := relationalTable.AllRelational_property()
+	// removeList is the text intervals of the foreign keys, which are added after all tables are created.
+	var removeList [][2]int
+	for i, property := range propertyList {
+		switch {
+		case property.Column_definition() != nil:
+			if constraint, key := newCheckConstraintInfo(text, property.Column_definition()); constraint != nil {
+				table.constraintMap[key] = constraint
+				continue
+			}
+			table.columnList = append(table.columnList, newColumnInfo(text, property.Column_definition()))
+		case property.Virtual_column_definition() != nil:
+			column := property.Virtual_column_definition()
+			table.columnList = append(table.columnList, &columnInfo{
+				name:       normalizeColumnName(column.Column_name()),
+				definition: getText(text, column),
+				others:     normalizeText(column),
+				nullable:   true,
+			})
+		case property.Out_of_line_constraint() != nil, property.Out_of_line_ref_constraint() != nil:
+			constraint, key := newConstraintInfo(text, property)
+			if constraint == nil {
+				continue
+			}
+			table.constraintMap[key] = constraint
+			if !constraint.foreignKey {
+				continue
+			}
+			if i > 0 {
+				removeList = append(removeList, [2]int{propertyList[i-1].GetStop().GetStop() + 1, property.GetStop().GetStop() + 1})
+			} else if len(propertyList) > 1 {
+				removeList = append(removeList, [2]int{property.GetStart().GetStart(), propertyList[1].GetStart().GetStart()})
+			}
+		}
+	}
+
+	// The statement is parsed with a trailing semicolon, which is not in the text.
+	stop := ctx.GetStop().GetStop()
+	if stop >= len(text) {
+		stop = len(text) - 1
+	}
+	var buf strings.Builder
+	start := ctx.GetStart().GetStart()
+	for _, interval := range removeList {
+		_, _ = buf.WriteString(string(text[start:interval[0]]))
+		start = interval[1]
+	}
+	_, _ = buf.WriteString(string(text[start : stop+1]))
+	table.createTable = strings.TrimSpace(buf.String())
+	return table, nil
+}
+
+func newColumnInfo(text []rune, ctx plsql.IColumn_definitionContext) *columnInfo {
+	column := &columnInfo{
+		name:       normalizeColumnName(ctx.Column_name()),
+		definition: getText(text, ctx),
+		nullable:   true,
+	}
+	if ctx.Datatype() != nil {
+		column.dataType = normalizeText(ctx.Datatype())
+		column.dataTypeText = getText(text, ctx.Datatype())
+	} else if ctx.Regular_id() != nil {
+		column.dataType = normalizeText(ctx.Regular_id())
+		column.dataTypeText = getText(text, ctx.Regular_id())
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		defaultText := getTextFromInterval(text, ctx.DEFAULT().GetSymbol().GetStart(), ctx.Expression().GetStop().GetStop())
+		column.defaultText = defaultText
+		column.defaultValue = normalizeText(ctx.Expression())
+		if ctx.ON() != nil {
+			column.defaultValue = "ON NULL " + column.defaultValue
+		}
+	}
+
+	var others []string
+	if ctx.Identity_clause() != nil {
+		others = append(others, normalizeText(ctx.Identity_clause()))
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		if constraint.NULL_() != nil {
+			column.nullable = constraint.NOT() == nil
+			continue
+		}
+		others = append(others, normalizeText(constraint))
+	}
+	if ctx.Inline_ref_constraint() != nil {
+		others = append(others, normalizeText(ctx.Inline_ref_constraint()))
+	}
+	column.others = strings.Join(others, " ")
+	return column
+}
+
+// newConstraintInfo returns the constraint and its key, the key is the constraint name or the normalized definition of the unnamed constraint.
+func newConstraintInfo(text []rune, ctx antlr.ParserRuleContext) (*constraintInfo, string) {
+	var constraintName plsql.IConstraint_nameContext
+	var constraintState plsql.IConstraint_stateContext
+	constraint := &constraintInfo{}
+	switch ctx := ctx.(type) {
+	case *plsql.Relational_propertyContext:
+		if ctx.Out_of_line_constraint() != nil {
+			return newConstraintInfo(text, ctx.Out_of_line_constraint())
+		}
+		return newConstraintInfo(text, ctx.Out_of_line_ref_constraint())
+	case *plsql.Out_of_line_constraintContext:
+		constraintName, constraintState = ctx.Constraint_name(), ctx.Constraint_state()
+		constraint.foreignKey = ctx.Foreign_key_clause() != nil
+		switch {
+		case constraintName != nil:
+		case ctx.PRIMARY() != nil:
+			constraint.dropClause = "PRIMARY KEY"
+		case ctx.UNIQUE() != nil:
+			var columnList []string
+			for _, column := range ctx.AllColumn_name() {
+				columnList = append(columnList, quoteIdentifier(normalizeColumnName(column)))
+			}
+			constraint.dropClause = fmt.Sprintf("UNIQUE (%s)", strings.Join(columnList, ", "))
+		}
+	case *plsql.Out_of_line_ref_constraintContext:
+		if ctx.FOREIGN() == nil {
+			// The SCOPE and WITH ROWID constraints are not supported.
+			return nil, ""
+		}
+		constraintName, constraintState = ctx.Constraint_name(), ctx.Constraint_state()
+		constraint.foreignKey = true
+	default:
+		return nil, ""
+	}
+
+	constraint.definition = getText(text, ctx)
+	constraint.normalized = normalizeText(ctx)
+	if constraintState != nil {
+		constraint.normalized = strings.TrimSpace(strings.TrimSuffix(constraint.normalized, normalizeText(constraintState)))
+	}
+	if constraintName != nil {
+		name := normalizeConstraintName(constraintName)
+		constraint.dropClause = fmt.Sprintf("CONSTRAINT %s", quoteIdentifier(name))
+		return constraint, name
+	}
+	return constraint, constraint.normalized
+}
+
+// newCheckConstraintInfo returns the named check constraint which is parsed as a column definition, such as CONSTRAINT "NAME" CHECK (...).
+// The grammar is ambiguous because the CONSTRAINT keyword can be a column name and the constraint name can be a data type.
+func newCheckConstraintInfo(text []rune, ctx plsql.IColumn_definitionContext) (*constraintInfo, string) {
+	if ctx.Column_name().GetText() != "CONSTRAINT" && ctx.Column_name().GetText() != "constraint" {
+		return nil, ""
+	}
+	constraintList := ctx.AllInline_constraint()
+	if len(constraintList) != 1 || constraintList[0].Check_constraint() == nil {
+		return nil, ""
+	}
+	var name string
+	if ctx.Datatype() != nil {
+		name = normalizeText(ctx.Datatype())
+	} else if ctx.Regular_id() != nil {
+		name = normalizeText(ctx.Regular_id())
+	}
+	constraint := &constraintInfo{
+		definition: getText(text, ctx),
+		normalized: normalizeText(ctx),
+		dropClause: fmt.Sprintf("CONSTRAINT %s", quoteIdentifier(name)),
+	}
+	if constraintState := constraintList[0].Constraint_state(); constraintState != nil {
+		constraint.normalized = strings.TrimSpace(strings.TrimSuffix(constraint.normalized, normalizeText(constraintState)))
+	}
+	return constraint, name
+}
+
+// addConstraint adds the constraints in the ALTER TABLE ADD CONSTRAINT statement to the table, such as the foreign keys in the dump.
+func (s *schemaInfo) addConstraint(text []rune, ctx plsql.IAlter_tableContext) error {
+	clauses := ctx.Constraint_clauses()
+	if clauses == nil || clauses.ADD() == nil {
+		return nil
+	}
+	tableName := normalizeTableviewName(ctx.Tableview_name())
+	table, ok := s.tableMap[tableName]
+	if !ok {
+		return errors.Errorf("try to add constraint on table %s, but table not found", tableName)
+	}
+	var constraintList []antlr.ParserRuleContext
+	for _, constraint := range clauses.AllOut_of_line_constraint() {
+		constraintList = append(constraintList, constraint)
+	}
+	if clauses.Out_of_line_ref_constraint() != nil {
+		constraintList = append(constraintList, clauses.Out_of_line_ref_constraint())
+	}
+	for _, ctx := range constraintList {
+		if constraint, key := newConstraintInfo(text, ctx); constraint != nil {
+			table.constraintMap[key] = constraint
+		}
+	}
+	return nil
+}
+
+func newIndexInfo(text []rune, ctx plsql.ICreate_indexContext) *indexInfo {
+	tableIndex := ctx.Table_index_clause()
+	if tableIndex == nil {
+		// The cluster and bitmap join indexes are not supported.
+		return nil
+	}
+	indexName := ctx.Index_name()
+	schemaName, name := "", normalizeIdentifier(indexName.Identifier())
+	if indexName.Id_expression() != nil {
+		schemaName, name = name, normalizeIDExpression(indexName.Id_expression())
+	}
+	return &indexInfo{
+		name:        quoteName(schemaName, name),
+		tableName:   normalizeTableviewName(tableIndex.Tableview_name()),
+		createIndex: getText(text, ctx),
+		normalized:  normalizeText(ctx),
+	}
+}
+
+func newSequenceInfo(text []rune, ctx plsql.ICreate_sequenceContext) *sequenceInfo {
+	var nameList []string
+	for _, id := range ctx.Sequence_name().AllId_expression() {
+		nameList = append(nameList, quoteIdentifier(normalizeIDExpression(id)))
+	}
+	sequence := &sequenceInfo{
+		name:           strings.Join(nameList, "."),
+		createSequence: getText(text, ctx),
+	}
+	var normalizedList []string
+	for _, spec := range ctx.AllSequence_spec() {
+		sequence.specList = append(sequence.specList, getText(text, spec))
+		normalizedList = append(normalizedList, normalizeText(spec))
+	}
+	sequence.normalized = strings.Join(normalizedList, " ")
+	return sequence
+}
+
+func newViewInfo(text []rune, ctx plsql.ICreate_viewContext) *viewInfo {
+	viewName := normalizeIDExpression(ctx.GetV())
+	view := &viewInfo{
+		name: quoteName(normalizeSchemaName(ctx.Schema_name()), viewName),
+		// The view is compared from the VIEW keyword, so the OR REPLACE, FORCE and editioning clauses are ignored.
+		normalized: normalizeTextFromToken(ctx, ctx.VIEW().GetSymbol().GetTokenIndex()),
+	}
+	createView := getText(text, ctx)
+	if ctx.REPLACE() == nil {
+		createView = "CREATE OR REPLACE " + getTextFromInterval(text, ctx.CREATE().GetSymbol().GetStop()+1, ctx.GetStop().GetStop())
+	}
+	view.createView = createView
+	return view
+}
+
+// getText returns the original text of the context.
+func getText(text []rune, ctx antlr.ParserRuleContext) string {
+	return getTextFromInterval(text, ctx.GetStart().GetStart(), ctx.GetStop().GetStop())
+}
+
+func getTextFromInterval(text []rune, start, stop int) string {
+	if stop >= len(text) {
+		stop = len(text) - 1
+	}
+	if start > stop {
+		return ""
+	}
+	return strings.TrimSpace(string(text[start : stop+1]))
+}
+
+// normalizeText returns the normalized text of the context for comparison, the whitespaces and comments are ignored,
+// the unquoted identifiers and keywords are in upper case, and the quoted identifiers are unquoted.
+func normalizeText(tree antlr.Tree) string {
+	return normalizeTextFromToken(tree, -1)
+}
+
+// normalizeTextFromToken is the same as normalizeText but skips the tokens before the token index.
+func normalizeTextFromToken(tree antlr.Tree, tokenIndex int) string {
+	var tokenList []string
+	var walk func(antlr.Tree)
+	walk = func(tree antlr.Tree) {
+		if node, ok := tree.(antlr.TerminalNode); ok {
+			token := node.GetSymbol()
+			if token.GetTokenIndex() < tokenIndex || token.GetTokenType() == antlr.TokenEOF || token.GetTokenType() == plsql.PlSqlParserSEMICOLON {
+				return
+			}
+			switch token.GetTokenType() {
+			case plsql.PlSqlParserDELIMITED_ID:
+				tokenList = append(tokenList, strings.Trim(token.GetText(), `"`))
+			case plsql.PlSqlParserCHAR_STRING, plsql.PlSqlParserNATIONAL_CHAR_STRING_LIT:
+				tokenList = append(tokenList, token.GetText())
+			default:
+				tokenList = append(tokenList, strings.ToUpper(token.GetText()))
+			}
+			return
+		}
+		for i := 0; i < tree.GetChildCount(); i++ {
+			walk(tree.GetChild(i))
+		}
+	}
+	walk(tree)
+	return strings.Join(tokenList, " ")
+}
+
+func normalizeIDExpression(ctx plsql.IId_expressionContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if ctx.Regular_id() != nil {
+		return strings.ToUpper(ctx.Regular_id().GetText())
+	}
+	if ctx.DELIMITED_ID() != nil {
+		return strings.Trim(ctx.DELIMITED_ID().GetText(), `"`)
+	}
+	return ""
+}
+
+func normalizeIdentifier(ctx plsql.IIdentifierContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return normalizeIDExpression(ctx.Id_expression())
+}
+
+func normalizeSchemaName(ctx plsql.ISchema_nameContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return normalizeIdentifier(ctx.Identifier())
+}
+
+func normalizeColumnName(ctx plsql.IColumn_nameContext) string {
+	list := []string{normalizeIdentifier(ctx.Identifier())}
+	for _, id := range ctx.AllId_expression() {
+		list = append(list, normalizeIDExpression(id))
+	}
+	return list[len(list)-1]
+}
+
+func normalizeConstraintName(ctx plsql.IConstraint_nameContext) string {
+	list := []string{normalizeIdentifier(ctx.Identifier())}
+	for _, id := range ctx.AllId_expression() {
+		list = append(list, normalizeIDExpression(id))
+	}
+	return list[len(list)-1]
+}
+
+// normalizeTableviewName returns the quoted table name.
+func normalizeTableviewName(ctx plsql.ITableview_nameContext) string {
+	if ctx.Id_expression() != nil {
+		return quoteName(normalizeIdentifier(ctx.Identifier()), normalizeIDExpression(ctx.Id_expression()))
+	}
+	return quoteName("", normalizeIdentifier(ctx.Identifier()))
+}
+
+func quoteName(schemaName, name string) string {
+	if schemaName == "" {
+		return quoteIdentifier(name)
+	}
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(name))
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, name)
+}
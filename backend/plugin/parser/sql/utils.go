@@ -30,6 +30,8 @@ func GetSQLFingerprint(engineType EngineType, sql string) (string, error) {
 	switch engineType {
 	case MySQL, TiDB, MariaDB:
 		return getMySQLFingerprint(sql)
+	case Oracle, MSSQL:
+		return getStandardFingerprint(sql), nil
 	default:
 		return "", errors.Errorf("engine type is not supported: %s", engineType)
 	}
@@ -195,6 +197,36 @@ func collapseUnion(query string) (string, error) {
 	return buf.String(), nil
 }
 
+var (
+	standardMultiLineCommentRegexp  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	standardSingleLineCommentRegexp = regexp.MustCompile(`(?m)--.*$`)
+	standardStringRegexp            = regexp.MustCompile(`[nN]?'(?:[^']|'')*'`)
+	standardHexRegexp               = regexp.MustCompile(`\b0[xX][0-9a-fA-F]*\b`)
+	standardNumberRegexp            = regexp.MustCompile(`\b[0-9]+(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?\b`)
+	standardNullRegexp              = regexp.MustCompile(`\bnull\b`)
+	standardListRegexp              = regexp.MustCompile(`\b(in|values?)(?:[\s,]*\([\s?,]*\))+`)
+	whitespaceRegexp                = regexp.MustCompile(`\s+`)
+)
+
+// getStandardFingerprint returns the fingerprint of the SQL for Oracle and SQL Server.
+// Different from MySQL, the strings are quoted by single quotes with doubled single quotes as escapes,
+// and the double quotes and square brackets are kept because they quote identifiers.
+func getStandardFingerprint(query string) string {
+	query = standardMultiLineCommentRegexp.ReplaceAllString(query, "")
+	query = standardSingleLineCommentRegexp.ReplaceAllString(query, "")
+	query = standardStringRegexp.ReplaceAllString(query, "?")
+	query = standardHexRegexp.ReplaceAllString(query, "?")
+	query = standardNumberRegexp.ReplaceAllString(query, "?")
+
+	query = strings.TrimSpace(query)
+	query = strings.TrimRight(query, ";")
+	query = whitespaceRegexp.ReplaceAllString(strings.TrimSpace(query), " ")
+	query = strings.ToLower(query)
+
+	query = standardNullRegexp.ReplaceAllString(query, "?")
+	return standardListRegexp.ReplaceAllString(query, "$1(?+)")
+}
+
 // SplitMultiSQLAndNormalize split multiple SQLs and normalize them.
 // For MySQL, filter DELIMITER statements and replace all non-semicolon delimiters with semicolons.
 func SplitMultiSQLAndNormalize(engineType EngineType, statement string) ([]SingleSQL, error) {
@@ -216,6 +216,40 @@ func TestExtractDatabaseList(t *testing.T) {
 	}
 }
 
+func TestGetStandardFingerprint(t *testing.T) {
+	tests := []struct {
+		stmt string
+		want string
+	}{
+		{
+			stmt: "-- this is comment\nSELECT * FROM \"MyTable\" WHERE ID = 1",
+			want: `select * from "mytable" where id = ?`,
+		},
+		{
+			stmt: "SELECT * FROM [dbo].[t1] /* comment */ WHERE [name] = N'it''s' AND flag = 0x1F;",
+			want: "select * from [dbo].[t1] where [name] = ? and flag = ?",
+		},
+		{
+			stmt: "SELECT *\n  FROM t\n WHERE id IN (1, 2, 3) AND deleted_at IS NULL",
+			want: "select * from t where id in(?+) and deleted_at is ?",
+		},
+		{
+			stmt: "INSERT INTO t (a, b) VALUES (1.5, 'x')",
+			want: "insert into t (a, b) values(?+)",
+		},
+		{
+			stmt: "SELECT * FROM t WHERE id = :1",
+			want: "select * from t where id = :?",
+		},
+	}
+
+	for _, test := range tests {
+		res, err := GetSQLFingerprint(Oracle, test.stmt)
+		require.NoError(t, err)
+		require.Equal(t, test.want, res)
+	}
+}
+
 func TestGetMySQLFingerprint(t *testing.T) {
 	tests := []struct {
 		stmt string
@@ -0,0 +1,104 @@
+// Package azblob provides the client for Azure Blob Storage.
+package azblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+var _ storage.Backend = (*Client)(nil)
+
+// Client wraps the Azure Blob Storage client.
+type Client struct {
+	c         *azblob.Client
+	container string
+}
+
+// NewClient returns a new Azure Blob Storage client authenticated with the shared key of the storage account.
+// The endpoint is the URL of the blob service. An empty endpoint means https://<accountName>.blob.core.windows.net/.
+func NewClient(accountName, accountKey, container, endpoint string) (*Client, error) {
+	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure Blob Storage shared key credential")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+	}
+	c, err := azblob.NewClientWithSharedKeyCredential(endpoint, credential, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure Blob Storage client")
+	}
+	return &Client{
+		c:         c,
+		container: container,
+	}, nil
+}
+
+// Put uploads the object with path.
+func (c *Client) Put(ctx context.Context, path string, body io.Reader) error {
+	if _, err := c.c.UploadStream(ctx, c.container, path, body, nil); err != nil {
+		return errors.Wrapf(err, "failed to upload object %q", path)
+	}
+	return nil
+}
+
+// Get returns the reader of the object with path.
+func (c *Client) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	resp, err := c.c.DownloadStream(ctx, c.container, path, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object %q", path)
+	}
+	return resp.Body, nil
+}
+
+// List lists the objects with prefix in their paths.
+func (c *Client) List(ctx context.Context, prefix string) ([]*storage.ObjectInfo, error) {
+	var ret []*storage.ObjectInfo
+	pager := c.c.NewListBlobsFlatPager(c.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the next page of Azure blobs")
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			info := &storage.ObjectInfo{
+				Path: *item.Name,
+			}
+			if item.Properties != nil {
+				if item.Properties.ContentLength != nil {
+					info.Size = *item.Properties.ContentLength
+				}
+				if item.Properties.LastModified != nil {
+					info.LastModified = *item.Properties.LastModified
+				}
+			}
+			ret = append(ret, info)
+		}
+	}
+	return ret, nil
+}
+
+// Delete deletes the objects with paths.
+func (c *Client) Delete(ctx context.Context, paths ...string) error {
+	for _, path := range paths {
+		if _, err := c.c.DeleteBlob(ctx, c.container, path, nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return errors.Wrapf(err, "failed to delete object %q", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+// Package codec provides the streaming compression, encryption and checksum of the backup files.
+//
+// The backup file is encoded as checksum(encrypt(compress(dump))).
+// The encryption is the envelope encryption: each backup file is encrypted by a random data key,
+// and the data key is encrypted by the workspace key and stored along with the backup record.
+package codec
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Compression is the compression algorithm of the backup file.
+type Compression string
+
+const (
+	// CompressionNone means the backup file is not compressed.
+	CompressionNone Compression = ""
+	// CompressionGzip is the gzip compression.
+	CompressionGzip Compression = "GZIP"
+	// CompressionZstd is the zstd compression.
+	CompressionZstd Compression = "ZSTD"
+)
+
+const (
+	// KeySize is the size of the workspace key and the data keys, which selects AES-256.
+	KeySize = 32
+	// chunkSize is the maximum size of the plaintext in an encrypted chunk.
+	chunkSize = 64 * 1024
+	// chunkHeaderSize is the size of the big-endian ciphertext length before each encrypted chunk.
+	chunkHeaderSize = 4
+)
+
+// GenerateKey generates a random key for the workspace key or the data key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate random key")
+	}
+	return key, nil
+}
+
+// KeyID returns the identifier of the key, which is used to tell which workspace key encrypts a data key.
+func KeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// WrapKey encrypts the data key by the workspace key.
+func WrapKey(workspaceKey, dataKey []byte) ([]byte, error) {
+	aead, err := newAEAD(workspaceKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+// UnwrapKey decrypts the data key by the workspace key.
+func UnwrapKey(workspaceKey, wrappedKey []byte) ([]byte, error) {
+	aead, err := newAEAD(workspaceKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, errors.Errorf("invalid wrapped key")
+	}
+	nonce, ciphertext := wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data key")
+	}
+	return dataKey, nil
+}
+
+// Checksum returns the SHA-256 checksum in hex of the content.
+func Checksum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", errors.Wrap(err, "failed to compute checksum")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Writer encodes the dump written to it and writes the encoded backup file to the underlying writer.
+type Writer struct {
+	compressor io.WriteCloser
+	encryptor  *encryptWriter
+	counter    *countingWriter
+	hash       hash.Hash
+	size       int64
+}
+
+// NewWriter returns a writer encoding the dump with the compression and the data key.
+// An empty data key means no encryption.
+// The caller must call Close to flush the encoded backup file.
+func NewWriter(w io.Writer, compression Compression, dataKey []byte) (*Writer, error) {
+	bw := &Writer{hash: sha256.New()}
+	bw.counter = &countingWriter{w: io.MultiWriter(w, bw.hash)}
+	var next io.Writer = bw.counter
+	if len(dataKey) > 0 {
+		aead, err := newAEAD(dataKey)
+		if err != nil {
+			return nil, err
+		}
+		bw.encryptor = &encryptWriter{w: next, aead: aead, buf: make([]byte, 0, chunkSize)}
+		next = bw.encryptor
+	}
+	switch compression {
+	case CompressionNone:
+		bw.compressor = nopWriteCloser{next}
+	case CompressionGzip:
+		bw.compressor = gzip.NewWriter(next)
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(next)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd encoder")
+		}
+		bw.compressor = encoder
+	default:
+		return nil, errors.Errorf("unsupported compression %q", compression)
+	}
+	return bw, nil
+}
+
+// Write writes the dump.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.compressor.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close flushes the encoded backup file. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.compressor.Close(); err != nil {
+		return errors.Wrap(err, "failed to flush compressed backup")
+	}
+	if w.encryptor != nil {
+		if err := w.encryptor.Close(); err != nil {
+			return errors.Wrap(err, "failed to flush encrypted backup")
+		}
+	}
+	return nil
+}
+
+// Checksum returns the SHA-256 checksum in hex of the encoded backup file. It is valid after Close.
+func (w *Writer) Checksum() string {
+	return hex.EncodeToString(w.hash.Sum(nil))
+}
+
+// DumpSize returns the size of the dump before encoding.
+func (w *Writer) DumpSize() int64 {
+	return w.size
+}
+
+// EncodedSize returns the size of the encoded backup file. It is valid after Close.
+func (w *Writer) EncodedSize() int64 {
+	return w.counter.n
+}
+
+// NewReader returns a reader decoding the encoded backup file with the compression and the data key.
+// An empty data key means the backup file is not encrypted.
+func NewReader(r io.Reader, compression Compression, dataKey []byte) (io.ReadCloser, error) {
+	var next io.Reader = r
+	if len(dataKey) > 0 {
+		aead, err := newAEAD(dataKey)
+		if err != nil {
+			return nil, err
+		}
+		next = &decryptReader{r: bufio.NewReader(r), aead: aead}
+	}
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(next), nil
+	case CompressionGzip:
+		reader, err := gzip.NewReader(next)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gzip decoder")
+		}
+		return reader, nil
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(next)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd decoder")
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, errors.Errorf("unsupported compression %q", compression)
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errors.Errorf("invalid key size %d, expecting %d", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AES-GCM")
+	}
+	return aead, nil
+}
+
+// chunkNonce returns the nonce of the encrypted chunk.
+// The data key is used for a single backup file, so the nonce only needs to be unique in the file.
+// The last chunk is marked in the nonce so that a truncated backup file fails to decrypt.
+func chunkNonce(size int, seq uint64, last bool) []byte {
+	nonce := make([]byte, size)
+	binary.BigEndian.PutUint64(nonce, seq)
+	if last {
+		nonce[size-1] = 1
+	}
+	return nonce
+}
+
+// encryptWriter encrypts the content by chunks, each of which is prefixed by the ciphertext length.
+type encryptWriter struct {
+	w    io.Writer
+	aead cipher.AEAD
+	buf  []byte
+	seq  uint64
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+		// Keep the full buffer until the next write, because the last chunk must be sealed in Close.
+		if len(w.buf) == cap(w.buf) && len(p) > 0 {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (w *encryptWriter) Close() error {
+	return w.seal(true)
+}
+
+func (w *encryptWriter) seal(last bool) error {
+	ciphertext := w.aead.Seal(nil, chunkNonce(w.aead.NonceSize(), w.seq, last), w.buf, nil)
+	header := make([]byte, chunkHeaderSize)
+	binary.BigEndian.PutUint32(header, uint32(len(ciphertext)))
+	if _, err := w.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(ciphertext); err != nil {
+		return err
+	}
+	w.seq++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// decryptReader decrypts the chunks written by encryptWriter.
+type decryptReader struct {
+	r    *bufio.Reader
+	aead cipher.AEAD
+	buf  []byte
+	seq  uint64
+	done bool
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *decryptReader) open() error {
+	header := make([]byte, chunkHeaderSize)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return errors.Wrap(err, "encrypted backup is truncated")
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size > chunkSize+uint32(r.aead.Overhead()) {
+		return errors.Errorf("invalid encrypted chunk size %d", size)
+	}
+	ciphertext := make([]byte, size)
+	if _, err := io.ReadFull(r.r, ciphertext); err != nil {
+		return errors.Wrap(err, "encrypted backup is truncated")
+	}
+	// The chunk is the last one if nothing follows it.
+	_, err := r.r.Peek(1)
+	last := err == io.EOF
+	plaintext, err := r.aead.Open(nil, chunkNonce(r.aead.NonceSize(), r.seq, last), ciphertext, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt backup, the backup is corrupted or the key is wrong")
+	}
+	r.seq++
+	r.buf = plaintext
+	r.done = last
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	a := require.New(t)
+	dataKey, err := GenerateKey()
+	a.NoError(err)
+	dumps := []string{
+		"",
+		"CREATE TABLE t(a INT);",
+		strings.Repeat("INSERT INTO t VALUES (1);\n", 10000),
+	}
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		for _, key := range [][]byte{nil, dataKey} {
+			for _, dump := range dumps {
+				var encoded bytes.Buffer
+				w, err := NewWriter(&encoded, compression, key)
+				a.NoError(err)
+				_, err = io.Copy(w, strings.NewReader(dump))
+				a.NoError(err)
+				a.NoError(w.Close())
+				a.Equal(int64(len(dump)), w.DumpSize())
+				a.Equal(int64(encoded.Len()), w.EncodedSize())
+
+				checksum, err := Checksum(bytes.NewReader(encoded.Bytes()))
+				a.NoError(err)
+				a.Equal(checksum, w.Checksum())
+
+				r, err := NewReader(bytes.NewReader(encoded.Bytes()), compression, key)
+				a.NoError(err)
+				decoded, err := io.ReadAll(r)
+				a.NoError(err)
+				a.NoError(r.Close())
+				a.Equal(dump, string(decoded))
+			}
+		}
+	}
+}
+
+func TestTamperedBackup(t *testing.T) {
+	a := require.New(t)
+	dataKey, err := GenerateKey()
+	a.NoError(err)
+	dump := strings.Repeat("INSERT INTO t VALUES (1);\n", 10000)
+
+	var encoded bytes.Buffer
+	w, err := NewWriter(&encoded, CompressionNone, dataKey)
+	a.NoError(err)
+	_, err = io.Copy(w, strings.NewReader(dump))
+	a.NoError(err)
+	a.NoError(w.Close())
+	content := encoded.Bytes()
+
+	// Flip a byte in the first chunk.
+	tampered := append([]byte{}, content...)
+	tampered[chunkHeaderSize] ^= 0xff
+	r, err := NewReader(bytes.NewReader(tampered), CompressionNone, dataKey)
+	a.NoError(err)
+	_, err = io.ReadAll(r)
+	a.Error(err)
+
+	// Drop the last chunk.
+	truncated := content[:chunkHeaderSize+chunkSize+16]
+	r, err = NewReader(bytes.NewReader(truncated), CompressionNone, dataKey)
+	a.NoError(err)
+	_, err = io.ReadAll(r)
+	a.Error(err)
+
+	// Use a wrong key.
+	wrongKey, err := GenerateKey()
+	a.NoError(err)
+	r, err = NewReader(bytes.NewReader(content), CompressionNone, wrongKey)
+	a.NoError(err)
+	_, err = io.ReadAll(r)
+	a.Error(err)
+}
+
+func TestWrapKey(t *testing.T) {
+	a := require.New(t)
+	workspaceKey, err := GenerateKey()
+	a.NoError(err)
+	dataKey, err := GenerateKey()
+	a.NoError(err)
+
+	wrapped, err := WrapKey(workspaceKey, dataKey)
+	a.NoError(err)
+	unwrapped, err := UnwrapKey(workspaceKey, wrapped)
+	a.NoError(err)
+	a.Equal(dataKey, unwrapped)
+
+	otherKey, err := GenerateKey()
+	a.NoError(err)
+	a.NotEqual(KeyID(workspaceKey), KeyID(otherKey))
+	_, err = UnwrapKey(otherKey, wrapped)
+	a.Error(err)
+}
@@ -0,0 +1,136 @@
+// Package fs provides the backup storage on a mounted directory, e.g. an NFS share.
+package fs
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+var _ storage.Backend = (*Client)(nil)
+
+// Client stores the objects as files under the root directory.
+type Client struct {
+	root string
+}
+
+// NewClient returns a new client storing the objects under the root directory.
+// The root directory must be an existing directory.
+func NewClient(root string) (*Client, error) {
+	if !filepath.IsAbs(root) {
+		return nil, errors.Errorf("backup storage directory %q must be an absolute path", root)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat backup storage directory %q", root)
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("backup storage directory %q is not a directory", root)
+	}
+	return &Client{root: root}, nil
+}
+
+// Put writes the object with path.
+// The object is written to a temporary file first and then renamed, so the readers never see a partial object.
+func (c *Client) Put(_ context.Context, path string, body io.Reader) error {
+	filePath, err := c.filePath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory for object %q", path)
+	}
+	fileTemp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for object %q", path)
+	}
+	defer os.Remove(fileTemp.Name())
+	if _, err := io.Copy(fileTemp, body); err != nil {
+		_ = fileTemp.Close()
+		return errors.Wrapf(err, "failed to write object %q", path)
+	}
+	if err := fileTemp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write object %q", path)
+	}
+	if err := os.Rename(fileTemp.Name(), filePath); err != nil {
+		return errors.Wrapf(err, "failed to write object %q", path)
+	}
+	return nil
+}
+
+// Get returns the reader of the object with path.
+func (c *Client) Get(_ context.Context, path string) (io.ReadCloser, error) {
+	filePath, err := c.filePath(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object %q", path)
+	}
+	return file, nil
+}
+
+// List lists the objects with prefix in their paths.
+func (c *Client) List(_ context.Context, prefix string) ([]*storage.ObjectInfo, error) {
+	var ret []*storage.ObjectInfo
+	err := filepath.WalkDir(c.root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(c.root, filePath)
+		if err != nil {
+			return err
+		}
+		path := filepath.ToSlash(rel)
+		if !strings.HasPrefix(path, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		ret = append(ret, &storage.ObjectInfo{
+			Path:         path,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects with prefix %q", prefix)
+	}
+	return ret, nil
+}
+
+// Delete deletes the objects with paths.
+func (c *Client) Delete(_ context.Context, paths ...string) error {
+	for _, path := range paths {
+		filePath, err := c.filePath(path)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to delete object %q", path)
+		}
+	}
+	return nil
+}
+
+// filePath returns the file path of the object, and rejects the paths escaping the root directory.
+func (c *Client) filePath(path string) (string, error) {
+	if path == "" || !fs.ValidPath(path) {
+		return "", errors.Errorf("invalid object path %q", path)
+	}
+	return filepath.Join(c.root, filepath.FromSlash(path)), nil
+}
@@ -0,0 +1,64 @@
+package fs
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemOperations(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	client, err := NewClient(t.TempDir())
+	a.NoError(err)
+
+	a.NoError(client.Put(ctx, "backup/db/1.sql", strings.NewReader("CREATE TABLE t(a INT);")))
+	a.NoError(client.Put(ctx, "backup/db/2.sql", strings.NewReader("")))
+	a.NoError(client.Put(ctx, "binlog/1/binlog.000001", strings.NewReader("binlog")))
+	// Overwrite the existing object.
+	a.NoError(client.Put(ctx, "backup/db/1.sql", strings.NewReader("CREATE TABLE t(b INT);")))
+
+	reader, err := client.Get(ctx, "backup/db/1.sql")
+	a.NoError(err)
+	content, err := io.ReadAll(reader)
+	a.NoError(err)
+	a.NoError(reader.Close())
+	a.Equal("CREATE TABLE t(b INT);", string(content))
+
+	list, err := client.List(ctx, "backup/")
+	a.NoError(err)
+	var paths []string
+	for _, object := range list {
+		paths = append(paths, object.Path)
+	}
+	sort.Strings(paths)
+	a.Equal([]string{"backup/db/1.sql", "backup/db/2.sql"}, paths)
+
+	a.NoError(client.Delete(ctx, "backup/db/1.sql", "backup/db/not-exist.sql"))
+	_, err = client.Get(ctx, "backup/db/1.sql")
+	a.Error(err)
+	list, err = client.List(ctx, "")
+	a.NoError(err)
+	a.Len(list, 2)
+}
+
+func TestFilesystemInvalidPath(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	client, err := NewClient(t.TempDir())
+	a.NoError(err)
+
+	for _, path := range []string{"", "../escape", "/abs/path", "a/../../b"} {
+		a.Error(client.Put(ctx, path, strings.NewReader("")), path)
+		_, err := client.Get(ctx, path)
+		a.Error(err, path)
+		a.Error(client.Delete(ctx, path), path)
+	}
+
+	_, err = NewClient("relative/dir")
+	a.Error(err)
+}
@@ -0,0 +1,89 @@
+// Package gcs provides the client for Google Cloud Storage.
+package gcs
+
+import (
+	"context"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	bbstorage "github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+var _ bbstorage.Backend = (*Client)(nil)
+
+// Client wraps the Google Cloud Storage client.
+type Client struct {
+	c      *storage.Client
+	bucket string
+}
+
+// NewClient returns a new Google Cloud Storage client.
+// The credentialJSON is the content of the service account key file.
+func NewClient(ctx context.Context, bucket string, credentialJSON []byte) (*Client, error) {
+	c, err := storage.NewClient(ctx, option.WithCredentialsJSON(credentialJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Google Cloud Storage client")
+	}
+	return &Client{
+		c:      c,
+		bucket: bucket,
+	}, nil
+}
+
+// Put uploads the object with path.
+func (c *Client) Put(ctx context.Context, path string, body io.Reader) error {
+	w := c.c.Bucket(c.bucket).Object(path).NewWriter(ctx)
+	if _, err := io.Copy(w, body); err != nil {
+		_ = w.Close()
+		return errors.Wrapf(err, "failed to upload object %q", path)
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrapf(err, "failed to upload object %q", path)
+	}
+	return nil
+}
+
+// Get returns the reader of the object with path.
+func (c *Client) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	r, err := c.c.Bucket(c.bucket).Object(path).NewReader(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object %q", path)
+	}
+	return r, nil
+}
+
+// List lists the objects with prefix in their paths.
+func (c *Client) List(ctx context.Context, prefix string) ([]*bbstorage.ObjectInfo, error) {
+	var ret []*bbstorage.ObjectInfo
+	it := c.c.Bucket(c.bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Google Cloud Storage objects")
+		}
+		ret = append(ret, &bbstorage.ObjectInfo{
+			Path:         attrs.Name,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+		})
+	}
+	return ret, nil
+}
+
+// Delete deletes the objects with paths.
+func (c *Client) Delete(ctx context.Context, paths ...string) error {
+	bucket := c.c.Bucket(c.bucket)
+	for _, path := range paths {
+		if err := bucket.Object(path).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return errors.Wrapf(err, "failed to delete object %q", path)
+		}
+	}
+	return nil
+}
@@ -1,10 +1,9 @@
-// Package s3 provides the client for AWS S3 storage.
+// Package s3 provides the client for AWS S3 and S3-compatible storage.
 package s3
 
 import (
 	"context"
 	"io"
-	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -13,12 +12,21 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 )
 
+var _ storage.Backend = (*Client)(nil)
+
+// deleteObjectsBatchSize is the maximum number of objects in a single DeleteObjects request.
+const deleteObjectsBatchSize = 1000
+
 // Client wraps the AWS S3 client.
 type Client struct {
 	c      *s3.Client
 	bucket string
+	// compatible is true if the client connects to an S3-compatible endpoint instead of AWS S3.
+	compatible bool
 }
 
 // GetCredentialsFromFile load AWS credentials from file.
@@ -38,6 +46,13 @@ func GetCredentialsFromFile(ctx context.Context, credentialsFileName string) (aw
 
 // NewClient returns a new AWS S3 client.
 func NewClient(ctx context.Context, region, bucket string, credentials aws.Credentials) (*Client, error) {
+	return NewCompatibleClient(ctx, region, bucket, "" /* endpoint */, false /* usePathStyle */, credentials)
+}
+
+// NewCompatibleClient returns a new client for the S3-compatible storage such as MinIO.
+// The endpoint is the URL of the storage service, e.g. http://minio:9000. An empty endpoint means AWS S3.
+// Most self-hosted S3-compatible storages require the path-style addressing.
+func NewCompatibleClient(ctx context.Context, region, bucket, endpoint string, usePathStyle bool, credentials aws.Credentials) (*Client, error) {
 	cfg, err := awsconfig.LoadDefaultConfig(ctx,
 		awsconfig.WithRegion(region),
 		awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(credentials.AccessKeyID, credentials.SecretAccessKey, "")),
@@ -45,9 +60,16 @@ func NewClient(ctx context.Context, region, bucket string, credentials aws.Crede
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load AWS S3 config")
 	}
+	c := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.EndpointResolver = s3.EndpointResolverFromURL(endpoint)
+		}
+		o.UsePathStyle = usePathStyle
+	})
 	return &Client{
-		c:      s3.NewFromConfig(cfg),
-		bucket: bucket,
+		c:          c,
+		bucket:     bucket,
+		compatible: endpoint != "",
 	}, nil
 }
 
@@ -82,12 +104,16 @@ func (c *Client) DownloadObject(ctx context.Context, path string, w io.WriterAt)
 // Defaults to multipart upload with chunk size 5MB.
 func (c *Client) UploadObject(ctx context.Context, path string, body io.Reader) (*manager.UploadOutput, error) {
 	uploader := manager.NewUploader(c.c)
-	return uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket:            &c.bucket,
-		Key:               &path,
-		Body:              body,
-		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
-	})
+	input := &s3.PutObjectInput{
+		Bucket: &c.bucket,
+		Key:    &path,
+		Body:   body,
+	}
+	// Not all S3-compatible storages support the additional checksum algorithms.
+	if !c.compatible {
+		input.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
+	}
+	return uploader.Upload(ctx, input)
 }
 
 // DeleteObjects deletes the objects with path.
@@ -108,20 +134,62 @@ func (c *Client) GetBucket() string {
 	return c.bucket
 }
 
-// DownloadFileFromCloud downloads a binlog or metadata file from the cloud storage.
-// In case of network errors which will get partially downloaded files, we first download to a temporary file.
-// After that, we then rename it to the target file path.
-func (c *Client) DownloadFileFromCloud(ctx context.Context, filePathLocal, filePathOnCloud string) error {
-	filePathTemp := filePathLocal + ".tmp"
-	fileTemp, err := os.Create(filePathTemp)
+// Put uploads the object with path.
+func (c *Client) Put(ctx context.Context, path string, body io.Reader) error {
+	if _, err := c.UploadObject(ctx, path, body); err != nil {
+		return errors.Wrapf(err, "failed to upload object %q", path)
+	}
+	return nil
+}
+
+// Get returns the reader of the object with path.
+func (c *Client) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	output, err := c.c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucket,
+		Key:    &path,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object %q", path)
+	}
+	return output.Body, nil
+}
+
+// List lists the objects with prefix in their paths.
+func (c *Client) List(ctx context.Context, prefix string) ([]*storage.ObjectInfo, error) {
+	objects, err := c.ListObjects(ctx, prefix)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
+		return nil, err
 	}
-	if _, err := c.DownloadObject(ctx, filePathOnCloud, fileTemp); err != nil {
-		return errors.Wrapf(err, "failed to download file %q from the cloud storage", filePathOnCloud)
+	var ret []*storage.ObjectInfo
+	for _, object := range objects {
+		info := &storage.ObjectInfo{
+			Path: aws.ToString(object.Key),
+			Size: object.Size,
+		}
+		if object.LastModified != nil {
+			info.LastModified = *object.LastModified
+		}
+		ret = append(ret, info)
 	}
-	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
-		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
+	return ret, nil
+}
+
+// Delete deletes the objects with paths.
+func (c *Client) Delete(ctx context.Context, paths ...string) error {
+	for len(paths) > 0 {
+		batch := paths
+		if len(batch) > deleteObjectsBatchSize {
+			batch = batch[:deleteObjectsBatchSize]
+		}
+		paths = paths[len(batch):]
+		output, err := c.DeleteObjects(ctx, batch...)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete objects")
+		}
+		if len(output.Errors) > 0 {
+			e := output.Errors[0]
+			return errors.Errorf("failed to delete object %q: %s", aws.ToString(e.Key), aws.ToString(e.Message))
+		}
 	}
 	return nil
 }
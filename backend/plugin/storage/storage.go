@@ -0,0 +1,73 @@
+// Package storage provides the interface of the object storage where the backups and binlog files are stored.
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ObjectInfo is the metadata of an object in the storage.
+type ObjectInfo struct {
+	// Path is the full path of the object in the storage.
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Backend is the interface of a backup storage backend.
+// The object paths are slash-separated and relative to the root of the backend, e.g. the bucket.
+type Backend interface {
+	// Put writes the object with path, overwriting the existing one.
+	Put(ctx context.Context, path string, body io.Reader) error
+	// Get returns the reader of the object with path. The caller should close the reader.
+	Get(ctx context.Context, path string) (io.ReadCloser, error)
+	// List lists the objects with prefix in their paths.
+	List(ctx context.Context, prefix string) ([]*ObjectInfo, error)
+	// Delete deletes the objects with paths. Deleting a non-existent object is not an error.
+	Delete(ctx context.Context, paths ...string) error
+}
+
+// UploadFile uploads the local file to the path in the storage backend.
+func UploadFile(ctx context.Context, backend Backend, filePathLocal, path string) error {
+	file, err := os.Open(filePathLocal)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %q", filePathLocal)
+	}
+	defer file.Close()
+	if err := backend.Put(ctx, path, file); err != nil {
+		return errors.Wrapf(err, "failed to upload file %q to the backup storage", filePathLocal)
+	}
+	return nil
+}
+
+// DownloadFile downloads the object with path to the local file.
+// In case of network errors which will get partially downloaded files, we first download to a temporary file.
+// After that, we then rename it to the target file path.
+func DownloadFile(ctx context.Context, backend Backend, filePathLocal, path string) error {
+	reader, err := backend.Get(ctx, path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to download file %q from the backup storage", path)
+	}
+	defer reader.Close()
+
+	filePathTemp := filePathLocal + ".tmp"
+	fileTemp, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
+	}
+	defer fileTemp.Close()
+	if _, err := io.Copy(fileTemp, reader); err != nil {
+		return errors.Wrapf(err, "failed to download file %q from the backup storage", path)
+	}
+	if err := fileTemp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close the local temporary file %s", filePathTemp)
+	}
+	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GoogleChatWebhook is the API message for Google Chat space webhook.
+type GoogleChatWebhook struct {
+	Text string `json:"text"`
+}
+
+// GoogleChatWebhookResponse is the API message for Google Chat space webhook response.
+type GoogleChatWebhookResponse struct {
+	Name string `json:"name"`
+}
+
+func init() {
+	register("bb.plugin.webhook.googlechat", &GoogleChatReceiver{})
+}
+
+// GoogleChatReceiver is the receiver for Google Chat spaces.
+type GoogleChatReceiver struct {
+}
+
+func (*GoogleChatReceiver) post(context Context) error {
+	status := ""
+	switch context.Level {
+	case WebhookSuccess:
+		status = "✅ "
+	case WebhookWarn:
+		status = "⚠️ "
+	case WebhookError:
+		status = "❗ "
+	}
+
+	// Google Chat text messages support the basic formatting, see https://developers.google.com/chat/format-messages.
+	lineList := []string{fmt.Sprintf("*%s%s*", status, context.Title)}
+	if context.Description != "" {
+		lineList = append(lineList, fmt.Sprintf("```%s```", context.Description))
+	}
+	for _, meta := range context.getMetaList() {
+		lineList = append(lineList, fmt.Sprintf("*%s:* %s", meta.Name, meta.Value))
+	}
+	lineList = append(lineList, fmt.Sprintf("By: %s (%s)", context.CreatorName, context.CreatorEmail))
+	lineList = append(lineList, fmt.Sprintf("<%s|View in Bytebase>", context.Link))
+
+	post := GoogleChatWebhook{
+		Text: strings.Join(lineList, "\n"),
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", context.URL)
+	}
+	req, err := http.NewRequest("POST",
+		context.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct webhook POST request to %s", context.URL)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	client := context.newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to POST webhook to %s", context.URL)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read POST webhook response from %s", context.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
+	}
+
+	webhookResponse := &GoogleChatWebhookResponse{}
+	if err := json.Unmarshal(b, webhookResponse); err != nil {
+		return errors.Wrapf(err, "malformed webhook response from %s", context.URL)
+	}
+
+	return nil
+}
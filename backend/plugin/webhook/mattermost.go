@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MattermostWebhook is the API message for Mattermost incoming webhook.
+type MattermostWebhook struct {
+	Text string `json:"text"`
+}
+
+func init() {
+	register("bb.plugin.webhook.mattermost", &MattermostReceiver{})
+}
+
+// MattermostReceiver is the receiver for Mattermost.
+type MattermostReceiver struct {
+}
+
+func (*MattermostReceiver) post(context Context) error {
+	status := ""
+	switch context.Level {
+	case WebhookSuccess:
+		status = ":white_check_mark: "
+	case WebhookWarn:
+		status = ":warning: "
+	case WebhookError:
+		status = ":exclamation: "
+	}
+
+	lineList := []string{fmt.Sprintf("#### %s%s", status, context.Title)}
+	if context.Description != "" {
+		lineList = append(lineList, fmt.Sprintf("> %s", context.Description))
+	}
+	for _, meta := range context.getMetaList() {
+		lineList = append(lineList, fmt.Sprintf("**%s:** %s", meta.Name, meta.Value))
+	}
+	lineList = append(lineList, fmt.Sprintf("By: %s (%s)", context.CreatorName, context.CreatorEmail))
+	lineList = append(lineList, fmt.Sprintf("[View in Bytebase](%s)", context.Link))
+
+	post := MattermostWebhook{
+		Text: strings.Join(lineList, "\n"),
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", context.URL)
+	}
+	req, err := http.NewRequest("POST",
+		context.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct webhook POST request to %s", context.URL)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	client := context.newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to POST webhook to %s", context.URL)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read POST webhook response from %s", context.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PagerDutyWebhookLink is the API message for PagerDuty Events v2 link.
+type PagerDutyWebhookLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// PagerDutyWebhookPayload is the API message for PagerDuty Events v2 payload.
+type PagerDutyWebhookPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// PagerDutyWebhook is the API message for PagerDuty Events v2 event.
+type PagerDutyWebhook struct {
+	RoutingKey  string                   `json:"routing_key"`
+	EventAction string                   `json:"event_action"`
+	DedupKey    string                   `json:"dedup_key,omitempty"`
+	Payload     *PagerDutyWebhookPayload `json:"payload,omitempty"`
+	Client      string                   `json:"client"`
+	ClientURL   string                   `json:"client_url,omitempty"`
+	LinkList    []PagerDutyWebhookLink   `json:"links,omitempty"`
+}
+
+// PagerDutyWebhookResponse is the API message for PagerDuty Events v2 response.
+type PagerDutyWebhookResponse struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	DedupKey string `json:"dedup_key"`
+}
+
+func init() {
+	register("bb.plugin.webhook.pagerduty", &PagerDutyReceiver{})
+}
+
+// PagerDutyReceiver is the receiver for PagerDuty Events v2.
+// The webhook URL is the enqueue endpoint along with the integration key of the service,
+// e.g. https://events.pagerduty.com/v2/enqueue?routing_key=<integration_key>.
+//
+// Only the errors and warnings trigger the incidents, which share the dedup key of the issue,
+// so that the later success of the issue resolves them. The other events are skipped.
+type PagerDutyReceiver struct {
+}
+
+func (*PagerDutyReceiver) post(context Context) error {
+	u, err := url.Parse(context.URL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse webhook URL %s", context.URL)
+	}
+	query := u.Query()
+	routingKey := query.Get("routing_key")
+	if routingKey == "" {
+		return errors.Errorf("routing_key is missing in webhook URL %s", context.URL)
+	}
+	// Do not send the integration key in the URL.
+	query.Del("routing_key")
+	u.RawQuery = query.Encode()
+
+	post := getPagerDutyEvent(context, routingKey)
+	if post == nil {
+		return nil
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", u.String())
+	}
+	req, err := http.NewRequest("POST",
+		u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct webhook POST request to %s", u.String())
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	client := context.newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to POST webhook to %s", u.String())
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read POST webhook response from %s", u.String())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", u.String(), resp.StatusCode, b)
+	}
+
+	webhookResponse := &PagerDutyWebhookResponse{}
+	if err := json.Unmarshal(b, webhookResponse); err != nil {
+		return errors.Wrapf(err, "malformed webhook response from %s", u.String())
+	}
+
+	if webhookResponse.Status != "success" {
+		return errors.Errorf("receive error status sent by webhook server, status %s, msg: %s", webhookResponse.Status, webhookResponse.Message)
+	}
+
+	return nil
+}
+
+// getPagerDutyEvent returns the PagerDuty event of the context, or nil if the context should be skipped.
+// The errors and warnings trigger the incidents with the error and warning severities,
+// and the successes resolve the incidents of the issue.
+func getPagerDutyEvent(context Context, routingKey string) *PagerDutyWebhook {
+	event := &PagerDutyWebhook{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		Client:      "Bytebase",
+		ClientURL:   context.Link,
+		LinkList: []PagerDutyWebhookLink{
+			{
+				Href: context.Link,
+				Text: "View in Bytebase",
+			},
+		},
+	}
+	if context.Issue != nil {
+		event.DedupKey = fmt.Sprintf("bytebase-issue-%d", context.Issue.ID)
+	}
+
+	var severity string
+	switch context.Level {
+	case WebhookError:
+		severity = "error"
+	case WebhookWarn:
+		severity = "warning"
+	case WebhookSuccess:
+		// Only the incidents of an issue can be resolved.
+		if event.DedupKey == "" {
+			return nil
+		}
+		event.EventAction = "resolve"
+		return event
+	default:
+		return nil
+	}
+	customDetails := map[string]string{}
+	if context.Description != "" {
+		customDetails["Description"] = context.Description
+	}
+	for _, meta := range context.getMetaList() {
+		customDetails[meta.Name] = meta.Value
+	}
+	customDetails["By"] = fmt.Sprintf("%s (%s)", context.CreatorName, context.CreatorEmail)
+	event.Payload = &PagerDutyWebhookPayload{
+		Summary:       context.Title,
+		Source:        "Bytebase",
+		Severity:      severity,
+		Class:         context.ActivityType,
+		CustomDetails: customDetails,
+	}
+	if context.CreatedTs != 0 {
+		event.Payload.Timestamp = time.Unix(context.CreatedTs, 0).UTC().Format(time.RFC3339)
+	}
+	if context.Project != nil {
+		event.Payload.Component = context.Project.Name
+	}
+	return event
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TelegramWebhook is the API message for Telegram bot sendMessage.
+type TelegramWebhook struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// TelegramWebhookResponse is the API message for Telegram bot sendMessage response.
+type TelegramWebhookResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// telegramBotTokenRegexp matches the bot token in the webhook URL path.
+var telegramBotTokenRegexp = regexp.MustCompile(`/bot[^/]*`)
+
+func init() {
+	register("bb.plugin.webhook.telegram", &TelegramReceiver{})
+}
+
+// TelegramReceiver is the receiver for Telegram bots.
+// The webhook URL is the sendMessage endpoint of the bot along with the chat ID,
+// e.g. https://api.telegram.org/bot<token>/sendMessage?chat_id=<chat_id>.
+type TelegramReceiver struct {
+}
+
+func (*TelegramReceiver) post(context Context) error {
+	u, err := url.Parse(context.URL)
+	if err != nil {
+		// The parse error contains the URL with the bot token.
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return errors.Wrap(err, "failed to parse webhook URL")
+	}
+	// Do not leak the bot token in the error messages.
+	redactedURL := redactTelegramURL(u)
+	chatID := u.Query().Get("chat_id")
+	if chatID == "" {
+		return errors.Errorf("chat_id is missing in webhook URL %s", redactedURL)
+	}
+
+	status := ""
+	switch context.Level {
+	case WebhookSuccess:
+		status = "✅ "
+	case WebhookWarn:
+		status = "⚠️ "
+	case WebhookError:
+		status = "❗ "
+	}
+
+	// Use the HTML parse mode, which only requires escaping "<", ">" and "&" in the text.
+	lineList := []string{fmt.Sprintf("<b>%s%s</b>", status, html.EscapeString(context.Title))}
+	if context.Description != "" {
+		lineList = append(lineList, fmt.Sprintf("<pre>%s</pre>", html.EscapeString(context.Description)))
+	}
+	for _, meta := range context.getMetaList() {
+		lineList = append(lineList, fmt.Sprintf("<b>%s:</b> %s", html.EscapeString(meta.Name), html.EscapeString(meta.Value)))
+	}
+	lineList = append(lineList, fmt.Sprintf("By: %s (%s)", html.EscapeString(context.CreatorName), html.EscapeString(context.CreatorEmail)))
+	lineList = append(lineList, fmt.Sprintf("<a href=\"%s\">View in Bytebase</a>", html.EscapeString(context.Link)))
+
+	post := TelegramWebhook{
+		ChatID:                chatID,
+		Text:                  strings.Join(lineList, "\n"),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", redactedURL)
+	}
+	req, err := http.NewRequest("POST",
+		context.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Errorf("failed to construct webhook POST request to %s", redactedURL)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	client := context.newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		// The client error contains the URL with the bot token.
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactedURL
+		}
+		return errors.Wrapf(err, "failed to POST webhook to %s", redactedURL)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read POST webhook response from %s", redactedURL)
+	}
+	defer resp.Body.Close()
+
+	webhookResponse := &TelegramWebhookResponse{}
+	if err := json.Unmarshal(b, webhookResponse); err != nil {
+		return errors.Wrapf(err, "malformed webhook response from %s, status code: %d", redactedURL, resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK || !webhookResponse.OK {
+		return errors.Errorf("failed to POST webhook %s, status code: %d, description: %s", redactedURL, resp.StatusCode, webhookResponse.Description)
+	}
+
+	return nil
+}
+
+// redactTelegramURL returns the webhook URL with the bot token redacted.
+func redactTelegramURL(u *url.URL) string {
+	redacted := *u
+	redacted.Path = telegramBotTokenRegexp.ReplaceAllString(u.Path, "/botREDACTED")
+	redacted.RawPath = ""
+	return redacted.String()
+}
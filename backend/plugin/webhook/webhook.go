@@ -2,6 +2,10 @@
 package webhook
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
 	"sync"
 	"time"
 
@@ -17,6 +21,9 @@ var (
 	timeout = 3 * time.Second
 )
 
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of the custom webhook request body.
+const SignatureHeader = "X-Bytebase-Signature-256"
+
 // meta is the webhook metadata.
 type meta struct {
 	Name  string
@@ -77,6 +84,18 @@ type Context struct {
 	Issue        *Issue
 	Project      *Project
 	TaskResult   *TaskResult
+	// Secret is the secret to sign the request body, only used by the custom webhook.
+	Secret string `json:"-"`
+
+	// result records the response of the delivery if not nil.
+	result *DeliveryResult
+}
+
+// DeliveryResult is the result of a webhook delivery attempt.
+type DeliveryResult struct {
+	// StatusCode is the HTTP status code of the response, or 0 if no response is received.
+	StatusCode int
+	Latency    time.Duration
 }
 
 // Receiver is the webhook receiver.
@@ -84,6 +103,29 @@ type Receiver interface {
 	post(context Context) error
 }
 
+// newHTTPClient returns the HTTP client to post the webhook, which records the response status code to the delivery result.
+func (c Context) newHTTPClient() *http.Client {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	if c.result != nil {
+		client.Transport = &recordingTransport{result: c.result}
+	}
+	return client
+}
+
+type recordingTransport struct {
+	result *DeliveryResult
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if resp != nil {
+		t.result.StatusCode = resp.StatusCode
+	}
+	return resp, err
+}
+
 func (c *Context) getMetaList() []meta {
 	m := []meta{}
 
@@ -160,3 +202,21 @@ func Post(webhookType string, context Context) error {
 	}
 	return r.post(context)
 }
+
+// Deliver posts the message to webhook and returns the response status code and the latency along with the error.
+func Deliver(webhookType string, context Context) (*DeliveryResult, error) {
+	result := &DeliveryResult{}
+	context.result = result
+	start := time.Now()
+	err := Post(webhookType, context)
+	result.Latency = time.Since(start)
+	return result, err
+}
+
+// Sign returns the HMAC-SHA256 signature of the body with the secret in the form of "sha256=<hex digest>".
+// The body carries the created_ts, so the receiver can also reject the replayed requests.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
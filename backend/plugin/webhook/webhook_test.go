@@ -2,6 +2,10 @@ package webhook
 
 import (
 	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -122,3 +126,211 @@ func TestContext_getMetaList(t *testing.T) {
 		a.Equal(want, context.getMetaList())
 	})
 }
+
+func TestSign(t *testing.T) {
+	a := require.New(t)
+	a.Equal("sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestDeliverCustom(t *testing.T) {
+	a := require.New(t)
+	code := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get(SignatureHeader) != Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if code != 0 {
+			w.WriteHeader(code)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	result, err := Deliver("bb.plugin.webhook.custom", Context{URL: server.URL, Title: "title", Secret: "secret"})
+	a.NoError(err)
+	a.Equal(http.StatusOK, result.StatusCode)
+
+	result, err = Deliver("bb.plugin.webhook.custom", Context{URL: server.URL, Title: "title", Secret: "wrong"})
+	a.Error(err)
+	a.Equal(http.StatusUnauthorized, result.StatusCode)
+
+	code = http.StatusServiceUnavailable
+	result, err = Deliver("bb.plugin.webhook.custom", Context{URL: server.URL, Title: "title", Secret: "secret"})
+	a.Error(err)
+	a.Equal(http.StatusServiceUnavailable, result.StatusCode)
+
+	server.Close()
+	result, err = Deliver("bb.plugin.webhook.custom", Context{URL: server.URL, Title: "title"})
+	a.Error(err)
+	a.Equal(0, result.StatusCode)
+}
+
+func TestPostMattermost(t *testing.T) {
+	a := require.New(t)
+	var got MattermostWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	err := Post("bb.plugin.webhook.mattermost", Context{
+		URL:         server.URL,
+		Level:       WebhookError,
+		Title:       "Task failed",
+		Link:        "http://localhost/issue/1",
+		CreatorName: "bot",
+		Project:     &Project{Name: "p1"},
+	})
+	a.NoError(err)
+	a.Equal("#### :exclamation: Task failed\n**Project:** p1\nBy: bot ()\n[View in Bytebase](http://localhost/issue/1)", got.Text)
+}
+
+func TestPostGoogleChat(t *testing.T) {
+	a := require.New(t)
+	var got GoogleChatWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"spaces/AAA/messages/BBB"}`))
+	}))
+	defer server.Close()
+
+	err := Post("bb.plugin.webhook.googlechat", Context{
+		URL:         server.URL,
+		Level:       WebhookSuccess,
+		Title:       "Issue done",
+		Description: "desc",
+		Link:        "http://localhost/issue/1",
+		CreatorName: "bot",
+	})
+	a.NoError(err)
+	a.Equal("*✅ Issue done*\n```desc```\nBy: bot ()\n<http://localhost/issue/1|View in Bytebase>", got.Text)
+}
+
+func TestPostTelegram(t *testing.T) {
+	a := require.New(t)
+	var got TelegramWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/botTOKEN/sendMessage" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Not Found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	err := Post("bb.plugin.webhook.telegram", Context{
+		URL:         server.URL + "/botTOKEN/sendMessage?chat_id=-100",
+		Level:       WebhookInfo,
+		Title:       "Create table <t1> & <t2>",
+		Link:        "http://localhost/issue/1",
+		CreatorName: "bot",
+	})
+	a.NoError(err)
+	a.Equal("-100", got.ChatID)
+	a.Equal("HTML", got.ParseMode)
+	a.Equal("<b>Create table &lt;t1&gt; &amp; &lt;t2&gt;</b>\nBy: bot ()\n<a href=\"http://localhost/issue/1\">View in Bytebase</a>", got.Text)
+
+	err = Post("bb.plugin.webhook.telegram", Context{URL: server.URL + "/botTOKEN/sendMessage"})
+	a.ErrorContains(err, "chat_id is missing")
+	a.NotContains(err.Error(), "TOKEN")
+
+	err = Post("bb.plugin.webhook.telegram", Context{URL: server.URL + "/botWRONG/sendMessage?chat_id=-100"})
+	a.ErrorContains(err, "Not Found")
+	a.ErrorContains(err, "/botREDACTED/sendMessage")
+	a.NotContains(err.Error(), "WRONG")
+}
+
+func TestPostPagerDuty(t *testing.T) {
+	a := require.New(t)
+	var got PagerDutyWebhook
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = PagerDutyWebhook{}
+		rawQuery = r.URL.RawQuery
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"` + got.DedupKey + `"}`))
+	}))
+	defer server.Close()
+
+	context := Context{
+		URL:          server.URL + "/v2/enqueue?routing_key=KEY",
+		ActivityType: "bb.pipeline.task.status.update",
+		Title:        "Task failed",
+		Link:         "http://localhost/issue/7",
+		CreatedTs:    1685577600,
+		Issue:        &Issue{ID: 7, Name: "issue"},
+		Project:      &Project{Name: "p1"},
+		TaskResult:   &TaskResult{Name: "task", Status: "FAILED"},
+	}
+
+	context.Level = WebhookError
+	a.NoError(Post("bb.plugin.webhook.pagerduty", context))
+	a.Empty(rawQuery)
+	a.Equal("KEY", got.RoutingKey)
+	a.Equal("trigger", got.EventAction)
+	a.Equal("bytebase-issue-7", got.DedupKey)
+	a.Equal(&PagerDutyWebhookPayload{
+		Summary:   "Task failed",
+		Source:    "Bytebase",
+		Severity:  "error",
+		Timestamp: "2023-06-01T00:00:00Z",
+		Component: "p1",
+		Class:     "bb.pipeline.task.status.update",
+		CustomDetails: map[string]string{
+			"Project":           "p1",
+			"Issue":             "issue",
+			"Issue Description": "",
+			"Task":              "task",
+			"Status":            "FAILED",
+			"By":                " ()",
+		},
+	}, got.Payload)
+
+	context.Level = WebhookWarn
+	a.NoError(Post("bb.plugin.webhook.pagerduty", context))
+	a.Equal("trigger", got.EventAction)
+	a.Equal("warning", got.Payload.Severity)
+
+	context.Level = WebhookSuccess
+	a.NoError(Post("bb.plugin.webhook.pagerduty", context))
+	a.Equal("resolve", got.EventAction)
+	a.Equal("bytebase-issue-7", got.DedupKey)
+	a.Nil(got.Payload)
+
+	// The other events are skipped.
+	got = PagerDutyWebhook{}
+	context.Level = WebhookInfo
+	a.NoError(Post("bb.plugin.webhook.pagerduty", context))
+	a.Empty(got.EventAction)
+
+	context.Level = WebhookSuccess
+	context.Issue = nil
+	a.NoError(Post("bb.plugin.webhook.pagerduty", context))
+	a.Empty(got.EventAction)
+
+	context.URL = server.URL + "/v2/enqueue"
+	a.ErrorContains(Post("bb.plugin.webhook.pagerduty", context), "routing_key is missing")
+}
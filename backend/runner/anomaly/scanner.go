@@ -320,7 +320,7 @@ func (s *Scanner) checkDatabaseAnomaly(ctx context.Context, instance *store.Inst
 
 func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.EnvironmentMessage, instance *store.InstanceMessage, database *store.DatabaseMessage, policyMap map[int]*api.BackupPlanPolicy) {
 	if disableBackupAnomalyCheck(instance.Engine) {
-		// skip checking backup anomalies for MongoDB, Spanner, Redis, Oracle, etc. because they don't support Backup.
+		// skip checking backup anomalies for Spanner, Redis, etc. because they don't support Backup.
 		return
 	}
 
@@ -429,7 +429,7 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 
 				hasValidBackup := false
 				if len(backupList) > 0 {
-					if backupList[0].UpdatedTs >= time.Now().Add(-backupMaxAge).Unix() {
+					if backupList[0].CreatedTs >= time.Now().Add(-backupMaxAge).Unix() {
 						hasValidBackup = true
 					}
 				}
@@ -439,7 +439,7 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 						ExpectedBackupSchedule: expectedSchedule,
 					}
 					if len(backupList) > 0 {
-						backupMissingAnomalyPayload.LastBackupTs = backupList[0].UpdatedTs
+						backupMissingAnomalyPayload.LastBackupTs = backupList[0].CreatedTs
 					}
 				}
 			}
@@ -485,13 +485,9 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 
 func disableBackupAnomalyCheck(dbTp db.Type) bool {
 	m := map[db.Type]struct{}{
-		db.MongoDB:  {},
-		db.Spanner:  {},
-		db.Redis:    {},
-		db.Oracle:   {},
-		db.MSSQL:    {},
-		db.MariaDB:  {},
-		db.Redshift: {},
+		db.Spanner: {},
+		db.Redis:   {},
+		db.MariaDB: {},
 	}
 	_, ok := m[dbTp]
 	return ok
@@ -499,13 +495,12 @@ func disableBackupAnomalyCheck(dbTp db.Type) bool {
 
 func disableSchemaDriftAnomalyCheck(dbTp db.Type) bool {
 	m := map[db.Type]struct{}{
-		db.MongoDB:  {},
-		db.Spanner:  {},
-		db.Redis:    {},
-		db.Oracle:   {},
-		db.MSSQL:    {},
-		db.MariaDB:  {},
-		db.Redshift: {},
+		db.MongoDB: {},
+		db.Spanner: {},
+		db.Redis:   {},
+		db.Oracle:  {},
+		db.MSSQL:   {},
+		db.MariaDB: {},
 	}
 	_, ok := m[dbTp]
 	return ok
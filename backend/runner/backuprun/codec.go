@@ -0,0 +1,211 @@
+package backuprun
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/plugin/storage/codec"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// getBackupEncryptionKey returns the workspace backup encryption key.
+func getBackupEncryptionKey(ctx context.Context, stores *store.Store) ([]byte, error) {
+	settingName := api.SettingBackupEncryptionKey
+	setting, err := stores.GetSettingV2(ctx, &store.FindSettingMessage{Name: &settingName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %s", settingName)
+	}
+	if setting == nil {
+		return nil, errors.Errorf("cannot find setting %s", settingName)
+	}
+	key, err := base64.StdEncoding.DecodeString(setting.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode setting %s", settingName)
+	}
+	return key, nil
+}
+
+// DumpBackup dumps the database to w, compressed with the compression and encrypted with a new data key.
+// It returns the backup payload in JSON recording the binlog info, the encoding and the checksum of the backup file.
+func DumpBackup(ctx context.Context, stores *store.Store, driver db.Driver, compression codec.Compression, w io.Writer) (string, error) {
+	workspaceKey, err := getBackupEncryptionKey(ctx, stores)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := codec.GenerateKey()
+	if err != nil {
+		return "", err
+	}
+	encryptedDataKey, err := codec.WrapKey(workspaceKey, dataKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encrypt the data key")
+	}
+	encoder, err := codec.NewWriter(w, compression, dataKey)
+	if err != nil {
+		return "", err
+	}
+	// Redshift doesn't support dumping the data, so only the schema is backed up.
+	schemaOnly := driver.GetType() == db.Redshift
+	dumpPayload, err := driver.Dump(ctx, encoder, schemaOnly)
+	if err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	var payload api.BackupPayload
+	if dumpPayload != "" {
+		if err := json.Unmarshal([]byte(dumpPayload), &payload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal backup payload %q", dumpPayload)
+		}
+	}
+	payload.SchemaOnly = schemaOnly
+	payload.Compression = string(compression)
+	payload.Encryption = &api.BackupEncryption{
+		KeyID:            codec.KeyID(workspaceKey),
+		EncryptedDataKey: encryptedDataKey,
+	}
+	payload.Checksum = encoder.Checksum()
+	payload.DumpSize = encoder.DumpSize()
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal backup payload")
+	}
+	return string(payloadBytes), nil
+}
+
+// VerifyBackupChecksum verifies the backup file against the checksum recorded in the backup payload.
+// The backups taken before recording checksums are not verified.
+func VerifyBackupChecksum(r io.Reader, payload api.BackupPayload) error {
+	if payload.Checksum == "" {
+		return nil
+	}
+	checksum, err := codec.Checksum(r)
+	if err != nil {
+		return err
+	}
+	if checksum != payload.Checksum {
+		return errors.Errorf("backup checksum mismatch, expecting %s but got %s, the backup file may be corrupted or tampered", payload.Checksum, checksum)
+	}
+	return nil
+}
+
+// NewBackupReader returns the reader decoding the backup file according to the backup payload.
+func NewBackupReader(ctx context.Context, stores *store.Store, payload api.BackupPayload, r io.Reader) (io.ReadCloser, error) {
+	var dataKey []byte
+	if payload.Encryption != nil {
+		workspaceKey, err := getBackupEncryptionKey(ctx, stores)
+		if err != nil {
+			return nil, err
+		}
+		if keyID := codec.KeyID(workspaceKey); keyID != payload.Encryption.KeyID {
+			return nil, errors.Errorf("backup is encrypted by the workspace key %s, but the current workspace key is %s", payload.Encryption.KeyID, keyID)
+		}
+		dataKey, err = codec.UnwrapKey(workspaceKey, payload.Encryption.EncryptedDataKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codec.NewReader(r, codec.Compression(payload.Compression), dataKey)
+}
+
+// BackupFileReader is the reader of the decoded backup file.
+type BackupFileReader struct {
+	io.ReadCloser
+	file *os.File
+	// downloaded is true if the backup file is downloaded from the backup storage and should be removed after use.
+	downloaded bool
+	// size is the size of the decoded backup file.
+	size int64
+}
+
+// Size returns the size of the decoded backup file.
+func (r *BackupFileReader) Size() int64 {
+	return r.size
+}
+
+// Close closes the backup file and removes the downloaded one.
+func (r *BackupFileReader) Close() error {
+	err := r.ReadCloser.Close()
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	if r.downloaded {
+		if removeErr := os.Remove(r.file.Name()); removeErr != nil {
+			log.Warn("Failed to remove the downloaded backup file", zap.String("path", r.file.Name()), zap.Error(removeErr))
+		}
+	}
+	return err
+}
+
+// OpenBackupFile returns the reader decoding the backup file on the local disk or in the backup storage.
+// The backup file is verified against the checksum in the backup record before decoding,
+// so the restore never replays a corrupted or tampered backup.
+func OpenBackupFile(ctx context.Context, stores *store.Store, backupStorages *Storages, dataDir string, backup *store.BackupMessage) (*BackupFileReader, error) {
+	backupAbsPathLocal := GetBackupAbsFilePath(dataDir, backup.DatabaseUID, backup.Name)
+	downloaded := backup.StorageBackend != api.BackupStorageBackendLocal
+	if downloaded {
+		if err := downloadBackupFileFromCloud(ctx, backupStorages, backup, backupAbsPathLocal); err != nil {
+			return nil, err
+		}
+	}
+	reader, err := func() (*BackupFileReader, error) {
+		file, err := os.Open(backupAbsPathLocal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open backup file at %s", backupAbsPathLocal)
+		}
+		if err := VerifyBackupChecksum(file, backup.Payload); err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "failed to verify backup %q", backup.Name)
+		}
+		fileInfo, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "failed to get stat of backup file %q", backupAbsPathLocal)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "failed to seek backup file %q", backupAbsPathLocal)
+		}
+		decoder, err := NewBackupReader(ctx, stores, backup.Payload, file)
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "failed to decode backup %q", backup.Name)
+		}
+		size := backup.Payload.DumpSize
+		if size == 0 {
+			size = fileInfo.Size()
+		}
+		return &BackupFileReader{ReadCloser: decoder, file: file, downloaded: downloaded, size: size}, nil
+	}()
+	if err != nil && downloaded {
+		if err := os.Remove(backupAbsPathLocal); err != nil {
+			log.Warn("Failed to remove the downloaded backup file", zap.String("path", backupAbsPathLocal), zap.Error(err))
+		}
+	}
+	return reader, err
+}
+
+func downloadBackupFileFromCloud(ctx context.Context, backupStorages *Storages, backup *store.BackupMessage, backupAbsPathLocal string) error {
+	backupStorage, err := backupStorages.Get(backup)
+	if err != nil {
+		return err
+	}
+	log.Debug("Downloading backup file from the backup storage.", zap.String("path", backup.Path))
+	if err := storage.DownloadFile(ctx, backupStorage, backupAbsPathLocal, backup.Path); err != nil {
+		return errors.Wrapf(err, "failed to download backup %q from the backup storage", backup.Path)
+	}
+	log.Debug("Successfully downloaded backup file from the backup storage.")
+	return nil
+}
@@ -25,17 +25,16 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
-	"github.com/bytebase/bytebase/backend/plugin/storage/s3"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
 
 // NewRunner creates a new backup runner.
-func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.Client, stateCfg *state.State, profile *config.Profile) *Runner {
+func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, backupStorages *Storages, stateCfg *state.State, profile *config.Profile) *Runner {
 	return &Runner{
 		store:                     store,
 		dbFactory:                 dbFactory,
-		s3Client:                  s3Client,
+		backupStorages:            backupStorages,
 		stateCfg:                  stateCfg,
 		profile:                   profile,
 		downloadBinlogInstanceIDs: make(map[int]bool),
@@ -46,7 +45,7 @@ func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.
 type Runner struct {
 	store                     *store.Store
 	dbFactory                 *dbfactory.DBFactory
-	s3Client                  *s3.Client
+	backupStorages            *Storages
 	stateCfg                  *state.State
 	profile                   *config.Profile
 	downloadBinlogInstanceIDs map[int]bool
@@ -109,7 +108,8 @@ func (r *Runner) purgeExpiredBackupData(ctx context.Context) {
 			return
 		}
 		for _, backup := range backupList {
-			backupTime := time.Unix(backup.UpdatedTs, 0)
+			// The backup record is updated by the restore drills, so the retention counts from the creation time.
+			backupTime := time.Unix(backup.CreatedTs, 0)
 			expireTime := backupTime.Add(time.Duration(bs.RetentionPeriodTs) * time.Second)
 			if time.Now().After(expireTime) {
 				log.Debug("Purging expired backup", zap.Int("databaseID", backup.DatabaseUID), zap.String("backup", backup.Name), zap.String("storageBackend", string(backup.StorageBackend)))
@@ -164,16 +164,14 @@ func (r *Runner) purgeBinlogFiles(ctx context.Context, instanceID, retentionPeri
 	switch r.profile.BackupStorageBackend {
 	case api.BackupStorageBackendLocal:
 		return r.purgeBinlogFilesLocal(binlogDir, retentionPeriodTs)
-	case api.BackupStorageBackendS3:
-		return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
 	default:
-		return errors.Errorf("purge binlog files not implemented for storage backend %s", r.profile.BackupStorageBackend)
+		return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
 	}
 }
 
 func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string, retentionPeriodTs int) error {
 	binlogDirOnCloud := common.GetBinlogRelativeDir(binlogDir)
-	listOutput, err := r.s3Client.ListObjects(ctx, binlogDirOnCloud)
+	listOutput, err := r.backupStorages.Workspace.List(ctx, binlogDirOnCloud)
 	if err != nil {
 		return errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
 	}
@@ -181,12 +179,12 @@ func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string,
 	for _, item := range listOutput {
 		expireTime := item.LastModified.Add(time.Duration(retentionPeriodTs) * time.Second)
 		if time.Now().After(expireTime) {
-			purgeBinlogPathList = append(purgeBinlogPathList, *item.Key)
+			purgeBinlogPathList = append(purgeBinlogPathList, item.Path)
 		}
 	}
 	if len(purgeBinlogPathList) > 0 {
 		log.Debug(fmt.Sprintf("Deleting %d expired binlog files from the cloud storage.", len(purgeBinlogPathList)))
-		if _, err := r.s3Client.DeleteObjects(ctx, purgeBinlogPathList...); err != nil {
+		if err := r.backupStorages.Workspace.Delete(ctx, purgeBinlogPathList...); err != nil {
 			return errors.Wrapf(err, "failed to delete %d expired binlog files from the cloud storage", len(purgeBinlogPathList))
 		}
 	}
@@ -246,9 +244,13 @@ func (r *Runner) purgeBackup(ctx context.Context, backup *store.BackupMessage) e
 			return errors.Wrapf(err, "failed to delete an expired backup file %q", backupFilePath)
 		}
 		log.Debug(fmt.Sprintf("Deleted expired local backup file %s", backupFilePath))
-	case api.BackupStorageBackendS3:
+	default:
+		backupStorage, err := r.backupStorages.Get(backup)
+		if err != nil {
+			return err
+		}
 		backupFilePath := getBackupRelativeFilePath(backup.DatabaseUID, backup.Name)
-		if _, err := r.s3Client.DeleteObjects(ctx, backupFilePath); err != nil {
+		if err := backupStorage.Delete(ctx, backupFilePath); err != nil {
 			return errors.Wrapf(err, "failed to delete backup file %s in the cloud storage", backupFilePath)
 		}
 		log.Debug(fmt.Sprintf("Deleted expired backup file %s in the cloud storage", backupFilePath))
@@ -301,7 +303,7 @@ func (r *Runner) downloadBinlogFilesForInstance(ctx context.Context, instance *s
 		log.Error("Failed to cast driver to mysql.Driver", zap.String("instance", instance.ResourceID))
 		return
 	}
-	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.s3Client); err != nil {
+	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.backupStorages.Workspace); err != nil {
 		log.Error("Failed to download all binlog files for instance", zap.String("instance", instance.ResourceID), zap.Error(err))
 		return
 	}
@@ -347,8 +349,8 @@ func (r *Runner) startAutoBackups(ctx context.Context) {
 		if instance.Deleted {
 			continue
 		}
-		// backup for ClickHouse, Snowflake, MongoDB, Spanner, Redis, Oracle is not supported.
-		if instance.Engine == db.ClickHouse || instance.Engine == db.Snowflake || instance.Engine == db.MongoDB || instance.Engine == db.Spanner || instance.Engine == db.Redis || instance.Engine == db.Oracle {
+		// backup for ClickHouse, Snowflake, Spanner, Redis is not supported.
+		if instance.Engine == db.ClickHouse || instance.Engine == db.Snowflake || instance.Engine == db.Spanner || instance.Engine == db.Redis {
 			continue
 		}
 		environment, err := r.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &instance.EnvironmentID})
@@ -428,6 +430,11 @@ func (r *Runner) ScheduleBackupTask(ctx context.Context, database *store.Databas
 	}
 	defer driver.Close(ctx)
 
+	storageBackend, backupStorage, err := r.getStorageBackend(ctx, environment)
+	if err != nil {
+		return nil, err
+	}
+
 	migrationHistoryVersion, err := utils.GetLatestSchemaVersion(ctx, r.store, instance.UID, database.UID, database.DatabaseName)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get migration history for database %q", database.DatabaseName)
@@ -442,7 +449,8 @@ func (r *Runner) ScheduleBackupTask(ctx context.Context, database *store.Databas
 		Status:                  api.BackupStatusPendingCreate,
 		BackupType:              backupType,
 		Comment:                 "",
-		StorageBackend:          r.profile.BackupStorageBackend,
+		StorageBackend:          storageBackend,
+		Storage:                 backupStorage,
 		MigrationHistoryVersion: migrationHistoryVersion,
 		Path:                    path,
 	}, database.UID, creatorID)
@@ -500,6 +508,30 @@ func (r *Runner) ScheduleBackupTask(ctx context.Context, database *store.Databas
 	return backupNew, nil
 }
 
+// getStorageBackend returns the storage backend and the name of the backup storage for the backups of databases in the environment.
+// The backup plan policy of the environment can choose the local disk, the workspace backup storage or a named backup storage.
+func (r *Runner) getStorageBackend(ctx context.Context, environment *store.EnvironmentMessage) (api.BackupStorageBackend, string, error) {
+	policy, err := r.store.GetBackupPlanPolicyByEnvID(ctx, environment.UID)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get backup plan policy for environment %q", environment.ResourceID)
+	}
+	if policy.Storage != "" {
+		backupStorage := r.profile.GetBackupStorage(policy.Storage)
+		if backupStorage == nil {
+			return "", "", errors.Errorf("backup storage %q of environment %q is not configured", policy.Storage, environment.ResourceID)
+		}
+		return backupStorage.Backend, backupStorage.Name, nil
+	}
+	switch policy.StorageBackend {
+	case "", r.profile.BackupStorageBackend:
+		return r.profile.BackupStorageBackend, "", nil
+	case api.BackupStorageBackendLocal:
+		return api.BackupStorageBackendLocal, "", nil
+	default:
+		return "", "", errors.Errorf("backup storage %s of environment %q is not configured for the workspace", policy.StorageBackend, environment.ResourceID)
+	}
+}
+
 // Get backup dir relative to the data dir.
 func getBackupRelativeDir(databaseID int) string {
 	return filepath.Join("backup", "db", fmt.Sprintf("%d", databaseID))
@@ -0,0 +1,36 @@
+package backuprun
+
+import (
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// Storages is the backup storages, i.e. the workspace backup storage and the named ones that the environments can choose.
+type Storages struct {
+	// Workspace is the backup storage configured by the --backup-bucket server flag, nil means the local disk.
+	// The MySQL binlog files are always kept in the workspace backup storage.
+	Workspace storage.Backend
+	// Named is the backup storages configured by the --backup-storage-config server flag by name.
+	Named map[string]storage.Backend
+}
+
+// Get returns the backup storage where the backup is stored, or nil if the backup is stored on the local disk.
+func (s *Storages) Get(backup *store.BackupMessage) (storage.Backend, error) {
+	if backup.StorageBackend == api.BackupStorageBackendLocal {
+		return nil, nil
+	}
+	if backup.Storage != "" {
+		backupStorage, ok := s.Named[backup.Storage]
+		if !ok {
+			return nil, errors.Errorf("backup storage %q is not configured", backup.Storage)
+		}
+		return backupStorage, nil
+	}
+	if s.Workspace == nil {
+		return nil, errors.Errorf("backup storage %s is not configured", backup.StorageBackend)
+	}
+	return s.Workspace, nil
+}
@@ -0,0 +1,156 @@
+package drillrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// maxReportedDiffs is the maximum number of differences reported in the drill result.
+const maxReportedDiffs = 10
+
+// compareSchema compares the tables, columns, indexes and views of the restored database with the source database,
+// and returns the differences.
+func compareSchema(source, restored *storepb.DatabaseMetadata) []string {
+	var diffs []string
+	restoredSchemas := make(map[string]*storepb.SchemaMetadata)
+	for _, schema := range restored.Schemas {
+		restoredSchemas[schema.Name] = schema
+	}
+	for _, sourceSchema := range source.Schemas {
+		restoredSchema, ok := restoredSchemas[sourceSchema.Name]
+		if !ok {
+			if len(sourceSchema.Tables) > 0 || len(sourceSchema.Views) > 0 {
+				diffs = append(diffs, fmt.Sprintf("schema %q is missing", sourceSchema.Name))
+			}
+			continue
+		}
+		restoredTables := make(map[string]*storepb.TableMetadata)
+		for _, table := range restoredSchema.Tables {
+			restoredTables[table.Name] = table
+		}
+		for _, sourceTable := range sourceSchema.Tables {
+			tableName := qualifiedName(sourceSchema.Name, sourceTable.Name)
+			restoredTable, ok := restoredTables[sourceTable.Name]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("table %q is missing", tableName))
+				continue
+			}
+			delete(restoredTables, sourceTable.Name)
+			diffs = append(diffs, compareTable(tableName, sourceTable, restoredTable)...)
+		}
+		for _, table := range restoredSchema.Tables {
+			if _, ok := restoredTables[table.Name]; ok {
+				diffs = append(diffs, fmt.Sprintf("table %q is unexpected", qualifiedName(sourceSchema.Name, table.Name)))
+			}
+		}
+
+		restoredViews := make(map[string]bool)
+		for _, view := range restoredSchema.Views {
+			restoredViews[view.Name] = true
+		}
+		for _, view := range sourceSchema.Views {
+			if !restoredViews[view.Name] {
+				diffs = append(diffs, fmt.Sprintf("view %q is missing", qualifiedName(sourceSchema.Name, view.Name)))
+			}
+		}
+	}
+	return diffs
+}
+
+func compareTable(tableName string, source, restored *storepb.TableMetadata) []string {
+	var diffs []string
+	restoredColumns := make(map[string]*storepb.ColumnMetadata)
+	for _, column := range restored.Columns {
+		restoredColumns[column.Name] = column
+	}
+	for _, sourceColumn := range source.Columns {
+		restoredColumn, ok := restoredColumns[sourceColumn.Name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("column %q of table %q is missing", sourceColumn.Name, tableName))
+			continue
+		}
+		if restoredColumn.Type != sourceColumn.Type {
+			diffs = append(diffs, fmt.Sprintf("column %q of table %q has type %q, expecting %q", sourceColumn.Name, tableName, restoredColumn.Type, sourceColumn.Type))
+		}
+	}
+	if len(restored.Columns) > len(source.Columns) {
+		diffs = append(diffs, fmt.Sprintf("table %q has %d columns, expecting %d", tableName, len(restored.Columns), len(source.Columns)))
+	}
+
+	restoredIndexes := make(map[string]bool)
+	for _, index := range restored.Indexes {
+		restoredIndexes[index.Name] = true
+	}
+	for _, index := range source.Indexes {
+		if !restoredIndexes[index.Name] {
+			diffs = append(diffs, fmt.Sprintf("index %q of table %q is missing", index.Name, tableName))
+		}
+	}
+	return diffs
+}
+
+// compareRowCount compares the row count of each restored table with the source table,
+// and returns the differences exceeding the tolerance in percent.
+func compareRowCount(ctx context.Context, engine db.Type, sourceDriver, restoredDriver db.Driver, restored *storepb.DatabaseMetadata, tolerance int) ([]string, error) {
+	var diffs []string
+	for _, schema := range restored.Schemas {
+		for _, table := range schema.Tables {
+			tableName := qualifiedName(schema.Name, table.Name)
+			statement := fmt.Sprintf("SELECT COUNT(*) FROM %s;", quoteTableName(engine, schema.Name, table.Name))
+			var sourceCount, restoredCount int64
+			if err := sourceDriver.GetDB().QueryRowContext(ctx, statement).Scan(&sourceCount); err != nil {
+				return nil, errors.Wrapf(err, "failed to count the rows of source table %q", tableName)
+			}
+			if err := restoredDriver.GetDB().QueryRowContext(ctx, statement).Scan(&restoredCount); err != nil {
+				return nil, errors.Wrapf(err, "failed to count the rows of restored table %q", tableName)
+			}
+			if !withinTolerance(sourceCount, restoredCount, tolerance) {
+				diffs = append(diffs, fmt.Sprintf("table %q has %d rows, expecting %d", tableName, restoredCount, sourceCount))
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// withinTolerance returns true if the restored count differs from the source count by no more than the tolerance in percent.
+func withinTolerance(sourceCount, restoredCount int64, tolerance int) bool {
+	diff := sourceCount - restoredCount
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff*100 <= sourceCount*int64(tolerance)
+}
+
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", schema, name)
+}
+
+func quoteIdentifier(engine db.Type, name string) string {
+	if engine == db.Postgres {
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+	}
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
+func quoteTableName(engine db.Type, schema, table string) string {
+	if schema == "" {
+		return quoteIdentifier(engine, table)
+	}
+	return fmt.Sprintf("%s.%s", quoteIdentifier(engine, schema), quoteIdentifier(engine, table))
+}
+
+func joinDiffs(diffs []string) string {
+	if len(diffs) > maxReportedDiffs {
+		return fmt.Sprintf("%s and %d more", strings.Join(diffs[:maxReportedDiffs], "; "), len(diffs)-maxReportedDiffs)
+	}
+	return strings.Join(diffs, "; ")
+}
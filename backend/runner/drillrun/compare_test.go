@@ -0,0 +1,80 @@
+package drillrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestCompareSchema(t *testing.T) {
+	a := require.New(t)
+	source := &storepb.DatabaseMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "t1",
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "integer"},
+							{Name: "name", Type: "text"},
+						},
+						Indexes: []*storepb.IndexMetadata{{Name: "t1_pkey"}},
+					},
+					{Name: "t2"},
+				},
+				Views: []*storepb.ViewMetadata{{Name: "v1"}},
+			},
+		},
+	}
+
+	a.Empty(compareSchema(source, source))
+
+	restored := &storepb.DatabaseMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "t1",
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "bigint"},
+							{Name: "name", Type: "text"},
+							{Name: "extra", Type: "text"},
+						},
+					},
+					{Name: "t3"},
+				},
+			},
+		},
+	}
+	a.Equal([]string{
+		`column "id" of table "public.t1" has type "bigint", expecting "integer"`,
+		`table "public.t1" has 3 columns, expecting 2`,
+		`index "t1_pkey" of table "public.t1" is missing`,
+		`table "public.t2" is missing`,
+		`table "public.t3" is unexpected`,
+		`view "public.v1" is missing`,
+	}, compareSchema(source, restored))
+}
+
+func TestWithinTolerance(t *testing.T) {
+	tests := []struct {
+		sourceCount   int64
+		restoredCount int64
+		tolerance     int
+		want          bool
+	}{
+		{0, 0, 0, true},
+		{100, 100, 0, true},
+		{100, 99, 0, false},
+		{100, 90, 10, true},
+		{100, 111, 10, false},
+		{0, 1, 100, false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, withinTolerance(test.sourceCount, test.restoredCount, test.tolerance), "%+v", test)
+	}
+}
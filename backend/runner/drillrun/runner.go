@@ -0,0 +1,356 @@
+// Package drillrun is the runner for backup restore drills.
+package drillrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	"github.com/bytebase/bytebase/backend/runner/backuprun"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+)
+
+const (
+	// drillRunnerInterval is the interval to check whether the databases are due for drills.
+	// The drills are scheduled daily or weekly, so an hourly check is precise enough.
+	drillRunnerInterval = time.Hour
+)
+
+// NewRunner creates a new backup restore drill runner.
+func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, backupStorages *backuprun.Storages, profile *config.Profile) *Runner {
+	return &Runner{
+		store:          store,
+		dbFactory:      dbFactory,
+		backupStorages: backupStorages,
+		profile:        profile,
+	}
+}
+
+// Runner is the runner periodically restoring the latest backups into scratch databases
+// to verify that the backups are restorable.
+type Runner struct {
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	backupStorages *backuprun.Storages
+	profile        *config.Profile
+}
+
+// Run is the runner for backup restore drills.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(drillRunnerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug("Backup restore drill runner started", zap.Duration("interval", drillRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("Backup restore drill runner PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+					}
+				}()
+				r.runDrills(ctx)
+			}()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) runDrills(ctx context.Context) {
+	policyType := api.PolicyTypeBackupRestoreDrill
+	environmentResourceType := api.PolicyResourceTypeEnvironment
+	policies, err := r.store.ListPoliciesV2(ctx, &store.FindPolicyMessage{
+		Type:         &policyType,
+		ResourceType: &environmentResourceType,
+	})
+	if err != nil {
+		log.Error("Failed to list backup restore drill policies", zap.Error(err))
+		return
+	}
+	for _, policy := range policies {
+		payload, err := api.UnmarshalBackupRestoreDrillPolicy(policy.Payload)
+		if err != nil {
+			log.Error("Failed to unmarshal backup restore drill policy", zap.Int("environmentID", policy.ResourceUID), zap.Error(err))
+			continue
+		}
+		period := getDrillPeriod(payload.Schedule)
+		if period == 0 {
+			continue
+		}
+		databases, err := r.listDrillDatabases(ctx, policy.ResourceUID, payload)
+		if err != nil {
+			log.Error("Failed to list databases for backup restore drills", zap.Int("environmentID", policy.ResourceUID), zap.Error(err))
+			continue
+		}
+		for _, database := range databases {
+			if ctx.Err() != nil {
+				return
+			}
+			backup, due, err := r.getDueBackup(ctx, database, period)
+			if err != nil {
+				log.Error("Failed to get the backup to drill", zap.String("instance", database.InstanceID), zap.String("database", database.DatabaseName), zap.Error(err))
+				continue
+			}
+			if !due {
+				continue
+			}
+			log.Debug("Start backup restore drill", zap.String("instance", database.InstanceID), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name))
+			drillErr := r.drill(ctx, database, backup, payload)
+			if err := r.recordDrillResult(ctx, database, backup, drillErr); err != nil {
+				log.Error("Failed to record backup restore drill result", zap.String("backup", backup.Name), zap.Error(err))
+			}
+		}
+	}
+}
+
+// getDrillPeriod returns the period between two drills of a database, or 0 if the drills are disabled.
+func getDrillPeriod(schedule api.BackupPlanPolicySchedule) time.Duration {
+	switch schedule {
+	case api.BackupPlanPolicyScheduleDaily:
+		return 24 * time.Hour
+	case api.BackupPlanPolicyScheduleWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// listDrillDatabases returns the databases to drill in the environment.
+func (r *Runner) listDrillDatabases(ctx context.Context, environmentUID int, policy *api.BackupRestoreDrillPolicy) ([]*store.DatabaseMessage, error) {
+	environment, err := r.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{UID: &environmentUID})
+	if err != nil {
+		return nil, err
+	}
+	if environment == nil || environment.Deleted {
+		return nil, nil
+	}
+	databases, err := r.store.ListDatabases(ctx, &store.FindDatabaseMessage{EnvironmentID: &environment.ResourceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(policy.DatabaseList) == 0 {
+		return databases, nil
+	}
+	selected := make(map[string]bool)
+	for _, name := range policy.DatabaseList {
+		instanceID, databaseName, err := api.ParseDrillDatabaseName(name)
+		if err != nil {
+			return nil, err
+		}
+		selected[fmt.Sprintf("%s/%s", instanceID, databaseName)] = true
+	}
+	var result []*store.DatabaseMessage
+	for _, database := range databases {
+		if selected[fmt.Sprintf("%s/%s", database.InstanceID, database.DatabaseName)] {
+			result = append(result, database)
+		}
+	}
+	return result, nil
+}
+
+// getDueBackup returns the latest backup of the database if the database has not been drilled in the period.
+func (r *Runner) getDueBackup(ctx context.Context, database *store.DatabaseMessage, period time.Duration) (*store.BackupMessage, bool, error) {
+	rowStatus := api.Normal
+	status := api.BackupStatusDone
+	backups, err := r.store.ListBackupV2(ctx, &store.FindBackupMessage{
+		DatabaseUID: &database.UID,
+		RowStatus:   &rowStatus,
+		Status:      &status,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	var latest *store.BackupMessage
+	var lastDrillTs int64
+	for _, backup := range backups {
+		if latest == nil || backup.CreatedTs > latest.CreatedTs {
+			latest = backup
+		}
+		if backup.Payload.Drill != nil && backup.Payload.Drill.Ts > lastDrillTs {
+			lastDrillTs = backup.Payload.Drill.Ts
+		}
+	}
+	if latest == nil {
+		return nil, false, nil
+	}
+	if time.Since(time.Unix(lastDrillTs, 0)) < period {
+		return nil, false, nil
+	}
+	return latest, true, nil
+}
+
+// drill restores the backup into a scratch database on the drill instance and checks it against the source database.
+func (r *Runner) drill(ctx context.Context, database *store.DatabaseMessage, backup *store.BackupMessage, policy *api.BackupRestoreDrillPolicy) error {
+	sourceInstance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &database.InstanceID})
+	if err != nil {
+		return err
+	}
+	if sourceInstance == nil {
+		return errors.Errorf("instance %q not found", database.InstanceID)
+	}
+	drillInstance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &policy.InstanceID})
+	if err != nil {
+		return err
+	}
+	if drillInstance == nil {
+		return errors.Errorf("drill instance %q not found", policy.InstanceID)
+	}
+	if drillInstance.Engine != sourceInstance.Engine {
+		return errors.Errorf("drill instance %q has engine %s, but the database engine is %s", drillInstance.ResourceID, drillInstance.Engine, sourceInstance.Engine)
+	}
+	switch sourceInstance.Engine {
+	case db.MySQL, db.MariaDB, db.TiDB, db.Postgres:
+	default:
+		return errors.Errorf("backup restore drills are not supported for engine %s", sourceInstance.Engine)
+	}
+
+	scratchDatabaseName := util.GetDrillDatabaseName(database.DatabaseName, time.Now().Unix())
+	defaultDriver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, drillInstance, "")
+	if err != nil {
+		return err
+	}
+	defer defaultDriver.Close(ctx)
+	if _, err := defaultDriver.GetDB().ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s;", quoteIdentifier(drillInstance.Engine, scratchDatabaseName))); err != nil {
+		return errors.Wrapf(err, "failed to create the scratch database %q", scratchDatabaseName)
+	}
+	defer func() {
+		// Use a fresh context so that the scratch database is dropped even if the runner is canceled.
+		if _, err := defaultDriver.GetDB().ExecContext(context.Background(), fmt.Sprintf("DROP DATABASE IF EXISTS %s;", quoteIdentifier(drillInstance.Engine, scratchDatabaseName))); err != nil {
+			log.Error("Failed to drop the scratch database", zap.String("instance", drillInstance.ResourceID), zap.String("database", scratchDatabaseName), zap.Error(err))
+		}
+	}()
+
+	scratchDriver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, drillInstance, scratchDatabaseName)
+	if err != nil {
+		return err
+	}
+	defer scratchDriver.Close(ctx)
+	if err := restoreBackup(ctx, r.store, r.backupStorages, r.profile.DataDir, scratchDriver, backup); err != nil {
+		return err
+	}
+
+	restored, err := scratchDriver.SyncDBSchema(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to sync the restored schema")
+	}
+	// The source schema is only comparable if it has not been migrated since the backup.
+	version, err := utils.GetLatestSchemaVersion(ctx, r.store, sourceInstance.UID, database.UID, database.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if version == backup.MigrationHistoryVersion {
+		dbSchema, err := r.store.GetDBSchema(ctx, database.UID)
+		if err != nil {
+			return err
+		}
+		if dbSchema != nil && dbSchema.Metadata != nil {
+			if diffs := compareSchema(dbSchema.Metadata, restored); len(diffs) > 0 {
+				return errors.Errorf("restored schema differs from the source: %s", joinDiffs(diffs))
+			}
+		}
+	}
+
+	if policy.RowCountCheck {
+		sourceDriver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, sourceInstance, database.DatabaseName)
+		if err != nil {
+			return err
+		}
+		defer sourceDriver.Close(ctx)
+		diffs, err := compareRowCount(ctx, sourceInstance.Engine, sourceDriver, scratchDriver, restored, policy.RowCountTolerance)
+		if err != nil {
+			return err
+		}
+		if len(diffs) > 0 {
+			return errors.Errorf("restored row count differs from the source: %s", joinDiffs(diffs))
+		}
+	}
+	return nil
+}
+
+// restoreBackup restores the backup into the database of the driver.
+func restoreBackup(ctx context.Context, stores *store.Store, backupStorages *backuprun.Storages, dataDir string, driver db.Driver, backup *store.BackupMessage) error {
+	backupFile, err := backuprun.OpenBackupFile(ctx, stores, backupStorages, dataDir, backup)
+	if err != nil {
+		return err
+	}
+	defer backupFile.Close()
+	if err := driver.Restore(ctx, backupFile); err != nil {
+		return errors.Wrap(err, "failed to restore backup")
+	}
+	return nil
+}
+
+// recordDrillResult records the drill result on the backup, and raises or resolves the drill failure anomaly of the database.
+func (r *Runner) recordDrillResult(ctx context.Context, database *store.DatabaseMessage, backup *store.BackupMessage, drillErr error) error {
+	result := &api.BackupDrillResult{
+		Ts:      time.Now().Unix(),
+		Success: drillErr == nil,
+	}
+	if drillErr != nil {
+		result.Detail = drillErr.Error()
+		log.Warn("Backup restore drill failed", zap.String("instance", database.InstanceID), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name), zap.Error(drillErr))
+	}
+	backupPayload := backup.Payload
+	backupPayload.Drill = result
+	payloadBytes, err := json.Marshal(backupPayload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal backup payload")
+	}
+	payload := string(payloadBytes)
+	if _, err := r.store.UpdateBackupV2(ctx, &store.UpdateBackupMessage{
+		UID:       backup.UID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payload,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update backup %q", backup.Name)
+	}
+
+	instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &database.InstanceID})
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return errors.Errorf("instance %q not found", database.InstanceID)
+	}
+	if drillErr == nil {
+		return r.store.ArchiveAnomalyV2(ctx, &store.ArchiveAnomalyMessage{
+			DatabaseUID: &database.UID,
+			Type:        api.AnomalyDatabaseBackupDrillFailed,
+		})
+	}
+	anomalyPayload, err := json.Marshal(api.AnomalyDatabaseBackupDrillFailedPayload{
+		BackupID:   backup.UID,
+		BackupName: backup.Name,
+		Detail:     result.Detail,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal anomaly payload")
+	}
+	if _, err := r.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+		InstanceUID: instance.UID,
+		DatabaseUID: &database.UID,
+		Type:        api.AnomalyDatabaseBackupDrillFailed,
+		Payload:     string(anomalyPayload),
+	}); err != nil {
+		return errors.Wrap(err, "failed to create backup drill failure anomaly")
+	}
+	return nil
+}
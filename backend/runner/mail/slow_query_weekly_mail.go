@@ -385,6 +385,12 @@ func engineTypeString(engine db.Type) string {
 		return "MySQL"
 	case db.Postgres:
 		return "Postgres"
+	case db.Oracle:
+		return "Oracle"
+	case db.MSSQL:
+		return "SQL Server"
+	case db.MongoDB:
+		return "MongoDB"
 	}
 	return ""
 }
@@ -651,6 +657,12 @@ func engineOrder(engine db.Type) int {
 		return 1
 	case db.Postgres:
 		return 2
+	case db.Oracle:
+		return 3
+	case db.MSSQL:
+		return 4
+	case db.MongoDB:
+		return 5
 	default:
 		return 100
 	}
@@ -20,6 +20,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/store"
 )
 
@@ -122,9 +123,71 @@ func (r *Runner) generateRollbackSQL(ctx context.Context, task *store.TaskMessag
 		r.generateMySQLRollbackSQL(ctx, task, payload, instance, project)
 	case db.Oracle:
 		r.generateOracleRollbackSQL(ctx, task, payload, instance, project)
+	case db.Postgres:
+		r.generatePostgresRollbackSQL(ctx, task, payload, instance, database, project)
 	}
 }
 
+func (r *Runner) generatePostgresRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, database *store.DatabaseMessage, project *store.ProjectMessage) {
+	var rollbackSQLStatus api.RollbackSQLStatus
+	var rollbackStatement, rollbackError string
+
+	rollbackSQL, err := r.generatePostgresRollbackSQLImpl(ctx, payload, instance, database)
+	if err != nil {
+		log.Error("Failed to generate rollback SQL statement", zap.Error(err))
+		rollbackSQLStatus = api.RollbackSQLStatusFailed
+		rollbackError = err.Error()
+	} else {
+		rollbackSQLStatus = api.RollbackSQLStatusDone
+		rollbackStatement = rollbackSQL
+	}
+
+	sheet, err := r.store.CreateSheet(ctx, &api.SheetCreate{
+		CreatorID:  api.SystemBotID,
+		ProjectID:  project.UID,
+		Name:       fmt.Sprintf("Sheet for rolling back task %d", task.ID),
+		Statement:  rollbackStatement,
+		Visibility: api.ProjectSheet,
+		Source:     api.SheetFromBytebaseArtifact,
+		Type:       api.SheetForSQL,
+		Payload:    "{}",
+	})
+	if err != nil {
+		log.Error("failed to create database creation sheet", zap.Error(err))
+		return
+	}
+
+	patch := &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackSheetID:   &sheet.ID,
+		RollbackError:     &rollbackError,
+	}
+	if _, err := r.store.UpdateTaskV2(ctx, patch); err != nil {
+		log.Error("Failed to patch task with the Postgres payload", zap.Int("taskID", task.ID))
+		return
+	}
+	log.Debug("Rollback SQL generation success", zap.Int("taskID", task.ID))
+}
+
+func (r *Runner) generatePostgresRollbackSQLImpl(ctx context.Context, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, database *store.DatabaseMessage) (string, error) {
+	if len(payload.DataBackupList) == 0 {
+		return "", errors.New("missing backup of the changed rows, may be there is no data change in the transaction")
+	}
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, database.DatabaseName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get admin database driver")
+	}
+	defer driver.Close(ctx)
+
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return "", errors.Errorf("failed to cast driver to pg.Driver")
+	}
+	return pgDriver.GenerateRollbackSQL(ctx, payload.DataBackupList)
+}
+
 func (r *Runner) generateOracleRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, project *store.ProjectMessage) {
 	var rollbackSQLStatus api.RollbackSQLStatus
 	var rollbackStatement, rollbackError string
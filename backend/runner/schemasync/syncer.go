@@ -16,6 +16,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/prom"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -94,8 +95,10 @@ func (s *Syncer) syncAllInstances(ctx context.Context) {
 				log.Debug("Failed to sync instance",
 					zap.String("instance", instance.ResourceID),
 					zap.String("error", err.Error()))
+				prom.SchemaSyncErrorsTotal.WithLabelValues(instance.ResourceID).Inc()
 				return
 			}
+			prom.ObserveSchemaSync(instance.ResourceID)
 		}(instance)
 	}
 	instanceWG.Wait()
@@ -9,11 +9,13 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/prom"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -101,6 +103,7 @@ func (s *Syncer) syncSlowQuery(ctx context.Context, instance *api.Instance) {
 				log.Debug("Failed to sync instance slow query",
 					zap.String("instance", instance.ResourceID),
 					zap.Error(err))
+				prom.SlowQuerySyncErrorsTotal.WithLabelValues(instance.ResourceID).Inc()
 			}
 		}(instance)
 	}
@@ -117,8 +120,10 @@ func (s *Syncer) syncInstanceSlowQuery(ctx context.Context, instance *store.Inst
 	}
 
 	switch instance.Engine {
-	case db.MySQL:
-		return s.syncMySQLSlowQuery(ctx, instance)
+	case db.MySQL, db.MongoDB:
+		return s.syncSlowQueryLogByDate(ctx, instance)
+	case db.Oracle, db.MSSQL:
+		return s.syncSlowQueryStatistics(ctx, instance)
 	case db.Postgres:
 		return s.syncPostgreSQLSlowQuery(ctx, instance)
 	default:
@@ -282,7 +287,9 @@ func getLatestLogTime(logMap map[string]*storepb.SlowQueryStatistics) time.Time
 	return time.Time{}
 }
 
-func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.InstanceMessage) error {
+// syncSlowQueryLogByDate syncs the slow query logs day by day since the latest synced date.
+// It's used by the engines recording every slow query with the time, i.e. MySQL slow_log and MongoDB system.profile.
+func (s *Syncer) syncSlowQueryLogByDate(ctx context.Context, instance *store.InstanceMessage) error {
 	today := time.Now().UTC().Truncate(24 * time.Hour)
 
 	earliestDate := today.AddDate(0, 0, -retentionCycle)
@@ -332,3 +339,128 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 
 	return nil
 }
+
+// syncSlowQueryStatistics syncs the slow query statistics of today.
+// It's used by the engines accumulating the statistics in memory, i.e. Oracle V$SQL and SQL Server sys.dm_exec_query_stats,
+// so we store the snapshot of the accumulated statistics and add the difference from the previous snapshot to the statistics of today.
+// The first sync of an instance only takes the snapshot, because the statistics accumulated before are not known to belong to today.
+// The snapshot is stored before the statistics, so a failed sync loses the difference rather than counting it twice.
+func (s *Syncer) syncSlowQueryStatistics(ctx context.Context, instance *store.InstanceMessage) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	earliestDate := today.AddDate(0, 0, -retentionCycle)
+
+	if err := s.store.DeleteOutdatedSlowLog(ctx, instance.UID, earliestDate); err != nil {
+		return err
+	}
+
+	driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, "")
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	if err := driver.CheckSlowQueryLogEnabled(ctx); err != nil {
+		return err
+	}
+
+	// Get the statistics of the whole retention cycle, so that the queries that are not executed today stay in the snapshot.
+	logs, err := driver.SyncSlowQuery(ctx, earliestDate)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.store.GetSlowQuerySnapshot(ctx, instance.UID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.UpsertSlowQuerySnapshot(ctx, instance.UID, logs); err != nil {
+		return err
+	}
+	if previous == nil {
+		return nil
+	}
+
+	nextDate := today.AddDate(0, 0, 1)
+	for dbName, slowLog := range logs {
+		statistics := diffSlowQueryStatistics(getStatisticsItems(previous[dbName]), getStatisticsItems(slowLog))
+		if len(statistics.Items) == 0 {
+			continue
+		}
+
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+			InstanceID:   &instance.ResourceID,
+			DatabaseName: &dbName,
+		})
+		if err != nil {
+			return err
+		}
+		if database == nil {
+			continue
+		}
+		todayLogs, err := s.store.ListSlowQuery(ctx, &store.ListSlowQueryMessage{
+			InstanceUID:  &instance.UID,
+			DatabaseUID:  &database.UID,
+			StartLogDate: &today,
+			EndLogDate:   &nextDate,
+		})
+		if err != nil {
+			return err
+		}
+		if len(todayLogs) != 0 {
+			statistics = pgMergeSlowQueryLog(statistics, todayLogs)
+		}
+
+		if err := s.store.UpsertSlowLog(ctx, &store.UpsertSlowLogMessage{
+			EnvironmentID: &instance.EnvironmentID,
+			InstanceID:    &instance.ResourceID,
+			DatabaseName:  dbName,
+			InstanceUID:   instance.UID,
+			LogDate:       today,
+			SlowLog:       statistics,
+			UpdaterID:     api.SystemBotID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getStatisticsItems returns the statistics items keyed by the SQL fingerprint.
+func getStatisticsItems(statistics *storepb.SlowQueryStatistics) map[string]*storepb.SlowQueryStatisticsItem {
+	items := make(map[string]*storepb.SlowQueryStatisticsItem)
+	for _, item := range statistics.GetItems() {
+		items[item.SqlFingerprint] = item
+	}
+	return items
+}
+
+// diffSlowQueryStatistics returns the statistics accumulated from the previous snapshot to the current snapshot.
+// The accumulated statistics of a fingerprint restart from zero if its plans are evicted from the plan cache
+// or the counters are reset, in which case the current statistics are taken as a whole.
+// The maximum values are not accumulative, so the current ones are used.
+func diffSlowQueryStatistics(previous, current map[string]*storepb.SlowQueryStatisticsItem) *storepb.SlowQueryStatistics {
+	result := &storepb.SlowQueryStatistics{}
+	for fingerprint, item := range current {
+		previousItem, ok := previous[fingerprint]
+		if !ok || item.Count < previousItem.Count || item.TotalQueryTime.AsDuration() < previousItem.TotalQueryTime.AsDuration() {
+			result.Items = append(result.Items, proto.Clone(item).(*storepb.SlowQueryStatisticsItem))
+			continue
+		}
+		if item.Count == previousItem.Count {
+			continue
+		}
+		result.Items = append(result.Items, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:      fingerprint,
+			Count:               item.Count - previousItem.Count,
+			LatestLogTime:       item.LatestLogTime,
+			TotalQueryTime:      durationpb.New(item.TotalQueryTime.AsDuration() - previousItem.TotalQueryTime.AsDuration()),
+			MaximumQueryTime:    item.MaximumQueryTime,
+			TotalRowsSent:       item.TotalRowsSent - previousItem.TotalRowsSent,
+			MaximumRowsSent:     item.MaximumRowsSent,
+			TotalRowsExamined:   item.TotalRowsExamined - previousItem.TotalRowsExamined,
+			MaximumRowsExamined: item.MaximumRowsExamined,
+		})
+	}
+	return result
+}
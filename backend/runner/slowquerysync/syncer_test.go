@@ -0,0 +1,56 @@
+package slowquerysync
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestDiffSlowQueryStatistics(t *testing.T) {
+	newItem := func(fingerprint string, count int64, totalQueryTime time.Duration, totalRowsSent int64) *storepb.SlowQueryStatisticsItem {
+		return &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   fingerprint,
+			Count:            count,
+			TotalQueryTime:   durationpb.New(totalQueryTime),
+			MaximumQueryTime: durationpb.New(2 * time.Second),
+			TotalRowsSent:    totalRowsSent,
+		}
+	}
+	previous := map[string]*storepb.SlowQueryStatisticsItem{
+		"accumulated": newItem("accumulated", 10, 20*time.Second, 100),
+		"unchanged":   newItem("unchanged", 5, 10*time.Second, 50),
+		"reset":       newItem("reset", 8, 16*time.Second, 80),
+		"evicted":     newItem("evicted", 3, 6*time.Second, 30),
+	}
+	current := map[string]*storepb.SlowQueryStatisticsItem{
+		"accumulated": newItem("accumulated", 15, 30*time.Second, 150),
+		"unchanged":   newItem("unchanged", 5, 10*time.Second, 50),
+		"reset":       newItem("reset", 2, 4*time.Second, 20),
+		"new":         newItem("new", 1, 2*time.Second, 10),
+	}
+	want := []*storepb.SlowQueryStatisticsItem{
+		newItem("accumulated", 5, 10*time.Second, 50),
+		newItem("new", 1, 2*time.Second, 10),
+		newItem("reset", 2, 4*time.Second, 20),
+	}
+
+	got := diffSlowQueryStatistics(previous, current).Items
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].SqlFingerprint < got[j].SqlFingerprint
+	})
+	require.Equal(t, len(want), len(got))
+	for i := range want {
+		require.Equal(t, want[i].SqlFingerprint, got[i].SqlFingerprint)
+		require.Equal(t, want[i].Count, got[i].Count)
+		require.Equal(t, want[i].TotalQueryTime.AsDuration(), got[i].TotalQueryTime.AsDuration())
+		require.Equal(t, want[i].MaximumQueryTime.AsDuration(), got[i].MaximumQueryTime.AsDuration())
+		require.Equal(t, want[i].TotalRowsSent, got[i].TotalRowsSent)
+	}
+	// The snapshot must not be modified by merging the difference.
+	require.NotSame(t, current["new"], got[1])
+}
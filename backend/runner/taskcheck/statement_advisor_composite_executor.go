@@ -3,6 +3,7 @@ package taskcheck
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -11,6 +12,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	advisorDB "github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
@@ -134,6 +136,8 @@ func (e *StatementAdvisorCompositeExecutor) Run(ctx context.Context, taskCheckRu
 		Catalog:   catalog,
 		Driver:    connection,
 		Context:   ctx,
+		// The unqualified object names in the Oracle migration resolve to the schema of the user running it.
+		CurrentSchema: getOracleCurrentSchema(instance, database),
 	})
 	if err != nil {
 		return nil, err
@@ -144,7 +148,10 @@ func (e *StatementAdvisorCompositeExecutor) Run(ctx context.Context, taskCheckRu
 		status := api.TaskCheckStatusSuccess
 		switch advice.Status {
 		case advisor.Success:
-			continue
+			// Record the suppressed advice so that reviewers can see the accepted exception.
+			if !advice.Suppressed {
+				continue
+			}
 		case advisor.Warn:
 			status = api.TaskCheckStatusWarn
 		case advisor.Error:
@@ -152,13 +159,15 @@ func (e *StatementAdvisorCompositeExecutor) Run(ctx context.Context, taskCheckRu
 		}
 
 		result = append(result, api.TaskCheckResult{
-			Status:    status,
-			Namespace: api.AdvisorNamespace,
-			Code:      advice.Code.Int(),
-			Title:     advice.Title,
-			Content:   advice.Content,
-			Line:      advice.Line,
-			Details:   advice.Details,
+			Status:         status,
+			Namespace:      api.AdvisorNamespace,
+			Code:           advice.Code.Int(),
+			Title:          advice.Title,
+			Content:        advice.Content,
+			Line:           advice.Line,
+			Details:        advice.Details,
+			Suppressed:     advice.Suppressed,
+			SuppressReason: advice.SuppressReason,
 		})
 	}
 
@@ -176,3 +185,17 @@ func (e *StatementAdvisorCompositeExecutor) Run(ctx context.Context, taskCheckRu
 
 	return result, nil
 }
+
+// getOracleCurrentSchema returns the schema of the admin data source user which runs the migration,
+// or the database name if there is no admin data source. It returns empty for the other engines.
+func getOracleCurrentSchema(instance *store.InstanceMessage, database *store.DatabaseMessage) string {
+	if instance.Engine != db.Oracle {
+		return ""
+	}
+	dataSource := utils.DataSourceFromInstanceWithType(instance, api.Admin)
+	if dataSource == nil || dataSource.Username == "" {
+		return database.DatabaseName
+	}
+	// Oracle stores the unquoted user name in upper case.
+	return strings.ToUpper(dataSource.Username)
+}
@@ -392,7 +392,7 @@ func postgresqlStatementTypeCheck(statement string, taskType api.TaskType) (resu
 				})
 			}
 		}
-	case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync:
+	case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync, api.TaskDatabaseSchemaUpdatePGOnlineSync:
 		for _, node := range stmts {
 			_, isDML := node.(ast.DMLNode)
 			_, isSelect := node.(*ast.SelectStmt)
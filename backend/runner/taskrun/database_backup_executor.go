@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -14,9 +16,11 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/prom"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/plugin/storage/codec"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/store"
 )
@@ -27,21 +31,21 @@ const (
 )
 
 // NewDatabaseBackupExecutor creates a new database backup task executor.
-func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile) Executor {
+func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, backupStorages *backuprun.Storages, profile config.Profile) Executor {
 	return &DatabaseBackupExecutor{
-		store:     store,
-		dbFactory: dbFactory,
-		s3Client:  s3Client,
-		profile:   profile,
+		store:          store,
+		dbFactory:      dbFactory,
+		backupStorages: backupStorages,
+		profile:        profile,
 	}
 }
 
 // DatabaseBackupExecutor is the task executor for database backup.
 type DatabaseBackupExecutor struct {
-	store     *store.Store
-	dbFactory *dbfactory.DBFactory
-	s3Client  *bbs3.Client
-	profile   config.Profile
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	backupStorages *backuprun.Storages
+	profile        config.Profile
 }
 
 // RunOnce will run database backup once.
@@ -80,7 +84,8 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, task *store.Tas
 		}
 	}
 	log.Debug("Start database backup.", zap.String("instance", instance.Title), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name))
-	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.s3Client, exec.profile, instance, database.DatabaseName, backup)
+	startTime := time.Now()
+	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.backupStorages, exec.profile, instance, database.DatabaseName, backup)
 	backupStatus := string(api.BackupStatusDone)
 	comment := ""
 	if backupErr != nil {
@@ -90,6 +95,7 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, task *store.Tas
 			log.Warn(err.Error())
 		}
 	}
+	observeBackup(backup.StorageBackend, backupStatus, startTime, backupPayload)
 	backupPatch := store.UpdateBackupMessage{
 		UID:       backup.UID,
 		Status:    &backupStatus,
@@ -111,6 +117,20 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, task *store.Tas
 	}, nil
 }
 
+// observeBackup records the backup metrics.
+func observeBackup(storageBackend api.BackupStorageBackend, status string, startTime time.Time, backupPayload string) {
+	prom.BackupDuration.WithLabelValues(string(storageBackend), status).Observe(time.Since(startTime).Seconds())
+	if status != string(api.BackupStatusDone) || backupPayload == "" {
+		return
+	}
+	var payload api.BackupPayload
+	if err := json.Unmarshal([]byte(backupPayload), &payload); err != nil {
+		log.Warn("Failed to unmarshal backup payload", zap.Error(err))
+		return
+	}
+	prom.BackupDumpSize.WithLabelValues(string(storageBackend)).Observe(float64(payload.DumpSize))
+}
+
 func removeLocalBackupFile(dataDir string, backup *store.BackupMessage) error {
 	if backup.StorageBackend != api.BackupStorageBackendLocal {
 		return nil
@@ -137,56 +157,74 @@ func getAvailableFSSpace(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
-func dumpBackupFile(ctx context.Context, driver db.Driver, backupFilePath string) (string, error) {
+func dumpBackupFile(ctx context.Context, stores *store.Store, driver db.Driver, compression codec.Compression, backupFilePath string) (string, error) {
 	backupFile, err := os.Create(backupFilePath)
 	if err != nil {
 		return "", errors.Errorf("failed to open backup path %q", backupFilePath)
 	}
 	defer backupFile.Close()
-	payload, err := driver.Dump(ctx, backupFile, false /* schemaOnly */)
+	payload, err := backuprun.DumpBackup(ctx, stores, driver, compression, backupFile)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to dump database to local backup file %q", backupFilePath)
 	}
+	if err := backupFile.Close(); err != nil {
+		return "", errors.Wrapf(err, "failed to close local backup file %q", backupFilePath)
+	}
+	return payload, nil
+}
+
+// dumpBackupToStorage dumps the database and streams the dump to the backup storage without a local copy.
+func dumpBackupToStorage(ctx context.Context, stores *store.Store, driver db.Driver, compression codec.Compression, backupStorage storage.Backend, path string) (string, error) {
+	reader, writer := io.Pipe()
+	var payload string
+	var dumpErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		payload, dumpErr = backuprun.DumpBackup(ctx, stores, driver, compression, writer)
+		// Closing with a nil error ends the stream normally, otherwise the uploading fails with dumpErr.
+		writer.CloseWithError(dumpErr)
+	}()
+	putErr := backupStorage.Put(ctx, path, reader)
+	// Unblock the dumping if the uploading stops before reaching the end of the stream.
+	reader.CloseWithError(errors.New("backup storage stopped receiving the backup"))
+	<-done
+	if dumpErr != nil {
+		return "", errors.Wrap(dumpErr, "failed to dump database to the backup storage")
+	}
+	if putErr != nil {
+		return "", errors.Wrapf(putErr, "failed to upload backup to the backup storage")
+	}
 	return payload, nil
 }
 
 // backupDatabase will take a backup of a database.
-func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) (string, error) {
+func (exec *DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, backupStorages *backuprun.Storages, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) (string, error) {
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, databaseName)
 	if err != nil {
 		return "", err
 	}
 	defer driver.Close(ctx)
 
-	backupFilePathLocal := filepath.Join(profile.DataDir, backup.Path)
-	payload, err := dumpBackupFile(ctx, driver, backupFilePathLocal)
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
-	}
-
 	switch backup.StorageBackend {
 	case api.BackupStorageBackendLocal:
-		return payload, nil
-	case api.BackupStorageBackendS3:
-		log.Debug("Uploading backup to s3 bucket.", zap.String("bucket", s3Client.GetBucket()), zap.String("path", backupFilePathLocal))
-		bucketFileToUpload, err := os.Open(backupFilePathLocal)
+		backupFilePathLocal := filepath.Join(profile.DataDir, backup.Path)
+		payload, err := dumpBackupFile(ctx, exec.store, driver, profile.BackupCompression, backupFilePathLocal)
 		if err != nil {
-			return "", errors.Wrapf(err, "failed to open backup file %q for uploading to s3 bucket", backupFilePathLocal)
+			return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
 		}
-		defer bucketFileToUpload.Close()
-
-		if _, err := s3Client.UploadObject(ctx, backup.Path, bucketFileToUpload); err != nil {
-			return "", errors.Wrapf(err, "failed to upload backup to AWS S3")
+		return payload, nil
+	default:
+		backupStorage, err := backupStorages.Get(backup)
+		if err != nil {
+			return "", err
 		}
-		log.Debug("Successfully uploaded backup to s3 bucket.")
-
-		if err := os.Remove(backupFilePathLocal); err != nil {
-			log.Warn("Failed to remove the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal), zap.Error(err))
-		} else {
-			log.Debug("Successfully removed the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal))
+		log.Debug("Uploading backup to the backup storage.", zap.String("storage", string(backup.StorageBackend)), zap.String("path", backup.Path))
+		payload, err := dumpBackupToStorage(ctx, exec.store, driver, profile.BackupCompression, backupStorage, backup.Path)
+		if err != nil {
+			return "", err
 		}
+		log.Debug("Successfully uploaded backup to the backup storage.", zap.String("path", backup.Path))
 		return payload, nil
-	default:
-		return "", errors.Errorf("backup to %s not implemented yet", backup.StorageBackend)
 	}
 }
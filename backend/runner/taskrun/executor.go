@@ -23,6 +23,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
@@ -180,6 +181,10 @@ func executeMigration(ctx context.Context, stores *store.Store, dbFactory *dbfac
 		task = updatedTask
 	}
 
+	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == db.Postgres {
+		return executePostgresMigrationWithDataBackup(ctx, stores, stateCfg, driver, task, statement, mi)
+	}
+
 	var executeBeforeCommitTx func(tx *sql.Tx) error
 	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == db.Oracle {
 		// getSetOracleTransactionIdFunc will update the task payload to set the Oracle transaction id, we need to re-retrieve the task to store to the RollbackGenerate.
@@ -225,6 +230,55 @@ func executeMigration(ctx context.Context, stores *store.Store, dbFactory *dbfac
 	return migrationID, schema, nil
 }
 
+// executePostgresMigrationWithDataBackup executes the data change and backs up the changed rows for the rollback SQL generation if enabled.
+func executePostgresMigrationWithDataBackup(ctx context.Context, stores *store.Store, stateCfg *state.State, driver db.Driver, task *store.TaskMessage, statement string, mi *db.MigrationInfo) (string, string, error) {
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return "", "", errors.Wrap(err, "invalid database data update payload")
+	}
+	pgDriver, ok := driver.(*pg.Driver)
+	if !payload.RollbackEnabled || !ok {
+		return utils.ExecuteMigrationDefault(ctx, stores, driver, mi, statement, nil /* executeBeforeCommitTx */)
+	}
+
+	var transactionID string
+	var backupList []*api.DataBackup
+	migrationID, schema, err := utils.ExecuteMigrationWithFunc(ctx, stores, driver, mi, statement, func(execStatement string) error {
+		txID, list, err := pgDriver.ExecuteWithDataBackup(ctx, execStatement, fmt.Sprintf("task_%d", task.ID))
+		if err != nil {
+			return err
+		}
+		transactionID, backupList = txID, list
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	// The statement is not executed if the migration has been applied before.
+	if transactionID == "" {
+		return migrationID, schema, nil
+	}
+
+	payload.TransactionID = transactionID
+	payload.DataBackupList = backupList
+	updatedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal database data update payload")
+	}
+	updatedPayloadString := string(updatedPayload)
+	updatedTask, err := stores.UpdateTaskV2(ctx, &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &updatedPayloadString,
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to update the task payload for Postgres rollback SQL")
+	}
+	// The runner will periodically scan the map to generate rollback SQL asynchronously.
+	stateCfg.RollbackGenerate.Store(task.ID, updatedTask)
+	return migrationID, schema, nil
+}
+
 func getSetOracleTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
 	return func(tx *sql.Tx) error {
 		payload := &api.TaskDatabaseDataUpdatePayload{}
@@ -508,7 +562,7 @@ func isWriteBack(ctx context.Context, stores *store.Store, license enterpriseAPI
 	if !license.IsFeatureEnabled(api.FeatureVCSSchemaWriteBack) {
 		return "", nil
 	}
-	if task.Type != api.TaskDatabaseSchemaBaseline && task.Type != api.TaskDatabaseSchemaUpdate && task.Type != api.TaskDatabaseSchemaUpdateGhostCutover {
+	if task.Type != api.TaskDatabaseSchemaBaseline && task.Type != api.TaskDatabaseSchemaUpdate && task.Type != api.TaskDatabaseSchemaUpdateGhostCutover && task.Type != api.TaskDatabaseSchemaUpdatePGOnlineCutover {
 		return "", nil
 	}
 	if repo == nil || repo.SchemaPathTemplate == "" {
@@ -0,0 +1,547 @@
+package taskrun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+)
+
+const (
+	// pgOnlineMigrationBatchSize is the number of rows copied from the original table to the shadow table in a batch.
+	pgOnlineMigrationBatchSize = 1000
+	// pgOnlineMigrationLockTimeout is the lock timeout for acquiring the ACCESS EXCLUSIVE lock on the original table during cutover.
+	pgOnlineMigrationLockTimeout = "5s"
+	pgDefaultSchema              = "public"
+)
+
+// alterTablePrefixReg matches the leading `ALTER TABLE [IF EXISTS] [ONLY] table` of an ALTER TABLE statement.
+// The table reference is captured in the second group so that we can point the statement to the shadow table.
+var alterTablePrefixReg = regexp.MustCompile(`(?is)^(\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?)((?:"(?:[^"]|"")*"|[A-Za-z_][\w$]*)(?:\s*\.\s*(?:"(?:[^"]|"")*"|[A-Za-z_][\w$]*))?)`)
+
+// pgQueryer is the common interface of *sql.DB and *sql.Tx.
+type pgQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// pgOnlineMigration is the online migration of a single PostgreSQL table.
+// It creates a shadow table, applies the ALTER TABLE statement to it, keeps it in sync with the original
+// table by a trigger and copies the existing rows in batches. The cutover swaps the two tables in a short
+// transaction holding the ACCESS EXCLUSIVE lock.
+// The trigger never fails the writes on the original table. If a change can't be replayed to the shadow
+// table, the error is recorded in the error table, and the migration is broken and can't be cut over.
+// The shadow table, the error table, the trigger and the trigger function are named after the sync task ID,
+// so that the cutover task can find them without sharing any in-memory state with the sync task.
+type pgOnlineMigration struct {
+	syncTaskID int
+	schema     string
+	table      string
+	// statement is the ALTER TABLE statement.
+	statement string
+}
+
+// newPGOnlineMigration parses the statement and returns the online migration for the altered table.
+// Only a single ALTER TABLE statement is supported, and it must not rename the table, its columns or
+// constraints, nor move the table to another schema.
+func newPGOnlineMigration(syncTaskID int, statement string) (*pgOnlineMigration, error) {
+	statement = strings.TrimSpace(statement)
+	nodes, err := parser.Parse(parser.Postgres, parser.ParseContext{}, statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse statement")
+	}
+	if len(nodes) != 1 {
+		return nil, errors.Errorf("online migration only supports a single ALTER TABLE statement, but got %d statements", len(nodes))
+	}
+	alterTable, ok := nodes[0].(*ast.AlterTableStmt)
+	if !ok || alterTable.Table == nil {
+		return nil, errors.New("online migration only supports ALTER TABLE statement")
+	}
+	for _, item := range alterTable.AlterItemList {
+		switch item.(type) {
+		case *ast.RenameColumnStmt, *ast.RenameTableStmt, *ast.RenameConstraintStmt, *ast.RenameIndexStmt, *ast.SetSchemaStmt, *ast.AttachPartitionStmt:
+			return nil, errors.Errorf("online migration doesn't support %q", item.Text())
+		}
+	}
+	if !alterTablePrefixReg.MatchString(statement) {
+		return nil, errors.New("failed to find the table in the ALTER TABLE statement")
+	}
+
+	schema := alterTable.Table.Schema
+	if schema == "" {
+		schema = pgDefaultSchema
+	}
+	return &pgOnlineMigration{
+		syncTaskID: syncTaskID,
+		schema:     schema,
+		table:      alterTable.Table.Name,
+		statement:  statement,
+	}, nil
+}
+
+func (m *pgOnlineMigration) shadowTableName() string {
+	return fmt.Sprintf("_bb_%d_shadow", m.syncTaskID)
+}
+
+func (m *pgOnlineMigration) errorTableName() string {
+	return fmt.Sprintf("_bb_%d_error", m.syncTaskID)
+}
+
+func (m *pgOnlineMigration) triggerName() string {
+	return fmt.Sprintf("_bb_%d_sync", m.syncTaskID)
+}
+
+func (m *pgOnlineMigration) originalTable() string {
+	return fmt.Sprintf("%s.%s", pgQuoteIdentifier(m.schema), pgQuoteIdentifier(m.table))
+}
+
+func (m *pgOnlineMigration) shadowTable() string {
+	return fmt.Sprintf("%s.%s", pgQuoteIdentifier(m.schema), pgQuoteIdentifier(m.shadowTableName()))
+}
+
+func (m *pgOnlineMigration) errorTable() string {
+	return fmt.Sprintf("%s.%s", pgQuoteIdentifier(m.schema), pgQuoteIdentifier(m.errorTableName()))
+}
+
+func (m *pgOnlineMigration) triggerFunction() string {
+	return fmt.Sprintf("%s.%s", pgQuoteIdentifier(m.schema), pgQuoteIdentifier(m.triggerName()))
+}
+
+// createShadowTableStatement returns the statement creating the shadow table with the original table definition.
+func (m *pgOnlineMigration) createShadowTableStatement() string {
+	return fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL);", m.shadowTable(), m.originalTable())
+}
+
+// alterShadowTableStatement returns the ALTER TABLE statement pointing to the shadow table.
+func (m *pgOnlineMigration) alterShadowTableStatement() string {
+	loc := alterTablePrefixReg.FindStringSubmatchIndex(m.statement)
+	return m.statement[:loc[4]] + m.shadowTable() + m.statement[loc[5]:]
+}
+
+// createTriggerStatement returns the statement creating the trigger that replays the changes on the original table to the shadow table.
+// The columns are the columns existing in both tables.
+// The replay runs in a subtransaction, and its error is recorded in the error table instead of failing the write on the original table.
+// The trigger stops replaying once the migration is broken.
+func (m *pgOnlineMigration) createTriggerStatement(columns, primaryKey []string) string {
+	var newValues, pkCondition []string
+	for _, column := range columns {
+		newValues = append(newValues, fmt.Sprintf("NEW.%s", pgQuoteIdentifier(column)))
+	}
+	for _, column := range primaryKey {
+		pkCondition = append(pkCondition, fmt.Sprintf("%s = OLD.%s", pgQuoteIdentifier(column), pgQuoteIdentifier(column)))
+	}
+	upsert := fmt.Sprintf("INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE VALUES (%s) %s;",
+		m.shadowTable(), pgJoinIdentifiers(columns), strings.Join(newValues, ", "), pgOnConflictClause(columns, primaryKey))
+	del := fmt.Sprintf("DELETE FROM %s WHERE %s;", m.shadowTable(), strings.Join(pkCondition, " AND "))
+
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "CREATE TABLE %s (message TEXT NOT NULL, created_ts TIMESTAMPTZ NOT NULL DEFAULT now());\n", m.errorTable())
+	_, _ = fmt.Fprintf(&buf, "CREATE FUNCTION %s() RETURNS trigger AS $bb$\n", m.triggerFunction())
+	_, _ = buf.WriteString("BEGIN\n")
+	_, _ = fmt.Fprintf(&buf, "  IF EXISTS (SELECT 1 FROM %s) THEN\n", m.errorTable())
+	_, _ = buf.WriteString("    RETURN NULL;\n")
+	_, _ = buf.WriteString("  END IF;\n")
+	_, _ = buf.WriteString("  BEGIN\n")
+	_, _ = buf.WriteString("    IF TG_OP = 'INSERT' THEN\n")
+	_, _ = fmt.Fprintf(&buf, "      %s\n", upsert)
+	_, _ = buf.WriteString("    ELSIF TG_OP = 'UPDATE' THEN\n")
+	_, _ = fmt.Fprintf(&buf, "      %s\n", del)
+	_, _ = fmt.Fprintf(&buf, "      %s\n", upsert)
+	_, _ = buf.WriteString("    ELSIF TG_OP = 'DELETE' THEN\n")
+	_, _ = fmt.Fprintf(&buf, "      %s\n", del)
+	_, _ = buf.WriteString("    END IF;\n")
+	_, _ = buf.WriteString("  EXCEPTION WHEN OTHERS THEN\n")
+	_, _ = fmt.Fprintf(&buf, "    INSERT INTO %s (message) VALUES (SQLSTATE || ': ' || SQLERRM);\n", m.errorTable())
+	_, _ = buf.WriteString("  END;\n")
+	_, _ = buf.WriteString("  RETURN NULL;\n")
+	_, _ = buf.WriteString("END;\n")
+	_, _ = buf.WriteString("$bb$ LANGUAGE plpgsql;\n")
+	_, _ = fmt.Fprintf(&buf, "CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE PROCEDURE %s();",
+		pgQuoteIdentifier(m.triggerName()), m.originalTable(), m.triggerFunction())
+	return buf.String()
+}
+
+// dropSyncObjectsStatement returns the statement dropping the trigger, the shadow table and the error table.
+func (m *pgOnlineMigration) dropSyncObjectsStatement() string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s; DROP FUNCTION IF EXISTS %s(); DROP TABLE IF EXISTS %s; DROP TABLE IF EXISTS %s;",
+		pgQuoteIdentifier(m.triggerName()), m.originalTable(), m.triggerFunction(), m.shadowTable(), m.errorTable())
+}
+
+// checkSyncError returns an error if the trigger failed to replay any change to the shadow table.
+func (m *pgOnlineMigration) checkSyncError(ctx context.Context, q pgQueryer) error {
+	var message string
+	if err := q.QueryRowContext(ctx, fmt.Sprintf("SELECT message FROM %s ORDER BY created_ts LIMIT 1", m.errorTable())).Scan(&message); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return errors.Wrap(err, "failed to check the sync error")
+	}
+	return errors.Errorf("the shadow table %s is out of sync because the trigger failed to replay a change: %s, please rerun the sync task", m.shadowTable(), message)
+}
+
+// copyBatchStatement returns the statement copying the next batch of rows ordered by the primary key.
+// The column values are converted to the new column types by assignment casts.
+// The rows are locked in SHARE mode so that the concurrent changes are replayed by the trigger after the batch is copied.
+// It returns the primary key of the last copied row as text, and the parameters are the primary key of the last row of the previous batch.
+func (m *pgOnlineMigration) copyBatchStatement(columns, primaryKey, primaryKeyTypes []string, first bool) string {
+	where := ""
+	if !first {
+		var params []string
+		for i, tp := range primaryKeyTypes {
+			params = append(params, fmt.Sprintf("CAST($%d::TEXT AS %s)", i+1, tp))
+		}
+		where = fmt.Sprintf(" WHERE (%s) > (%s)", pgJoinIdentifiers(primaryKey), strings.Join(params, ", "))
+	}
+	var pkText []string
+	for _, column := range primaryKey {
+		pkText = append(pkText, fmt.Sprintf("%s::TEXT", pgQuoteIdentifier(column)))
+	}
+	var orderByDesc []string
+	for _, column := range primaryKey {
+		orderByDesc = append(orderByDesc, fmt.Sprintf("%s DESC", pgQuoteIdentifier(column)))
+	}
+	return fmt.Sprintf(`WITH batch AS (SELECT %s FROM %s%s ORDER BY %s LIMIT %d FOR SHARE), `+
+		`copied AS (INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM batch ON CONFLICT DO NOTHING) `+
+		`SELECT %s, (SELECT COUNT(1) FROM batch) FROM batch ORDER BY %s LIMIT 1`,
+		pgJoinIdentifiers(columns), m.originalTable(), where, pgJoinIdentifiers(primaryKey), pgOnlineMigrationBatchSize,
+		m.shadowTable(), pgJoinIdentifiers(columns), pgJoinIdentifiers(columns),
+		strings.Join(pkText, ", "), strings.Join(orderByDesc, ", "))
+}
+
+// validateOriginalTable checks if the original table can be migrated online.
+// The trigger and the keyset pagination need a primary key, and the objects not copied by
+// CREATE TABLE ... (LIKE ...) or depending on the table OID would be lost after the swap.
+func (m *pgOnlineMigration) validateOriginalTable(ctx context.Context, q pgQueryer) error {
+	// OVERRIDING SYSTEM VALUE is supported since PostgreSQL 10.
+	var version int
+	if err := q.QueryRowContext(ctx, `SELECT current_setting('server_version_num')::INT`).Scan(&version); err != nil {
+		return err
+	}
+	if version < 100000 {
+		return errors.Errorf("online migration requires PostgreSQL 10 or later, but got server version %d", version)
+	}
+
+	var relkind string
+	var rowSecurity bool
+	if err := q.QueryRowContext(ctx, `SELECT relkind, relrowsecurity FROM pg_class WHERE oid = to_regclass($1::TEXT)`, m.originalTable()).Scan(&relkind, &rowSecurity); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("table %s not found", m.originalTable())
+		}
+		return err
+	}
+	if relkind != "r" {
+		return errors.Errorf("online migration only supports ordinary table, but %s has relkind %q", m.originalTable(), relkind)
+	}
+	if rowSecurity {
+		return errors.Errorf("online migration doesn't support table %s with row level security", m.originalTable())
+	}
+
+	checks := []struct {
+		query  string
+		reason string
+	}{
+		{
+			query:  `SELECT COUNT(1) FROM pg_constraint WHERE contype = 'f' AND (conrelid = $1::TEXT::regclass OR confrelid = $1::TEXT::regclass)`,
+			reason: "referenced by or referencing foreign keys",
+		},
+		{
+			query:  `SELECT COUNT(1) FROM pg_depend d JOIN pg_rewrite r ON r.oid = d.objid WHERE d.classid = 'pg_rewrite'::regclass AND d.refobjid = $1::TEXT::regclass AND r.ev_class <> $1::TEXT::regclass`,
+			reason: "referenced by views or rules",
+		},
+		{
+			query:  `SELECT COUNT(1) FROM pg_trigger WHERE tgrelid = $1::TEXT::regclass AND NOT tgisinternal`,
+			reason: "with triggers",
+		},
+		{
+			query:  `SELECT COUNT(1) FROM pg_inherits WHERE inhrelid = $1::TEXT::regclass OR inhparent = $1::TEXT::regclass`,
+			reason: "with inheritance",
+		},
+	}
+	for _, check := range checks {
+		var count int
+		if err := q.QueryRowContext(ctx, check.query, m.originalTable()).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.Errorf("online migration doesn't support table %s %s", m.originalTable(), check.reason)
+		}
+	}
+	return nil
+}
+
+// pgGetPrimaryKey returns the primary key columns and their types of the table in key order.
+func pgGetPrimaryKey(ctx context.Context, q pgQueryer, table string) ([]string, []string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1::TEXT::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::INT2[], a.attnum)`, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var columns, types []string
+	for rows.Next() {
+		var column, tp string
+		if err := rows.Scan(&column, &tp); err != nil {
+			return nil, nil, err
+		}
+		columns = append(columns, column)
+		types = append(types, tp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return columns, types, nil
+}
+
+// pgGetCommonColumns returns the non-generated columns existing in both tables in the column order of the second table.
+func pgGetCommonColumns(ctx context.Context, q pgQueryer, schema, table, otherTable string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT column_name
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $3 AND is_generated = 'NEVER' AND column_name IN (
+			SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 AND is_generated = 'NEVER'
+		)
+		ORDER BY ordinal_position`, schema, table, otherTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+type pgIndex struct {
+	name       string
+	definition string
+}
+
+func pgGetIndexes(ctx context.Context, q pgQueryer, table string) ([]*pgIndex, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT c.relname, pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE i.indrelid = $1::TEXT::regclass
+		ORDER BY c.relname`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var indexes []*pgIndex
+	for rows.Next() {
+		index := &pgIndex{}
+		if err := rows.Scan(&index.name, &index.definition); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// pgIndexDefinitionKey returns the index definition without the index and table names,
+// e.g. "CREATE UNIQUE INDEX t_pkey ON public.t USING btree (id)" becomes "UNIQUE btree (id)".
+func pgIndexDefinitionKey(definition string) string {
+	key := definition
+	if i := strings.Index(definition, " USING "); i >= 0 {
+		key = definition[i+len(" USING "):]
+	}
+	if strings.HasPrefix(definition, "CREATE UNIQUE INDEX ") {
+		return "UNIQUE " + key
+	}
+	return key
+}
+
+// pgIndexRenameStatements returns the statements renaming the shadow table indexes to the original index names.
+// The indexes are matched by the definition, and the ones created or changed by the migration keep their names.
+func pgIndexRenameStatements(schema string, originalIndexes, shadowIndexes []*pgIndex) []string {
+	originalNames := make(map[string][]string)
+	for _, index := range originalIndexes {
+		key := pgIndexDefinitionKey(index.definition)
+		originalNames[key] = append(originalNames[key], index.name)
+	}
+	var statements []string
+	for _, index := range shadowIndexes {
+		key := pgIndexDefinitionKey(index.definition)
+		names := originalNames[key]
+		if len(names) == 0 {
+			continue
+		}
+		originalNames[key] = names[1:]
+		if names[0] == index.name {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s.%s RENAME TO %s;", pgQuoteIdentifier(schema), pgQuoteIdentifier(index.name), pgQuoteIdentifier(names[0])))
+	}
+	return statements
+}
+
+// cutover swaps the original table and the shadow table in the transaction.
+func (m *pgOnlineMigration) cutover(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = '%s'", pgOnlineMigrationLockTimeout)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", m.originalTable())); err != nil {
+		return errors.Wrapf(err, "failed to lock table %s", m.originalTable())
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TRIGGER %s ON %s; DROP FUNCTION %s();", pgQuoteIdentifier(m.triggerName()), m.originalTable(), m.triggerFunction())); err != nil {
+		return errors.Wrap(err, "failed to drop sync trigger")
+	}
+	// No more changes are replayed after the trigger is dropped, so this is the last check.
+	if err := m.checkSyncError(ctx, tx); err != nil {
+		return err
+	}
+
+	shadowColumns, err := pgGetCommonColumns(ctx, tx, m.schema, m.shadowTableName(), m.shadowTableName())
+	if err != nil {
+		return err
+	}
+	shadowColumnSet := make(map[string]bool)
+	for _, column := range shadowColumns {
+		shadowColumnSet[column] = true
+	}
+	var statements []string
+	// Serial sequences are owned by the original table and would be dropped with it, while identity
+	// sequences are recreated for the shadow table and have to continue from the original ones.
+	sequenceRows, err := tx.QueryContext(ctx, `
+		SELECT s.oid::regclass::TEXT, a.attname, d.deptype
+		FROM pg_depend d
+		JOIN pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+		JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.classid = 'pg_class'::regclass AND d.refclassid = 'pg_class'::regclass AND d.refobjid = $1::TEXT::regclass AND d.deptype IN ('a', 'i')`,
+		m.originalTable())
+	if err != nil {
+		return err
+	}
+	defer sequenceRows.Close()
+	for sequenceRows.Next() {
+		var sequence, column, depType string
+		if err := sequenceRows.Scan(&sequence, &column, &depType); err != nil {
+			return err
+		}
+		if !shadowColumnSet[column] {
+			continue
+		}
+		if depType == "a" {
+			statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s;", sequence, m.shadowTable(), pgQuoteIdentifier(column)))
+		} else {
+			statements = append(statements, fmt.Sprintf("SELECT setval(pg_get_serial_sequence(%s, %s), last_value, is_called) FROM %s;",
+				pgQuoteLiteral(m.shadowTable()), pgQuoteLiteral(column), sequence))
+		}
+	}
+	if err := sequenceRows.Err(); err != nil {
+		return err
+	}
+	sequenceRows.Close()
+
+	// The grants and the table comment are not copied by CREATE TABLE ... (LIKE ...).
+	grantRows, err := tx.QueryContext(ctx, `
+		SELECT CASE WHEN acl.grantee = 0 THEN 'PUBLIC' ELSE quote_ident(pg_get_userbyid(acl.grantee)) END, acl.privilege_type, acl.is_grantable
+		FROM pg_class c, aclexplode(c.relacl) acl
+		WHERE c.oid = $1::TEXT::regclass AND acl.grantee <> c.relowner`,
+		m.originalTable())
+	if err != nil {
+		return err
+	}
+	defer grantRows.Close()
+	for grantRows.Next() {
+		var grantee, privilege string
+		var grantable bool
+		if err := grantRows.Scan(&grantee, &privilege, &grantable); err != nil {
+			return err
+		}
+		grant := fmt.Sprintf("GRANT %s ON %s TO %s", privilege, m.shadowTable(), grantee)
+		if grantable {
+			grant += " WITH GRANT OPTION"
+		}
+		statements = append(statements, grant+";")
+	}
+	if err := grantRows.Err(); err != nil {
+		return err
+	}
+	grantRows.Close()
+
+	var comment sql.NullString
+	if err := tx.QueryRowContext(ctx, `SELECT obj_description($1::TEXT::regclass, 'pg_class')`, m.originalTable()).Scan(&comment); err != nil {
+		return err
+	}
+	if comment.Valid {
+		statements = append(statements, fmt.Sprintf("COMMENT ON TABLE %s IS %s;", m.shadowTable(), pgQuoteLiteral(comment.String)))
+	}
+
+	originalIndexes, err := pgGetIndexes(ctx, tx, m.originalTable())
+	if err != nil {
+		return err
+	}
+	shadowIndexes, err := pgGetIndexes(ctx, tx, m.shadowTable())
+	if err != nil {
+		return err
+	}
+	statements = append(statements,
+		fmt.Sprintf("DROP TABLE %s;", m.errorTable()),
+		fmt.Sprintf("DROP TABLE %s;", m.originalTable()),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", m.shadowTable(), pgQuoteIdentifier(m.table)),
+	)
+	statements = append(statements, pgIndexRenameStatements(m.schema, originalIndexes, shadowIndexes)...)
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to execute %q", statement)
+		}
+	}
+	return nil
+}
+
+func pgOnConflictClause(columns, primaryKey []string) string {
+	pkSet := make(map[string]bool)
+	for _, column := range primaryKey {
+		pkSet[column] = true
+	}
+	var sets []string
+	for _, column := range columns {
+		if pkSet[column] {
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", pgQuoteIdentifier(column), pgQuoteIdentifier(column)))
+	}
+	if len(sets) == 0 {
+		return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", pgJoinIdentifiers(primaryKey))
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", pgJoinIdentifiers(primaryKey), strings.Join(sets, ", "))
+}
+
+func pgJoinIdentifiers(identifiers []string) string {
+	var quoted []string
+	for _, identifier := range identifiers {
+		quoted = append(quoted, pgQuoteIdentifier(identifier))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func pgQuoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func pgQuoteLiteral(s string) string {
+	return fmt.Sprintf(`'%s'`, strings.ReplaceAll(s, `'`, `''`))
+}
@@ -0,0 +1,125 @@
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	// Register postgres parser.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+)
+
+func TestNewPGOnlineMigration(t *testing.T) {
+	tests := []struct {
+		statement   string
+		schema      string
+		table       string
+		alterShadow string
+		errPart     string
+	}{
+		{
+			statement:   "ALTER TABLE t ADD COLUMN c INT;",
+			schema:      "public",
+			table:       "t",
+			alterShadow: `ALTER TABLE "public"."_bb_12_shadow" ADD COLUMN c INT;`,
+		},
+		{
+			statement:   "  alter table if exists only \"Sales\".\"Order\" ALTER COLUMN amount TYPE BIGINT, ADD COLUMN note TEXT",
+			schema:      "Sales",
+			table:       "Order",
+			alterShadow: `alter table if exists only "Sales"."_bb_12_shadow" ALTER COLUMN amount TYPE BIGINT, ADD COLUMN note TEXT`,
+		},
+		{
+			statement: "ALTER TABLE t RENAME COLUMN a TO b;",
+			errPart:   "online migration doesn't support",
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN c INT; ALTER TABLE t ADD COLUMN d INT;",
+			errPart:   "only supports a single ALTER TABLE statement",
+		},
+		{
+			statement: "CREATE INDEX idx ON t (a);",
+			errPart:   "only supports ALTER TABLE statement",
+		},
+	}
+
+	for _, test := range tests {
+		migration, err := newPGOnlineMigration(12, test.statement)
+		if test.errPart != "" {
+			require.Error(t, err, test.statement)
+			require.Contains(t, err.Error(), test.errPart, test.statement)
+			continue
+		}
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.schema, migration.schema, test.statement)
+		require.Equal(t, test.table, migration.table, test.statement)
+		require.Equal(t, test.alterShadow, migration.alterShadowTableStatement(), test.statement)
+	}
+}
+
+func TestPGOnlineMigrationCopyBatchStatement(t *testing.T) {
+	migration := &pgOnlineMigration{syncTaskID: 1, schema: "public", table: "t"}
+	columns := []string{"a", "b", "c"}
+	primaryKey := []string{"a", "b"}
+	primaryKeyTypes := []string{"integer", "text"}
+
+	require.Equal(t,
+		`WITH batch AS (SELECT "a", "b", "c" FROM "public"."t" ORDER BY "a", "b" LIMIT 1000 FOR SHARE), `+
+			`copied AS (INSERT INTO "public"."_bb_1_shadow" ("a", "b", "c") OVERRIDING SYSTEM VALUE SELECT "a", "b", "c" FROM batch ON CONFLICT DO NOTHING) `+
+			`SELECT "a"::TEXT, "b"::TEXT, (SELECT COUNT(1) FROM batch) FROM batch ORDER BY "a" DESC, "b" DESC LIMIT 1`,
+		migration.copyBatchStatement(columns, primaryKey, primaryKeyTypes, true /* first */))
+	require.Equal(t,
+		`WITH batch AS (SELECT "a", "b", "c" FROM "public"."t" WHERE ("a", "b") > (CAST($1::TEXT AS integer), CAST($2::TEXT AS text)) ORDER BY "a", "b" LIMIT 1000 FOR SHARE), `+
+			`copied AS (INSERT INTO "public"."_bb_1_shadow" ("a", "b", "c") OVERRIDING SYSTEM VALUE SELECT "a", "b", "c" FROM batch ON CONFLICT DO NOTHING) `+
+			`SELECT "a"::TEXT, "b"::TEXT, (SELECT COUNT(1) FROM batch) FROM batch ORDER BY "a" DESC, "b" DESC LIMIT 1`,
+		migration.copyBatchStatement(columns, primaryKey, primaryKeyTypes, false /* first */))
+}
+
+func TestPGOnlineMigrationCreateTriggerStatement(t *testing.T) {
+	migration := &pgOnlineMigration{syncTaskID: 1, schema: "public", table: "t"}
+	want := `CREATE TABLE "public"."_bb_1_error" (message TEXT NOT NULL, created_ts TIMESTAMPTZ NOT NULL DEFAULT now());
+CREATE FUNCTION "public"."_bb_1_sync"() RETURNS trigger AS $bb$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "public"."_bb_1_error") THEN
+    RETURN NULL;
+  END IF;
+  BEGIN
+    IF TG_OP = 'INSERT' THEN
+      INSERT INTO "public"."_bb_1_shadow" ("id", "name") OVERRIDING SYSTEM VALUE VALUES (NEW."id", NEW."name") ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name";
+    ELSIF TG_OP = 'UPDATE' THEN
+      DELETE FROM "public"."_bb_1_shadow" WHERE "id" = OLD."id";
+      INSERT INTO "public"."_bb_1_shadow" ("id", "name") OVERRIDING SYSTEM VALUE VALUES (NEW."id", NEW."name") ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name";
+    ELSIF TG_OP = 'DELETE' THEN
+      DELETE FROM "public"."_bb_1_shadow" WHERE "id" = OLD."id";
+    END IF;
+  EXCEPTION WHEN OTHERS THEN
+    INSERT INTO "public"."_bb_1_error" (message) VALUES (SQLSTATE || ': ' || SQLERRM);
+  END;
+  RETURN NULL;
+END;
+$bb$ LANGUAGE plpgsql;
+CREATE TRIGGER "_bb_1_sync" AFTER INSERT OR UPDATE OR DELETE ON "public"."t" FOR EACH ROW EXECUTE PROCEDURE "public"."_bb_1_sync"();`
+	require.Equal(t, want, migration.createTriggerStatement([]string{"id", "name"}, []string{"id"}))
+}
+
+func TestPGOnConflictClause(t *testing.T) {
+	require.Equal(t, `ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`, pgOnConflictClause([]string{"id", "name"}, []string{"id"}))
+	require.Equal(t, `ON CONFLICT ("a", "b") DO NOTHING`, pgOnConflictClause([]string{"a", "b"}, []string{"a", "b"}))
+}
+
+func TestPGIndexRenameStatements(t *testing.T) {
+	originalIndexes := []*pgIndex{
+		{name: "t_pkey", definition: "CREATE UNIQUE INDEX t_pkey ON public.t USING btree (id)"},
+		{name: "idx_name", definition: "CREATE INDEX idx_name ON public.t USING btree (name)"},
+		{name: "idx_dropped", definition: "CREATE INDEX idx_dropped ON public.t USING btree (dropped)"},
+	}
+	shadowIndexes := []*pgIndex{
+		{name: "_bb_1_shadow_pkey", definition: "CREATE UNIQUE INDEX _bb_1_shadow_pkey ON public._bb_1_shadow USING btree (id)"},
+		{name: "_bb_1_shadow_name_idx", definition: "CREATE INDEX _bb_1_shadow_name_idx ON public._bb_1_shadow USING btree (name)"},
+		{name: "idx_new", definition: "CREATE INDEX idx_new ON public._bb_1_shadow USING btree (email)"},
+	}
+	require.Equal(t, []string{
+		`ALTER INDEX "public"."_bb_1_shadow_pkey" RENAME TO "t_pkey";`,
+		`ALTER INDEX "public"."_bb_1_shadow_name_idx" RENAME TO "idx_name";`,
+	}, pgIndexRenameStatements("public", originalIndexes, shadowIndexes))
+}
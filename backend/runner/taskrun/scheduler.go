@@ -19,6 +19,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/component/prom"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -37,7 +38,8 @@ const (
 
 var (
 	taskCancellationImplemented = map[api.TaskType]bool{
-		api.TaskDatabaseSchemaUpdateGhostSync: true,
+		api.TaskDatabaseSchemaUpdateGhostSync:    true,
+		api.TaskDatabaseSchemaUpdatePGOnlineSync: true,
 	}
 	applicableTaskStatusTransition = map[api.TaskStatus][]api.TaskStatus{
 		api.TaskPendingApproval: {api.TaskPending, api.TaskDone},
@@ -208,6 +210,7 @@ func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 					s.stateCfg.Unlock()
 
 					s.stateCfg.RunningTasks.Store(task.ID, true)
+					prom.TasksRunning.WithLabelValues(string(task.Type)).Inc()
 					go func(ctx context.Context, task *store.TaskMessage, executor Executor) {
 						defer func() {
 							s.stateCfg.RunningTasks.Delete(task.ID)
@@ -216,12 +219,15 @@ func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 							s.stateCfg.Lock()
 							s.stateCfg.InstanceOutstandingConnections[task.InstanceID]--
 							s.stateCfg.Unlock()
+							prom.TasksRunning.WithLabelValues(string(task.Type)).Dec()
 						}()
 
 						executorCtx, cancel := context.WithCancel(ctx)
 						s.stateCfg.RunningTasksCancel.Store(task.ID, cancel)
 
+						startTime := time.Now()
 						done, result, err := RunExecutorOnce(executorCtx, executor, task)
+						observeTaskRun(task, startTime, executorCtx.Err() != nil, done, err)
 
 						select {
 						case <-executorCtx.Done():
@@ -318,6 +324,23 @@ func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
+// observeTaskRun records the task run metrics. The transient errors are not recorded because the task will be retried.
+func observeTaskRun(task *store.TaskMessage, startTime time.Time, canceled, done bool, err error) {
+	var status api.TaskStatus
+	switch {
+	case canceled:
+		status = api.TaskCanceled
+	case done && err != nil:
+		status = api.TaskFailed
+	case done:
+		status = api.TaskDone
+	default:
+		return
+	}
+	prom.TaskRunsTotal.WithLabelValues(string(task.Type), string(status)).Inc()
+	prom.TaskRunDuration.WithLabelValues(string(task.Type), string(status)).Observe(time.Since(startTime).Seconds())
+}
+
 // PatchTask patches the statement, earliest allowed time and rollbackEnabled for a task.
 func (s *Scheduler) PatchTask(ctx context.Context, task *store.TaskMessage, taskPatch *api.TaskPatch, issue *store.IssueMessage) error {
 	if taskPatch.SheetID != nil {
@@ -690,11 +713,12 @@ func (s *Scheduler) ClearRunningTasks(ctx context.Context) error {
 
 var (
 	allowedStatementUpdateTaskTypes = map[api.TaskType]bool{
-		api.TaskDatabaseCreate:                true,
-		api.TaskDatabaseSchemaUpdate:          true,
-		api.TaskDatabaseSchemaUpdateSDL:       true,
-		api.TaskDatabaseDataUpdate:            true,
-		api.TaskDatabaseSchemaUpdateGhostSync: true,
+		api.TaskDatabaseCreate:                   true,
+		api.TaskDatabaseSchemaUpdate:             true,
+		api.TaskDatabaseSchemaUpdateSDL:          true,
+		api.TaskDatabaseDataUpdate:               true,
+		api.TaskDatabaseSchemaUpdateGhostSync:    true,
+		api.TaskDatabaseSchemaUpdatePGOnlineSync: true,
 	}
 	allowedPatchStatementStatus = map[api.TaskStatus]bool{
 		api.TaskPendingApproval: true,
@@ -718,23 +742,28 @@ func canUpdateTaskStatement(task *store.TaskMessage) *echo.HTTPError {
 // scheduleIfNeeded schedules the task if
 //  2. it has no blocking tasks.
 //  3. it has passed the earliest allowed time.
-func (s *Scheduler) scheduleIfNeeded(ctx context.Context, task *store.TaskMessage) error {
+//
+// It returns true if the task is scheduled.
+func (s *Scheduler) scheduleIfNeeded(ctx context.Context, task *store.TaskMessage) (bool, error) {
 	blocked, err := s.isTaskBlocked(ctx, task)
 	if err != nil {
-		return errors.Wrap(err, "failed to check if task is blocked")
+		return false, errors.Wrap(err, "failed to check if task is blocked")
 	}
 	if blocked {
-		return nil
+		return false, nil
 	}
 	if task.EarliestAllowedTs != 0 && time.Now().Before(time.Unix(task.EarliestAllowedTs, 0)) {
-		return nil
+		return false, nil
 	}
 
-	return s.PatchTaskStatus(ctx, task, &api.TaskStatusPatch{
+	if err := s.PatchTaskStatus(ctx, task, &api.TaskStatusPatch{
 		ID:        task.ID,
 		UpdaterID: api.SystemBotID,
 		Status:    api.TaskRunning,
-	})
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (s *Scheduler) isTaskBlocked(ctx context.Context, task *store.TaskMessage) (bool, error) {
@@ -822,14 +851,86 @@ func (s *Scheduler) schedulePendingTasks(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// Group the pending tasks by stage to apply the rollout strategy of each stage.
+	var stageIDList []int
+	stagePendingTasks := make(map[int][]*store.TaskMessage)
 	for _, task := range tasks {
-		if err := s.scheduleIfNeeded(ctx, task); err != nil {
+		if _, ok := stagePendingTasks[task.StageID]; !ok {
+			stageIDList = append(stageIDList, task.StageID)
+		}
+		stagePendingTasks[task.StageID] = append(stagePendingTasks[task.StageID], task)
+	}
+	for _, stageID := range stageIDList {
+		if err := s.scheduleStagePendingTasks(ctx, stagePendingTasks[stageID]); err != nil {
+			return errors.Wrapf(err, "failed to schedule tasks in stage %d", stageID)
+		}
+	}
+	return nil
+}
+
+// scheduleStagePendingTasks schedules the pending tasks in a stage under the rollout strategy of the stage.
+func (s *Scheduler) scheduleStagePendingTasks(ctx context.Context, pendingTasks []*store.TaskMessage) error {
+	pipelineID, stageID := pendingTasks[0].PipelineID, pendingTasks[0].StageID
+	strategy, err := s.getStageRolloutStrategy(ctx, pipelineID, stageID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get rollout strategy")
+	}
+	limit := -1
+	if strategy != nil {
+		stageTasks, err := s.store.ListTasks(ctx, &api.TaskFind{PipelineID: &pipelineID, StageID: &stageID})
+		if err != nil {
+			return errors.Wrap(err, "failed to list tasks")
+		}
+		pendingTasks, limit = utils.GetRolloutStrategyPendingTasks(strategy, stageTasks)
+	}
+	for _, task := range pendingTasks {
+		if limit == 0 {
+			break
+		}
+		scheduled, err := s.scheduleIfNeeded(ctx, task)
+		if err != nil {
 			return errors.Wrap(err, "failed to schedule task")
 		}
+		if scheduled && limit > 0 {
+			limit--
+		}
 	}
 	return nil
 }
 
+// getStageRolloutStrategy returns the rollout strategy of the deployment which the stage is created from.
+// The stage is matched with the deployment by name, so the changes of the strategy apply to the ongoing rollouts.
+func (s *Scheduler) getStageRolloutStrategy(ctx context.Context, pipelineID int, stageID int) (*store.RolloutStrategy, error) {
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &pipelineID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get issue by pipeline %d", pipelineID)
+	}
+	if issue == nil {
+		return nil, nil
+	}
+	stages, err := s.store.ListStageV2(ctx, pipelineID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list stages of pipeline %d", pipelineID)
+	}
+	var stageName string
+	for _, stage := range stages {
+		if stage.ID == stageID {
+			stageName = stage.Name
+			break
+		}
+	}
+	deploymentConfig, err := s.store.GetDeploymentConfigV2(ctx, issue.Project.UID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get deployment config of project %d", issue.Project.UID)
+	}
+	for _, deployment := range deploymentConfig.Schedule.Deployments {
+		if deployment.Name == stageName {
+			return deployment.Spec.Strategy, nil
+		}
+	}
+	return nil, nil
+}
+
 // PatchTaskStatus patches a single task.
 func (s *Scheduler) PatchTaskStatus(ctx context.Context, task *store.TaskMessage, taskStatusPatch *api.TaskStatusPatch) (err error) {
 	defer func() {
@@ -0,0 +1,192 @@
+package taskrun
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+)
+
+// NewSchemaUpdatePGOnlineSyncExecutor creates a schema update (PostgreSQL online migration) sync task executor.
+func NewSchemaUpdatePGOnlineSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State) Executor {
+	return &SchemaUpdatePGOnlineSyncExecutor{
+		store:     store,
+		dbFactory: dbFactory,
+		stateCfg:  stateCfg,
+	}
+}
+
+// SchemaUpdatePGOnlineSyncExecutor is the schema update (PostgreSQL online migration) sync task executor.
+// It creates the shadow table with the new schema, installs the trigger replaying the changes on the
+// original table and copies the existing rows in batches. The shadow table is kept in sync by the trigger
+// until the cutover task swaps the tables.
+type SchemaUpdatePGOnlineSyncExecutor struct {
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	stateCfg  *state.State
+}
+
+// RunOnce will run SchemaUpdatePGOnlineSync task once.
+func (exec *SchemaUpdatePGOnlineSyncExecutor) RunOnce(ctx context.Context, task *store.TaskMessage) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	payload := &api.TaskDatabaseSchemaUpdatePGOnlineSyncPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return true, nil, errors.Wrap(err, "invalid database schema update PostgreSQL online sync payload")
+	}
+	statement, err := exec.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return true, nil, err
+	}
+
+	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return true, nil, err
+	}
+	if instance == nil {
+		return true, nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	database, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return true, nil, err
+	}
+	if database == nil {
+		return true, nil, errors.Errorf("database not found")
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
+	renderedStatement := utils.RenderStatement(statement, materials)
+	migration, err := newPGOnlineMigration(task.ID, renderedStatement)
+	if err != nil {
+		return true, nil, err
+	}
+
+	driver, err := exec.dbFactory.GetAdminDatabaseDriver(ctx, instance, database.DatabaseName)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+	sqlDB := driver.GetDB()
+
+	if err := exec.sync(ctx, sqlDB, task, migration); err != nil {
+		// Drop the trigger as soon as possible so that it doesn't slow down the writes on the original table.
+		// The task context may be canceled, so we use a new context for cleaning up.
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, cleanupErr := sqlDB.ExecContext(cleanupCtx, migration.dropSyncObjectsStatement()); cleanupErr != nil {
+			log.Error("failed to clean up PostgreSQL online migration",
+				zap.Int("task_id", task.ID),
+				zap.Error(cleanupErr),
+			)
+		}
+		return true, nil, err
+	}
+	return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
+}
+
+func (exec *SchemaUpdatePGOnlineSyncExecutor) sync(ctx context.Context, sqlDB *sql.DB, task *store.TaskMessage, migration *pgOnlineMigration) error {
+	if err := migration.validateOriginalTable(ctx, sqlDB); err != nil {
+		return err
+	}
+	primaryKey, primaryKeyTypes, err := pgGetPrimaryKey(ctx, sqlDB, migration.originalTable())
+	if err != nil {
+		return err
+	}
+	if len(primaryKey) == 0 {
+		return errors.Errorf("online migration requires a primary key on table %s", migration.originalTable())
+	}
+
+	// Clean up the leftover of the previous run before creating the shadow table.
+	if _, err := sqlDB.ExecContext(ctx, migration.dropSyncObjectsStatement()); err != nil {
+		return errors.Wrap(err, "failed to clean up the previous online migration")
+	}
+	if _, err := sqlDB.ExecContext(ctx, migration.createShadowTableStatement()); err != nil {
+		return errors.Wrap(err, "failed to create shadow table")
+	}
+	// The shadow table will replace the original table, so it should have the same owner.
+	var owner string
+	var isCurrentUser bool
+	if err := sqlDB.QueryRowContext(ctx, `SELECT quote_ident(pg_get_userbyid(relowner)), pg_get_userbyid(relowner) = current_user FROM pg_class WHERE oid = $1::TEXT::regclass`, migration.originalTable()).Scan(&owner, &isCurrentUser); err != nil {
+		return err
+	}
+	if !isCurrentUser {
+		if _, err := sqlDB.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s OWNER TO %s;", migration.shadowTable(), owner)); err != nil {
+			return errors.Wrapf(err, "failed to change the owner of shadow table to %s", owner)
+		}
+	}
+	// Run the migration on the empty shadow table, so it doesn't lock the original table.
+	if _, err := sqlDB.ExecContext(ctx, migration.alterShadowTableStatement()); err != nil {
+		return errors.Wrap(err, "failed to alter shadow table")
+	}
+	shadowPrimaryKey, _, err := pgGetPrimaryKey(ctx, sqlDB, migration.shadowTable())
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(primaryKey, shadowPrimaryKey) {
+		return errors.Errorf("online migration doesn't support changing the primary key of table %s", migration.originalTable())
+	}
+	columns, err := pgGetCommonColumns(ctx, sqlDB, migration.schema, migration.table, migration.shadowTableName())
+	if err != nil {
+		return err
+	}
+	if _, err := sqlDB.ExecContext(ctx, migration.createTriggerStatement(columns, primaryKey)); err != nil {
+		return errors.Wrap(err, "failed to create sync trigger")
+	}
+
+	var totalUnit int64
+	if err := sqlDB.QueryRowContext(ctx, `SELECT GREATEST(reltuples, 0)::BIGINT FROM pg_class WHERE oid = $1::TEXT::regclass`, migration.originalTable()).Scan(&totalUnit); err != nil {
+		return err
+	}
+	createdTs := time.Now().Unix()
+	var completedUnit int64
+	var lastPrimaryKey []any
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.New("task canceled")
+		}
+		statement := migration.copyBatchStatement(columns, primaryKey, primaryKeyTypes, lastPrimaryKey == nil)
+		values := make([]string, len(primaryKey))
+		dest := make([]any, 0, len(primaryKey)+1)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		var count int64
+		dest = append(dest, &count)
+		if err := sqlDB.QueryRowContext(ctx, statement, lastPrimaryKey...).Scan(dest...); err != nil {
+			if err == sql.ErrNoRows {
+				break
+			}
+			return errors.Wrap(err, "failed to copy rows to shadow table")
+		}
+		lastPrimaryKey = nil
+		for _, value := range values {
+			lastPrimaryKey = append(lastPrimaryKey, value)
+		}
+
+		completedUnit += count
+		if totalUnit < completedUnit {
+			totalUnit = completedUnit
+		}
+		exec.stateCfg.TaskProgress.Store(task.ID, api.Progress{
+			TotalUnit:     totalUnit,
+			CompletedUnit: completedUnit,
+			CreatedTs:     createdTs,
+			UpdatedTs:     time.Now().Unix(),
+		})
+		if count < pgOnlineMigrationBatchSize {
+			break
+		}
+	}
+	return migration.checkSyncError(ctx, sqlDB)
+}
@@ -38,15 +38,24 @@ func ComputeDatabaseSchemaDiff(ctx context.Context, instance *store.InstanceMess
 	switch instance.Engine {
 	case db.Postgres:
 		engine = parser.Postgres
-	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+	case db.MySQL, db.MariaDB, db.OceanBase:
 		engine = parser.MySQL
+	case db.TiDB:
+		engine = parser.TiDB
+	case db.Oracle:
+		engine = parser.Oracle
 	default:
+		// TODO: support SQL Server after we have the T-SQL parser.
 		return "", errors.Errorf("unsupported database engine %q", instance.Engine)
 	}
 
-	sdlFormat, err := transform.SchemaTransform(engine, schema.String())
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to transform SDL format")
+	sdlFormat := schema.String()
+	// The Oracle differ compares the dumped schema directly.
+	if engine != parser.Oracle {
+		sdlFormat, err = transform.SchemaTransform(engine, sdlFormat)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to transform SDL format")
+		}
 	}
 	diff, err := differ.SchemaDiff(engine, sdlFormat, newSchema)
 	if err != nil {
@@ -0,0 +1,177 @@
+// Package webhookrun is the runner delivering the project webhook events in the outbox.
+package webhookrun
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/prom"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/webhook"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	webhookRunnerInterval = time.Second
+	// deliveryBatchSize is the maximum number of deliveries attempted in one round.
+	deliveryBatchSize = 100
+	// maxDeliveryAttempts is the number of attempts before giving up a delivery,
+	// which retries for about an hour with the backoff below.
+	maxDeliveryAttempts = 8
+	baseRetryDelay      = 30 * time.Second
+	maxRetryDelay       = time.Hour
+	// deliveryRetention is the retention of the finished deliveries.
+	deliveryRetention = 30 * 24 * time.Hour
+	purgeInterval     = time.Hour
+	maxErrorLength    = 1024
+)
+
+// NewRunner creates a new webhook delivery runner.
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		store: store,
+	}
+}
+
+// Runner is the runner delivering the pending project webhook events with exponential backoff.
+type Runner struct {
+	store       *store.Store
+	lastPurgeTs time.Time
+}
+
+// Run is the runner for webhook deliveries.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(webhookRunnerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug("Webhook runner started", zap.Duration("interval", webhookRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("Webhook runner PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+					}
+				}()
+				r.deliver(ctx)
+				r.purge(ctx)
+			}()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver attempts the due deliveries. The deliveries of the same webhook are attempted in order,
+// and the deliveries of different webhooks are attempted concurrently.
+// It waits for all attempts, so that a delivery is never attempted twice at the same time.
+func (r *Runner) deliver(ctx context.Context) {
+	deliveries, err := r.store.ListDueProjectWebhookDeliveries(ctx, time.Now().Unix(), deliveryBatchSize)
+	if err != nil {
+		log.Error("Failed to list due webhook deliveries", zap.Error(err))
+		return
+	}
+	deliveryMap := make(map[int][]*store.ProjectWebhookDeliveryMessage)
+	for _, delivery := range deliveries {
+		deliveryMap[delivery.ProjectWebhookID] = append(deliveryMap[delivery.ProjectWebhookID], delivery)
+	}
+
+	var wg sync.WaitGroup
+	for webhookID, deliveryList := range deliveryMap {
+		wg.Add(1)
+		go func(webhookID int, deliveryList []*store.ProjectWebhookDeliveryMessage) {
+			defer wg.Done()
+			hook, err := r.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &webhookID})
+			if err != nil {
+				log.Error("Failed to get project webhook", zap.Int("webhook_id", webhookID), zap.Error(err))
+				return
+			}
+			// The deliveries are deleted along with the webhook.
+			if hook == nil {
+				return
+			}
+			for _, delivery := range deliveryList {
+				if err := r.deliverOne(ctx, hook, delivery); err != nil {
+					log.Error("Failed to record webhook delivery", zap.Int("delivery_id", delivery.ID), zap.Error(err))
+				}
+			}
+		}(webhookID, deliveryList)
+	}
+	wg.Wait()
+}
+
+func (r *Runner) deliverOne(ctx context.Context, hook *store.ProjectWebhookMessage, delivery *store.ProjectWebhookDeliveryMessage) error {
+	update := &store.UpdateProjectWebhookDeliveryMessage{
+		ID:       delivery.ID,
+		Status:   api.ProjectWebhookDeliveryDone,
+		Attempts: delivery.Attempts + 1,
+	}
+	var webhookCtx webhook.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &webhookCtx); err != nil {
+		update.Status = api.ProjectWebhookDeliveryFailed
+		update.Error = errors.Wrap(err, "malformed webhook payload").Error()
+		return r.store.UpdateProjectWebhookDelivery(ctx, update)
+	}
+	// Use the current URL and secret, so that the retries pick up the fixes on the webhook.
+	webhookCtx.URL = hook.URL
+	webhookCtx.Secret = hook.Secret
+
+	result, err := webhook.Deliver(hook.Type, webhookCtx)
+	update.ResponseCode = result.StatusCode
+	update.LatencyMs = int(result.Latency.Milliseconds())
+	if err != nil {
+		// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
+		log.Warn("Failed to post webhook event",
+			zap.String("webhook type", hook.Type),
+			zap.String("webhook name", hook.Title),
+			zap.String("activity type", webhookCtx.ActivityType),
+			zap.String("title", webhookCtx.Title),
+			zap.Int("attempts", update.Attempts),
+			zap.Error(err))
+		prom.WebhookDeliveryFailuresTotal.WithLabelValues(hook.Type).Inc()
+		update.Error, _ = common.TruncateString(err.Error(), maxErrorLength)
+		if update.Attempts >= maxDeliveryAttempts {
+			update.Status = api.ProjectWebhookDeliveryFailed
+		} else {
+			update.Status = api.ProjectWebhookDeliveryPending
+			update.NextAttemptTs = time.Now().Add(getRetryDelay(update.Attempts)).Unix()
+		}
+	}
+	return r.store.UpdateProjectWebhookDelivery(ctx, update)
+}
+
+// purge deletes the finished deliveries out of the retention hourly.
+func (r *Runner) purge(ctx context.Context) {
+	if time.Since(r.lastPurgeTs) < purgeInterval {
+		return
+	}
+	r.lastPurgeTs = time.Now()
+	if err := r.store.DeleteFinishedProjectWebhookDeliveries(ctx, time.Now().Add(-deliveryRetention).Unix()); err != nil {
+		log.Error("Failed to purge webhook deliveries", zap.Error(err))
+	}
+}
+
+// getRetryDelay returns the delay before the next attempt after the attempts,
+// which doubles from the base delay and is capped at the maximum delay.
+func getRetryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
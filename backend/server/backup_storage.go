@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/component/config"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/plugin/storage/azblob"
+	"github.com/bytebase/bytebase/backend/plugin/storage/fs"
+	"github.com/bytebase/bytebase/backend/plugin/storage/gcs"
+	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/runner/backuprun"
+)
+
+// azureCredential is the content of the credential file for Azure Blob Storage.
+type azureCredential struct {
+	AccountName string `json:"accountName"`
+	AccountKey  string `json:"accountKey"`
+}
+
+// newBackupStorages returns the workspace backup storage configured by the --backup-bucket server flag
+// and the named backup storages configured by the --backup-storage-config server flag.
+func newBackupStorages(ctx context.Context, profile *config.Profile) (*backuprun.Storages, error) {
+	workspace, err := newBackupStorage(ctx, &config.BackupStorage{
+		Backend:        profile.BackupStorageBackend,
+		Region:         profile.BackupRegion,
+		Bucket:         profile.BackupBucket,
+		CredentialFile: profile.BackupCredentialFile,
+		Endpoint:       profile.BackupEndpoint,
+		UsePathStyle:   profile.BackupUsePathStyle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	named := make(map[string]storage.Backend)
+	for _, backupStorage := range profile.BackupStorageList {
+		client, err := newBackupStorage(ctx, backupStorage)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create backup storage %q", backupStorage.Name)
+		}
+		named[backupStorage.Name] = client
+	}
+	return &backuprun.Storages{
+		Workspace: workspace,
+		Named:     named,
+	}, nil
+}
+
+// newBackupStorage returns the storage backend of the backup storage.
+// It returns nil if the backups are only stored on the local disk.
+func newBackupStorage(ctx context.Context, backupStorage *config.BackupStorage) (storage.Backend, error) {
+	switch backupStorage.Backend {
+	case api.BackupStorageBackendS3:
+		credentials, err := bbs3.GetCredentialsFromFile(ctx, backupStorage.CredentialFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get credentials from file")
+		}
+		client, err := bbs3.NewCompatibleClient(ctx, backupStorage.Region, backupStorage.Bucket, backupStorage.Endpoint, backupStorage.UsePathStyle, credentials)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create AWS S3 client")
+		}
+		return client, nil
+	case api.BackupStorageBackendGCS:
+		credentialJSON, err := os.ReadFile(backupStorage.CredentialFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read credential file %q", backupStorage.CredentialFile)
+		}
+		client, err := gcs.NewClient(ctx, backupStorage.Bucket, credentialJSON)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case api.BackupStorageBackendAzure:
+		content, err := os.ReadFile(backupStorage.CredentialFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read credential file %q", backupStorage.CredentialFile)
+		}
+		var credential azureCredential
+		if err := json.Unmarshal(content, &credential); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal credential file %q", backupStorage.CredentialFile)
+		}
+		client, err := azblob.NewClient(credential.AccountName, credential.AccountKey, backupStorage.Bucket, backupStorage.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case api.BackupStorageBackendFilesystem:
+		client, err := fs.NewClient(backupStorage.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, nil
+	}
+}
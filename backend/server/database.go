@@ -288,9 +288,6 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		if instance.Deleted {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("instance %q deleted", database.InstanceID))
 		}
-		if instance.Engine == db.MongoDB {
-			return echo.NewHTTPError(http.StatusBadRequest, "Backup is not supported for MongoDB")
-		}
 
 		storeBackupList, err := s.store.ListBackupV2(ctx, &store.FindBackupMessage{
 			DatabaseUID: &id,
@@ -1421,7 +1421,11 @@ func getCreateDatabaseStatement(dbType db.Type, createDatabaseContext api.Create
 }
 
 // creates gh-ost TaskCreate list and dependency.
+// PostgreSQL doesn't support gh-ost, so it uses the trigger-based online migration instead.
 func createGhostTaskList(database *store.DatabaseMessage, instance *store.InstanceMessage, vcsPushEvent *vcs.PushEvent, detail *api.MigrationDetail, schemaVersion string) ([]api.TaskCreate, []api.TaskIndexDAG, error) {
+	if instance.Engine == db.Postgres {
+		return createPGOnlineTaskList(database, instance, vcsPushEvent, detail, schemaVersion)
+	}
 	var taskCreateList []api.TaskCreate
 	// task "sync"
 	payloadSync := api.TaskDatabaseSchemaUpdateGhostSyncPayload{
@@ -1467,6 +1471,52 @@ func createGhostTaskList(database *store.DatabaseMessage, instance *store.Instan
 	return taskCreateList, taskIndexDAGList, nil
 }
 
+// creates PostgreSQL online migration TaskCreate list and dependency.
+func createPGOnlineTaskList(database *store.DatabaseMessage, instance *store.InstanceMessage, vcsPushEvent *vcs.PushEvent, detail *api.MigrationDetail, schemaVersion string) ([]api.TaskCreate, []api.TaskIndexDAG, error) {
+	var taskCreateList []api.TaskCreate
+	// task "sync"
+	payloadSync := api.TaskDatabaseSchemaUpdatePGOnlineSyncPayload{
+		SheetID:       detail.SheetID,
+		SchemaVersion: schemaVersion,
+		VCSPushEvent:  vcsPushEvent,
+	}
+	bytesSync, err := json.Marshal(payloadSync)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to marshal database schema update online sync payload, error: %v", err))
+	}
+	taskCreateList = append(taskCreateList, api.TaskCreate{
+		Name:              fmt.Sprintf("Update schema online sync for database %q", database.DatabaseName),
+		InstanceID:        instance.UID,
+		DatabaseID:        &database.UID,
+		Status:            api.TaskPendingApproval,
+		Type:              api.TaskDatabaseSchemaUpdatePGOnlineSync,
+		EarliestAllowedTs: detail.EarliestAllowedTs,
+		Payload:           string(bytesSync),
+	})
+
+	// task "cutover"
+	payloadCutover := api.TaskDatabaseSchemaUpdatePGOnlineCutoverPayload{}
+	bytesCutover, err := json.Marshal(payloadCutover)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to marshal database schema update online cutover payload, error: %v", err))
+	}
+	taskCreateList = append(taskCreateList, api.TaskCreate{
+		Name:              fmt.Sprintf("Update schema online cutover for database %q", database.DatabaseName),
+		InstanceID:        instance.UID,
+		DatabaseID:        &database.UID,
+		Status:            api.TaskPendingApproval,
+		Type:              api.TaskDatabaseSchemaUpdatePGOnlineCutover,
+		EarliestAllowedTs: detail.EarliestAllowedTs,
+		Payload:           string(bytesCutover),
+	})
+
+	// Task "sync" blocks task "cutover".
+	taskIndexDAGList := []api.TaskIndexDAG{
+		{FromIndex: 0, ToIndex: 1},
+	}
+	return taskCreateList, taskIndexDAGList, nil
+}
+
 // checkCharacterSetCollationOwner checks if the character set, collation and owner are legal according to the dbType.
 func checkCharacterSetCollationOwner(dbType db.Type, characterSet, collation, owner string) error {
 	switch dbType {
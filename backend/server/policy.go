@@ -422,7 +422,7 @@ func (s *Server) hasAccessToUpsertPolicy(pType api.PolicyType, policyUpsert *api
 		if payload.Value == api.PipelineApprovalValueManualNever && !s.licenseService.IsFeatureEnabled(api.FeatureApprovalPolicy) {
 			return errors.Errorf(api.FeatureApprovalPolicy.AccessErrorMessage())
 		}
-	case api.PolicyTypeBackupPlan:
+	case api.PolicyTypeBackupPlan, api.PolicyTypeBackupRestoreDrill:
 		if !s.licenseService.IsFeatureEnabled(api.FeatureBackupPolicy) {
 			return errors.Errorf(api.FeatureBackupPolicy.AccessErrorMessage())
 		}
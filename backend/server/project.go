@@ -670,8 +670,12 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 				switch spec.Operator {
 				case api.InOperatorType:
 					operatorTp = store.InOperatorType
+				case api.NotInOperatorType:
+					operatorTp = store.NotInOperatorType
 				case api.ExistsOperatorType:
 					operatorTp = store.ExistsOperatorType
+				case api.DoesNotExistOperatorType:
+					operatorTp = store.DoesNotExistOperatorType
 				}
 				labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, &store.LabelSelectorRequirement{
 					Key:      spec.Key,
@@ -679,10 +683,20 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 					Values:   spec.Values,
 				})
 			}
+			var strategy *store.RolloutStrategy
+			if d.Spec.Strategy != nil {
+				strategy = &store.RolloutStrategy{
+					CanaryPercentage: d.Spec.Strategy.CanaryPercentage,
+					CanaryCount:      d.Spec.Strategy.CanaryCount,
+					MaxParallelism:   d.Spec.Strategy.MaxParallelism,
+					FailureThreshold: d.Spec.Strategy.FailureThreshold,
+				}
+			}
 			schedule.Deployments = append(schedule.Deployments, &store.Deployment{
 				Name: d.Name,
 				Spec: &store.DeploymentSpec{
 					Selector: &labelSelector,
+					Strategy: strategy,
 				},
 			})
 		}
@@ -805,7 +819,7 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 		}
 
 		for _, file := range fileList {
-			sheetInfo, err := parseSheetInfo(file.Path, repo.SheetPathTemplate)
+			sheetInfo, err := utils.ParseSheetInfo(file.Path, repo.SheetPathTemplate)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse sheet info from template").SetInternal(err)
 			}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -79,6 +80,7 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			Title:        hookCreate.Name,
 			URL:          hookCreate.URL,
 			ActivityList: hookCreate.ActivityList,
+			Secret:       hookCreate.Secret,
 		})
 		if err != nil {
 			if common.ErrorCode(err) == common.Conflict {
@@ -159,6 +161,7 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			Title:        hookPatch.Name,
 			URL:          hookPatch.URL,
 			ActivityList: activityList,
+			Secret:       hookPatch.Secret,
 		})
 		if err != nil {
 			if common.ErrorCode(err) == common.NotFound {
@@ -259,6 +262,7 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 				CreatorEmail: "support@bytebase.com",
 				CreatedTs:    time.Now().Unix(),
 				Project:      &webhookPlugin.Project{Name: project.Title},
+				Secret:       webhook.Secret,
 			},
 		)
 
@@ -272,6 +276,121 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+
+	g.GET("/project/:projectID/webhook/:webhookID/delivery", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		webhook, err := s.getProjectWebhookFromParams(c)
+		if err != nil {
+			return err
+		}
+
+		limit := maxProjectWebhookDeliveryListSize
+		deliveryList, err := s.store.ListProjectWebhookDeliveries(ctx, &store.FindProjectWebhookDeliveryMessage{
+			ProjectWebhookID: &webhook.ID,
+			Limit:            &limit,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch delivery list for project webhook ID: %d", webhook.ID)).SetInternal(err)
+		}
+
+		apiDeliveryList := []*api.ProjectWebhookDelivery{}
+		for _, delivery := range deliveryList {
+			apiDeliveryList = append(apiDeliveryList, convertToAPIProjectWebhookDelivery(delivery))
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiDeliveryList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook delivery list response: %v", webhook.ID)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.POST("/project/:projectID/webhook/:webhookID/delivery/:deliveryID/redeliver", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		webhook, err := s.getProjectWebhookFromParams(c)
+		if err != nil {
+			return err
+		}
+
+		deliveryID, err := strconv.Atoi(c.Param("deliveryID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Delivery ID is not a number: %s", c.Param("deliveryID"))).SetInternal(err)
+		}
+		delivery, err := s.store.GetProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+			ID:               &deliveryID,
+			ProjectWebhookID: &webhook.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project webhook delivery ID: %v", deliveryID)).SetInternal(err)
+		}
+		if delivery == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook delivery ID not found: %d", deliveryID))
+		}
+
+		// Redeliver the same payload as a new delivery, so that the history of the original delivery is kept.
+		deliveryList, err := s.store.CreateProjectWebhookDeliveries(ctx, []*store.ProjectWebhookDeliveryMessage{
+			{
+				ProjectWebhookID: webhook.ID,
+				Payload:          delivery.Payload,
+			},
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to redeliver project webhook delivery ID: %v", deliveryID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, convertToAPIProjectWebhookDelivery(deliveryList[0])); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook redelivery response: %v", deliveryID)).SetInternal(err)
+		}
+		return nil
+	})
+}
+
+// maxProjectWebhookDeliveryListSize is the maximum number of the latest deliveries in the delivery history.
+const maxProjectWebhookDeliveryListSize = 100
+
+// getProjectWebhookFromParams gets the project webhook by the projectID and webhookID route params.
+func (s *Server) getProjectWebhookFromParams(c echo.Context) (*store.ProjectWebhookMessage, error) {
+	projectID, err := strconv.Atoi(c.Param("projectID"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project ID is not a number: %s", c.Param("projectID"))).SetInternal(err)
+	}
+	id, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project webhook ID is not a number: %s", c.Param("webhookID"))).SetInternal(err)
+	}
+	webhook, err := s.store.GetProjectWebhookV2(c.Request().Context(), &store.FindProjectWebhookMessage{
+		ID:        &id,
+		ProjectID: &projectID,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project webhook ID: %v", id)).SetInternal(err)
+	}
+	if webhook == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook ID not found: %d", id))
+	}
+	return webhook, nil
+}
+
+func convertToAPIProjectWebhookDelivery(delivery *store.ProjectWebhookDeliveryMessage) *api.ProjectWebhookDelivery {
+	apiDelivery := &api.ProjectWebhookDelivery{
+		ID:               delivery.ID,
+		CreatedTs:        delivery.CreatedTs,
+		UpdatedTs:        delivery.UpdatedTs,
+		ProjectWebhookID: delivery.ProjectWebhookID,
+		Status:           delivery.Status,
+		Attempts:         delivery.Attempts,
+		NextAttemptTs:    delivery.NextAttemptTs,
+		ResponseCode:     delivery.ResponseCode,
+		LatencyMs:        delivery.LatencyMs,
+		Error:            delivery.Error,
+	}
+	var webhookCtx webhookPlugin.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &webhookCtx); err == nil {
+		apiDelivery.ActivityType = webhookCtx.ActivityType
+		apiDelivery.Title = webhookCtx.Title
+	}
+	return apiDelivery
 }
 
 // getProjectSlug is the slug formatter for Project.
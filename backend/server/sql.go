@@ -32,6 +32,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
 )
 
 func (s *Server) registerSQLRoutes(g *echo.Group) {
@@ -407,6 +408,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		}
 
 		var sensitiveSchemaInfo *db.SensitiveSchemaInfo
+		currentDatabase := exec.DatabaseName
 		switch instance.Engine {
 		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
 			databaseList, err := parser.ExtractDatabaseList(parser.MySQL, exec.Statement)
@@ -418,11 +420,18 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", exec.Statement)).SetInternal(err)
 			}
-		case db.Postgres:
+		case db.Postgres, db.Redshift, db.MSSQL, db.Snowflake:
 			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{exec.DatabaseName}, exec.DatabaseName)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", exec.Statement)).SetInternal(err)
 			}
+		case db.Oracle:
+			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{exec.DatabaseName}, exec.DatabaseName)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", exec.Statement)).SetInternal(err)
+			}
+			// The sensitive field extractor takes the Oracle schema as the database.
+			currentDatabase = getOracleCurrentSchema(instance)
 		}
 
 		start := time.Now().UnixNano()
@@ -473,7 +482,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			rowSet, err := driver.QueryConn(ctx, conn, exec.Statement, &db.QueryContext{
 				Limit:           exec.Limit,
 				ReadOnly:        true,
-				CurrentDatabase: exec.DatabaseName,
+				CurrentDatabase: currentDatabase,
 				// TODO(rebelice): we cannot deal with multi-SensitiveDataMaskType now. Fix it.
 				SensitiveDataMaskType: db.SensitiveDataMaskTypeDefault,
 				SensitiveSchemaInfo:   sensitiveSchemaInfo,
@@ -928,7 +937,7 @@ func checkPostgreSQLIndexHit(statement string, plan string) []advisor.Advice {
 }
 
 func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.InstanceMessage, databaseList []string, currentDatabase string) (*db.SensitiveSchemaInfo, error) {
-	type sensitiveDataMap map[api.SensitiveData]api.SensitiveDataMaskType
+	type sensitiveDataMap map[api.SensitiveData]*db.SensitiveDataMask
 	isEmpty := true
 	result := &db.SensitiveSchemaInfo{
 		DatabaseList: []db.DatabaseSchema{},
@@ -969,7 +978,7 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 				Schema: data.Schema,
 				Table:  data.Table,
 				Column: data.Column,
-			}] = data.Type
+			}] = convertToSensitiveDataMask(data)
 		}
 
 		dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
@@ -982,16 +991,27 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 			TableList: []db.TableSchema{},
 		}
 		for _, schema := range dbSchema.Metadata.Schemas {
+			if instance.Engine == db.Oracle {
+				// The schema of Oracle is the user, and each schema is taken as one database by the sensitive field extractor.
+				if len(databaseSchema.TableList) > 0 {
+					isEmpty = false
+					result.DatabaseList = append(result.DatabaseList, databaseSchema)
+				}
+				databaseSchema = db.DatabaseSchema{
+					Name:      schema.Name,
+					TableList: []db.TableSchema{},
+				}
+			}
 			for _, table := range schema.Tables {
 				tableSchema := db.TableSchema{
 					Name:       table.Name,
 					ColumnList: []db.ColumnInfo{},
 				}
-				if instance.Engine == db.Postgres {
+				if instance.Engine == db.Postgres || instance.Engine == db.Redshift {
 					tableSchema.Name = fmt.Sprintf("%s.%s", schema.Name, table.Name)
 				}
 				for _, column := range table.Columns {
-					_, sensitive := columnMap[api.SensitiveData{
+					mask, sensitive := columnMap[api.SensitiveData{
 						Schema: schema.Name,
 						Table:  table.Name,
 						Column: column.Name,
@@ -999,6 +1019,7 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 					tableSchema.ColumnList = append(tableSchema.ColumnList, db.ColumnInfo{
 						Name:      column.Name,
 						Sensitive: sensitive,
+						Mask:      mask,
 					})
 				}
 				databaseSchema.TableList = append(databaseSchema.TableList, tableSchema)
@@ -1018,6 +1039,19 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 	return result, nil
 }
 
+// getOracleCurrentSchema returns the current schema of the Oracle query, which is the user of the data source used by the query.
+func getOracleCurrentSchema(instance *store.InstanceMessage) string {
+	dataSource := utils.DataSourceFromInstanceWithType(instance, api.RO)
+	if dataSource == nil {
+		dataSource = utils.DataSourceFromInstanceWithType(instance, api.Admin)
+	}
+	if dataSource == nil {
+		return ""
+	}
+	// Oracle stores the unquoted user name in upper case.
+	return strings.ToUpper(dataSource.Username)
+}
+
 func isExcludeDatabase(dbType db.Type, database string) bool {
 	switch dbType {
 	case db.MySQL, db.MariaDB:
@@ -1202,3 +1236,21 @@ func evaluateCondition(expression string, attributes map[string]any) (bool, erro
 	}
 	return boolVal, nil
 }
+
+// convertToSensitiveDataMask converts the sensitive data policy rule into the mask algorithm, nil means the default mask.
+func convertToSensitiveDataMask(data api.SensitiveData) *db.SensitiveDataMask {
+	if data.Type == api.SensitiveDataMaskTypeDefault || data.Type == "" {
+		return nil
+	}
+	mask := &db.SensitiveDataMask{
+		Type: db.SensitiveDataMaskType(data.Type),
+	}
+	if option := data.Option; option != nil {
+		mask.PrefixLength = option.PrefixLength
+		mask.SuffixLength = option.SuffixLength
+		mask.Salt = option.Salt
+		mask.BucketSize = option.BucketSize
+		mask.DateUnit = option.DateUnit
+	}
+	return mask
+}
@@ -51,8 +51,8 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 			return err
 		}
 		for _, task := range tasks {
-			// Skip gh-ost cutover task as this task has no statement.
-			if task.Type == api.TaskDatabaseSchemaUpdateGhostCutover {
+			// Skip gh-ost and PostgreSQL online migration cutover tasks as these tasks have no statement.
+			if task.Type == api.TaskDatabaseSchemaUpdateGhostCutover || task.Type == api.TaskDatabaseSchemaUpdatePGOnlineCutover {
 				continue
 			}
 			taskPatch := *taskPatch
@@ -45,9 +45,6 @@ import (
 )
 
 const (
-	// sqlReviewDocs is the URL for SQL review doc.
-	sqlReviewDocs = "https://www.bytebase.com/docs/reference/error-code/advisor"
-
 	// issueNameTemplate should be consistent with UI issue names generated from the frontend except for the timestamp.
 	// Because we cannot get the correct timezone of the client here.
 	// Example: "[db-5] Alter schema".
@@ -462,11 +459,22 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 		}
 
 		response := &api.VCSSQLReviewResult{}
-		switch repo.VCS.Type {
-		case vcs.GitHub:
-			response = convertSQLAdviceToGitHubActionResult(sqlFileName2Advice)
-		case vcs.GitLab:
-			response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
+		if request.Format != "" {
+			content, err := advisor.ExportAdviceMap(request.Format, sqlFileName2Advice)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Failed to export SQL review result").SetInternal(err)
+			}
+			response = &api.VCSSQLReviewResult{
+				Status:  advisor.GetAdviceMapStatus(sqlFileName2Advice),
+				Content: []string{content},
+			}
+		} else {
+			switch repo.VCS.Type {
+			case vcs.GitHub:
+				response = convertSQLAdviceToGitHubActionResult(sqlFileName2Advice)
+			case vcs.GitLab:
+				response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
+			}
 		}
 
 		log.Debug("SQL review finished",
@@ -1717,71 +1725,11 @@ func (s *Server) tryUpdateTasksFromModifiedFile(ctx context.Context, databases [
 // GitLab test report: https://docs.gitlab.com/ee/ci/testing/unit_test_reports.html
 // junit XML format: https://llg.cubic.org/docs/junit/
 func convertSQLAdviceToGitLabCIResult(adviceMap map[string][]advisor.Advice) *api.VCSSQLReviewResult {
-	testsuiteList := []string{}
-	status := advisor.Success
-
-	fileList := []string{}
-	for filePath := range adviceMap {
-		fileList = append(fileList, filePath)
-	}
-	sort.Strings(fileList)
-
-	for _, filePath := range fileList {
-		adviceList := adviceMap[filePath]
-		testcaseList := []string{}
-		pathes := strings.Split(filePath, "/")
-		filename := pathes[len(pathes)-1]
-
-		for _, advice := range adviceList {
-			if advice.Code == 0 {
-				continue
-			}
-
-			line := advice.Line
-			if line <= 0 {
-				line = 1
-			}
-
-			if advice.Status == advisor.Error {
-				status = advice.Status
-			} else if advice.Status == advisor.Warn && status != advisor.Error {
-				status = advice.Status
-			}
-
-			content := fmt.Sprintf("Error: %s.\nYou can check the docs at %s#%d",
-				advice.Content,
-				sqlReviewDocs,
-				advice.Code,
-			)
-
-			testcase := fmt.Sprintf(
-				"<testcase name=\"%s\" classname=\"%s\" file=\"%s#L%d\">\n<failure>\n%s\n</failure>\n</testcase>",
-				fmt.Sprintf("[%s] %s#L%d: %s", advice.Status, filename, line, advice.Title),
-				filePath,
-				filePath,
-				line,
-				content,
-			)
-
-			testcaseList = append(testcaseList, testcase)
-		}
-
-		if len(testcaseList) > 0 {
-			testsuiteList = append(
-				testsuiteList,
-				fmt.Sprintf("<testsuite name=\"%s\">\n%s\n</testsuite>", filePath, strings.Join(testcaseList, "\n")),
-			)
-		}
-	}
-
+	// The JUnit export never fails.
+	content, _ := advisor.ExportAdviceMap(advisor.ExportFormatJUnit, adviceMap)
 	return &api.VCSSQLReviewResult{
-		Status: status,
-		Content: []string{
-			fmt.Sprintf(
-				"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<testsuites name=\"SQL Review\">\n%s\n</testsuites>",
-				strings.Join(testsuiteList, "\n"),
-			),
-		},
+		Status:  advisor.GetAdviceMapStatus(adviceMap),
+		Content: []string{content},
 	}
 }
 
@@ -1800,15 +1748,29 @@ func convertSQLAdviceToGitHubActionResult(adviceMap map[string][]advisor.Advice)
 	for _, filePath := range fileList {
 		adviceList := adviceMap[filePath]
 		for _, advice := range adviceList {
-			if advice.Code == 0 || advice.Status == advisor.Success {
-				continue
-			}
-
 			line := advice.Line
 			if line <= 0 {
 				line = 1
 			}
 
+			if advice.Suppressed {
+				msg := fmt.Sprintf(
+					"::notice file=%s,line=%d,col=1,endColumn=2,title=%s (%d)::%s\nDoc: %s#%d",
+					filePath,
+					line,
+					advice.Title,
+					advice.Code,
+					advisor.GetSuppressedAdviceMessage(advice),
+					advisor.ErrorCodeDocsURL,
+					advice.Code,
+				)
+				messageList = append(messageList, strings.ReplaceAll(msg, "\n", "%0A"))
+				continue
+			}
+			if advice.Code == 0 || advice.Status == advisor.Success {
+				continue
+			}
+
 			prefix := ""
 			if advice.Status == advisor.Error {
 				prefix = "error"
@@ -1828,7 +1790,7 @@ func convertSQLAdviceToGitHubActionResult(adviceMap map[string][]advisor.Advice)
 				advice.Title,
 				advice.Code,
 				advice.Content,
-				sqlReviewDocs,
+				advisor.ErrorCodeDocsURL,
 				advice.Code,
 			)
 			// To indent the output message in action
@@ -184,6 +184,42 @@ func TestVCSSQLReview_ConvertSQLAdviceToGitHubActionResult(t *testing.T) {
 	assert.Equal(t, expect, res.Content)
 }
 
+func TestVCSSQLReview_ConvertSuppressedSQLAdvice(t *testing.T) {
+	adviceMap := map[string][]advisor.Advice{
+		"file1.sql": {
+			{
+				Status:         advisor.Success,
+				Code:           advisor.StatementNoWhere,
+				Title:          "statement.where.require",
+				Content:        "\"DELETE FROM t\" requires WHERE clause",
+				Line:           2,
+				Suppressed:     true,
+				SuppressReason: "backfill",
+			},
+		},
+	}
+
+	gitLabExpect :=
+		`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="SQL Review">
+<testsuite name="file1.sql">
+<testcase name="[SUPPRESSED] file1.sql#L2: statement.where.require" classname="file1.sql" file="file1.sql#L2">
+<skipped message="Suppressed with reason &#34;backfill&#34;: &#34;DELETE FROM t&#34; requires WHERE clause"/>
+</testcase>
+</testsuite>
+</testsuites>`
+	gitLabRes := convertSQLAdviceToGitLabCIResult(adviceMap)
+	assert.Equal(t, advisor.Success, gitLabRes.Status)
+	assert.Equal(t, []string{gitLabExpect}, gitLabRes.Content)
+
+	gitHubExpect := []string{
+		"::notice file=file1.sql,line=2,col=1,endColumn=2,title=statement.where.require (202)::Suppressed with reason \"backfill\": \"DELETE FROM t\" requires WHERE clause%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#202",
+	}
+	gitHubRes := convertSQLAdviceToGitHubActionResult(adviceMap)
+	assert.Equal(t, advisor.Success, gitHubRes.Status)
+	assert.Equal(t, gitHubExpect, gitHubRes.Content)
+}
+
 func TestGetFileInfo(t *testing.T) {
 	t.Run("a SQL format DDL", func(t *testing.T) {
 		mi, fileType, repo, err := getFileInfo(
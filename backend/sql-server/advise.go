@@ -43,8 +43,15 @@ type sqlCheckRequestBody struct {
 	DatabaseType string                      `json:"databaseType"`
 	TemplateID   advisor.SQLReviewTemplateID `json:"templateId"`
 	Override     string                      `json:"override"`
+	// Format is the optional output format, such as SARIF or JUNIT. If empty, the response is the advice list.
+	Format advisor.ExportFormat `json:"format"`
+	// FilePath is the file path of the statement in the exported result.
+	FilePath string `json:"filePath"`
 }
 
+// defaultExportFilePath is the file path of the statement in the exported result if the file path is not specified.
+const defaultExportFilePath = "statement.sql"
+
 func (s *Server) registerAdvisorRoutes(g *echo.Group) {
 	g.POST("/advise", s.sqlCheckController)
 }
@@ -59,6 +66,8 @@ func (s *Server) registerAdvisorRoutes(g *echo.Group) {
 // @Param  databaseType  body  string  true   "The database type."  Enums(MYSQL, POSTGRES, TIDB)
 // @Param  templateId    body  string  false  "The SQL check template id. Required if the config is not specified." Enums(bb.sql-review.prod, bb.sql-review.dev)
 // @Param  override      body  string  false  "The SQL check config override string in YAML format. Check https://github.com/bytebase/bytebase/tree/main/plugin/advisor/config/sql-review.override.yaml for example. Required if the template is not specified."
+// @Param  format        body  string  false  "The output format. Return the advice list if not specified."  Enums(SARIF, JUNIT)
+// @Param  filePath      body  string  false  "The file path of the statement in the SARIF or JUnit output. Default to statement.sql."
 // @Success  200  {array}   advisor.Advice
 // @Failure  400  {object}  echo.HTTPError
 // @Failure  500  {object}  echo.HTTPError
@@ -128,6 +137,22 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		},
 	})
 
+	if request.Format != "" {
+		filePath := request.FilePath
+		if filePath == "" {
+			filePath = defaultExportFilePath
+		}
+		content, err := advisor.ExportAdviceMap(request.Format, map[string][]advisor.Advice{filePath: adviceList})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format: %s", request.Format)).SetInternal(err)
+		}
+		contentType := echo.MIMEApplicationJSONCharsetUTF8
+		if request.Format == advisor.ExportFormatJUnit {
+			contentType = echo.MIMEApplicationXMLCharsetUTF8
+		}
+		return c.Blob(http.StatusOK, contentType, []byte(content))
+	}
+
 	return c.JSON(http.StatusOK, adviceList)
 }
 
@@ -378,6 +378,17 @@ func findActivityImpl(ctx context.Context, tx *Tx, find *api.ActivityFind) ([]*a
 	if v := find.CreatedTsBefore; v != nil {
 		where, args = append(where, fmt.Sprintf("created_ts <= $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.ProjectID; v != nil {
+		// The container of an activity depends on its type, so we need to find the project per type.
+		// The SQL editor query activity is contained by the instance, we use the database in its payload instead.
+		projectArg := len(args) + 1
+		where, args = append(where, fmt.Sprintf(`(
+			(type LIKE 'bb.issue.%%' AND container_id IN (SELECT id FROM issue WHERE project_id = $%d))
+			OR (type LIKE 'bb.pipeline.%%' AND container_id IN (SELECT pipeline_id FROM issue WHERE project_id = $%d AND pipeline_id IS NOT NULL))
+			OR ((type LIKE 'bb.project.%%' OR type = '%s') AND container_id = $%d)
+			OR (type = '%s' AND payload->>'databaseId' IN (SELECT id::TEXT FROM db WHERE project_id = $%d))
+		)`, projectArg, projectArg, api.ActivityDatabaseRecoveryPITRDone, projectArg, api.ActivitySQLEditorQuery, projectArg)), append(args, *v)
+	}
 
 	var query = `
 		SELECT
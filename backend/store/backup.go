@@ -320,6 +320,8 @@ type BackupMessage struct {
 	Comment string
 	// Storage Backend is the storage backend of the backup.
 	StorageBackend api.BackupStorageBackend
+	// Storage is the name of the backup storage chosen by the backup plan policy, empty means the workspace backup storage.
+	Storage string
 	// MigrationHistoryVersion is the migration history version of the database.
 	MigrationHistoryVersion string
 	// Path is the path of the backup file.
@@ -528,12 +530,13 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 			status,
 			type,
 			storage_backend,
+			storage,
 			migration_history_version,
 			path,
 			comment
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, row_status, name, storage_backend, migration_history_version, path, created_ts, updated_ts, status, type, comment, database_id
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, row_status, name, storage_backend, storage, migration_history_version, path, created_ts, updated_ts, status, type, comment, database_id
 	`
 	var backup BackupMessage
 	if err := tx.QueryRowContext(ctx, query,
@@ -544,6 +547,7 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 		create.Status,
 		create.BackupType,
 		create.StorageBackend,
+		create.Storage,
 		create.MigrationHistoryVersion,
 		create.Path,
 		create.Comment,
@@ -552,6 +556,7 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 		&backup.RowStatus,
 		&backup.Name,
 		&backup.StorageBackend,
+		&backup.Storage,
 		&backup.MigrationHistoryVersion,
 		&backup.Path,
 		&backup.CreatedTs,
@@ -653,7 +658,7 @@ func (s *Store) UpdateBackupV2(ctx context.Context, patch *UpdateBackupMessage)
 			UPDATE backup
 			SET `+strings.Join(set, ", ")+`
 			WHERE id = $%d
-			RETURNING id, row_status, created_ts, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment, payload
+			RETURNING id, row_status, created_ts, updated_ts, database_id, name, status, type, storage_backend, storage, migration_history_version, path, comment, payload
 		`, len(args)),
 		args...,
 	).Scan(
@@ -666,6 +671,7 @@ func (s *Store) UpdateBackupV2(ctx context.Context, patch *UpdateBackupMessage)
 		&backup.Status,
 		&backup.BackupType,
 		&backup.StorageBackend,
+		&backup.Storage,
 		&backup.MigrationHistoryVersion,
 		&backup.Path,
 		&backup.Comment,
@@ -713,6 +719,7 @@ func (*Store) listBackupImplV2(ctx context.Context, tx *Tx, find *FindBackupMess
 			row_status,
 			name,
 			storage_backend,
+			storage,
 			migration_history_version,
 			path,
 			created_ts,
@@ -737,6 +744,7 @@ func (*Store) listBackupImplV2(ctx context.Context, tx *Tx, find *FindBackupMess
 			&backup.RowStatus,
 			&backup.Name,
 			&backup.StorageBackend,
+			&backup.Storage,
 			&backup.MigrationHistoryVersion,
 			&backup.Path,
 			&backup.CreatedTs,
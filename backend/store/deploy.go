@@ -49,6 +49,7 @@ func (s *Schedule) toAPIDeploymentSchedule() *api.DeploymentSchedule {
 			Name: d.Name,
 			Spec: &api.DeploymentSpec{
 				Selector: d.Spec.Selector.toAPILabelSelector(),
+				Strategy: d.Spec.Strategy.toAPIRolloutStrategy(),
 			},
 		})
 	}
@@ -66,6 +67,32 @@ type Deployment struct {
 // DeploymentSpec is the message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Strategy is the rollout strategy for the databases in the deployment.
+	Strategy *RolloutStrategy `json:"strategy,omitempty"`
+}
+
+// RolloutStrategy is the message for the rollout strategy of a deployment.
+type RolloutStrategy struct {
+	// CanaryPercentage is the percentage of databases in the deployment to roll out first.
+	CanaryPercentage int `json:"canaryPercentage,omitempty"`
+	// CanaryCount is the number of databases in the deployment to roll out first.
+	CanaryCount int `json:"canaryCount,omitempty"`
+	// MaxParallelism is the maximum number of tasks running at the same time in the deployment.
+	MaxParallelism int `json:"maxParallelism,omitempty"`
+	// FailureThreshold is the number of failed tasks that halts the remaining tasks in the deployment.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+func (s *RolloutStrategy) toAPIRolloutStrategy() *api.RolloutStrategy {
+	if s == nil {
+		return nil
+	}
+	return &api.RolloutStrategy{
+		CanaryPercentage: s.CanaryPercentage,
+		CanaryCount:      s.CanaryCount,
+		MaxParallelism:   s.MaxParallelism,
+		FailureThreshold: s.FailureThreshold,
+	}
 }
 
 // LabelSelector is the message for label selector.
@@ -81,8 +108,12 @@ func (ls *LabelSelector) toAPILabelSelector() *api.LabelSelector {
 		switch r.Operator {
 		case InOperatorType:
 			operatorTp = api.InOperatorType
+		case NotInOperatorType:
+			operatorTp = api.NotInOperatorType
 		case ExistsOperatorType:
 			operatorTp = api.ExistsOperatorType
+		case DoesNotExistOperatorType:
+			operatorTp = api.DoesNotExistOperatorType
 		}
 		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, &api.LabelSelectorRequirement{
 			Key:      r.Key,
@@ -94,15 +125,18 @@ func (ls *LabelSelector) toAPILabelSelector() *api.LabelSelector {
 }
 
 // OperatorType is the type of label selector requirement operator.
-// Valid operators are In, Exists.
-// Note: NotIn and DoesNotExist are not supported initially.
+// Valid operators are In, NotIn, Exists and DoesNotExist.
 type OperatorType string
 
 const (
 	// InOperatorType is the operator type for In.
 	InOperatorType OperatorType = "In"
+	// NotInOperatorType is the operator type for NotIn.
+	NotInOperatorType OperatorType = "NotIn"
 	// ExistsOperatorType is the operator type for Exists.
 	ExistsOperatorType OperatorType = "Exists"
+	// DoesNotExistOperatorType is the operator type for DoesNotExist.
+	DoesNotExistOperatorType OperatorType = "DoesNotExist"
 )
 
 // LabelSelectorRequirement is the message for label selector.
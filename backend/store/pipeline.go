@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 )
 
 // PipelineMessage is the message for pipelines.
@@ -31,6 +32,101 @@ func (s *Store) CreatePipelineV2(ctx context.Context, create *PipelineMessage, c
 	}
 	defer tx.Rollback()
 
+	pipeline, err := createPipelineImplV2(ctx, tx, create, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.pipelineCache.Store(pipeline.ID, pipeline)
+	return pipeline, nil
+}
+
+// CreatePipelineAIO creates the pipeline along with its stages, tasks and task DAGs, and links it to the plan in one transaction.
+// It returns a conflict error if the plan already has a pipeline.
+func (s *Store) CreatePipelineAIO(ctx context.Context, planUID int64, pipelineCreate *api.PipelineCreate, creatorID int) (*PipelineMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pipeline, err := createPipelineImplV2(ctx, tx, &PipelineMessage{Name: pipelineCreate.Name}, creatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create pipeline")
+	}
+
+	var stageCreates []*StageMessage
+	for _, stage := range pipelineCreate.StageList {
+		stageCreates = append(stageCreates, &StageMessage{
+			Name:          stage.Name,
+			EnvironmentID: stage.EnvironmentID,
+			PipelineID:    pipeline.ID,
+		})
+	}
+	createdStages, err := createStageImplV2(ctx, tx, stageCreates, creatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create stages")
+	}
+	if len(createdStages) != len(stageCreates) {
+		return nil, errors.Errorf("failed to create stages, expect to have created %d stages, got %d", len(stageCreates), len(createdStages))
+	}
+
+	for i, stageCreate := range pipelineCreate.StageList {
+		createdStage := createdStages[i]
+
+		var taskCreateList []*api.TaskCreate
+		for _, taskCreate := range stageCreate.TaskList {
+			c := taskCreate
+			c.CreatorID = creatorID
+			c.PipelineID = pipeline.ID
+			c.StageID = createdStage.ID
+			taskCreateList = append(taskCreateList, &c)
+		}
+		tasks, err := createTasksImplV2(ctx, tx, taskCreateList...)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create tasks")
+		}
+
+		for _, indexDAG := range stageCreate.TaskIndexDAGList {
+			if err := createTaskDAGImplV2(ctx, tx, &TaskDAGMessage{
+				FromTaskID: tasks[indexDAG.FromIndex].ID,
+				ToTaskID:   tasks[indexDAG.ToIndex].ID,
+			}); err != nil {
+				return nil, errors.Wrap(err, "failed to create task DAG")
+			}
+		}
+	}
+
+	// Only link the pipeline if the plan has none, so concurrent callers cannot create two pipelines for one plan.
+	result, err := tx.ExecContext(ctx, `
+		UPDATE plan
+		SET pipeline_id = $1, updater_id = $2
+		WHERE id = $3 AND pipeline_id IS NULL
+	`, pipeline.ID, creatorID, planUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update plan")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("plan %d not found or already has a pipeline", planUID)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+
+	s.pipelineCache.Store(pipeline.ID, pipeline)
+	return pipeline, nil
+}
+
+func createPipelineImplV2(ctx context.Context, tx *Tx, create *PipelineMessage, creatorID int) (*PipelineMessage, error) {
 	query := `
 		INSERT INTO pipeline (
 			creator_id,
@@ -54,12 +150,6 @@ func (s *Store) CreatePipelineV2(ctx context.Context, create *PipelineMessage, c
 		}
 		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
-
-	s.pipelineCache.Store(pipeline.ID, pipeline)
 	return pipeline, nil
 }
 
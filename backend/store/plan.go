@@ -0,0 +1,272 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// PlanMessage is the message for plan.
+type PlanMessage struct {
+	ProjectID   string
+	PipelineUID *int
+	Name        string
+	Description string
+	Config      *storepb.PlanConfig
+	// output only
+	UID       int64
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+}
+
+// FindPlanMessage is the message to find a plan.
+type FindPlanMessage struct {
+	UID         *int64
+	ProjectID   *string
+	PipelineUID *int
+	// ProjectIDs limits the plans to the given projects.
+	ProjectIDs *[]string
+
+	Limit  *int
+	Offset *int
+}
+
+// UpdatePlanMessage is the message to update a plan.
+type UpdatePlanMessage struct {
+	UID         int64
+	UpdaterID   int
+	Name        *string
+	Description *string
+	Config      *storepb.PlanConfig
+	PipelineUID *int
+}
+
+// CreatePlan creates a new plan.
+func (s *Store) CreatePlan(ctx context.Context, plan *PlanMessage, creatorID int) (*PlanMessage, error) {
+	config, err := protojson.Marshal(plan.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal plan config")
+	}
+
+	project, err := s.GetProjectV2(ctx, &FindProjectMessage{ResourceID: &plan.ProjectID})
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.Errorf("project %q not found", plan.ProjectID)
+	}
+
+	query := `
+		INSERT INTO plan (
+			creator_id,
+			updater_id,
+			project_id,
+			pipeline_id,
+			name,
+			description,
+			config
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_ts, updated_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	created := &PlanMessage{
+		ProjectID:   plan.ProjectID,
+		PipelineUID: plan.PipelineUID,
+		Name:        plan.Name,
+		Description: plan.Description,
+		Config:      plan.Config,
+		CreatorID:   creatorID,
+		UpdaterID:   creatorID,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		creatorID,
+		creatorID,
+		project.UID,
+		plan.PipelineUID,
+		plan.Name,
+		plan.Description,
+		string(config),
+	).Scan(
+		&created.UID,
+		&created.CreatedTs,
+		&created.UpdatedTs,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to insert plan")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+	return created, nil
+}
+
+// GetPlan gets a plan.
+func (s *Store) GetPlan(ctx context.Context, find *FindPlanMessage) (*PlanMessage, error) {
+	plans, err := s.ListPlans(ctx, find)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list plans")
+	}
+	if len(plans) == 0 {
+		return nil, nil
+	}
+	if len(plans) > 1 {
+		return nil, errors.Errorf("expected 1 plan, got %d", len(plans))
+	}
+	return plans[0], nil
+}
+
+// ListPlans retrieves a list of plans.
+func (s *Store) ListPlans(ctx context.Context, find *FindPlanMessage) ([]*PlanMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("plan.id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectID; v != nil {
+		where, args = append(where, fmt.Sprintf("project.resource_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PipelineUID; v != nil {
+		where, args = append(where, fmt.Sprintf("plan.pipeline_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectIDs; v != nil {
+		if len(*v) == 0 {
+			where = append(where, "FALSE")
+		} else {
+			list := []string{}
+			for _, projectID := range *v {
+				list = append(list, fmt.Sprintf("$%d", len(args)+1))
+				args = append(args, projectID)
+			}
+			where = append(where, fmt.Sprintf("project.resource_id IN (%s)", strings.Join(list, ", ")))
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			plan.id,
+			plan.creator_id,
+			plan.created_ts,
+			plan.updater_id,
+			plan.updated_ts,
+			project.resource_id,
+			plan.pipeline_id,
+			plan.name,
+			plan.description,
+			plan.config
+		FROM plan
+		LEFT JOIN project ON plan.project_id = project.id
+		WHERE %s
+		ORDER BY plan.id DESC
+	`, strings.Join(where, " AND "))
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := find.Offset; v != nil {
+		query += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query")
+	}
+	defer rows.Close()
+
+	var plans []*PlanMessage
+	for rows.Next() {
+		plan := PlanMessage{
+			Config: &storepb.PlanConfig{},
+		}
+		var pipelineUID sql.NullInt32
+		var config []byte
+		if err := rows.Scan(
+			&plan.UID,
+			&plan.CreatorID,
+			&plan.CreatedTs,
+			&plan.UpdaterID,
+			&plan.UpdatedTs,
+			&plan.ProjectID,
+			&pipelineUID,
+			&plan.Name,
+			&plan.Description,
+			&config,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		if pipelineUID.Valid {
+			v := int(pipelineUID.Int32)
+			plan.PipelineUID = &v
+		}
+		if err := protojson.Unmarshal(config, plan.Config); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal plan config")
+		}
+		plans = append(plans, &plan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to scan rows")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+	return plans, nil
+}
+
+// UpdatePlan updates an existing plan.
+func (s *Store) UpdatePlan(ctx context.Context, patch *UpdatePlanMessage) (*PlanMessage, error) {
+	set, args := []string{"updater_id = $1"}, []any{patch.UpdaterID}
+	if v := patch.Name; v != nil {
+		set, args = append(set, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Description; v != nil {
+		set, args = append(set, fmt.Sprintf("description = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Config; v != nil {
+		config, err := protojson.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal plan config")
+		}
+		set, args = append(set, fmt.Sprintf("config = $%d", len(args)+1)), append(args, string(config))
+	}
+	if v := patch.PipelineUID; v != nil {
+		set, args = append(set, fmt.Sprintf("pipeline_id = $%d", len(args)+1)), append(args, *v)
+	}
+	args = append(args, patch.UID)
+
+	query := fmt.Sprintf(`
+		UPDATE plan
+		SET %s
+		WHERE id = $%d
+	`, strings.Join(set, ", "), len(args))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to update plan")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+	return s.GetPlan(ctx, &FindPlanMessage{UID: &patch.UID})
+}
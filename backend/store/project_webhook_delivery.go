@@ -0,0 +1,259 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// ProjectWebhookDeliveryMessage is the message for a project webhook delivery in the outbox.
+type ProjectWebhookDeliveryMessage struct {
+	ProjectWebhookID int
+	// Payload is the webhook context in JSON.
+	Payload string
+	// NextAttemptTs is the time of the next delivery attempt, 0 for now.
+	NextAttemptTs int64
+
+	// Output only fields.
+	ID           int
+	CreatedTs    int64
+	UpdatedTs    int64
+	Status       api.ProjectWebhookDeliveryStatus
+	Attempts     int
+	ResponseCode int
+	LatencyMs    int
+	Error        string
+}
+
+// FindProjectWebhookDeliveryMessage is the message to find project webhook deliveries.
+type FindProjectWebhookDeliveryMessage struct {
+	ID               *int
+	ProjectWebhookID *int
+	Status           *api.ProjectWebhookDeliveryStatus
+	// NextAttemptBeforeTs finds the deliveries due at the time.
+	NextAttemptBeforeTs *int64
+
+	Limit *int
+}
+
+// UpdateProjectWebhookDeliveryMessage is the message to record a delivery attempt.
+type UpdateProjectWebhookDeliveryMessage struct {
+	ID            int
+	Status        api.ProjectWebhookDeliveryStatus
+	Attempts      int
+	NextAttemptTs int64
+	ResponseCode  int
+	LatencyMs     int
+	Error         string
+}
+
+// CreateProjectWebhookDeliveries creates the pending project webhook deliveries.
+func (s *Store) CreateProjectWebhookDeliveries(ctx context.Context, creates []*ProjectWebhookDeliveryMessage) ([]*ProjectWebhookDeliveryMessage, error) {
+	query := `
+		INSERT INTO project_webhook_delivery (
+			project_webhook_id,
+			status,
+			next_attempt_ts,
+			payload
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_ts, updated_ts, next_attempt_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	var deliveries []*ProjectWebhookDeliveryMessage
+	for _, create := range creates {
+		nextAttemptTs := create.NextAttemptTs
+		if nextAttemptTs == 0 {
+			nextAttemptTs = time.Now().Unix()
+		}
+		delivery := &ProjectWebhookDeliveryMessage{
+			ProjectWebhookID: create.ProjectWebhookID,
+			Payload:          create.Payload,
+			Status:           api.ProjectWebhookDeliveryPending,
+		}
+		if err := tx.QueryRowContext(ctx, query,
+			create.ProjectWebhookID,
+			api.ProjectWebhookDeliveryPending,
+			nextAttemptTs,
+			create.Payload,
+		).Scan(
+			&delivery.ID,
+			&delivery.CreatedTs,
+			&delivery.UpdatedTs,
+			&delivery.NextAttemptTs,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to insert project webhook delivery")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+	return deliveries, nil
+}
+
+// GetProjectWebhookDelivery gets a project webhook delivery.
+func (s *Store) GetProjectWebhookDelivery(ctx context.Context, find *FindProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	deliveries, err := s.ListProjectWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if len(deliveries) > 1 {
+		return nil, errors.Errorf("expected 1 project webhook delivery, got %d", len(deliveries))
+	}
+	return deliveries[0], nil
+}
+
+// ListProjectWebhookDeliveries lists the project webhook deliveries, the latest first.
+func (s *Store) ListProjectWebhookDeliveries(ctx context.Context, find *FindProjectWebhookDeliveryMessage) ([]*ProjectWebhookDeliveryMessage, error) {
+	return s.listProjectWebhookDeliveriesImpl(ctx, find, "DESC")
+}
+
+// ListDueProjectWebhookDeliveries lists the pending project webhook deliveries due at the time, the earliest first.
+func (s *Store) ListDueProjectWebhookDeliveries(ctx context.Context, ts int64, limit int) ([]*ProjectWebhookDeliveryMessage, error) {
+	status := api.ProjectWebhookDeliveryPending
+	return s.listProjectWebhookDeliveriesImpl(ctx, &FindProjectWebhookDeliveryMessage{
+		Status:              &status,
+		NextAttemptBeforeTs: &ts,
+		Limit:               &limit,
+	}, "ASC")
+}
+
+func (s *Store) listProjectWebhookDeliveriesImpl(ctx context.Context, find *FindProjectWebhookDeliveryMessage, order string) ([]*ProjectWebhookDeliveryMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectWebhookID; v != nil {
+		where, args = append(where, fmt.Sprintf("project_webhook_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.NextAttemptBeforeTs; v != nil {
+		where, args = append(where, fmt.Sprintf("next_attempt_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			project_webhook_id,
+			status,
+			attempts,
+			next_attempt_ts,
+			response_code,
+			latency_ms,
+			error,
+			payload
+		FROM project_webhook_delivery
+		WHERE %s
+		ORDER BY id %s
+	`, strings.Join(where, " AND "), order)
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query")
+	}
+	defer rows.Close()
+
+	var deliveries []*ProjectWebhookDeliveryMessage
+	for rows.Next() {
+		var delivery ProjectWebhookDeliveryMessage
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.CreatedTs,
+			&delivery.UpdatedTs,
+			&delivery.ProjectWebhookID,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptTs,
+			&delivery.ResponseCode,
+			&delivery.LatencyMs,
+			&delivery.Error,
+			&delivery.Payload,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to scan rows")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit tx")
+	}
+	return deliveries, nil
+}
+
+// UpdateProjectWebhookDelivery records a delivery attempt of the project webhook delivery.
+func (s *Store) UpdateProjectWebhookDelivery(ctx context.Context, update *UpdateProjectWebhookDeliveryMessage) error {
+	query := `
+		UPDATE project_webhook_delivery
+		SET status = $1, attempts = $2, next_attempt_ts = $3, response_code = $4, latency_ms = $5, error = $6
+		WHERE id = $7
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query,
+		update.Status,
+		update.Attempts,
+		update.NextAttemptTs,
+		update.ResponseCode,
+		update.LatencyMs,
+		update.Error,
+		update.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update project webhook delivery")
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit tx")
+	}
+	return nil
+}
+
+// DeleteFinishedProjectWebhookDeliveries deletes the finished project webhook deliveries last updated before the time.
+func (s *Store) DeleteFinishedProjectWebhookDeliveries(ctx context.Context, beforeTs int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin tx")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_webhook_delivery WHERE status != $1 AND updated_ts < $2`, api.ProjectWebhookDeliveryPending, beforeTs); err != nil {
+		return errors.Wrap(err, "failed to delete project webhook deliveries")
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit tx")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -346,3 +347,66 @@ func (*Store) getLatestSlowLogDateImpl(ctx context.Context, tx *Tx, instanceUID
 	}
 	return result, nil
 }
+
+// GetSlowQuerySnapshot gets the latest snapshot of the accumulated slow query statistics of the instance, keyed by the database name.
+// It returns nil if the instance has no snapshot.
+func (s *Store) GetSlowQuerySnapshot(ctx context.Context, instanceUID int) (map[string]*storepb.SlowQueryStatistics, error) {
+	var snapshotBytes []byte
+	if err := s.db.db.QueryRowContext(ctx, `
+		SELECT snapshot
+		FROM slow_query_snapshot
+		WHERE instance_id = $1`,
+		instanceUID,
+	).Scan(&snapshotBytes); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get slow query snapshot")
+	}
+
+	var rawSnapshot map[string]json.RawMessage
+	if err := json.Unmarshal(snapshotBytes, &rawSnapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal slow query snapshot")
+	}
+	snapshot := make(map[string]*storepb.SlowQueryStatistics)
+	for dbName, raw := range rawSnapshot {
+		statistics := &storepb.SlowQueryStatistics{}
+		if err := protojson.Unmarshal(raw, statistics); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal slow query snapshot of database %q", dbName)
+		}
+		snapshot[dbName] = statistics
+	}
+	return snapshot, nil
+}
+
+// UpsertSlowQuerySnapshot upserts the latest snapshot of the accumulated slow query statistics of the instance, keyed by the database name.
+func (s *Store) UpsertSlowQuerySnapshot(ctx context.Context, instanceUID int, snapshot map[string]*storepb.SlowQueryStatistics) error {
+	rawSnapshot := make(map[string]json.RawMessage)
+	for dbName, statistics := range snapshot {
+		raw, err := protojson.Marshal(statistics)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal slow query snapshot of database %q", dbName)
+		}
+		rawSnapshot[dbName] = raw
+	}
+	snapshotBytes, err := json.Marshal(rawSnapshot)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal slow query snapshot")
+	}
+
+	if _, err := s.db.db.ExecContext(ctx, `
+		INSERT INTO slow_query_snapshot (
+			instance_id,
+			snapshot
+		)
+		VALUES ($1, $2)
+		ON CONFLICT (instance_id) DO UPDATE SET
+			updated_ts = extract(epoch from now()),
+			snapshot = EXCLUDED.snapshot`,
+		instanceUID,
+		string(snapshotBytes),
+	); err != nil {
+		return errors.Wrapf(err, "failed to upsert slow query snapshot")
+	}
+	return nil
+}
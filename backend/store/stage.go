@@ -26,6 +26,19 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	}
 	defer tx.Rollback()
 
+	stages, err := createStageImplV2(ctx, tx, stagesCreate, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return stages, nil
+}
+
+func createStageImplV2(ctx context.Context, tx *Tx, stagesCreate []*StageMessage, creatorID int) ([]*StageMessage, error) {
 	var valueStr []string
 	var values []any
 	for i, create := range stagesCreate {
@@ -75,10 +88,6 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
-
 	return stages, nil
 }
 
@@ -210,6 +210,24 @@ func (s *Store) GetTaskV2ByID(ctx context.Context, id int) (*TaskMessage, error)
 
 // CreateTasksV2 creates a new task.
 func (s *Store) CreateTasksV2(ctx context.Context, creates ...*api.TaskCreate) ([]*TaskMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tasks, err := createTasksImplV2(ctx, tx, creates...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func createTasksImplV2(ctx context.Context, tx *Tx, creates ...*api.TaskCreate) ([]*TaskMessage, error) {
 	var query strings.Builder
 	var values []any
 	var queryValues []string
@@ -259,12 +277,6 @@ func (s *Store) CreateTasksV2(ctx context.Context, creates ...*api.TaskCreate) (
 		return nil, err
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
 	var tasks []*TaskMessage
 	rows, err := tx.QueryContext(ctx, query.String(), values...)
 	if err != nil {
@@ -301,10 +313,6 @@ func (s *Store) CreateTasksV2(ctx context.Context, creates ...*api.TaskCreate) (
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
 	return tasks, nil
 }
 
@@ -27,6 +27,14 @@ func (s *Store) CreateTaskDAGV2(ctx context.Context, create *TaskDAGMessage) err
 	}
 	defer tx.Rollback()
 
+	if err := createTaskDAGImplV2(ctx, tx, create); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func createTaskDAGImplV2(ctx context.Context, tx *Tx, create *TaskDAGMessage) error {
 	query := `
 		INSERT INTO task_dag (
 			from_task_id,
@@ -37,21 +45,14 @@ func (s *Store) CreateTaskDAGV2(ctx context.Context, create *TaskDAGMessage) err
 		RETURNING from_task_id, to_task_id
 	`
 	var taskDAG TaskDAGMessage
-	if err := tx.QueryRowContext(ctx, query,
+	return tx.QueryRowContext(ctx, query,
 		create.FromTaskID,
 		create.ToTaskID,
 		"{}", /* payload */
 	).Scan(
 		&taskDAG.FromTaskID,
 		&taskDAG.ToTaskID,
-	); err != nil {
-		return err
-	}
-	if err != nil {
-		return err
-	}
-
-	return tx.Commit()
+	)
 }
 
 // ListTaskDags lists task dags.
@@ -25,6 +25,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/storage/codec"
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
@@ -225,10 +226,22 @@ func TestSchemaAndDataUpdate(t *testing.T) {
 	err = ctl.waitBackup(backup.DatabaseID, backup.ID)
 	a.NoError(err)
 
+	backups, err := ctl.listBackups(database.ID)
+	a.NoError(err)
+	for _, b := range backups {
+		if b.ID == backup.ID {
+			backup = b
+		}
+	}
+	// The backup file is encrypted and checksummed.
 	backupPath := path.Join(dataDir, backup.Path)
 	backupContent, err := os.ReadFile(backupPath)
 	a.NoError(err)
-	a.Equal(string(backupContent), backupDump)
+	a.NotContains(string(backupContent), backupDump)
+	checksum, err := codec.Checksum(bytes.NewReader(backupContent))
+	a.NoError(err)
+	a.Equal(backup.Payload.Checksum, checksum)
+	a.Equal(int64(len(backupDump)), backup.Payload.DumpSize)
 
 	// Create an issue that creates a database.
 	cloneDatabaseName := "testClone"
@@ -244,9 +244,23 @@ func isMatchExpression(labels map[string]string, expression *api.LabelSelectorRe
 			}
 		}
 		return false
+	case api.NotInOperatorType:
+		value, ok := labels[expression.Key]
+		if !ok {
+			return true
+		}
+		for _, exprValue := range expression.Values {
+			if exprValue == value {
+				return false
+			}
+		}
+		return true
 	case api.ExistsOperatorType:
 		_, ok := labels[expression.Key]
 		return ok
+	case api.DoesNotExistOperatorType:
+		_, ok := labels[expression.Key]
+		return !ok
 	default:
 		return false
 	}
@@ -320,6 +334,105 @@ func GetDatabaseMatrixFromDeploymentSchedule(schedule *api.DeploymentSchedule, d
 	return matrix, nil
 }
 
+// GetRolloutStrategyPendingTasks returns the pending tasks in a stage that are allowed to start under the rollout strategy,
+// and the maximum number of tasks that can be started. A negative limit means no limit.
+// stageTasks should be all the tasks in the stage.
+func GetRolloutStrategyPendingTasks(strategy *store.RolloutStrategy, stageTasks []*store.TaskMessage) ([]*store.TaskMessage, int) {
+	sort.Slice(stageTasks, func(i, j int) bool {
+		return stageTasks[i].ID < stageTasks[j].ID
+	})
+	var pendingTasks []*store.TaskMessage
+	for _, task := range stageTasks {
+		if task.Status == api.TaskPending {
+			pendingTasks = append(pendingTasks, task)
+		}
+	}
+	if strategy == nil {
+		return pendingTasks, -1
+	}
+
+	runningCount, failedCount := 0, 0
+	for _, task := range stageTasks {
+		switch task.Status {
+		case api.TaskRunning:
+			runningCount++
+		case api.TaskFailed:
+			failedCount++
+		}
+	}
+	// Halt the remaining tasks until the failed tasks are retried or skipped.
+	if strategy.FailureThreshold > 0 && failedCount >= strategy.FailureThreshold {
+		return nil, 0
+	}
+
+	// The tasks are created in the order of databases, so the canary databases are the ones of the earliest tasks.
+	// databaseKeyList is the list of databases in the stage in order, and the task ID is used if the task has no database.
+	var databaseKeyList []string
+	databaseKeyToTasks := make(map[string][]*store.TaskMessage)
+	for _, task := range stageTasks {
+		key := fmt.Sprintf("task/%d", task.ID)
+		if task.DatabaseID != nil {
+			key = fmt.Sprintf("database/%d", *task.DatabaseID)
+		}
+		if _, ok := databaseKeyToTasks[key]; !ok {
+			databaseKeyList = append(databaseKeyList, key)
+		}
+		databaseKeyToTasks[key] = append(databaseKeyToTasks[key], task)
+	}
+	canaryCount := getCanaryDatabaseCount(strategy, len(databaseKeyList))
+	if canaryCount > 0 {
+		var canaryPendingTasks []*store.TaskMessage
+		canaryDone := true
+		// verifiedCount is the number of canary databases whose tasks are all done.
+		verifiedCount := 0
+		for _, key := range databaseKeyList[:canaryCount] {
+			verified := true
+			for _, task := range databaseKeyToTasks[key] {
+				// Skipped tasks are DONE, and canceled tasks will never run, so both of them finish the canary.
+				if task.Status != api.TaskDone && task.Status != api.TaskCanceled {
+					canaryDone = false
+				}
+				if task.Status != api.TaskDone {
+					verified = false
+				}
+				if task.Status == api.TaskPending {
+					canaryPendingTasks = append(canaryPendingTasks, task)
+				}
+			}
+			if verified {
+				verifiedCount++
+			}
+		}
+		// The rest databases will not start until all the canary databases are done or canceled,
+		// and at least one canary database is done so that the change is verified on it.
+		if !canaryDone || verifiedCount == 0 {
+			pendingTasks = canaryPendingTasks
+		}
+	}
+
+	if strategy.MaxParallelism <= 0 {
+		return pendingTasks, -1
+	}
+	limit := strategy.MaxParallelism - runningCount
+	if limit < 0 {
+		limit = 0
+	}
+	return pendingTasks, limit
+}
+
+// getCanaryDatabaseCount returns the number of canary databases among the databases in the stage.
+// At least one database is chosen if the canary percentage is set.
+func getCanaryDatabaseCount(strategy *store.RolloutStrategy, databaseCount int) int {
+	count := strategy.CanaryCount
+	if strategy.CanaryPercentage > 0 {
+		count = (databaseCount*strategy.CanaryPercentage + 99) / 100
+	}
+	if count > databaseCount {
+		count = databaseCount
+	}
+	return count
+}
+
 // RefreshToken is a token refresher that stores the latest access token configuration to repository.
 func RefreshToken(ctx context.Context, store *store.Store, webURL string) common.TokenRefresher {
 	return func(token, refreshToken string, expiresTs int64) error {
@@ -401,8 +514,8 @@ func PassAllCheck(task *store.TaskMessage, allowedStatus api.TaskCheckStatus, ta
 			runs = append(runs, run)
 		}
 	}
-	// schema update, data update and gh-ost sync task have required task check.
-	if task.Type == api.TaskDatabaseSchemaUpdate || task.Type == api.TaskDatabaseSchemaUpdateSDL || task.Type == api.TaskDatabaseDataUpdate || task.Type == api.TaskDatabaseSchemaUpdateGhostSync {
+	// schema update, data update, gh-ost sync and PostgreSQL online sync task have required task check.
+	if task.Type == api.TaskDatabaseSchemaUpdate || task.Type == api.TaskDatabaseSchemaUpdateSDL || task.Type == api.TaskDatabaseDataUpdate || task.Type == api.TaskDatabaseSchemaUpdateGhostSync || task.Type == api.TaskDatabaseSchemaUpdatePGOnlineSync {
 		pass, err := passCheck(runs, api.TaskCheckDatabaseConnect, allowedStatus)
 		if err != nil {
 			return false, err
@@ -845,3 +958,49 @@ func GetSecretMapFromDatabaseMessage(databaseMessage *store.DatabaseMessage) map
 	}
 	return materials
 }
+
+// SheetInfo represents the sheet related information from sheetPathTemplate.
+type SheetInfo struct {
+	EnvironmentID string
+	DatabaseName  string
+	SheetName     string
+}
+
+// ParseSheetInfo matches sheetPath against sheetPathTemplate. If sheetPath matches, then it will derive SheetInfo from the sheetPath.
+// Both sheetPath and sheetPathTemplate are the full file path(including the base directory) of the repository.
+func ParseSheetInfo(sheetPath string, sheetPathTemplate string) (*SheetInfo, error) {
+	placeholderList := []string{
+		"ENV_ID",
+		"DB_NAME",
+		"NAME",
+	}
+	sheetPathRegex := sheetPathTemplate
+	for _, placeholder := range placeholderList {
+		sheetPathRegex = strings.ReplaceAll(sheetPathRegex, fmt.Sprintf("{{%s}}", placeholder), fmt.Sprintf("(?P<%s>[a-zA-Z0-9\\+\\-\\=\\_\\#\\!\\$\\. ]+)", placeholder))
+	}
+	sheetRegex, err := regexp.Compile(fmt.Sprintf("^%s$", sheetPathRegex))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid sheet path template %q", sheetPathTemplate)
+	}
+	if !sheetRegex.MatchString(sheetPath) {
+		return nil, errors.Errorf("sheet path %q does not match sheet path template %q", sheetPath, sheetPathTemplate)
+	}
+
+	matchList := sheetRegex.FindStringSubmatch(sheetPath)
+	sheetInfo := &SheetInfo{}
+	for _, placeholder := range placeholderList {
+		index := sheetRegex.SubexpIndex(placeholder)
+		if index >= 0 {
+			switch placeholder {
+			case "ENV_ID":
+				sheetInfo.EnvironmentID = matchList[index]
+			case "DB_NAME":
+				sheetInfo.DatabaseName = matchList[index]
+			case "NAME":
+				sheetInfo.SheetName = matchList[index]
+			}
+		}
+	}
+
+	return sheetInfo, nil
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -253,6 +254,49 @@ func TestGetDatabaseMatrixFromDeploymentSchedule(t *testing.T) {
 				{dbs[5], dbs[6]},
 			},
 		},
+		{
+			"NotIn and DoesNotExist operators",
+			&api.DeploymentSchedule{
+				Deployments: []*api.Deployment{
+					{
+						Spec: &api.DeploymentSpec{
+							Selector: &api.LabelSelector{
+								MatchExpressions: []*api.LabelSelectorRequirement{
+									{
+										Key:      "bb.location",
+										Operator: "NotIn",
+										Values:   []string{"earth"},
+									},
+									{
+										Key:      "bb.tenant",
+										Operator: "Exists",
+									},
+								},
+							},
+						},
+					},
+					{
+						Spec: &api.DeploymentSpec{
+							Selector: &api.LabelSelector{
+								MatchExpressions: []*api.LabelSelectorRequirement{
+									{
+										Key:      "bb.tenant",
+										Operator: "DoesNotExist",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			[]*store.DatabaseMessage{
+				dbs[0], dbs[1], dbs[2], dbs[3], dbs[4],
+			},
+			[][]*store.DatabaseMessage{
+				{dbs[0], dbs[2]},
+				{dbs[3], dbs[4]},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -261,6 +305,101 @@ func TestGetDatabaseMatrixFromDeploymentSchedule(t *testing.T) {
 	}
 }
 
+func TestGetRolloutStrategyPendingTasks(t *testing.T) {
+	newTask := func(id int, databaseID int, status api.TaskStatus) *store.TaskMessage {
+		return &store.TaskMessage{ID: id, DatabaseID: &databaseID, Status: status}
+	}
+
+	tests := []struct {
+		name      string
+		strategy  *store.RolloutStrategy
+		tasks     []*store.TaskMessage
+		wantIDs   []int
+		wantLimit int
+	}{
+		{
+			"No strategy",
+			nil,
+			[]*store.TaskMessage{newTask(2, 2, api.TaskPending), newTask(1, 1, api.TaskPending), newTask(3, 3, api.TaskFailed)},
+			[]int{1, 2},
+			-1,
+		},
+		{
+			"Canary databases are not done",
+			&store.RolloutStrategy{CanaryCount: 2},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskDone), newTask(2, 2, api.TaskRunning), newTask(3, 3, api.TaskPending), newTask(4, 4, api.TaskPending)},
+			nil,
+			-1,
+		},
+		{
+			"Canary databases are done",
+			&store.RolloutStrategy{CanaryPercentage: 50},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskDone), newTask(2, 2, api.TaskDone), newTask(3, 3, api.TaskPending), newTask(4, 4, api.TaskPending)},
+			[]int{3, 4},
+			-1,
+		},
+		{
+			"Canary databases are done or canceled",
+			&store.RolloutStrategy{CanaryCount: 2},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskDone), newTask(2, 2, api.TaskCanceled), newTask(3, 3, api.TaskPending)},
+			[]int{3},
+			-1,
+		},
+		{
+			"Canary databases are all canceled",
+			&store.RolloutStrategy{CanaryCount: 2},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskCanceled), newTask(2, 2, api.TaskCanceled), newTask(3, 3, api.TaskPending), newTask(4, 4, api.TaskPending)},
+			nil,
+			-1,
+		},
+		{
+			"Canary database with done and canceled tasks",
+			&store.RolloutStrategy{CanaryCount: 1},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskDone), newTask(2, 1, api.TaskCanceled), newTask(3, 2, api.TaskPending)},
+			nil,
+			-1,
+		},
+		{
+			"Canary database with multiple tasks",
+			&store.RolloutStrategy{CanaryPercentage: 10},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskDone), newTask(2, 1, api.TaskPending), newTask(3, 2, api.TaskPending), newTask(4, 3, api.TaskPending)},
+			[]int{2},
+			-1,
+		},
+		{
+			"Max parallelism",
+			&store.RolloutStrategy{MaxParallelism: 3},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskRunning), newTask(2, 2, api.TaskPending), newTask(3, 3, api.TaskPending), newTask(4, 4, api.TaskPending)},
+			[]int{2, 3, 4},
+			2,
+		},
+		{
+			"Failure threshold reached",
+			&store.RolloutStrategy{FailureThreshold: 2},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskFailed), newTask(2, 2, api.TaskFailed), newTask(3, 3, api.TaskPending)},
+			nil,
+			0,
+		},
+		{
+			"Failure threshold not reached",
+			&store.RolloutStrategy{FailureThreshold: 2, MaxParallelism: 1},
+			[]*store.TaskMessage{newTask(1, 1, api.TaskFailed), newTask(2, 2, api.TaskRunning), newTask(3, 3, api.TaskPending)},
+			[]int{3},
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		tasks, limit := GetRolloutStrategyPendingTasks(test.strategy, test.tasks)
+		var ids []int
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		require.Equal(t, test.wantIDs, ids, test.name)
+		require.Equal(t, test.wantLimit, limit, test.name)
+	}
+}
+
 func TestMergeTaskCreateLists(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -417,3 +556,88 @@ func TestGetRenderedStatement(t *testing.T) {
 		assert.Equal(t, tc.expected, actual)
 	}
 }
+
+func TestParseSheetInfo(t *testing.T) {
+	tests := []struct {
+		filePath          string
+		sheetPathTemplate string
+		want              *SheetInfo
+		err               error
+	}{
+		{
+			filePath:          "sheet/test.sql",
+			sheetPathTemplate: "sheet/{{NAME}}.sql",
+			want: &SheetInfo{
+				EnvironmentID: "",
+				DatabaseName:  "",
+				SheetName:     "test",
+			},
+			err: nil,
+		},
+		{
+			filePath:          "sheet/dev##TEST##test.sql",
+			sheetPathTemplate: "sheet/{{ENV_ID}}##{{DB_NAME}}##{{NAME}}.sql",
+			want: &SheetInfo{
+				EnvironmentID: "dev",
+				DatabaseName:  "TEST",
+				SheetName:     "test",
+			},
+			err: nil,
+		},
+		{
+			filePath:          "sheet/dev##test.sql",
+			sheetPathTemplate: "sheet/{{ENV_ID}}##{{NAME}}.sql",
+			want: &SheetInfo{
+				EnvironmentID: "dev",
+				DatabaseName:  "",
+				SheetName:     "test",
+			},
+			err: nil,
+		},
+		{
+			filePath:          "sheet/employee##test.sql",
+			sheetPathTemplate: "sheet/{{DB_NAME}}##{{NAME}}.sql",
+			want: &SheetInfo{
+				EnvironmentID: "",
+				DatabaseName:  "employee",
+				SheetName:     "test",
+			},
+			err: nil,
+		},
+		{
+			filePath:          "sheet/db-name.sql",
+			sheetPathTemplate: "sheet/{{DB_NAME}}.sql",
+			want: &SheetInfo{
+				EnvironmentID: "",
+				DatabaseName:  "db-name",
+				SheetName:     "",
+			},
+			err: nil,
+		},
+		{
+			filePath:          "sheet/db/test.sql",
+			sheetPathTemplate: "sheet/{{NAME}}.sql",
+			want:              nil,
+			err:               errors.Errorf("sheet path \"sheet/db/test.sql\" does not match sheet path template \"sheet/{{NAME}}.sql\""),
+		},
+		{
+			filePath:          "my-sheet/test.sql",
+			sheetPathTemplate: "sheet/{{NAME}}.sql",
+			want:              nil,
+			err:               errors.Errorf("sheet path \"my-sheet/test.sql\" does not match sheet path template \"sheet/{{NAME}}.sql\""),
+		},
+	}
+
+	for _, test := range tests {
+		result, err := ParseSheetInfo(test.filePath, test.sheetPathTemplate)
+		if err != nil {
+			if test.err != nil {
+				require.Equal(t, test.err.Error(), err.Error())
+			} else {
+				t.Error(err)
+			}
+		} else {
+			require.Equal(t, test.want, result)
+		}
+	}
+}
@@ -69,6 +69,9 @@ export const useRollbackLogic = () => {
       case "ORACLE":
         // We don't have a check for oracle similar to the MySQL version check.
         break;
+      case "POSTGRES":
+        // The changed rows are backed up by Bytebase, no engine version requirement.
+        break;
       default:
         return "NONE";
     }
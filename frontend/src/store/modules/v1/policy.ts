@@ -7,6 +7,7 @@ import {
   PolicyResourceType,
   policyTypeToJSON,
   BackupPlanSchedule,
+  BackupStorageBackend,
   ApprovalStrategy,
 } from "@/types/proto/v1/org_policy_service";
 import { MaybeRef, UNKNOWN_ID } from "@/types";
@@ -246,6 +247,8 @@ export const getDefaultBackupPlanPolicy = (
     enforce: true,
     backupPlanPolicy: {
       schedule: defaultBackupSchedule,
+      storageBackend: BackupStorageBackend.BACKUP_STORAGE_BACKEND_UNSPECIFIED,
+      storage: "",
     },
     state: State.ACTIVE,
   };
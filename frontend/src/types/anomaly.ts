@@ -15,6 +15,7 @@ export type AnomalyType =
   | "bb.anomaly.instance.migration-schema"
   | "bb.anomaly.database.backup.policy-violation"
   | "bb.anomaly.database.backup.missing"
+  | "bb.anomaly.database.backup.drill-failed"
   | "bb.anomaly.database.connection"
   | "bb.anomaly.database.schema.drift";
 
@@ -33,6 +34,12 @@ export type AnomalyDatabaseBackupMissingPayload = {
   lastBackupTs: number;
 };
 
+export type AnomalyDatabaseBackupDrillFailedPayload = {
+  backupId: number;
+  backupName: string;
+  detail: string;
+};
+
 export type AnomalyDatabaseConnectionPayload = {
   detail: string;
 };
@@ -46,6 +53,7 @@ export type AnomalyDatabaseSchemaDriftPayload = {
 export type AnomalyPayload =
   | AnomalyDatabaseBackupPolicyViolationPayload
   | AnomalyDatabaseBackupMissingPayload
+  | AnomalyDatabaseBackupDrillFailedPayload
   | AnomalyDatabaseConnectionPayload
   | AnomalyDatabaseSchemaDriftPayload;
 
@@ -281,6 +281,8 @@ export type TaskCheckResult = {
   line: number | undefined;
   namespace: TaskCheckNamespace;
   details?: string;
+  suppressed?: boolean;
+  suppressReason?: string;
 };
 
 export type TaskCheckRunResultPayload = {
@@ -0,0 +1,413 @@
+/* eslint-disable */
+import * as _m0 from "protobufjs/minimal";
+
+export const protobufPackage = "bytebase.store";
+
+export interface PlanConfig {
+  steps: PlanConfig_Step[];
+}
+
+export interface PlanConfig_Step {
+  specs: PlanConfig_Spec[];
+}
+
+export interface PlanConfig_Spec {
+  /** A UUID4 string that uniquely identifies the Spec. */
+  id: string;
+  changeDatabaseConfig?: PlanConfig_ChangeDatabaseConfig | undefined;
+}
+
+export interface PlanConfig_ChangeDatabaseConfig {
+  /**
+   * The resource name of the target.
+   * Format: instances/{instance}/databases/{database}
+   */
+  target: string;
+  /**
+   * The resource name of the sheet.
+   * Format: projects/{project}/sheets/{sheet}
+   */
+  sheet: string;
+  type: PlanConfig_ChangeDatabaseConfig_Type;
+  /**
+   * schema_version is parsed from VCS file name.
+   * It is automatically generated in the UI workflow.
+   */
+  schemaVersion: string;
+  /** If rollback_enabled, build the rollback SQL statement of the DATA task. */
+  rollbackEnabled: boolean;
+}
+
+/** Type is the database change type. */
+export enum PlanConfig_ChangeDatabaseConfig_Type {
+  TYPE_UNSPECIFIED = 0,
+  /** BASELINE - Used for establishing schema baseline. */
+  BASELINE = 1,
+  /** MIGRATE - Used for DDL changes including CREATE DATABASE. */
+  MIGRATE = 2,
+  /** MIGRATE_SDL - Used for schema changes via state-based schema migration including CREATE DATABASE. */
+  MIGRATE_SDL = 3,
+  /** DATA - Used for DML change. */
+  DATA = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function planConfig_ChangeDatabaseConfig_TypeFromJSON(object: any): PlanConfig_ChangeDatabaseConfig_Type {
+  switch (object) {
+    case 0:
+    case "TYPE_UNSPECIFIED":
+      return PlanConfig_ChangeDatabaseConfig_Type.TYPE_UNSPECIFIED;
+    case 1:
+    case "BASELINE":
+      return PlanConfig_ChangeDatabaseConfig_Type.BASELINE;
+    case 2:
+    case "MIGRATE":
+      return PlanConfig_ChangeDatabaseConfig_Type.MIGRATE;
+    case 3:
+    case "MIGRATE_SDL":
+      return PlanConfig_ChangeDatabaseConfig_Type.MIGRATE_SDL;
+    case 4:
+    case "DATA":
+      return PlanConfig_ChangeDatabaseConfig_Type.DATA;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return PlanConfig_ChangeDatabaseConfig_Type.UNRECOGNIZED;
+  }
+}
+
+export function planConfig_ChangeDatabaseConfig_TypeToJSON(object: PlanConfig_ChangeDatabaseConfig_Type): string {
+  switch (object) {
+    case PlanConfig_ChangeDatabaseConfig_Type.TYPE_UNSPECIFIED:
+      return "TYPE_UNSPECIFIED";
+    case PlanConfig_ChangeDatabaseConfig_Type.BASELINE:
+      return "BASELINE";
+    case PlanConfig_ChangeDatabaseConfig_Type.MIGRATE:
+      return "MIGRATE";
+    case PlanConfig_ChangeDatabaseConfig_Type.MIGRATE_SDL:
+      return "MIGRATE_SDL";
+    case PlanConfig_ChangeDatabaseConfig_Type.DATA:
+      return "DATA";
+    case PlanConfig_ChangeDatabaseConfig_Type.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+function createBasePlanConfig(): PlanConfig {
+  return { steps: [] };
+}
+
+export const PlanConfig = {
+  encode(message: PlanConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.steps) {
+      PlanConfig_Step.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.steps.push(PlanConfig_Step.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanConfig {
+    return { steps: Array.isArray(object?.steps) ? object.steps.map((e: any) => PlanConfig_Step.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: PlanConfig): unknown {
+    const obj: any = {};
+    if (message.steps) {
+      obj.steps = message.steps.map((e) => e ? PlanConfig_Step.toJSON(e) : undefined);
+    } else {
+      obj.steps = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanConfig>): PlanConfig {
+    return PlanConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PlanConfig>): PlanConfig {
+    const message = createBasePlanConfig();
+    message.steps = object.steps?.map((e) => PlanConfig_Step.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBasePlanConfig_Step(): PlanConfig_Step {
+  return { specs: [] };
+}
+
+export const PlanConfig_Step = {
+  encode(message: PlanConfig_Step, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.specs) {
+      PlanConfig_Spec.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanConfig_Step {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanConfig_Step();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.specs.push(PlanConfig_Spec.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanConfig_Step {
+    return { specs: Array.isArray(object?.specs) ? object.specs.map((e: any) => PlanConfig_Spec.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: PlanConfig_Step): unknown {
+    const obj: any = {};
+    if (message.specs) {
+      obj.specs = message.specs.map((e) => e ? PlanConfig_Spec.toJSON(e) : undefined);
+    } else {
+      obj.specs = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanConfig_Step>): PlanConfig_Step {
+    return PlanConfig_Step.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PlanConfig_Step>): PlanConfig_Step {
+    const message = createBasePlanConfig_Step();
+    message.specs = object.specs?.map((e) => PlanConfig_Spec.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBasePlanConfig_Spec(): PlanConfig_Spec {
+  return { id: "", changeDatabaseConfig: undefined };
+}
+
+export const PlanConfig_Spec = {
+  encode(message: PlanConfig_Spec, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.changeDatabaseConfig !== undefined) {
+      PlanConfig_ChangeDatabaseConfig.encode(message.changeDatabaseConfig, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanConfig_Spec {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanConfig_Spec();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.changeDatabaseConfig = PlanConfig_ChangeDatabaseConfig.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanConfig_Spec {
+    return {
+      id: isSet(object.id) ? String(object.id) : "",
+      changeDatabaseConfig: isSet(object.changeDatabaseConfig)
+        ? PlanConfig_ChangeDatabaseConfig.fromJSON(object.changeDatabaseConfig)
+        : undefined,
+    };
+  },
+
+  toJSON(message: PlanConfig_Spec): unknown {
+    const obj: any = {};
+    message.id !== undefined && (obj.id = message.id);
+    message.changeDatabaseConfig !== undefined && (obj.changeDatabaseConfig = message.changeDatabaseConfig
+      ? PlanConfig_ChangeDatabaseConfig.toJSON(message.changeDatabaseConfig)
+      : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanConfig_Spec>): PlanConfig_Spec {
+    return PlanConfig_Spec.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PlanConfig_Spec>): PlanConfig_Spec {
+    const message = createBasePlanConfig_Spec();
+    message.id = object.id ?? "";
+    message.changeDatabaseConfig = (object.changeDatabaseConfig !== undefined && object.changeDatabaseConfig !== null)
+      ? PlanConfig_ChangeDatabaseConfig.fromPartial(object.changeDatabaseConfig)
+      : undefined;
+    return message;
+  },
+};
+
+function createBasePlanConfig_ChangeDatabaseConfig(): PlanConfig_ChangeDatabaseConfig {
+  return { target: "", sheet: "", type: 0, schemaVersion: "", rollbackEnabled: false };
+}
+
+export const PlanConfig_ChangeDatabaseConfig = {
+  encode(message: PlanConfig_ChangeDatabaseConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.target !== "") {
+      writer.uint32(10).string(message.target);
+    }
+    if (message.sheet !== "") {
+      writer.uint32(18).string(message.sheet);
+    }
+    if (message.type !== 0) {
+      writer.uint32(24).int32(message.type);
+    }
+    if (message.schemaVersion !== "") {
+      writer.uint32(34).string(message.schemaVersion);
+    }
+    if (message.rollbackEnabled === true) {
+      writer.uint32(40).bool(message.rollbackEnabled);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanConfig_ChangeDatabaseConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanConfig_ChangeDatabaseConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.target = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.sheet = reader.string();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.rollbackEnabled = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanConfig_ChangeDatabaseConfig {
+    return {
+      target: isSet(object.target) ? String(object.target) : "",
+      sheet: isSet(object.sheet) ? String(object.sheet) : "",
+      type: isSet(object.type) ? planConfig_ChangeDatabaseConfig_TypeFromJSON(object.type) : 0,
+      schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "",
+      rollbackEnabled: isSet(object.rollbackEnabled) ? Boolean(object.rollbackEnabled) : false,
+    };
+  },
+
+  toJSON(message: PlanConfig_ChangeDatabaseConfig): unknown {
+    const obj: any = {};
+    message.target !== undefined && (obj.target = message.target);
+    message.sheet !== undefined && (obj.sheet = message.sheet);
+    message.type !== undefined && (obj.type = planConfig_ChangeDatabaseConfig_TypeToJSON(message.type));
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    message.rollbackEnabled !== undefined && (obj.rollbackEnabled = message.rollbackEnabled);
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanConfig_ChangeDatabaseConfig>): PlanConfig_ChangeDatabaseConfig {
+    return PlanConfig_ChangeDatabaseConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PlanConfig_ChangeDatabaseConfig>): PlanConfig_ChangeDatabaseConfig {
+    const message = createBasePlanConfig_ChangeDatabaseConfig();
+    message.target = object.target ?? "";
+    message.sheet = object.sheet ?? "";
+    message.type = object.type ?? 0;
+    message.schemaVersion = object.schemaVersion ?? "";
+    message.rollbackEnabled = object.rollbackEnabled ?? false;
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin ? T
+  : T extends Array<infer U> ? Array<DeepPartial<U>> : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
+  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -59,6 +59,7 @@ export interface Anomaly {
   databaseBackupPolicyViolationDetail?: Anomaly_DatabaseBackupPolicyViolationDetail | undefined;
   databaseBackupMissingDetail?: Anomaly_DatabaseBackupMissingDetail | undefined;
   databaseSchemaDriftDetail?: Anomaly_DatabaseSchemaDriftDetail | undefined;
+  databaseBackupDrillFailedDetail?: Anomaly_DatabaseBackupDrillFailedDetail | undefined;
 }
 
 /** AnomalyType is the type of the anomaly. */
@@ -89,6 +90,11 @@ export enum Anomaly_AnomalyType {
    * e.g. the database schema had been changed without bytebase migration.
    */
   DATABASE_SCHEMA_DRIFT = 6,
+  /**
+   * DATABASE_BACKUP_DRILL_FAILED - DATABASE_BACKUP_DRILL_FAILED is the anomaly type for the failed backup restore drill,
+   * e.g. the latest backup cannot be restored to a scratch database.
+   */
+  DATABASE_BACKUP_DRILL_FAILED = 7,
   UNRECOGNIZED = -1,
 }
 
@@ -115,6 +121,9 @@ export function anomaly_AnomalyTypeFromJSON(object: any): Anomaly_AnomalyType {
     case 6:
     case "DATABASE_SCHEMA_DRIFT":
       return Anomaly_AnomalyType.DATABASE_SCHEMA_DRIFT;
+    case 7:
+    case "DATABASE_BACKUP_DRILL_FAILED":
+      return Anomaly_AnomalyType.DATABASE_BACKUP_DRILL_FAILED;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -138,6 +147,8 @@ export function anomaly_AnomalyTypeToJSON(object: Anomaly_AnomalyType): string {
       return "DATABASE_CONNECTION";
     case Anomaly_AnomalyType.DATABASE_SCHEMA_DRIFT:
       return "DATABASE_SCHEMA_DRIFT";
+    case Anomaly_AnomalyType.DATABASE_BACKUP_DRILL_FAILED:
+      return "DATABASE_BACKUP_DRILL_FAILED";
     case Anomaly_AnomalyType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -295,6 +306,14 @@ export interface Anomaly_DatabaseSchemaDriftDetail {
   actualSchema: string;
 }
 
+/** DatabaseBackupDrillFailedDetail is the detail for database backup drill failed anomaly. */
+export interface Anomaly_DatabaseBackupDrillFailedDetail {
+  /** backup is the name of the drilled backup. */
+  backup: string;
+  /** detail is the detail of the drill failure. */
+  detail: string;
+}
+
 function createBaseSearchAnomaliesRequest(): SearchAnomaliesRequest {
   return { filter: "", pageSize: 0, pageToken: "" };
 }
@@ -464,6 +483,7 @@ function createBaseAnomaly(): Anomaly {
     databaseBackupPolicyViolationDetail: undefined,
     databaseBackupMissingDetail: undefined,
     databaseSchemaDriftDetail: undefined,
+    databaseBackupDrillFailedDetail: undefined,
   };
 }
 
@@ -497,6 +517,10 @@ export const Anomaly = {
     if (message.databaseSchemaDriftDetail !== undefined) {
       Anomaly_DatabaseSchemaDriftDetail.encode(message.databaseSchemaDriftDetail, writer.uint32(66).fork()).ldelim();
     }
+    if (message.databaseBackupDrillFailedDetail !== undefined) {
+      Anomaly_DatabaseBackupDrillFailedDetail.encode(message.databaseBackupDrillFailedDetail, writer.uint32(74).fork())
+        .ldelim();
+    }
     return writer;
   },
 
@@ -566,6 +590,16 @@ export const Anomaly = {
 
           message.databaseSchemaDriftDetail = Anomaly_DatabaseSchemaDriftDetail.decode(reader, reader.uint32());
           continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.databaseBackupDrillFailedDetail = Anomaly_DatabaseBackupDrillFailedDetail.decode(
+            reader,
+            reader.uint32(),
+          );
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -595,6 +629,9 @@ export const Anomaly = {
       databaseSchemaDriftDetail: isSet(object.databaseSchemaDriftDetail)
         ? Anomaly_DatabaseSchemaDriftDetail.fromJSON(object.databaseSchemaDriftDetail)
         : undefined,
+      databaseBackupDrillFailedDetail: isSet(object.databaseBackupDrillFailedDetail)
+        ? Anomaly_DatabaseBackupDrillFailedDetail.fromJSON(object.databaseBackupDrillFailedDetail)
+        : undefined,
     };
   },
 
@@ -621,6 +658,10 @@ export const Anomaly = {
       (obj.databaseSchemaDriftDetail = message.databaseSchemaDriftDetail
         ? Anomaly_DatabaseSchemaDriftDetail.toJSON(message.databaseSchemaDriftDetail)
         : undefined);
+    message.databaseBackupDrillFailedDetail !== undefined &&
+      (obj.databaseBackupDrillFailedDetail = message.databaseBackupDrillFailedDetail
+        ? Anomaly_DatabaseBackupDrillFailedDetail.toJSON(message.databaseBackupDrillFailedDetail)
+        : undefined);
     return obj;
   },
 
@@ -653,6 +694,10 @@ export const Anomaly = {
       (object.databaseSchemaDriftDetail !== undefined && object.databaseSchemaDriftDetail !== null)
         ? Anomaly_DatabaseSchemaDriftDetail.fromPartial(object.databaseSchemaDriftDetail)
         : undefined;
+    message.databaseBackupDrillFailedDetail =
+      (object.databaseBackupDrillFailedDetail !== undefined && object.databaseBackupDrillFailedDetail !== null)
+        ? Anomaly_DatabaseBackupDrillFailedDetail.fromPartial(object.databaseBackupDrillFailedDetail)
+        : undefined;
     return message;
   },
 };
@@ -1017,6 +1062,77 @@ export const Anomaly_DatabaseSchemaDriftDetail = {
   },
 };
 
+function createBaseAnomaly_DatabaseBackupDrillFailedDetail(): Anomaly_DatabaseBackupDrillFailedDetail {
+  return { backup: "", detail: "" };
+}
+
+export const Anomaly_DatabaseBackupDrillFailedDetail = {
+  encode(message: Anomaly_DatabaseBackupDrillFailedDetail, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.backup !== "") {
+      writer.uint32(10).string(message.backup);
+    }
+    if (message.detail !== "") {
+      writer.uint32(18).string(message.detail);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Anomaly_DatabaseBackupDrillFailedDetail {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAnomaly_DatabaseBackupDrillFailedDetail();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.backup = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.detail = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Anomaly_DatabaseBackupDrillFailedDetail {
+    return {
+      backup: isSet(object.backup) ? String(object.backup) : "",
+      detail: isSet(object.detail) ? String(object.detail) : "",
+    };
+  },
+
+  toJSON(message: Anomaly_DatabaseBackupDrillFailedDetail): unknown {
+    const obj: any = {};
+    message.backup !== undefined && (obj.backup = message.backup);
+    message.detail !== undefined && (obj.detail = message.detail);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Anomaly_DatabaseBackupDrillFailedDetail>): Anomaly_DatabaseBackupDrillFailedDetail {
+    return Anomaly_DatabaseBackupDrillFailedDetail.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Anomaly_DatabaseBackupDrillFailedDetail>): Anomaly_DatabaseBackupDrillFailedDetail {
+    const message = createBaseAnomaly_DatabaseBackupDrillFailedDetail();
+    message.backup = object.backup ?? "";
+    message.detail = object.detail ?? "";
+    return message;
+  },
+};
+
 export type AnomalyServiceDefinition = typeof AnomalyServiceDefinition;
 export const AnomalyServiceDefinition = {
   name: "AnomalyService",
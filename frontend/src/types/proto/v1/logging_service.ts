@@ -15,27 +15,35 @@ export interface ListLogsRequest {
    */
   parent: string;
   /**
-   * filter is the filter to apply on the list logs request,
+   * The filter of the log.
    * follow the [ebnf](https://en.wikipedia.org/wiki/Extended_Backus%E2%80%93Naur_form) syntax.
-   * The field only support in filter:
-   * - creator
-   * - container
-   * - level
-   * - action
+   * Supported filter keys:
+   * - creator: the creator of the log entry, e.g. `creator = "user:{email}"`.
+   * - resource: the resource of the log entry, e.g. `resource = "issues/{issue}"`.
+   *   Supported resource formats are the same as the `resource_name` in `LogEntry`.
+   * - action: the action of the log entry, e.g. `action = "ACTION_ISSUE_CREATE"`.
+   * - level: the level of the log entry, e.g. `level = "LEVEL_ERROR"`.
+   * - create_time: the create time of the log entry, support `>`, `>=`, `<` and `<=`,
+   *   e.g. `create_time >= "2023-01-01T00:00:00Z"`.
+   *   Should use [RFC-3339 format](https://www.rfc-editor.org/rfc/rfc3339).
+   * Multiple expressions can be combined with `&&`.
    * For example:
-   * List the logs of type 'ACTION_ISSUE_COMMENT_CREATE' in issue/123: 'action="ACTION_ISSUE_COMMENT_CREATE", container="issue/123"'
+   * List the logs of type 'ACTION_ISSUE_COMMENT_CREATE' in issues/123: 'action = "ACTION_ISSUE_COMMENT_CREATE" && resource = "issues/123"'
    */
   filter: string;
   /**
-   * Not used. The maximum number of logs to return.
+   * The maximum number of logs to return.
    * The service may return fewer than this value.
-   * If unspecified, at most 100 log entries will be returned.
+   * If unspecified, at most 50 log entries will be returned.
    * The maximum value is 1000; values above 1000 will be coerced to 1000.
    */
   pageSize: number;
   /**
-   * Not used. A page token, received from a previous `ListLogs` call.
+   * A page token, received from a previous `ListLogs` call.
    * Provide this to retrieve the subsequent page.
+   *
+   * When paginating, all other parameters provided to `ListLogs` must match
+   * the call that provided the page token.
    */
   pageToken: string;
 }
@@ -75,6 +83,8 @@ export interface LogEntry {
   resourceName: string;
   /** The payload of the log entry. */
   jsonPayload?: { [key: string]: any };
+  /** The human readable comment of the log entry. */
+  comment: string;
 }
 
 export enum LogEntry_Action {
@@ -487,6 +497,7 @@ function createBaseLogEntry(): LogEntry {
     level: 0,
     resourceName: "",
     jsonPayload: undefined,
+    comment: "",
   };
 }
 
@@ -513,6 +524,9 @@ export const LogEntry = {
     if (message.jsonPayload !== undefined) {
       Struct.encode(Struct.wrap(message.jsonPayload), writer.uint32(58).fork()).ldelim();
     }
+    if (message.comment !== "") {
+      writer.uint32(66).string(message.comment);
+    }
     return writer;
   },
 
@@ -572,6 +586,13 @@ export const LogEntry = {
 
           message.jsonPayload = Struct.unwrap(Struct.decode(reader, reader.uint32()));
           continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.comment = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -590,6 +611,7 @@ export const LogEntry = {
       level: isSet(object.level) ? logEntry_LevelFromJSON(object.level) : 0,
       resourceName: isSet(object.resourceName) ? String(object.resourceName) : "",
       jsonPayload: isObject(object.jsonPayload) ? object.jsonPayload : undefined,
+      comment: isSet(object.comment) ? String(object.comment) : "",
     };
   },
 
@@ -602,6 +624,7 @@ export const LogEntry = {
     message.level !== undefined && (obj.level = logEntry_LevelToJSON(message.level));
     message.resourceName !== undefined && (obj.resourceName = message.resourceName);
     message.jsonPayload !== undefined && (obj.jsonPayload = message.jsonPayload);
+    message.comment !== undefined && (obj.comment = message.comment);
     return obj;
   },
 
@@ -618,6 +641,7 @@ export const LogEntry = {
     message.level = object.level ?? 0;
     message.resourceName = object.resourceName ?? "";
     message.jsonPayload = object.jsonPayload ?? undefined;
+    message.comment = object.comment ?? "";
     return message;
   },
 };
@@ -252,9 +252,56 @@ export function backupPlanScheduleToJSON(object: BackupPlanSchedule): string {
   }
 }
 
+export enum BackupStorageBackend {
+  /** BACKUP_STORAGE_BACKEND_UNSPECIFIED - The backups are stored in the workspace backup storage configured by the server flags. */
+  BACKUP_STORAGE_BACKEND_UNSPECIFIED = 0,
+  /** BACKUP_STORAGE_BACKEND_LOCAL - The backups are stored on the local disk of the server. */
+  BACKUP_STORAGE_BACKEND_LOCAL = 1,
+  UNRECOGNIZED = -1,
+}
+
+export function backupStorageBackendFromJSON(object: any): BackupStorageBackend {
+  switch (object) {
+    case 0:
+    case "BACKUP_STORAGE_BACKEND_UNSPECIFIED":
+      return BackupStorageBackend.BACKUP_STORAGE_BACKEND_UNSPECIFIED;
+    case 1:
+    case "BACKUP_STORAGE_BACKEND_LOCAL":
+      return BackupStorageBackend.BACKUP_STORAGE_BACKEND_LOCAL;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return BackupStorageBackend.UNRECOGNIZED;
+  }
+}
+
+export function backupStorageBackendToJSON(object: BackupStorageBackend): string {
+  switch (object) {
+    case BackupStorageBackend.BACKUP_STORAGE_BACKEND_UNSPECIFIED:
+      return "BACKUP_STORAGE_BACKEND_UNSPECIFIED";
+    case BackupStorageBackend.BACKUP_STORAGE_BACKEND_LOCAL:
+      return "BACKUP_STORAGE_BACKEND_LOCAL";
+    case BackupStorageBackend.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export enum SensitiveDataMaskType {
   MASK_TYPE_UNSPECIFIED = 0,
   DEFAULT = 1,
+  /** FULL - Replace the whole value with asterisks. */
+  FULL = 2,
+  /** PARTIAL - Keep the first and last N characters. */
+  PARTIAL = 3,
+  /** HASH - Replace the value with its salted SHA-256 hash. */
+  HASH = 4,
+  /** RANGE - Replace numbers and dates with the bucket they fall into. */
+  RANGE = 5,
+  /** EMAIL - Keep the first character of the local part and the domain of emails. */
+  EMAIL = 6,
+  /** PHONE - Keep the last four digits of phone numbers. */
+  PHONE = 7,
   UNRECOGNIZED = -1,
 }
 
@@ -266,6 +313,24 @@ export function sensitiveDataMaskTypeFromJSON(object: any): SensitiveDataMaskTyp
     case 1:
     case "DEFAULT":
       return SensitiveDataMaskType.DEFAULT;
+    case 2:
+    case "FULL":
+      return SensitiveDataMaskType.FULL;
+    case 3:
+    case "PARTIAL":
+      return SensitiveDataMaskType.PARTIAL;
+    case 4:
+    case "HASH":
+      return SensitiveDataMaskType.HASH;
+    case 5:
+    case "RANGE":
+      return SensitiveDataMaskType.RANGE;
+    case 6:
+    case "EMAIL":
+      return SensitiveDataMaskType.EMAIL;
+    case 7:
+    case "PHONE":
+      return SensitiveDataMaskType.PHONE;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -279,6 +344,18 @@ export function sensitiveDataMaskTypeToJSON(object: SensitiveDataMaskType): stri
       return "MASK_TYPE_UNSPECIFIED";
     case SensitiveDataMaskType.DEFAULT:
       return "DEFAULT";
+    case SensitiveDataMaskType.FULL:
+      return "FULL";
+    case SensitiveDataMaskType.PARTIAL:
+      return "PARTIAL";
+    case SensitiveDataMaskType.HASH:
+      return "HASH";
+    case SensitiveDataMaskType.RANGE:
+      return "RANGE";
+    case SensitiveDataMaskType.EMAIL:
+      return "EMAIL";
+    case SensitiveDataMaskType.PHONE:
+      return "PHONE";
     case SensitiveDataMaskType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -465,6 +542,14 @@ export interface DeploymentApprovalStrategy {
 export interface BackupPlanPolicy {
   schedule: BackupPlanSchedule;
   retentionDuration?: Duration;
+  /** The storage backend for the backups of databases in the environment. */
+  storageBackend: BackupStorageBackend;
+  /**
+   * The name of the backup storage configured by the --backup-storage-config server flag.
+   * The backups of databases in the environment are stored in the backup storage with its own credentials.
+   * It can only be set if storage_backend is unspecified.
+   */
+  storage: string;
 }
 
 export interface SlowQueryPolicy {
@@ -480,6 +565,21 @@ export interface SensitiveData {
   table: string;
   column: string;
   maskType: SensitiveDataMaskType;
+  /** The option of the mask algorithm, it's only applicable to the PARTIAL, HASH and RANGE types. */
+  maskOption?: SensitiveDataMaskOption;
+}
+
+export interface SensitiveDataMaskOption {
+  /** The number of the leading characters kept by the PARTIAL type. */
+  prefixLength: number;
+  /** The number of the trailing characters kept by the PARTIAL type. */
+  suffixLength: number;
+  /** The salt prepended to the value before hashing by the HASH type, it's required by the HASH type. */
+  salt: string;
+  /** The width of the numeric bucket used by the RANGE type. */
+  bucketSize: number;
+  /** The unit that the dates are truncated to by the RANGE type, one of YEAR, MONTH and DAY. */
+  dateUnit: string;
 }
 
 export interface AccessControlPolicy {
@@ -1408,7 +1508,7 @@ export const DeploymentApprovalStrategy = {
 };
 
 function createBaseBackupPlanPolicy(): BackupPlanPolicy {
-  return { schedule: 0, retentionDuration: undefined };
+  return { schedule: 0, retentionDuration: undefined, storageBackend: 0, storage: "" };
 }
 
 export const BackupPlanPolicy = {
@@ -1419,6 +1519,12 @@ export const BackupPlanPolicy = {
     if (message.retentionDuration !== undefined) {
       Duration.encode(message.retentionDuration, writer.uint32(18).fork()).ldelim();
     }
+    if (message.storageBackend !== 0) {
+      writer.uint32(24).int32(message.storageBackend);
+    }
+    if (message.storage !== "") {
+      writer.uint32(34).string(message.storage);
+    }
     return writer;
   },
 
@@ -1443,6 +1549,20 @@ export const BackupPlanPolicy = {
 
           message.retentionDuration = Duration.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.storageBackend = reader.int32() as any;
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.storage = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1456,6 +1576,8 @@ export const BackupPlanPolicy = {
     return {
       schedule: isSet(object.schedule) ? backupPlanScheduleFromJSON(object.schedule) : 0,
       retentionDuration: isSet(object.retentionDuration) ? Duration.fromJSON(object.retentionDuration) : undefined,
+      storageBackend: isSet(object.storageBackend) ? backupStorageBackendFromJSON(object.storageBackend) : 0,
+      storage: isSet(object.storage) ? String(object.storage) : "",
     };
   },
 
@@ -1464,6 +1586,8 @@ export const BackupPlanPolicy = {
     message.schedule !== undefined && (obj.schedule = backupPlanScheduleToJSON(message.schedule));
     message.retentionDuration !== undefined &&
       (obj.retentionDuration = message.retentionDuration ? Duration.toJSON(message.retentionDuration) : undefined);
+    message.storageBackend !== undefined && (obj.storageBackend = backupStorageBackendToJSON(message.storageBackend));
+    message.storage !== undefined && (obj.storage = message.storage);
     return obj;
   },
 
@@ -1477,6 +1601,8 @@ export const BackupPlanPolicy = {
     message.retentionDuration = (object.retentionDuration !== undefined && object.retentionDuration !== null)
       ? Duration.fromPartial(object.retentionDuration)
       : undefined;
+    message.storageBackend = object.storageBackend ?? 0;
+    message.storage = object.storage ?? "";
     return message;
   },
 };
@@ -1602,7 +1728,7 @@ export const SensitiveDataPolicy = {
 };
 
 function createBaseSensitiveData(): SensitiveData {
-  return { schema: "", table: "", column: "", maskType: 0 };
+  return { schema: "", table: "", column: "", maskType: 0, maskOption: undefined };
 }
 
 export const SensitiveData = {
@@ -1619,6 +1745,9 @@ export const SensitiveData = {
     if (message.maskType !== 0) {
       writer.uint32(32).int32(message.maskType);
     }
+    if (message.maskOption !== undefined) {
+      SensitiveDataMaskOption.encode(message.maskOption, writer.uint32(42).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -1657,6 +1786,13 @@ export const SensitiveData = {
 
           message.maskType = reader.int32() as any;
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.maskOption = SensitiveDataMaskOption.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1672,6 +1808,7 @@ export const SensitiveData = {
       table: isSet(object.table) ? String(object.table) : "",
       column: isSet(object.column) ? String(object.column) : "",
       maskType: isSet(object.maskType) ? sensitiveDataMaskTypeFromJSON(object.maskType) : 0,
+      maskOption: isSet(object.maskOption) ? SensitiveDataMaskOption.fromJSON(object.maskOption) : undefined,
     };
   },
 
@@ -1681,6 +1818,8 @@ export const SensitiveData = {
     message.table !== undefined && (obj.table = message.table);
     message.column !== undefined && (obj.column = message.column);
     message.maskType !== undefined && (obj.maskType = sensitiveDataMaskTypeToJSON(message.maskType));
+    message.maskOption !== undefined &&
+      (obj.maskOption = message.maskOption ? SensitiveDataMaskOption.toJSON(message.maskOption) : undefined);
     return obj;
   },
 
@@ -1694,6 +1833,119 @@ export const SensitiveData = {
     message.table = object.table ?? "";
     message.column = object.column ?? "";
     message.maskType = object.maskType ?? 0;
+    message.maskOption = (object.maskOption !== undefined && object.maskOption !== null)
+      ? SensitiveDataMaskOption.fromPartial(object.maskOption)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseSensitiveDataMaskOption(): SensitiveDataMaskOption {
+  return { prefixLength: 0, suffixLength: 0, salt: "", bucketSize: 0, dateUnit: "" };
+}
+
+export const SensitiveDataMaskOption = {
+  encode(message: SensitiveDataMaskOption, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.prefixLength !== 0) {
+      writer.uint32(8).int32(message.prefixLength);
+    }
+    if (message.suffixLength !== 0) {
+      writer.uint32(16).int32(message.suffixLength);
+    }
+    if (message.salt !== "") {
+      writer.uint32(26).string(message.salt);
+    }
+    if (message.bucketSize !== 0) {
+      writer.uint32(33).double(message.bucketSize);
+    }
+    if (message.dateUnit !== "") {
+      writer.uint32(42).string(message.dateUnit);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SensitiveDataMaskOption {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSensitiveDataMaskOption();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.prefixLength = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.suffixLength = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.salt = reader.string();
+          continue;
+        case 4:
+          if (tag !== 33) {
+            break;
+          }
+
+          message.bucketSize = reader.double();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.dateUnit = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SensitiveDataMaskOption {
+    return {
+      prefixLength: isSet(object.prefixLength) ? Number(object.prefixLength) : 0,
+      suffixLength: isSet(object.suffixLength) ? Number(object.suffixLength) : 0,
+      salt: isSet(object.salt) ? String(object.salt) : "",
+      bucketSize: isSet(object.bucketSize) ? Number(object.bucketSize) : 0,
+      dateUnit: isSet(object.dateUnit) ? String(object.dateUnit) : "",
+    };
+  },
+
+  toJSON(message: SensitiveDataMaskOption): unknown {
+    const obj: any = {};
+    message.prefixLength !== undefined && (obj.prefixLength = Math.round(message.prefixLength));
+    message.suffixLength !== undefined && (obj.suffixLength = Math.round(message.suffixLength));
+    message.salt !== undefined && (obj.salt = message.salt);
+    message.bucketSize !== undefined && (obj.bucketSize = message.bucketSize);
+    message.dateUnit !== undefined && (obj.dateUnit = message.dateUnit);
+    return obj;
+  },
+
+  create(base?: DeepPartial<SensitiveDataMaskOption>): SensitiveDataMaskOption {
+    return SensitiveDataMaskOption.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<SensitiveDataMaskOption>): SensitiveDataMaskOption {
+    const message = createBaseSensitiveDataMaskOption();
+    message.prefixLength = object.prefixLength ?? 0;
+    message.suffixLength = object.suffixLength ?? 0;
+    message.salt = object.salt ?? "";
+    message.bucketSize = object.bucketSize ?? 0;
+    message.dateUnit = object.dateUnit ?? "";
     return message;
   },
 };
@@ -211,6 +211,10 @@ export enum OperatorType {
   OPERATOR_TYPE_IN = 1,
   /** OPERATOR_TYPE_EXISTS - The operator is "Exists". */
   OPERATOR_TYPE_EXISTS = 2,
+  /** OPERATOR_TYPE_NOT_IN - The operator is "NotIn". */
+  OPERATOR_TYPE_NOT_IN = 3,
+  /** OPERATOR_TYPE_DOES_NOT_EXIST - The operator is "DoesNotExist". */
+  OPERATOR_TYPE_DOES_NOT_EXIST = 4,
   UNRECOGNIZED = -1,
 }
 
@@ -225,6 +229,12 @@ export function operatorTypeFromJSON(object: any): OperatorType {
     case 2:
     case "OPERATOR_TYPE_EXISTS":
       return OperatorType.OPERATOR_TYPE_EXISTS;
+    case 3:
+    case "OPERATOR_TYPE_NOT_IN":
+      return OperatorType.OPERATOR_TYPE_NOT_IN;
+    case 4:
+    case "OPERATOR_TYPE_DOES_NOT_EXIST":
+      return OperatorType.OPERATOR_TYPE_DOES_NOT_EXIST;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -240,6 +250,10 @@ export function operatorTypeToJSON(object: OperatorType): string {
       return "OPERATOR_TYPE_IN";
     case OperatorType.OPERATOR_TYPE_EXISTS:
       return "OPERATOR_TYPE_EXISTS";
+    case OperatorType.OPERATOR_TYPE_NOT_IN:
+      return "OPERATOR_TYPE_NOT_IN";
+    case OperatorType.OPERATOR_TYPE_DOES_NOT_EXIST:
+      return "OPERATOR_TYPE_DOES_NOT_EXIST";
     case OperatorType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -517,6 +531,11 @@ export interface Webhook {
    * - TYPE_ISSUE_COMMENT_CREAT
    */
   notificationTypes: Activity_Type[];
+  /**
+   * secret is the secret to sign the webhook request body, only used by the custom webhook.
+   * It is write-only and never returned by the server.
+   */
+  secret: string;
 }
 
 export enum Webhook_Type {
@@ -528,6 +547,10 @@ export enum Webhook_Type {
   TYPE_FEISHU = 5,
   TYPE_WECOM = 6,
   TYPE_CUSTOM = 7,
+  TYPE_MATTERMOST = 8,
+  TYPE_GOOGLE_CHAT = 9,
+  TYPE_TELEGRAM = 10,
+  TYPE_PAGERDUTY = 11,
   UNRECOGNIZED = -1,
 }
 
@@ -557,6 +580,18 @@ export function webhook_TypeFromJSON(object: any): Webhook_Type {
     case 7:
     case "TYPE_CUSTOM":
       return Webhook_Type.TYPE_CUSTOM;
+    case 8:
+    case "TYPE_MATTERMOST":
+      return Webhook_Type.TYPE_MATTERMOST;
+    case 9:
+    case "TYPE_GOOGLE_CHAT":
+      return Webhook_Type.TYPE_GOOGLE_CHAT;
+    case 10:
+    case "TYPE_TELEGRAM":
+      return Webhook_Type.TYPE_TELEGRAM;
+    case 11:
+    case "TYPE_PAGERDUTY":
+      return Webhook_Type.TYPE_PAGERDUTY;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -582,6 +617,14 @@ export function webhook_TypeToJSON(object: Webhook_Type): string {
       return "TYPE_WECOM";
     case Webhook_Type.TYPE_CUSTOM:
       return "TYPE_CUSTOM";
+    case Webhook_Type.TYPE_MATTERMOST:
+      return "TYPE_MATTERMOST";
+    case Webhook_Type.TYPE_GOOGLE_CHAT:
+      return "TYPE_GOOGLE_CHAT";
+    case Webhook_Type.TYPE_TELEGRAM:
+      return "TYPE_TELEGRAM";
+    case Webhook_Type.TYPE_PAGERDUTY:
+      return "TYPE_PAGERDUTY";
     case Webhook_Type.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -611,6 +654,34 @@ export interface ScheduleDeployment {
 
 export interface DeploymentSpec {
   labelSelector?: LabelSelector;
+  /**
+   * The rollout strategy for the databases in the deployment.
+   * All databases are rolled out at the same time if the strategy is not set.
+   */
+  strategy?: RolloutStrategy;
+}
+
+export interface RolloutStrategy {
+  /**
+   * The percentage of databases in the deployment to roll out first.
+   * The rest databases will not start until all the canary databases are done.
+   */
+  canaryPercentage: number;
+  /**
+   * The number of databases in the deployment to roll out first.
+   * Only one of canary_percentage and canary_count can be set.
+   */
+  canaryCount: number;
+  /**
+   * The maximum number of tasks running at the same time in the deployment.
+   * Zero means no limit.
+   */
+  maxParallelism: number;
+  /**
+   * The number of failed tasks that halts the remaining tasks in the deployment.
+   * Zero means never halt.
+   */
+  failureThreshold: number;
 }
 
 export interface LabelSelector {
@@ -2537,7 +2608,7 @@ export const TestWebhookResponse = {
 };
 
 function createBaseWebhook(): Webhook {
-  return { name: "", type: 0, title: "", url: "", notificationTypes: [] };
+  return { name: "", type: 0, title: "", url: "", notificationTypes: [], secret: "" };
 }
 
 export const Webhook = {
@@ -2559,6 +2630,9 @@ export const Webhook = {
       writer.int32(v);
     }
     writer.ldelim();
+    if (message.secret !== "") {
+      writer.uint32(50).string(message.secret);
+    }
     return writer;
   },
 
@@ -2614,6 +2688,13 @@ export const Webhook = {
           }
 
           break;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.secret = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2632,6 +2713,7 @@ export const Webhook = {
       notificationTypes: Array.isArray(object?.notificationTypes)
         ? object.notificationTypes.map((e: any) => activity_TypeFromJSON(e))
         : [],
+      secret: isSet(object.secret) ? String(object.secret) : "",
     };
   },
 
@@ -2646,6 +2728,7 @@ export const Webhook = {
     } else {
       obj.notificationTypes = [];
     }
+    message.secret !== undefined && (obj.secret = message.secret);
     return obj;
   },
 
@@ -2660,6 +2743,7 @@ export const Webhook = {
     message.title = object.title ?? "";
     message.url = object.url ?? "";
     message.notificationTypes = object.notificationTypes?.map((e) => e) || [];
+    message.secret = object.secret ?? "";
     return message;
   },
 };
@@ -2888,7 +2972,7 @@ export const ScheduleDeployment = {
 };
 
 function createBaseDeploymentSpec(): DeploymentSpec {
-  return { labelSelector: undefined };
+  return { labelSelector: undefined, strategy: undefined };
 }
 
 export const DeploymentSpec = {
@@ -2896,6 +2980,9 @@ export const DeploymentSpec = {
     if (message.labelSelector !== undefined) {
       LabelSelector.encode(message.labelSelector, writer.uint32(10).fork()).ldelim();
     }
+    if (message.strategy !== undefined) {
+      RolloutStrategy.encode(message.strategy, writer.uint32(18).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -2913,6 +3000,13 @@ export const DeploymentSpec = {
 
           message.labelSelector = LabelSelector.decode(reader, reader.uint32());
           continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.strategy = RolloutStrategy.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2923,13 +3017,18 @@ export const DeploymentSpec = {
   },
 
   fromJSON(object: any): DeploymentSpec {
-    return { labelSelector: isSet(object.labelSelector) ? LabelSelector.fromJSON(object.labelSelector) : undefined };
+    return {
+      labelSelector: isSet(object.labelSelector) ? LabelSelector.fromJSON(object.labelSelector) : undefined,
+      strategy: isSet(object.strategy) ? RolloutStrategy.fromJSON(object.strategy) : undefined,
+    };
   },
 
   toJSON(message: DeploymentSpec): unknown {
     const obj: any = {};
     message.labelSelector !== undefined &&
       (obj.labelSelector = message.labelSelector ? LabelSelector.toJSON(message.labelSelector) : undefined);
+    message.strategy !== undefined &&
+      (obj.strategy = message.strategy ? RolloutStrategy.toJSON(message.strategy) : undefined);
     return obj;
   },
 
@@ -2942,6 +3041,106 @@ export const DeploymentSpec = {
     message.labelSelector = (object.labelSelector !== undefined && object.labelSelector !== null)
       ? LabelSelector.fromPartial(object.labelSelector)
       : undefined;
+    message.strategy = (object.strategy !== undefined && object.strategy !== null)
+      ? RolloutStrategy.fromPartial(object.strategy)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseRolloutStrategy(): RolloutStrategy {
+  return { canaryPercentage: 0, canaryCount: 0, maxParallelism: 0, failureThreshold: 0 };
+}
+
+export const RolloutStrategy = {
+  encode(message: RolloutStrategy, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.canaryPercentage !== 0) {
+      writer.uint32(8).int32(message.canaryPercentage);
+    }
+    if (message.canaryCount !== 0) {
+      writer.uint32(16).int32(message.canaryCount);
+    }
+    if (message.maxParallelism !== 0) {
+      writer.uint32(24).int32(message.maxParallelism);
+    }
+    if (message.failureThreshold !== 0) {
+      writer.uint32(32).int32(message.failureThreshold);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutStrategy {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutStrategy();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.canaryPercentage = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.canaryCount = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.maxParallelism = reader.int32();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.failureThreshold = reader.int32();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutStrategy {
+    return {
+      canaryPercentage: isSet(object.canaryPercentage) ? Number(object.canaryPercentage) : 0,
+      canaryCount: isSet(object.canaryCount) ? Number(object.canaryCount) : 0,
+      maxParallelism: isSet(object.maxParallelism) ? Number(object.maxParallelism) : 0,
+      failureThreshold: isSet(object.failureThreshold) ? Number(object.failureThreshold) : 0,
+    };
+  },
+
+  toJSON(message: RolloutStrategy): unknown {
+    const obj: any = {};
+    message.canaryPercentage !== undefined && (obj.canaryPercentage = Math.round(message.canaryPercentage));
+    message.canaryCount !== undefined && (obj.canaryCount = Math.round(message.canaryCount));
+    message.maxParallelism !== undefined && (obj.maxParallelism = Math.round(message.maxParallelism));
+    message.failureThreshold !== undefined && (obj.failureThreshold = Math.round(message.failureThreshold));
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutStrategy>): RolloutStrategy {
+    return RolloutStrategy.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutStrategy>): RolloutStrategy {
+    const message = createBaseRolloutStrategy();
+    message.canaryPercentage = object.canaryPercentage ?? 0;
+    message.canaryCount = object.canaryCount ?? 0;
+    message.maxParallelism = object.maxParallelism ?? 0;
+    message.failureThreshold = object.failureThreshold ?? 0;
     return message;
   },
 };
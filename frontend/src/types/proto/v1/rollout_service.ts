@@ -2,6 +2,7 @@
 import type { CallContext, CallOptions } from "nice-grpc-common";
 import * as _m0 from "protobufjs/minimal";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 
 export const protobufPackage = "bytebase.v1";
 
@@ -80,6 +81,512 @@ export interface Plan {
   uid: string;
   title: string;
   description: string;
+  /**
+   * The rollout created from the plan.
+   * It is empty if the rollout has not been created yet.
+   * Format: projects/{project}/rollouts/{rollout}
+   */
+  rollout: string;
+  /**
+   * The steps of the plan.
+   * Tasks generated from a step are blocked by the tasks generated from the previous step in the same stage.
+   */
+  steps: Plan_Step[];
+}
+
+export interface Plan_Step {
+  specs: Plan_Spec[];
+}
+
+export interface Plan_Spec {
+  /** A UUID4 string that uniquely identifies the Spec. */
+  id: string;
+  changeDatabaseConfig?: Plan_ChangeDatabaseConfig | undefined;
+}
+
+export interface Plan_ChangeDatabaseConfig {
+  /**
+   * The resource name of the target.
+   * Format: instances/{instance}/databases/{database}
+   */
+  target: string;
+  /**
+   * The resource name of the sheet.
+   * Format: projects/{project}/sheets/{sheet}
+   */
+  sheet: string;
+  type: Plan_ChangeDatabaseConfig_Type;
+  /**
+   * schema_version is parsed from VCS file name.
+   * It is automatically generated in the UI workflow.
+   */
+  schemaVersion: string;
+  /** If rollback_enabled, build the rollback SQL statement of the DATA task. */
+  rollbackEnabled: boolean;
+}
+
+/** Type is the database change type. */
+export enum Plan_ChangeDatabaseConfig_Type {
+  TYPE_UNSPECIFIED = 0,
+  /**
+   * BASELINE - Used for establishing schema baseline, this is used when
+   * 1. Onboard the database into Bytebase since Bytebase needs to know the current database schema.
+   * 2. Had schema drift and need to re-establish the baseline.
+   */
+  BASELINE = 1,
+  /** MIGRATE - Used for DDL changes including CREATE DATABASE. */
+  MIGRATE = 2,
+  /** MIGRATE_SDL - Used for schema changes via state-based schema migration including CREATE DATABASE. */
+  MIGRATE_SDL = 3,
+  /** DATA - Used for DML change. */
+  DATA = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function plan_ChangeDatabaseConfig_TypeFromJSON(object: any): Plan_ChangeDatabaseConfig_Type {
+  switch (object) {
+    case 0:
+    case "TYPE_UNSPECIFIED":
+      return Plan_ChangeDatabaseConfig_Type.TYPE_UNSPECIFIED;
+    case 1:
+    case "BASELINE":
+      return Plan_ChangeDatabaseConfig_Type.BASELINE;
+    case 2:
+    case "MIGRATE":
+      return Plan_ChangeDatabaseConfig_Type.MIGRATE;
+    case 3:
+    case "MIGRATE_SDL":
+      return Plan_ChangeDatabaseConfig_Type.MIGRATE_SDL;
+    case 4:
+    case "DATA":
+      return Plan_ChangeDatabaseConfig_Type.DATA;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Plan_ChangeDatabaseConfig_Type.UNRECOGNIZED;
+  }
+}
+
+export function plan_ChangeDatabaseConfig_TypeToJSON(object: Plan_ChangeDatabaseConfig_Type): string {
+  switch (object) {
+    case Plan_ChangeDatabaseConfig_Type.TYPE_UNSPECIFIED:
+      return "TYPE_UNSPECIFIED";
+    case Plan_ChangeDatabaseConfig_Type.BASELINE:
+      return "BASELINE";
+    case Plan_ChangeDatabaseConfig_Type.MIGRATE:
+      return "MIGRATE";
+    case Plan_ChangeDatabaseConfig_Type.MIGRATE_SDL:
+      return "MIGRATE_SDL";
+    case Plan_ChangeDatabaseConfig_Type.DATA:
+      return "DATA";
+    case Plan_ChangeDatabaseConfig_Type.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface GetRolloutRequest {
+  /**
+   * The name of the rollout to retrieve.
+   * Format: projects/{project}/rollouts/{rollout}
+   */
+  name: string;
+}
+
+export interface CreateRolloutRequest {
+  /**
+   * The parent project where this rollout will be created.
+   * Format: projects/{project}
+   */
+  parent: string;
+  /**
+   * The plan used to create the rollout.
+   * Format: projects/{project}/plans/{plan}
+   */
+  plan: string;
+}
+
+export interface PreviewRolloutRequest {
+  /**
+   * The name of the project.
+   * Format: projects/{project}
+   */
+  project: string;
+  /** The plan used to preview the rollout. */
+  plan?: Plan;
+}
+
+export interface ListTaskRunsRequest {
+  /**
+   * The parent, which owns this collection of task runs.
+   * Format: projects/{project}/rollouts/{rollout}/stages/{stage}/tasks/{task}
+   * Use "projects/{project}/rollouts/{rollout}/stages/-/tasks/-" to list all task runs from a rollout.
+   */
+  parent: string;
+  /**
+   * Not used. The maximum number of task runs to return. The service may return fewer than
+   * this value.
+   * If unspecified, at most 50 task runs will be returned.
+   * The maximum value is 1000; values above 1000 will be coerced to 1000.
+   */
+  pageSize: number;
+  /**
+   * Not used. A page token, received from a previous `ListTaskRuns` call.
+   * Provide this to retrieve the subsequent page.
+   *
+   * When paginating, all other parameters provided to `ListTaskRuns` must match
+   * the call that provided the page token.
+   */
+  pageToken: string;
+}
+
+export interface ListTaskRunsResponse {
+  /** The task runs from the specified request. */
+  taskRuns: TaskRun[];
+  /**
+   * Not used. A token, which can be sent as `page_token` to retrieve the next page.
+   * If this field is omitted, there are no subsequent pages.
+   */
+  nextPageToken: string;
+}
+
+export interface Rollout {
+  /**
+   * The resource name of the rollout.
+   * Format: projects/{project}/rollouts/{rollout}
+   */
+  name: string;
+  /** The system-assigned, unique identifier for a resource. */
+  uid: string;
+  /**
+   * The plan that this rollout is based on.
+   * Format: projects/{project}/plans/{plan}
+   */
+  plan: string;
+  title: string;
+  /** stages and thus tasks of the rollout. */
+  stages: Stage[];
+}
+
+export interface Stage {
+  /** Format: projects/{project}/rollouts/{rollout}/stages/{stage} */
+  name: string;
+  /** The system-assigned, unique identifier for a resource. */
+  uid: string;
+  /** Format: environments/{environment} */
+  environment: string;
+  title: string;
+  tasks: Task[];
+}
+
+export interface Task {
+  /** Format: projects/{project}/rollouts/{rollout}/stages/{stage}/tasks/{task} */
+  name: string;
+  /** The system-assigned, unique identifier for a resource. */
+  uid: string;
+  title: string;
+  /**
+   * A UUID4 string that uniquely identifies the Spec.
+   * Could be empty if the rollout of the task does not have an associating plan.
+   */
+  specId: string;
+  /** Status is the status of the task. */
+  status: Task_Status;
+  type: Task_Type;
+  /** Format: projects/{project}/rollouts/{rollout}/stages/{stage}/tasks/{task} */
+  blockedByTasks: string[];
+  /**
+   * Format: instances/{instance} if the task is DatabaseCreate.
+   * Format: instances/{instance}/databases/{database}
+   */
+  target: string;
+  databaseCreate?: Task_DatabaseCreate | undefined;
+  databaseSchemaBaseline?: Task_DatabaseSchemaBaseline | undefined;
+  databaseSchemaUpdate?: Task_DatabaseSchemaUpdate | undefined;
+  databaseDataUpdate?: Task_DatabaseDataUpdate | undefined;
+}
+
+export enum Task_Status {
+  STATUS_UNSPECIFIED = 0,
+  PENDING_APPROVAL = 1,
+  PENDING = 2,
+  RUNNING = 3,
+  DONE = 4,
+  FAILED = 5,
+  CANCELED = 6,
+  UNRECOGNIZED = -1,
+}
+
+export function task_StatusFromJSON(object: any): Task_Status {
+  switch (object) {
+    case 0:
+    case "STATUS_UNSPECIFIED":
+      return Task_Status.STATUS_UNSPECIFIED;
+    case 1:
+    case "PENDING_APPROVAL":
+      return Task_Status.PENDING_APPROVAL;
+    case 2:
+    case "PENDING":
+      return Task_Status.PENDING;
+    case 3:
+    case "RUNNING":
+      return Task_Status.RUNNING;
+    case 4:
+    case "DONE":
+      return Task_Status.DONE;
+    case 5:
+    case "FAILED":
+      return Task_Status.FAILED;
+    case 6:
+    case "CANCELED":
+      return Task_Status.CANCELED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Task_Status.UNRECOGNIZED;
+  }
+}
+
+export function task_StatusToJSON(object: Task_Status): string {
+  switch (object) {
+    case Task_Status.STATUS_UNSPECIFIED:
+      return "STATUS_UNSPECIFIED";
+    case Task_Status.PENDING_APPROVAL:
+      return "PENDING_APPROVAL";
+    case Task_Status.PENDING:
+      return "PENDING";
+    case Task_Status.RUNNING:
+      return "RUNNING";
+    case Task_Status.DONE:
+      return "DONE";
+    case Task_Status.FAILED:
+      return "FAILED";
+    case Task_Status.CANCELED:
+      return "CANCELED";
+    case Task_Status.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export enum Task_Type {
+  TYPE_UNSPECIFIED = 0,
+  GENERAL = 1,
+  /** DATABASE_CREATE - use payload DatabaseCreate */
+  DATABASE_CREATE = 2,
+  /** DATABASE_SCHEMA_BASELINE - use payload DatabaseSchemaBaseline */
+  DATABASE_SCHEMA_BASELINE = 3,
+  /** DATABASE_SCHEMA_UPDATE - use payload DatabaseSchemaUpdate */
+  DATABASE_SCHEMA_UPDATE = 4,
+  /** DATABASE_SCHEMA_UPDATE_SDL - use payload DatabaseSchemaUpdate */
+  DATABASE_SCHEMA_UPDATE_SDL = 5,
+  /** DATABASE_SCHEMA_UPDATE_GHOST_SYNC - use payload DatabaseSchemaUpdate */
+  DATABASE_SCHEMA_UPDATE_GHOST_SYNC = 6,
+  /** DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER - use payload nil */
+  DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER = 7,
+  /** DATABASE_DATA_UPDATE - use payload DatabaseDataUpdate */
+  DATABASE_DATA_UPDATE = 8,
+  /** DATABASE_BACKUP - use payload nil */
+  DATABASE_BACKUP = 9,
+  /** DATABASE_RESTORE_RESTORE - use payload nil */
+  DATABASE_RESTORE_RESTORE = 10,
+  /** DATABASE_RESTORE_CUTOVER - use payload nil */
+  DATABASE_RESTORE_CUTOVER = 11,
+  /** DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC - use payload DatabaseSchemaUpdate */
+  DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC = 12,
+  /** DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER - use payload nil */
+  DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER = 13,
+  UNRECOGNIZED = -1,
+}
+
+export function task_TypeFromJSON(object: any): Task_Type {
+  switch (object) {
+    case 0:
+    case "TYPE_UNSPECIFIED":
+      return Task_Type.TYPE_UNSPECIFIED;
+    case 1:
+    case "GENERAL":
+      return Task_Type.GENERAL;
+    case 2:
+    case "DATABASE_CREATE":
+      return Task_Type.DATABASE_CREATE;
+    case 3:
+    case "DATABASE_SCHEMA_BASELINE":
+      return Task_Type.DATABASE_SCHEMA_BASELINE;
+    case 4:
+    case "DATABASE_SCHEMA_UPDATE":
+      return Task_Type.DATABASE_SCHEMA_UPDATE;
+    case 5:
+    case "DATABASE_SCHEMA_UPDATE_SDL":
+      return Task_Type.DATABASE_SCHEMA_UPDATE_SDL;
+    case 6:
+    case "DATABASE_SCHEMA_UPDATE_GHOST_SYNC":
+      return Task_Type.DATABASE_SCHEMA_UPDATE_GHOST_SYNC;
+    case 7:
+    case "DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER":
+      return Task_Type.DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER;
+    case 8:
+    case "DATABASE_DATA_UPDATE":
+      return Task_Type.DATABASE_DATA_UPDATE;
+    case 9:
+    case "DATABASE_BACKUP":
+      return Task_Type.DATABASE_BACKUP;
+    case 10:
+    case "DATABASE_RESTORE_RESTORE":
+      return Task_Type.DATABASE_RESTORE_RESTORE;
+    case 11:
+    case "DATABASE_RESTORE_CUTOVER":
+      return Task_Type.DATABASE_RESTORE_CUTOVER;
+    case 12:
+    case "DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC":
+      return Task_Type.DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC;
+    case 13:
+    case "DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER":
+      return Task_Type.DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Task_Type.UNRECOGNIZED;
+  }
+}
+
+export function task_TypeToJSON(object: Task_Type): string {
+  switch (object) {
+    case Task_Type.TYPE_UNSPECIFIED:
+      return "TYPE_UNSPECIFIED";
+    case Task_Type.GENERAL:
+      return "GENERAL";
+    case Task_Type.DATABASE_CREATE:
+      return "DATABASE_CREATE";
+    case Task_Type.DATABASE_SCHEMA_BASELINE:
+      return "DATABASE_SCHEMA_BASELINE";
+    case Task_Type.DATABASE_SCHEMA_UPDATE:
+      return "DATABASE_SCHEMA_UPDATE";
+    case Task_Type.DATABASE_SCHEMA_UPDATE_SDL:
+      return "DATABASE_SCHEMA_UPDATE_SDL";
+    case Task_Type.DATABASE_SCHEMA_UPDATE_GHOST_SYNC:
+      return "DATABASE_SCHEMA_UPDATE_GHOST_SYNC";
+    case Task_Type.DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER:
+      return "DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER";
+    case Task_Type.DATABASE_DATA_UPDATE:
+      return "DATABASE_DATA_UPDATE";
+    case Task_Type.DATABASE_BACKUP:
+      return "DATABASE_BACKUP";
+    case Task_Type.DATABASE_RESTORE_RESTORE:
+      return "DATABASE_RESTORE_RESTORE";
+    case Task_Type.DATABASE_RESTORE_CUTOVER:
+      return "DATABASE_RESTORE_CUTOVER";
+    case Task_Type.DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC:
+      return "DATABASE_SCHEMA_UPDATE_PG_ONLINE_SYNC";
+    case Task_Type.DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER:
+      return "DATABASE_SCHEMA_UPDATE_PG_ONLINE_CUTOVER";
+    case Task_Type.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface Task_DatabaseCreate {
+  /**
+   * The project owning the database.
+   * Format: projects/{project}
+   */
+  project: string;
+  /** database name */
+  database: string;
+  /** table name */
+  table: string;
+  /** Format: projects/{project}/sheets/{sheet} */
+  sheet: string;
+  characterSet: string;
+  collation: string;
+}
+
+export interface Task_DatabaseSchemaBaseline {
+  schemaVersion: string;
+}
+
+export interface Task_DatabaseSchemaUpdate {
+  /** Format: projects/{project}/sheets/{sheet} */
+  sheet: string;
+  schemaVersion: string;
+}
+
+export interface Task_DatabaseDataUpdate {
+  /** Format: projects/{project}/sheets/{sheet} */
+  sheet: string;
+  schemaVersion: string;
+  /** Build the rollback SQL if rollback_enabled. */
+  rollbackEnabled: boolean;
+}
+
+export interface TaskRun {
+  /** Format: projects/{project}/rollouts/{rollout}/stages/{stage}/tasks/{task}/taskRuns/{taskRun} */
+  name: string;
+  /** The system-assigned, unique identifier for a resource. */
+  uid: string;
+  /** Format: user:hello@world.com */
+  creator: string;
+  /** Format: user:hello@world.com */
+  updater: string;
+  createTime?: Date;
+  updateTime?: Date;
+  title: string;
+  status: TaskRun_Status;
+  /** Below are the results of a task run. */
+  detail: string;
+  schemaVersion: string;
+}
+
+export enum TaskRun_Status {
+  STATUS_UNSPECIFIED = 0,
+  RUNNING = 1,
+  DONE = 2,
+  FAILED = 3,
+  CANCELED = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function taskRun_StatusFromJSON(object: any): TaskRun_Status {
+  switch (object) {
+    case 0:
+    case "STATUS_UNSPECIFIED":
+      return TaskRun_Status.STATUS_UNSPECIFIED;
+    case 1:
+    case "RUNNING":
+      return TaskRun_Status.RUNNING;
+    case 2:
+    case "DONE":
+      return TaskRun_Status.DONE;
+    case 3:
+    case "FAILED":
+      return TaskRun_Status.FAILED;
+    case 4:
+    case "CANCELED":
+      return TaskRun_Status.CANCELED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return TaskRun_Status.UNRECOGNIZED;
+  }
+}
+
+export function taskRun_StatusToJSON(object: TaskRun_Status): string {
+  switch (object) {
+    case TaskRun_Status.STATUS_UNSPECIFIED:
+      return "STATUS_UNSPECIFIED";
+    case TaskRun_Status.RUNNING:
+      return "RUNNING";
+    case TaskRun_Status.DONE:
+      return "DONE";
+    case TaskRun_Status.FAILED:
+      return "FAILED";
+    case TaskRun_Status.CANCELED:
+      return "CANCELED";
+    case TaskRun_Status.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
 }
 
 function createBaseGetPlanRequest(): GetPlanRequest {
@@ -440,7 +947,7 @@ export const UpdatePlanRequest = {
 };
 
 function createBasePlan(): Plan {
-  return { name: "", uid: "", title: "", description: "" };
+  return { name: "", uid: "", title: "", description: "", rollout: "", steps: [] };
 }
 
 export const Plan = {
@@ -457,6 +964,12 @@ export const Plan = {
     if (message.description !== "") {
       writer.uint32(34).string(message.description);
     }
+    if (message.rollout !== "") {
+      writer.uint32(42).string(message.rollout);
+    }
+    for (const v of message.steps) {
+      Plan_Step.encode(v!, writer.uint32(50).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -495,6 +1008,20 @@ export const Plan = {
 
           message.description = reader.string();
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.rollout = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.steps.push(Plan_Step.decode(reader, reader.uint32()));
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -510,6 +1037,8 @@ export const Plan = {
       uid: isSet(object.uid) ? String(object.uid) : "",
       title: isSet(object.title) ? String(object.title) : "",
       description: isSet(object.description) ? String(object.description) : "",
+      rollout: isSet(object.rollout) ? String(object.rollout) : "",
+      steps: Array.isArray(object?.steps) ? object.steps.map((e: any) => Plan_Step.fromJSON(e)) : [],
     };
   },
 
@@ -519,6 +1048,12 @@ export const Plan = {
     message.uid !== undefined && (obj.uid = message.uid);
     message.title !== undefined && (obj.title = message.title);
     message.description !== undefined && (obj.description = message.description);
+    message.rollout !== undefined && (obj.rollout = message.rollout);
+    if (message.steps) {
+      obj.steps = message.steps.map((e) => e ? Plan_Step.toJSON(e) : undefined);
+    } else {
+      obj.steps = [];
+    }
     return obj;
   },
 
@@ -532,126 +1067,1720 @@ export const Plan = {
     message.uid = object.uid ?? "";
     message.title = object.title ?? "";
     message.description = object.description ?? "";
+    message.rollout = object.rollout ?? "";
+    message.steps = object.steps?.map((e) => Plan_Step.fromPartial(e)) || [];
     return message;
   },
 };
 
-export type RolloutServiceDefinition = typeof RolloutServiceDefinition;
-export const RolloutServiceDefinition = {
-  name: "RolloutService",
-  fullName: "bytebase.v1.RolloutService",
-  methods: {
-    getPlan: {
-      name: "GetPlan",
-      requestType: GetPlanRequest,
-      requestStream: false,
-      responseType: Plan,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([4, 110, 97, 109, 101])],
-          578365826: [
-            new Uint8Array([
-              31,
-              18,
-              29,
-              47,
-              118,
-              49,
-              47,
-              123,
-              110,
-              97,
-              109,
-              101,
-              61,
-              112,
-              114,
-              111,
-              106,
-              101,
-              99,
-              116,
-              115,
-              47,
-              42,
-              47,
-              112,
-              108,
-              97,
-              110,
-              115,
-              47,
-              42,
-              125,
-            ]),
-          ],
-        },
-      },
-    },
-    listPlans: {
-      name: "ListPlans",
-      requestType: ListPlansRequest,
-      requestStream: false,
-      responseType: ListPlansResponse,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
-          578365826: [
-            new Uint8Array([
-              31,
-              18,
-              29,
-              47,
-              118,
-              49,
-              47,
-              123,
-              112,
-              97,
-              114,
-              101,
-              110,
-              116,
-              61,
-              112,
-              114,
-              111,
-              106,
-              101,
-              99,
-              116,
-              115,
-              47,
-              42,
-              125,
-              47,
-              112,
-              108,
-              97,
-              110,
-              115,
-            ]),
-          ],
-        },
-      },
-    },
-    createPlan: {
-      name: "CreatePlan",
-      requestType: CreatePlanRequest,
-      requestStream: false,
-      responseType: Plan,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([0])],
-          578365826: [
-            new Uint8Array([
-              37,
-              58,
-              4,
-              112,
+function createBasePlan_Step(): Plan_Step {
+  return { specs: [] };
+}
+
+export const Plan_Step = {
+  encode(message: Plan_Step, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.specs) {
+      Plan_Spec.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Plan_Step {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlan_Step();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.specs.push(Plan_Spec.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Plan_Step {
+    return { specs: Array.isArray(object?.specs) ? object.specs.map((e: any) => Plan_Spec.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: Plan_Step): unknown {
+    const obj: any = {};
+    if (message.specs) {
+      obj.specs = message.specs.map((e) => e ? Plan_Spec.toJSON(e) : undefined);
+    } else {
+      obj.specs = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Plan_Step>): Plan_Step {
+    return Plan_Step.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Plan_Step>): Plan_Step {
+    const message = createBasePlan_Step();
+    message.specs = object.specs?.map((e) => Plan_Spec.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBasePlan_Spec(): Plan_Spec {
+  return { id: "", changeDatabaseConfig: undefined };
+}
+
+export const Plan_Spec = {
+  encode(message: Plan_Spec, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.changeDatabaseConfig !== undefined) {
+      Plan_ChangeDatabaseConfig.encode(message.changeDatabaseConfig, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Plan_Spec {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlan_Spec();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.changeDatabaseConfig = Plan_ChangeDatabaseConfig.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Plan_Spec {
+    return {
+      id: isSet(object.id) ? String(object.id) : "",
+      changeDatabaseConfig: isSet(object.changeDatabaseConfig)
+        ? Plan_ChangeDatabaseConfig.fromJSON(object.changeDatabaseConfig)
+        : undefined,
+    };
+  },
+
+  toJSON(message: Plan_Spec): unknown {
+    const obj: any = {};
+    message.id !== undefined && (obj.id = message.id);
+    message.changeDatabaseConfig !== undefined && (obj.changeDatabaseConfig = message.changeDatabaseConfig
+      ? Plan_ChangeDatabaseConfig.toJSON(message.changeDatabaseConfig)
+      : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Plan_Spec>): Plan_Spec {
+    return Plan_Spec.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Plan_Spec>): Plan_Spec {
+    const message = createBasePlan_Spec();
+    message.id = object.id ?? "";
+    message.changeDatabaseConfig = (object.changeDatabaseConfig !== undefined && object.changeDatabaseConfig !== null)
+      ? Plan_ChangeDatabaseConfig.fromPartial(object.changeDatabaseConfig)
+      : undefined;
+    return message;
+  },
+};
+
+function createBasePlan_ChangeDatabaseConfig(): Plan_ChangeDatabaseConfig {
+  return { target: "", sheet: "", type: 0, schemaVersion: "", rollbackEnabled: false };
+}
+
+export const Plan_ChangeDatabaseConfig = {
+  encode(message: Plan_ChangeDatabaseConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.target !== "") {
+      writer.uint32(10).string(message.target);
+    }
+    if (message.sheet !== "") {
+      writer.uint32(18).string(message.sheet);
+    }
+    if (message.type !== 0) {
+      writer.uint32(24).int32(message.type);
+    }
+    if (message.schemaVersion !== "") {
+      writer.uint32(34).string(message.schemaVersion);
+    }
+    if (message.rollbackEnabled === true) {
+      writer.uint32(40).bool(message.rollbackEnabled);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Plan_ChangeDatabaseConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlan_ChangeDatabaseConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.target = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.sheet = reader.string();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.rollbackEnabled = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Plan_ChangeDatabaseConfig {
+    return {
+      target: isSet(object.target) ? String(object.target) : "",
+      sheet: isSet(object.sheet) ? String(object.sheet) : "",
+      type: isSet(object.type) ? plan_ChangeDatabaseConfig_TypeFromJSON(object.type) : 0,
+      schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "",
+      rollbackEnabled: isSet(object.rollbackEnabled) ? Boolean(object.rollbackEnabled) : false,
+    };
+  },
+
+  toJSON(message: Plan_ChangeDatabaseConfig): unknown {
+    const obj: any = {};
+    message.target !== undefined && (obj.target = message.target);
+    message.sheet !== undefined && (obj.sheet = message.sheet);
+    message.type !== undefined && (obj.type = plan_ChangeDatabaseConfig_TypeToJSON(message.type));
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    message.rollbackEnabled !== undefined && (obj.rollbackEnabled = message.rollbackEnabled);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Plan_ChangeDatabaseConfig>): Plan_ChangeDatabaseConfig {
+    return Plan_ChangeDatabaseConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Plan_ChangeDatabaseConfig>): Plan_ChangeDatabaseConfig {
+    const message = createBasePlan_ChangeDatabaseConfig();
+    message.target = object.target ?? "";
+    message.sheet = object.sheet ?? "";
+    message.type = object.type ?? 0;
+    message.schemaVersion = object.schemaVersion ?? "";
+    message.rollbackEnabled = object.rollbackEnabled ?? false;
+    return message;
+  },
+};
+
+function createBaseGetRolloutRequest(): GetRolloutRequest {
+  return { name: "" };
+}
+
+export const GetRolloutRequest = {
+  encode(message: GetRolloutRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetRolloutRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetRolloutRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetRolloutRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: GetRolloutRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetRolloutRequest>): GetRolloutRequest {
+    return GetRolloutRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<GetRolloutRequest>): GetRolloutRequest {
+    const message = createBaseGetRolloutRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseCreateRolloutRequest(): CreateRolloutRequest {
+  return { parent: "", plan: "" };
+}
+
+export const CreateRolloutRequest = {
+  encode(message: CreateRolloutRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.plan !== "") {
+      writer.uint32(18).string(message.plan);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CreateRolloutRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateRolloutRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.plan = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateRolloutRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      plan: isSet(object.plan) ? String(object.plan) : "",
+    };
+  },
+
+  toJSON(message: CreateRolloutRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.plan !== undefined && (obj.plan = message.plan);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateRolloutRequest>): CreateRolloutRequest {
+    return CreateRolloutRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CreateRolloutRequest>): CreateRolloutRequest {
+    const message = createBaseCreateRolloutRequest();
+    message.parent = object.parent ?? "";
+    message.plan = object.plan ?? "";
+    return message;
+  },
+};
+
+function createBasePreviewRolloutRequest(): PreviewRolloutRequest {
+  return { project: "", plan: undefined };
+}
+
+export const PreviewRolloutRequest = {
+  encode(message: PreviewRolloutRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.project !== "") {
+      writer.uint32(10).string(message.project);
+    }
+    if (message.plan !== undefined) {
+      Plan.encode(message.plan, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PreviewRolloutRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePreviewRolloutRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.project = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.plan = Plan.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PreviewRolloutRequest {
+    return {
+      project: isSet(object.project) ? String(object.project) : "",
+      plan: isSet(object.plan) ? Plan.fromJSON(object.plan) : undefined,
+    };
+  },
+
+  toJSON(message: PreviewRolloutRequest): unknown {
+    const obj: any = {};
+    message.project !== undefined && (obj.project = message.project);
+    message.plan !== undefined && (obj.plan = message.plan ? Plan.toJSON(message.plan) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<PreviewRolloutRequest>): PreviewRolloutRequest {
+    return PreviewRolloutRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PreviewRolloutRequest>): PreviewRolloutRequest {
+    const message = createBasePreviewRolloutRequest();
+    message.project = object.project ?? "";
+    message.plan = (object.plan !== undefined && object.plan !== null) ? Plan.fromPartial(object.plan) : undefined;
+    return message;
+  },
+};
+
+function createBaseListTaskRunsRequest(): ListTaskRunsRequest {
+  return { parent: "", pageSize: 0, pageToken: "" };
+}
+
+export const ListTaskRunsRequest = {
+  encode(message: ListTaskRunsRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.pageSize !== 0) {
+      writer.uint32(16).int32(message.pageSize);
+    }
+    if (message.pageToken !== "") {
+      writer.uint32(26).string(message.pageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListTaskRunsRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListTaskRunsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.pageSize = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.pageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListTaskRunsRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
+      pageToken: isSet(object.pageToken) ? String(object.pageToken) : "",
+    };
+  },
+
+  toJSON(message: ListTaskRunsRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
+    message.pageToken !== undefined && (obj.pageToken = message.pageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListTaskRunsRequest>): ListTaskRunsRequest {
+    return ListTaskRunsRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListTaskRunsRequest>): ListTaskRunsRequest {
+    const message = createBaseListTaskRunsRequest();
+    message.parent = object.parent ?? "";
+    message.pageSize = object.pageSize ?? 0;
+    message.pageToken = object.pageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseListTaskRunsResponse(): ListTaskRunsResponse {
+  return { taskRuns: [], nextPageToken: "" };
+}
+
+export const ListTaskRunsResponse = {
+  encode(message: ListTaskRunsResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.taskRuns) {
+      TaskRun.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.nextPageToken !== "") {
+      writer.uint32(18).string(message.nextPageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListTaskRunsResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListTaskRunsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.taskRuns.push(TaskRun.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListTaskRunsResponse {
+    return {
+      taskRuns: Array.isArray(object?.taskRuns) ? object.taskRuns.map((e: any) => TaskRun.fromJSON(e)) : [],
+      nextPageToken: isSet(object.nextPageToken) ? String(object.nextPageToken) : "",
+    };
+  },
+
+  toJSON(message: ListTaskRunsResponse): unknown {
+    const obj: any = {};
+    if (message.taskRuns) {
+      obj.taskRuns = message.taskRuns.map((e) => e ? TaskRun.toJSON(e) : undefined);
+    } else {
+      obj.taskRuns = [];
+    }
+    message.nextPageToken !== undefined && (obj.nextPageToken = message.nextPageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListTaskRunsResponse>): ListTaskRunsResponse {
+    return ListTaskRunsResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListTaskRunsResponse>): ListTaskRunsResponse {
+    const message = createBaseListTaskRunsResponse();
+    message.taskRuns = object.taskRuns?.map((e) => TaskRun.fromPartial(e)) || [];
+    message.nextPageToken = object.nextPageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseRollout(): Rollout {
+  return { name: "", uid: "", plan: "", title: "", stages: [] };
+}
+
+export const Rollout = {
+  encode(message: Rollout, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.uid !== "") {
+      writer.uint32(18).string(message.uid);
+    }
+    if (message.plan !== "") {
+      writer.uint32(26).string(message.plan);
+    }
+    if (message.title !== "") {
+      writer.uint32(34).string(message.title);
+    }
+    for (const v of message.stages) {
+      Stage.encode(v!, writer.uint32(42).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Rollout {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRollout();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.uid = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.plan = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.stages.push(Stage.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Rollout {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      uid: isSet(object.uid) ? String(object.uid) : "",
+      plan: isSet(object.plan) ? String(object.plan) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      stages: Array.isArray(object?.stages) ? object.stages.map((e: any) => Stage.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: Rollout): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.uid !== undefined && (obj.uid = message.uid);
+    message.plan !== undefined && (obj.plan = message.plan);
+    message.title !== undefined && (obj.title = message.title);
+    if (message.stages) {
+      obj.stages = message.stages.map((e) => e ? Stage.toJSON(e) : undefined);
+    } else {
+      obj.stages = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Rollout>): Rollout {
+    return Rollout.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Rollout>): Rollout {
+    const message = createBaseRollout();
+    message.name = object.name ?? "";
+    message.uid = object.uid ?? "";
+    message.plan = object.plan ?? "";
+    message.title = object.title ?? "";
+    message.stages = object.stages?.map((e) => Stage.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseStage(): Stage {
+  return { name: "", uid: "", environment: "", title: "", tasks: [] };
+}
+
+export const Stage = {
+  encode(message: Stage, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.uid !== "") {
+      writer.uint32(18).string(message.uid);
+    }
+    if (message.environment !== "") {
+      writer.uint32(26).string(message.environment);
+    }
+    if (message.title !== "") {
+      writer.uint32(34).string(message.title);
+    }
+    for (const v of message.tasks) {
+      Task.encode(v!, writer.uint32(42).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Stage {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseStage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.uid = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.environment = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.tasks.push(Task.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Stage {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      uid: isSet(object.uid) ? String(object.uid) : "",
+      environment: isSet(object.environment) ? String(object.environment) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      tasks: Array.isArray(object?.tasks) ? object.tasks.map((e: any) => Task.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: Stage): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.uid !== undefined && (obj.uid = message.uid);
+    message.environment !== undefined && (obj.environment = message.environment);
+    message.title !== undefined && (obj.title = message.title);
+    if (message.tasks) {
+      obj.tasks = message.tasks.map((e) => e ? Task.toJSON(e) : undefined);
+    } else {
+      obj.tasks = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Stage>): Stage {
+    return Stage.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Stage>): Stage {
+    const message = createBaseStage();
+    message.name = object.name ?? "";
+    message.uid = object.uid ?? "";
+    message.environment = object.environment ?? "";
+    message.title = object.title ?? "";
+    message.tasks = object.tasks?.map((e) => Task.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseTask(): Task {
+  return {
+    name: "",
+    uid: "",
+    title: "",
+    specId: "",
+    status: 0,
+    type: 0,
+    blockedByTasks: [],
+    target: "",
+    databaseCreate: undefined,
+    databaseSchemaBaseline: undefined,
+    databaseSchemaUpdate: undefined,
+    databaseDataUpdate: undefined,
+  };
+}
+
+export const Task = {
+  encode(message: Task, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.uid !== "") {
+      writer.uint32(18).string(message.uid);
+    }
+    if (message.title !== "") {
+      writer.uint32(26).string(message.title);
+    }
+    if (message.specId !== "") {
+      writer.uint32(34).string(message.specId);
+    }
+    if (message.status !== 0) {
+      writer.uint32(40).int32(message.status);
+    }
+    if (message.type !== 0) {
+      writer.uint32(48).int32(message.type);
+    }
+    for (const v of message.blockedByTasks) {
+      writer.uint32(58).string(v!);
+    }
+    if (message.target !== "") {
+      writer.uint32(66).string(message.target);
+    }
+    if (message.databaseCreate !== undefined) {
+      Task_DatabaseCreate.encode(message.databaseCreate, writer.uint32(74).fork()).ldelim();
+    }
+    if (message.databaseSchemaBaseline !== undefined) {
+      Task_DatabaseSchemaBaseline.encode(message.databaseSchemaBaseline, writer.uint32(82).fork()).ldelim();
+    }
+    if (message.databaseSchemaUpdate !== undefined) {
+      Task_DatabaseSchemaUpdate.encode(message.databaseSchemaUpdate, writer.uint32(90).fork()).ldelim();
+    }
+    if (message.databaseDataUpdate !== undefined) {
+      Task_DatabaseDataUpdate.encode(message.databaseDataUpdate, writer.uint32(98).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Task {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.uid = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.specId = reader.string();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.status = reader.int32() as any;
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.blockedByTasks.push(reader.string());
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.target = reader.string();
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.databaseCreate = Task_DatabaseCreate.decode(reader, reader.uint32());
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.databaseSchemaBaseline = Task_DatabaseSchemaBaseline.decode(reader, reader.uint32());
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.databaseSchemaUpdate = Task_DatabaseSchemaUpdate.decode(reader, reader.uint32());
+          continue;
+        case 12:
+          if (tag !== 98) {
+            break;
+          }
+
+          message.databaseDataUpdate = Task_DatabaseDataUpdate.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Task {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      uid: isSet(object.uid) ? String(object.uid) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      specId: isSet(object.specId) ? String(object.specId) : "",
+      status: isSet(object.status) ? task_StatusFromJSON(object.status) : 0,
+      type: isSet(object.type) ? task_TypeFromJSON(object.type) : 0,
+      blockedByTasks: Array.isArray(object?.blockedByTasks) ? object.blockedByTasks.map((e: any) => String(e)) : [],
+      target: isSet(object.target) ? String(object.target) : "",
+      databaseCreate: isSet(object.databaseCreate) ? Task_DatabaseCreate.fromJSON(object.databaseCreate) : undefined,
+      databaseSchemaBaseline: isSet(object.databaseSchemaBaseline)
+        ? Task_DatabaseSchemaBaseline.fromJSON(object.databaseSchemaBaseline)
+        : undefined,
+      databaseSchemaUpdate: isSet(object.databaseSchemaUpdate)
+        ? Task_DatabaseSchemaUpdate.fromJSON(object.databaseSchemaUpdate)
+        : undefined,
+      databaseDataUpdate: isSet(object.databaseDataUpdate)
+        ? Task_DatabaseDataUpdate.fromJSON(object.databaseDataUpdate)
+        : undefined,
+    };
+  },
+
+  toJSON(message: Task): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.uid !== undefined && (obj.uid = message.uid);
+    message.title !== undefined && (obj.title = message.title);
+    message.specId !== undefined && (obj.specId = message.specId);
+    message.status !== undefined && (obj.status = task_StatusToJSON(message.status));
+    message.type !== undefined && (obj.type = task_TypeToJSON(message.type));
+    if (message.blockedByTasks) {
+      obj.blockedByTasks = message.blockedByTasks.map((e) => e);
+    } else {
+      obj.blockedByTasks = [];
+    }
+    message.target !== undefined && (obj.target = message.target);
+    message.databaseCreate !== undefined &&
+      (obj.databaseCreate = message.databaseCreate ? Task_DatabaseCreate.toJSON(message.databaseCreate) : undefined);
+    message.databaseSchemaBaseline !== undefined && (obj.databaseSchemaBaseline = message.databaseSchemaBaseline
+      ? Task_DatabaseSchemaBaseline.toJSON(message.databaseSchemaBaseline)
+      : undefined);
+    message.databaseSchemaUpdate !== undefined && (obj.databaseSchemaUpdate = message.databaseSchemaUpdate
+      ? Task_DatabaseSchemaUpdate.toJSON(message.databaseSchemaUpdate)
+      : undefined);
+    message.databaseDataUpdate !== undefined && (obj.databaseDataUpdate = message.databaseDataUpdate
+      ? Task_DatabaseDataUpdate.toJSON(message.databaseDataUpdate)
+      : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Task>): Task {
+    return Task.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Task>): Task {
+    const message = createBaseTask();
+    message.name = object.name ?? "";
+    message.uid = object.uid ?? "";
+    message.title = object.title ?? "";
+    message.specId = object.specId ?? "";
+    message.status = object.status ?? 0;
+    message.type = object.type ?? 0;
+    message.blockedByTasks = object.blockedByTasks?.map((e) => e) || [];
+    message.target = object.target ?? "";
+    message.databaseCreate = (object.databaseCreate !== undefined && object.databaseCreate !== null)
+      ? Task_DatabaseCreate.fromPartial(object.databaseCreate)
+      : undefined;
+    message.databaseSchemaBaseline =
+      (object.databaseSchemaBaseline !== undefined && object.databaseSchemaBaseline !== null)
+        ? Task_DatabaseSchemaBaseline.fromPartial(object.databaseSchemaBaseline)
+        : undefined;
+    message.databaseSchemaUpdate = (object.databaseSchemaUpdate !== undefined && object.databaseSchemaUpdate !== null)
+      ? Task_DatabaseSchemaUpdate.fromPartial(object.databaseSchemaUpdate)
+      : undefined;
+    message.databaseDataUpdate = (object.databaseDataUpdate !== undefined && object.databaseDataUpdate !== null)
+      ? Task_DatabaseDataUpdate.fromPartial(object.databaseDataUpdate)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseTask_DatabaseCreate(): Task_DatabaseCreate {
+  return { project: "", database: "", table: "", sheet: "", characterSet: "", collation: "" };
+}
+
+export const Task_DatabaseCreate = {
+  encode(message: Task_DatabaseCreate, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.project !== "") {
+      writer.uint32(10).string(message.project);
+    }
+    if (message.database !== "") {
+      writer.uint32(18).string(message.database);
+    }
+    if (message.table !== "") {
+      writer.uint32(26).string(message.table);
+    }
+    if (message.sheet !== "") {
+      writer.uint32(34).string(message.sheet);
+    }
+    if (message.characterSet !== "") {
+      writer.uint32(42).string(message.characterSet);
+    }
+    if (message.collation !== "") {
+      writer.uint32(50).string(message.collation);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Task_DatabaseCreate {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTask_DatabaseCreate();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.project = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.database = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.table = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.sheet = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.characterSet = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.collation = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Task_DatabaseCreate {
+    return {
+      project: isSet(object.project) ? String(object.project) : "",
+      database: isSet(object.database) ? String(object.database) : "",
+      table: isSet(object.table) ? String(object.table) : "",
+      sheet: isSet(object.sheet) ? String(object.sheet) : "",
+      characterSet: isSet(object.characterSet) ? String(object.characterSet) : "",
+      collation: isSet(object.collation) ? String(object.collation) : "",
+    };
+  },
+
+  toJSON(message: Task_DatabaseCreate): unknown {
+    const obj: any = {};
+    message.project !== undefined && (obj.project = message.project);
+    message.database !== undefined && (obj.database = message.database);
+    message.table !== undefined && (obj.table = message.table);
+    message.sheet !== undefined && (obj.sheet = message.sheet);
+    message.characterSet !== undefined && (obj.characterSet = message.characterSet);
+    message.collation !== undefined && (obj.collation = message.collation);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Task_DatabaseCreate>): Task_DatabaseCreate {
+    return Task_DatabaseCreate.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Task_DatabaseCreate>): Task_DatabaseCreate {
+    const message = createBaseTask_DatabaseCreate();
+    message.project = object.project ?? "";
+    message.database = object.database ?? "";
+    message.table = object.table ?? "";
+    message.sheet = object.sheet ?? "";
+    message.characterSet = object.characterSet ?? "";
+    message.collation = object.collation ?? "";
+    return message;
+  },
+};
+
+function createBaseTask_DatabaseSchemaBaseline(): Task_DatabaseSchemaBaseline {
+  return { schemaVersion: "" };
+}
+
+export const Task_DatabaseSchemaBaseline = {
+  encode(message: Task_DatabaseSchemaBaseline, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.schemaVersion !== "") {
+      writer.uint32(10).string(message.schemaVersion);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Task_DatabaseSchemaBaseline {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTask_DatabaseSchemaBaseline();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Task_DatabaseSchemaBaseline {
+    return { schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "" };
+  },
+
+  toJSON(message: Task_DatabaseSchemaBaseline): unknown {
+    const obj: any = {};
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Task_DatabaseSchemaBaseline>): Task_DatabaseSchemaBaseline {
+    return Task_DatabaseSchemaBaseline.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Task_DatabaseSchemaBaseline>): Task_DatabaseSchemaBaseline {
+    const message = createBaseTask_DatabaseSchemaBaseline();
+    message.schemaVersion = object.schemaVersion ?? "";
+    return message;
+  },
+};
+
+function createBaseTask_DatabaseSchemaUpdate(): Task_DatabaseSchemaUpdate {
+  return { sheet: "", schemaVersion: "" };
+}
+
+export const Task_DatabaseSchemaUpdate = {
+  encode(message: Task_DatabaseSchemaUpdate, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.sheet !== "") {
+      writer.uint32(10).string(message.sheet);
+    }
+    if (message.schemaVersion !== "") {
+      writer.uint32(18).string(message.schemaVersion);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Task_DatabaseSchemaUpdate {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTask_DatabaseSchemaUpdate();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.sheet = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Task_DatabaseSchemaUpdate {
+    return {
+      sheet: isSet(object.sheet) ? String(object.sheet) : "",
+      schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "",
+    };
+  },
+
+  toJSON(message: Task_DatabaseSchemaUpdate): unknown {
+    const obj: any = {};
+    message.sheet !== undefined && (obj.sheet = message.sheet);
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Task_DatabaseSchemaUpdate>): Task_DatabaseSchemaUpdate {
+    return Task_DatabaseSchemaUpdate.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Task_DatabaseSchemaUpdate>): Task_DatabaseSchemaUpdate {
+    const message = createBaseTask_DatabaseSchemaUpdate();
+    message.sheet = object.sheet ?? "";
+    message.schemaVersion = object.schemaVersion ?? "";
+    return message;
+  },
+};
+
+function createBaseTask_DatabaseDataUpdate(): Task_DatabaseDataUpdate {
+  return { sheet: "", schemaVersion: "", rollbackEnabled: false };
+}
+
+export const Task_DatabaseDataUpdate = {
+  encode(message: Task_DatabaseDataUpdate, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.sheet !== "") {
+      writer.uint32(10).string(message.sheet);
+    }
+    if (message.schemaVersion !== "") {
+      writer.uint32(18).string(message.schemaVersion);
+    }
+    if (message.rollbackEnabled === true) {
+      writer.uint32(24).bool(message.rollbackEnabled);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Task_DatabaseDataUpdate {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTask_DatabaseDataUpdate();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.sheet = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.rollbackEnabled = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Task_DatabaseDataUpdate {
+    return {
+      sheet: isSet(object.sheet) ? String(object.sheet) : "",
+      schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "",
+      rollbackEnabled: isSet(object.rollbackEnabled) ? Boolean(object.rollbackEnabled) : false,
+    };
+  },
+
+  toJSON(message: Task_DatabaseDataUpdate): unknown {
+    const obj: any = {};
+    message.sheet !== undefined && (obj.sheet = message.sheet);
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    message.rollbackEnabled !== undefined && (obj.rollbackEnabled = message.rollbackEnabled);
+    return obj;
+  },
+
+  create(base?: DeepPartial<Task_DatabaseDataUpdate>): Task_DatabaseDataUpdate {
+    return Task_DatabaseDataUpdate.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Task_DatabaseDataUpdate>): Task_DatabaseDataUpdate {
+    const message = createBaseTask_DatabaseDataUpdate();
+    message.sheet = object.sheet ?? "";
+    message.schemaVersion = object.schemaVersion ?? "";
+    message.rollbackEnabled = object.rollbackEnabled ?? false;
+    return message;
+  },
+};
+
+function createBaseTaskRun(): TaskRun {
+  return {
+    name: "",
+    uid: "",
+    creator: "",
+    updater: "",
+    createTime: undefined,
+    updateTime: undefined,
+    title: "",
+    status: 0,
+    detail: "",
+    schemaVersion: "",
+  };
+}
+
+export const TaskRun = {
+  encode(message: TaskRun, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.uid !== "") {
+      writer.uint32(18).string(message.uid);
+    }
+    if (message.creator !== "") {
+      writer.uint32(26).string(message.creator);
+    }
+    if (message.updater !== "") {
+      writer.uint32(34).string(message.updater);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(42).fork()).ldelim();
+    }
+    if (message.updateTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.updateTime), writer.uint32(50).fork()).ldelim();
+    }
+    if (message.title !== "") {
+      writer.uint32(58).string(message.title);
+    }
+    if (message.status !== 0) {
+      writer.uint32(64).int32(message.status);
+    }
+    if (message.detail !== "") {
+      writer.uint32(74).string(message.detail);
+    }
+    if (message.schemaVersion !== "") {
+      writer.uint32(82).string(message.schemaVersion);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): TaskRun {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTaskRun();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.uid = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.creator = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.updater = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.updateTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.status = reader.int32() as any;
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.detail = reader.string();
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.schemaVersion = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TaskRun {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      uid: isSet(object.uid) ? String(object.uid) : "",
+      creator: isSet(object.creator) ? String(object.creator) : "",
+      updater: isSet(object.updater) ? String(object.updater) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      updateTime: isSet(object.updateTime) ? fromJsonTimestamp(object.updateTime) : undefined,
+      title: isSet(object.title) ? String(object.title) : "",
+      status: isSet(object.status) ? taskRun_StatusFromJSON(object.status) : 0,
+      detail: isSet(object.detail) ? String(object.detail) : "",
+      schemaVersion: isSet(object.schemaVersion) ? String(object.schemaVersion) : "",
+    };
+  },
+
+  toJSON(message: TaskRun): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.uid !== undefined && (obj.uid = message.uid);
+    message.creator !== undefined && (obj.creator = message.creator);
+    message.updater !== undefined && (obj.updater = message.updater);
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.updateTime !== undefined && (obj.updateTime = message.updateTime.toISOString());
+    message.title !== undefined && (obj.title = message.title);
+    message.status !== undefined && (obj.status = taskRun_StatusToJSON(message.status));
+    message.detail !== undefined && (obj.detail = message.detail);
+    message.schemaVersion !== undefined && (obj.schemaVersion = message.schemaVersion);
+    return obj;
+  },
+
+  create(base?: DeepPartial<TaskRun>): TaskRun {
+    return TaskRun.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<TaskRun>): TaskRun {
+    const message = createBaseTaskRun();
+    message.name = object.name ?? "";
+    message.uid = object.uid ?? "";
+    message.creator = object.creator ?? "";
+    message.updater = object.updater ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.updateTime = object.updateTime ?? undefined;
+    message.title = object.title ?? "";
+    message.status = object.status ?? 0;
+    message.detail = object.detail ?? "";
+    message.schemaVersion = object.schemaVersion ?? "";
+    return message;
+  },
+};
+
+export type RolloutServiceDefinition = typeof RolloutServiceDefinition;
+export const RolloutServiceDefinition = {
+  name: "RolloutService",
+  fullName: "bytebase.v1.RolloutService",
+  methods: {
+    getPlan: {
+      name: "GetPlan",
+      requestType: GetPlanRequest,
+      requestStream: false,
+      responseType: Plan,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              31,
+              18,
+              29,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              112,
+              108,
+              97,
+              110,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    listPlans: {
+      name: "ListPlans",
+      requestType: ListPlansRequest,
+      requestStream: false,
+      responseType: ListPlansResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              31,
+              18,
+              29,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              47,
+              112,
+              108,
+              97,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    createPlan: {
+      name: "CreatePlan",
+      requestType: CreatePlanRequest,
+      requestStream: false,
+      responseType: Plan,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([0])],
+          578365826: [
+            new Uint8Array([
+              37,
+              58,
+              4,
+              112,
               108,
               97,
               110,
@@ -750,6 +2879,251 @@ export const RolloutServiceDefinition = {
         },
       },
     },
+    getRollout: {
+      name: "GetRollout",
+      requestType: GetRolloutRequest,
+      requestStream: false,
+      responseType: Rollout,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              34,
+              18,
+              32,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              114,
+              111,
+              108,
+              108,
+              111,
+              117,
+              116,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    createRollout: {
+      name: "CreateRollout",
+      requestType: CreateRolloutRequest,
+      requestStream: false,
+      responseType: Rollout,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([11, 112, 97, 114, 101, 110, 116, 44, 112, 108, 97, 110])],
+          578365826: [
+            new Uint8Array([
+              37,
+              58,
+              1,
+              42,
+              34,
+              32,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              47,
+              114,
+              111,
+              108,
+              108,
+              111,
+              117,
+              116,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    previewRollout: {
+      name: "PreviewRollout",
+      requestType: PreviewRolloutRequest,
+      requestStream: false,
+      responseType: Rollout,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([12, 112, 114, 111, 106, 101, 99, 116, 44, 112, 108, 97, 110])],
+          578365826: [
+            new Uint8Array([
+              44,
+              58,
+              1,
+              42,
+              34,
+              39,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              58,
+              112,
+              114,
+              101,
+              118,
+              105,
+              101,
+              119,
+              82,
+              111,
+              108,
+              108,
+              111,
+              117,
+              116,
+            ]),
+          ],
+        },
+      },
+    },
+    listTaskRuns: {
+      name: "ListTaskRuns",
+      requestType: ListTaskRunsRequest,
+      requestStream: false,
+      responseType: ListTaskRunsResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              62,
+              18,
+              60,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              114,
+              111,
+              108,
+              108,
+              111,
+              117,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              116,
+              97,
+              103,
+              101,
+              115,
+              47,
+              42,
+              47,
+              116,
+              97,
+              115,
+              107,
+              115,
+              47,
+              42,
+              125,
+              47,
+              116,
+              97,
+              115,
+              107,
+              82,
+              117,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
   },
 } as const;
 
@@ -758,6 +3132,13 @@ export interface RolloutServiceImplementation<CallContextExt = {}> {
   listPlans(request: ListPlansRequest, context: CallContext & CallContextExt): Promise<DeepPartial<ListPlansResponse>>;
   createPlan(request: CreatePlanRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Plan>>;
   updatePlan(request: UpdatePlanRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Plan>>;
+  getRollout(request: GetRolloutRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Rollout>>;
+  createRollout(request: CreateRolloutRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Rollout>>;
+  previewRollout(request: PreviewRolloutRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Rollout>>;
+  listTaskRuns(
+    request: ListTaskRunsRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<ListTaskRunsResponse>>;
 }
 
 export interface RolloutServiceClient<CallOptionsExt = {}> {
@@ -765,6 +3146,13 @@ export interface RolloutServiceClient<CallOptionsExt = {}> {
   listPlans(request: DeepPartial<ListPlansRequest>, options?: CallOptions & CallOptionsExt): Promise<ListPlansResponse>;
   createPlan(request: DeepPartial<CreatePlanRequest>, options?: CallOptions & CallOptionsExt): Promise<Plan>;
   updatePlan(request: DeepPartial<UpdatePlanRequest>, options?: CallOptions & CallOptionsExt): Promise<Plan>;
+  getRollout(request: DeepPartial<GetRolloutRequest>, options?: CallOptions & CallOptionsExt): Promise<Rollout>;
+  createRollout(request: DeepPartial<CreateRolloutRequest>, options?: CallOptions & CallOptionsExt): Promise<Rollout>;
+  previewRollout(request: DeepPartial<PreviewRolloutRequest>, options?: CallOptions & CallOptionsExt): Promise<Rollout>;
+  listTaskRuns(
+    request: DeepPartial<ListTaskRunsRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<ListTaskRunsResponse>;
 }
 
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
@@ -774,6 +3162,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = t.seconds * 1_000;
+  millis += t.nanos / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
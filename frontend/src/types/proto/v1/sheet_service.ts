@@ -55,37 +55,39 @@ export interface SearchSheetsRequest {
   /**
    * The parent resource of the sheet.
    * Foramt: projects/{project}
+   * Use "projects/-" to search sheets from all projects.
    */
   parent: string;
   /**
-   * Not used. The maximum number of sheets to return. The service may return fewer than
+   * The maximum number of sheets to return. The service may return fewer than
    * this value.
    * If unspecified, at most 50 sheets will be returned.
    * The maximum value is 1000; values above 1000 will be coerced to 1000.
    */
   pageSize: number;
   /**
-   * Not used. A page token, received from a previous `SearchSheets` call.
+   * A page token, received from a previous `SearchSheets` call.
    * Provide this to retrieve the subsequent page.
    *
    * When paginating, all other parameters provided to `SearchSheets` must match
    * the call that provided the page token.
    */
   pageToken: string;
-}
-
-export interface SearchSheetsResponse {
-  /** The sheets that matched the search criteria. */
-  sheets: Sheet[];
   /**
    * To filter the search result.
    * Format: only support the following spec for now:
    * - `creator = user:{email}`, `creator != user:{email}`
    * - `starred = true`, `starred = false`.
+   * Multiple expressions can be combined with `&&`.
    */
   filter: string;
+}
+
+export interface SearchSheetsResponse {
+  /** The sheets that matched the search criteria. */
+  sheets: Sheet[];
   /**
-   * Not used. A token, which can be sent as `page_token` to retrieve the next page.
+   * A token, which can be sent as `page_token` to retrieve the next page.
    * If this field is omitted, there are no subsequent pages.
    */
   nextPageToken: string;
@@ -130,7 +132,10 @@ export interface Sheet {
    * set the `raw` to true in GetSheet request to retrieve the full content.
    */
   content: Uint8Array;
-  /** content_size is the full size of the content, may not match the size of the `content` field. */
+  /**
+   * content_size is the full size of the content, may not match the size of the `content` field.
+   * The size is encoded as a decimal string.
+   */
   contentSize: Uint8Array;
   visibility: Sheet_Visibility;
   /** The source of the sheet. */
@@ -554,7 +559,7 @@ export const DeleteSheetRequest = {
 };
 
 function createBaseSearchSheetsRequest(): SearchSheetsRequest {
-  return { parent: "", pageSize: 0, pageToken: "" };
+  return { parent: "", pageSize: 0, pageToken: "", filter: "" };
 }
 
 export const SearchSheetsRequest = {
@@ -568,6 +573,9 @@ export const SearchSheetsRequest = {
     if (message.pageToken !== "") {
       writer.uint32(26).string(message.pageToken);
     }
+    if (message.filter !== "") {
+      writer.uint32(34).string(message.filter);
+    }
     return writer;
   },
 
@@ -599,6 +607,13 @@ export const SearchSheetsRequest = {
 
           message.pageToken = reader.string();
           continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.filter = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -613,6 +628,7 @@ export const SearchSheetsRequest = {
       parent: isSet(object.parent) ? String(object.parent) : "",
       pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
       pageToken: isSet(object.pageToken) ? String(object.pageToken) : "",
+      filter: isSet(object.filter) ? String(object.filter) : "",
     };
   },
 
@@ -621,6 +637,7 @@ export const SearchSheetsRequest = {
     message.parent !== undefined && (obj.parent = message.parent);
     message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
     message.pageToken !== undefined && (obj.pageToken = message.pageToken);
+    message.filter !== undefined && (obj.filter = message.filter);
     return obj;
   },
 
@@ -633,12 +650,13 @@ export const SearchSheetsRequest = {
     message.parent = object.parent ?? "";
     message.pageSize = object.pageSize ?? 0;
     message.pageToken = object.pageToken ?? "";
+    message.filter = object.filter ?? "";
     return message;
   },
 };
 
 function createBaseSearchSheetsResponse(): SearchSheetsResponse {
-  return { sheets: [], filter: "", nextPageToken: "" };
+  return { sheets: [], nextPageToken: "" };
 }
 
 export const SearchSheetsResponse = {
@@ -646,9 +664,6 @@ export const SearchSheetsResponse = {
     for (const v of message.sheets) {
       Sheet.encode(v!, writer.uint32(10).fork()).ldelim();
     }
-    if (message.filter !== "") {
-      writer.uint32(18).string(message.filter);
-    }
     if (message.nextPageToken !== "") {
       writer.uint32(26).string(message.nextPageToken);
     }
@@ -669,13 +684,6 @@ export const SearchSheetsResponse = {
 
           message.sheets.push(Sheet.decode(reader, reader.uint32()));
           continue;
-        case 2:
-          if (tag !== 18) {
-            break;
-          }
-
-          message.filter = reader.string();
-          continue;
         case 3:
           if (tag !== 26) {
             break;
@@ -695,7 +703,6 @@ export const SearchSheetsResponse = {
   fromJSON(object: any): SearchSheetsResponse {
     return {
       sheets: Array.isArray(object?.sheets) ? object.sheets.map((e: any) => Sheet.fromJSON(e)) : [],
-      filter: isSet(object.filter) ? String(object.filter) : "",
       nextPageToken: isSet(object.nextPageToken) ? String(object.nextPageToken) : "",
     };
   },
@@ -707,7 +714,6 @@ export const SearchSheetsResponse = {
     } else {
       obj.sheets = [];
     }
-    message.filter !== undefined && (obj.filter = message.filter);
     message.nextPageToken !== undefined && (obj.nextPageToken = message.nextPageToken);
     return obj;
   },
@@ -719,7 +725,6 @@ export const SearchSheetsResponse = {
   fromPartial(object: DeepPartial<SearchSheetsResponse>): SearchSheetsResponse {
     const message = createBaseSearchSheetsResponse();
     message.sheets = object.sheets?.map((e) => Sheet.fromPartial(e)) || [];
-    message.filter = object.filter ?? "";
     message.nextPageToken = object.nextPageToken ?? "";
     return message;
   },
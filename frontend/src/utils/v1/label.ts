@@ -37,8 +37,12 @@ export const isDatabaseMatchesSelectorV1 = (
     switch (rule.operator) {
       case OperatorType.OPERATOR_TYPE_IN:
         return checkLabelIn(database, rule);
+      case OperatorType.OPERATOR_TYPE_NOT_IN:
+        return !checkLabelIn(database, rule);
       case OperatorType.OPERATOR_TYPE_EXISTS:
         return checkLabelExists(database, rule);
+      case OperatorType.OPERATOR_TYPE_DOES_NOT_EXIST:
+        return !checkLabelExists(database, rule);
       default:
         // unknown operators are taken as mismatch
         console.warn(`known operator "${rule.operator}"`);